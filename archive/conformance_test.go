@@ -0,0 +1,19 @@
+package archive_test
+
+import (
+	"bytes"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/archive"
+	"github.com/unmango/aferox/testing/conformance"
+	"github.com/unmango/aferox/writer/tar"
+)
+
+var _ = conformance.Describe("TarFs conformance", func(seed afero.Fs) (afero.Fs, error) {
+	buf := &bytes.Buffer{}
+	if err := tar.Export(seed, "", buf); err != nil {
+		return nil, err
+	}
+
+	return archive.NewTarFs(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+}, conformance.Without(conformance.ReadOnly))
@@ -0,0 +1,141 @@
+// Package archive opens tar and zip archives, compressed or not, as
+// read-only [afero.Fs] implementations.
+package archive
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strconv"
+)
+
+// Format is an archive or compression format detected from magic bytes.
+type Format int
+
+const (
+	Unknown Format = iota
+	Tar
+	Zip
+	Gzip
+	Bzip2
+	Zstd
+	Xz
+)
+
+// ErrUnknownFormat is returned for content that is not a supported archive.
+var ErrUnknownFormat = errors.New("unknown archive format")
+
+// sniffSize is the number of bytes needed to detect a format, enough to
+// read a whole tar header.
+const sniffSize = 512
+
+// emptyBlock starts the end-of-archive marker of a tar archive.
+var emptyBlock [sniffSize]byte
+
+// The position of the checksum in a tar header.
+const (
+	chksumOffset = 148
+	chksumSize   = 8
+)
+
+var magics = []struct {
+	format Format
+	offset int
+	magic  []byte
+}{
+	{Gzip, 0, []byte{0x1f, 0x8b}},
+	{Bzip2, 0, []byte("BZh")},
+	{Zstd, 0, []byte{0x28, 0xb5, 0x2f, 0xfd}},
+	{Xz, 0, []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}},
+	{Zip, 0, []byte("PK\x03\x04")},
+	{Zip, 0, []byte("PK\x05\x06")},
+	{Tar, 257, []byte("ustar")},
+}
+
+// String returns the name of the format.
+func (f Format) String() string {
+	switch f {
+	case Tar:
+		return "tar"
+	case Zip:
+		return "zip"
+	case Gzip:
+		return "gzip"
+	case Bzip2:
+		return "bzip2"
+	case Zstd:
+		return "zstd"
+	case Xz:
+		return "xz"
+	default:
+		return "unknown"
+	}
+}
+
+// Compressed reports whether f is a compression format wrapping another format.
+func (f Format) Compressed() bool {
+	return f == Gzip || f == Bzip2 || f == Zstd || f == Xz
+}
+
+// Detect returns the format of the content of r from its magic bytes, or for
+// tar archives without a magic, such as V7 archives, the checksum of their header.
+// Content starting with a block of zeros is an empty tar archive, as the
+// end-of-archive marker written by Close of an archive/tar Writer.
+func Detect(r io.ReaderAt) (Format, error) {
+	buf := make([]byte, sniffSize)
+	n, err := r.ReadAt(buf, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return Unknown, err
+	}
+
+	return detect(buf[:n]), nil
+}
+
+func detect(buf []byte) Format {
+	for _, m := range magics {
+		end := m.offset + len(m.magic)
+		if len(buf) >= end && bytes.Equal(buf[m.offset:end], m.magic) {
+			return m.format
+		}
+	}
+
+	if len(buf) < sniffSize {
+		return Unknown
+	}
+
+	// V7 tar headers have no magic, so are recognized by their checksum
+	if buf[0] != 0 && tarChecksum(buf[:sniffSize]) {
+		return Tar
+	}
+	if bytes.Equal(buf[:sniffSize], emptyBlock[:]) {
+		return Tar
+	}
+
+	return Unknown
+}
+
+// tarChecksum reports whether the checksum of the tar header in block is
+// valid. Like archive/tar, it accepts sums of both unsigned and signed bytes.
+func tarChecksum(block []byte) bool {
+	field := bytes.Trim(block[chksumOffset:chksumOffset+chksumSize], " \x00")
+	if len(field) == 0 {
+		return false
+	}
+
+	expected, err := strconv.ParseInt(string(field), 8, 64)
+	if err != nil {
+		return false
+	}
+
+	var unsigned, signed int64
+	for i, b := range block {
+		if i >= chksumOffset && i < chksumOffset+chksumSize {
+			b = ' '
+		}
+
+		unsigned += int64(b)
+		signed += int64(int8(b))
+	}
+
+	return expected == unsigned || expected == signed
+}
@@ -0,0 +1,104 @@
+package archive
+
+import (
+	"archive/tar"
+	"io"
+	"os"
+	"path"
+	"syscall"
+
+	"github.com/unmango/aferox"
+)
+
+// tarFile is an entry of a [TarFs]. Regular files read their contents
+// directly from the archive, while directories list their entries.
+type tarFile struct {
+	aferox.ReadOnlyFile
+
+	name   string
+	header *tar.Header
+	fs     *TarFs
+
+	// Set for directories and other entries respectively
+	names []string
+	r     *io.SectionReader
+}
+
+// Close implements afero.File.
+func (f *tarFile) Close() error {
+	return nil
+}
+
+// Name implements afero.File.
+func (f *tarFile) Name() string {
+	return f.name
+}
+
+// Read implements afero.File.
+func (f *tarFile) Read(p []byte) (int, error) {
+	if f.r == nil {
+		return 0, syscall.EISDIR
+	}
+
+	return f.r.Read(p)
+}
+
+// ReadAt implements afero.File.
+func (f *tarFile) ReadAt(p []byte, off int64) (int, error) {
+	if f.r == nil {
+		return 0, syscall.EISDIR
+	}
+
+	return f.r.ReadAt(p, off)
+}
+
+// Seek implements afero.File.
+func (f *tarFile) Seek(offset int64, whence int) (int64, error) {
+	if f.r == nil {
+		return 0, syscall.EISDIR
+	}
+
+	return f.r.Seek(offset, whence)
+}
+
+// Readdir implements afero.File.
+func (f *tarFile) Readdir(count int) ([]os.FileInfo, error) {
+	names, err := f.Readdirnames(count)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]os.FileInfo, 0, len(names))
+	for _, name := range names {
+		info, _, err := f.fs.LstatIfPossible(path.Join(f.name, name))
+		if err != nil {
+			return nil, err
+		}
+
+		infos = append(infos, info)
+	}
+
+	return infos, nil
+}
+
+// Readdirnames implements afero.File.
+func (f *tarFile) Readdirnames(n int) ([]string, error) {
+	if f.r != nil {
+		return nil, syscall.ENOTDIR
+	}
+	if n > 0 && len(f.names) == 0 {
+		return nil, io.EOF
+	}
+	if n <= 0 || n > len(f.names) {
+		n = len(f.names)
+	}
+
+	names := f.names[:n]
+	f.names = f.names[n:]
+	return names, nil
+}
+
+// Stat implements afero.File.
+func (f *tarFile) Stat() (os.FileInfo, error) {
+	return f.header.FileInfo(), nil
+}
@@ -0,0 +1,185 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/spf13/afero"
+	"github.com/ulikunitz/xz"
+	"github.com/unmango/aferox/internal/archivepath"
+	"github.com/unmango/go/fopt"
+)
+
+// defaultThreshold is the size of decompressed archives kept in memory,
+// beyond which they are spooled to temporary files.
+const defaultThreshold = 32 << 20
+
+type options struct {
+	temp      afero.Fs
+	threshold int64
+}
+
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{
+		temp:      afero.NewOsFs(),
+		threshold: defaultThreshold,
+	}
+}
+
+// WithSpill decompresses compressed archives in memory up to threshold bytes,
+// and moves larger archives to temporary files in fsys. The temporary file is
+// removed by [TarFs.Close]. By default archives larger than 32MiB are spooled
+// to the temporary directory of the operating system.
+func WithSpill(fsys afero.Fs, threshold int64) Option {
+	return func(options *options) {
+		options.temp = fsys
+		options.threshold = threshold
+	}
+}
+
+// WithTempFs always decompresses compressed archives to temporary files in
+// fsys, rather than in memory. The temporary file is removed by [TarFs.Close].
+func WithTempFs(fsys afero.Fs) Option {
+	return WithSpill(fsys, 0)
+}
+
+// Open detects the format of the archive in r, which holds size bytes, and
+// returns a read-only [afero.Fs] of its contents. Tar archives compressed
+// with gzip, bzip2, zstd or xz are decompressed before they are indexed.
+//
+// Tar archives are returned as a *[TarFs], which should be closed when it is
+// no longer needed to release the decompressed copy of the archive.
+func Open(r io.ReaderAt, size int64, options ...Option) (afero.Fs, error) {
+	opts := defaultOptions()
+	fopt.ApplyAll(&opts, options)
+
+	format, err := Detect(r)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case format == Tar:
+		return NewTarFs(r, size)
+	case format == Zip:
+		zr, err := zip.NewReader(r, size)
+		if err != nil {
+			return nil, fmt.Errorf("reading zip: %w", err)
+		}
+
+		return afero.FromIOFS{FS: zipFS{zr}}, nil
+	case format.Compressed():
+		return opts.decompress(format, io.NewSectionReader(r, 0, size))
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// OpenFile detects the format of the archive in file and returns a
+// read-only [afero.Fs] of its contents, see [Open].
+func OpenFile(file afero.File, options ...Option) (afero.Fs, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	return Open(file, info.Size(), options...)
+}
+
+// decompress reads the tar archive compressed in r into memory, or a
+// temporary file once it grows past the threshold, and indexes it.
+func (o options) decompress(format Format, r io.Reader) (afero.Fs, error) {
+	dr, err := decompressor(format, r)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", format, err)
+	}
+	defer dr.Close()
+
+	buf := &bytes.Buffer{}
+	if n, err := io.CopyN(buf, dr, o.threshold+1); errors.Is(err, io.EOF) && n <= o.threshold {
+		return openTar(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	} else if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("reading %s: %w", format, err)
+	}
+
+	file, err := afero.TempFile(o.temp, "", "aferox-archive-")
+	if err != nil {
+		return nil, err
+	}
+
+	remove := func() error {
+		return errors.Join(file.Close(), o.temp.Remove(file.Name()))
+	}
+
+	size, err := io.Copy(file, io.MultiReader(buf, dr))
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("reading %s: %w", format, err), remove())
+	}
+
+	fsys, err := openTar(file, size)
+	if err != nil {
+		return nil, errors.Join(err, remove())
+	}
+
+	fsys.close = remove
+	return fsys, nil
+}
+
+// openTar indexes the decompressed archive in r, which must be a tar archive.
+func openTar(r io.ReaderAt, size int64) (*TarFs, error) {
+	if format, err := Detect(r); err != nil {
+		return nil, err
+	} else if format != Tar {
+		return nil, ErrUnknownFormat
+	}
+
+	return NewTarFs(r, size)
+}
+
+func decompressor(format Format, r io.Reader) (io.ReadCloser, error) {
+	switch format {
+	case Gzip:
+		return gzip.NewReader(r)
+	case Bzip2:
+		return io.NopCloser(bzip2.NewReader(r)), nil
+	case Zstd:
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+
+		return zr.IOReadCloser(), nil
+	case Xz:
+		xr, err := xz.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+
+		return io.NopCloser(xr), nil
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// zipFS adapts the rooted, slash or OS separated names used with afero to
+// the unrooted names expected by [zip.Reader].
+type zipFS struct {
+	r *zip.Reader
+}
+
+func (z zipFS) Open(name string) (fs.File, error) {
+	if rel := archivepath.Clean(name); rel != "" {
+		return z.r.Open(rel)
+	}
+
+	return z.r.Open(".")
+}
@@ -0,0 +1,232 @@
+package archive_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	"github.com/ulikunitz/xz"
+	"github.com/unmango/aferox/archive"
+)
+
+// tarball returns a tar archive of name/contents pairs
+func tarball(files ...string) []byte {
+	GinkgoHelper()
+	buf := &bytes.Buffer{}
+	w := tar.NewWriter(buf)
+	for i := 0; i < len(files); i += 2 {
+		Expect(w.WriteHeader(&tar.Header{
+			Name:     files[i],
+			Typeflag: tar.TypeReg,
+			Mode:     0644,
+			Size:     int64(len(files[i+1])),
+		})).To(Succeed())
+		_, err := w.Write([]byte(files[i+1]))
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	Expect(w.Close()).To(Succeed())
+	return buf.Bytes()
+}
+
+// v7 rewrites the headers of the tar archive in data as V7 headers, which have no magic
+func v7(data []byte) []byte {
+	GinkgoHelper()
+	out := bytes.Clone(data)
+	r := tar.NewReader(bytes.NewReader(data))
+	offset := int64(0)
+	for {
+		header, err := r.Next()
+		if err == io.EOF {
+			return out
+		}
+		Expect(err).NotTo(HaveOccurred())
+
+		block := out[offset : offset+512]
+		clear(block[257:])
+		copy(block[148:156], "        ")
+		sum := 0
+		for _, b := range block {
+			sum += int(b)
+		}
+		copy(block[148:156], fmt.Sprintf("%06o\x00 ", sum))
+		offset += 512 + (header.Size+511)/512*512
+	}
+}
+
+func compress(data []byte, wrap func(io.Writer) (io.WriteCloser, error)) []byte {
+	GinkgoHelper()
+	buf := &bytes.Buffer{}
+	w, err := wrap(buf)
+	Expect(err).NotTo(HaveOccurred())
+	_, err = w.Write(data)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(w.Close()).To(Succeed())
+	return buf.Bytes()
+}
+
+var (
+	gzipped = func(w io.Writer) (io.WriteCloser, error) {
+		return gzip.NewWriter(w), nil
+	}
+	zstded = func(w io.Writer) (io.WriteCloser, error) {
+		return zstd.NewWriter(w)
+	}
+	xzed = func(w io.Writer) (io.WriteCloser, error) {
+		return xz.NewWriter(w)
+	}
+)
+
+func zipball(files ...string) []byte {
+	GinkgoHelper()
+	buf := &bytes.Buffer{}
+	w := zip.NewWriter(buf)
+	for i := 0; i < len(files); i += 2 {
+		f, err := w.Create(files[i])
+		Expect(err).NotTo(HaveOccurred())
+		_, err = f.Write([]byte(files[i+1]))
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	Expect(w.Close()).To(Succeed())
+	return buf.Bytes()
+}
+
+var _ = Describe("Detect", func() {
+	DescribeTable("formats",
+		func(data func() []byte, expected archive.Format) {
+			format, err := archive.Detect(bytes.NewReader(data()))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(format).To(Equal(expected))
+		},
+		Entry("tar", func() []byte { return tarball("a.txt", "a") }, archive.Tar),
+		Entry("v7 tar", func() []byte { return v7(tarball("a.txt", "a")) }, archive.Tar),
+		Entry("empty tar", func() []byte { return tarball() }, archive.Tar),
+		Entry("zip", func() []byte { return zipball("a.txt", "a") }, archive.Zip),
+		Entry("gzip", func() []byte { return compress([]byte("a"), gzipped) }, archive.Gzip),
+		Entry("zstd", func() []byte { return compress([]byte("a"), zstded) }, archive.Zstd),
+		Entry("xz", func() []byte { return compress([]byte("a"), xzed) }, archive.Xz),
+		Entry("bzip2", func() []byte { return []byte("BZh91AY&SY") }, archive.Bzip2),
+		Entry("text", func() []byte { return []byte("testing") }, archive.Unknown),
+		Entry("empty", func() []byte { return nil }, archive.Unknown),
+	)
+})
+
+var _ = Describe("Open", func() {
+	DescribeTable("archives",
+		func(data func() []byte) {
+			b := data()
+
+			fsys, err := archive.Open(bytes.NewReader(b), int64(len(b)))
+
+			Expect(err).NotTo(HaveOccurred())
+			contents, err := afero.ReadFile(fsys, "dir/test.txt")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(Equal("testing"))
+
+			names, err := afero.ReadDir(fsys, "/")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names).To(ConsistOf(HaveField("Name()", "dir")))
+			Expect(names[0].IsDir()).To(BeTrue())
+		},
+		Entry("tar", func() []byte { return tarball("dir/test.txt", "testing") }),
+		Entry("v7 tar", func() []byte { return v7(tarball("dir/test.txt", "testing")) }),
+		Entry("tar.gz", func() []byte { return compress(tarball("dir/test.txt", "testing"), gzipped) }),
+		Entry("tar.zst", func() []byte { return compress(tarball("dir/test.txt", "testing"), zstded) }),
+		Entry("tar.xz", func() []byte { return compress(tarball("dir/test.txt", "testing"), xzed) }),
+		Entry("zip", func() []byte { return zipball("dir/test.txt", "testing") }),
+	)
+
+	DescribeTable("empty archives",
+		func(data func() []byte) {
+			b := data()
+
+			fsys, err := archive.Open(bytes.NewReader(b), int64(len(b)))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(afero.ReadDir(fsys, "/")).To(BeEmpty())
+		},
+		Entry("tar", func() []byte { return tarball() }),
+		Entry("tar.gz", func() []byte { return compress(tarball(), gzipped) }),
+	)
+
+	It("should reject unknown formats", func() {
+		_, err := archive.Open(bytes.NewReader([]byte("testing")), 7)
+
+		Expect(err).To(MatchError(archive.ErrUnknownFormat))
+	})
+
+	It("should reject compressed files that are not tar archives", func() {
+		b := compress([]byte("testing"), gzipped)
+
+		_, err := archive.Open(bytes.NewReader(b), int64(len(b)))
+
+		Expect(err).To(MatchError(archive.ErrUnknownFormat))
+	})
+
+	It("should return read-only filesystems", func() {
+		b := zipball("test.txt", "testing")
+		fsys, err := archive.Open(bytes.NewReader(b), int64(len(b)))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = fsys.Create("other.txt")
+
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should open archives from files", func() {
+		base := afero.NewMemMapFs()
+		Expect(afero.WriteFile(base, "test.tgz",
+			compress(tarball("test.txt", "testing"), gzipped), os.ModePerm,
+		)).To(Succeed())
+		file, err := base.Open("test.tgz")
+		Expect(err).NotTo(HaveOccurred())
+
+		fsys, err := archive.OpenFile(file)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(afero.ReadFile(fsys, "test.txt")).To(BeEquivalentTo("testing"))
+	})
+
+	It("should decompress to the temp Fs", func() {
+		temp := afero.NewMemMapFs()
+		b := compress(tarball("test.txt", "testing"), gzipped)
+
+		fsys, err := archive.Open(bytes.NewReader(b), int64(len(b)), archive.WithTempFs(temp))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(afero.ReadFile(fsys, "test.txt")).To(BeEquivalentTo("testing"))
+		Expect(afero.ReadDir(temp, os.TempDir())).To(HaveLen(1))
+
+		Expect(fsys.(*archive.TarFs).Close()).To(Succeed())
+		Expect(afero.ReadDir(temp, os.TempDir())).To(BeEmpty())
+	})
+
+	It("should decompress archives larger than the threshold to the temp Fs", func() {
+		temp := afero.NewMemMapFs()
+		small := compress(tarball("test.txt", "testing"), gzipped)
+		large := compress(tarball("test.txt", strings.Repeat("x", 4096)), gzipped)
+
+		fsys, err := archive.Open(bytes.NewReader(small), int64(len(small)), archive.WithSpill(temp, 4096))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(afero.ReadFile(fsys, "test.txt")).To(BeEquivalentTo("testing"))
+		Expect(afero.Exists(temp, os.TempDir())).To(BeFalse())
+
+		fsys, err = archive.Open(bytes.NewReader(large), int64(len(large)), archive.WithSpill(temp, 4096))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(afero.ReadFile(fsys, "test.txt")).To(HaveLen(4096))
+		Expect(afero.ReadDir(temp, os.TempDir())).To(HaveLen(1))
+		Expect(fsys.(*archive.TarFs).Close()).To(Succeed())
+	})
+})
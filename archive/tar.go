@@ -0,0 +1,221 @@
+package archive
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox"
+	"github.com/unmango/aferox/internal/archivepath"
+)
+
+// maxHops is the number of symlinks followed before giving up with ELOOP.
+const maxHops = 40
+
+// TarFs implements [afero.Fs] as a read-only view of an uncompressed tar archive.
+//
+// The archive is indexed once when the TarFs is created, recording the offset
+// of each entry's contents, so files are read directly from the archive rather
+// than by re-reading the stream. Directories implied by the names of entries
+// are listed even without entries of their own, later entries replace earlier
+// ones of the same name, and symlinks and hard links are followed within the archive.
+type TarFs struct {
+	aferox.ReadOnlyFs
+
+	r       io.ReaderAt
+	entries map[string]*tarEntry
+	close   func() error
+}
+
+var _ afero.Lstater = (*TarFs)(nil)
+
+type tarEntry struct {
+	header *tar.Header
+	offset int64
+}
+
+// NewTarFs indexes the tar archive in r, which holds size bytes.
+func NewTarFs(r io.ReaderAt, size int64) (*TarFs, error) {
+	sr := io.NewSectionReader(r, 0, size)
+	tr := tar.NewReader(sr)
+	f := &TarFs{r: r, entries: map[string]*tarEntry{}}
+
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return f, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("reading tar: %w", err)
+		}
+
+		// The reader seeks past the contents of entries, so the
+		// position of the section is the start of this entry's contents
+		offset, err := sr.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, err
+		}
+
+		if name := archivepath.Clean(header.Name); name != "" {
+			f.entries[name] = &tarEntry{header, offset}
+		}
+	}
+}
+
+// Close releases the temporary copy of a decompressed archive, see [WithTempFs].
+func (f *TarFs) Close() error {
+	if f.close == nil {
+		return nil
+	}
+
+	return f.close()
+}
+
+// Name implements [afero.Fs].
+func (f *TarFs) Name() string {
+	return "TarFs"
+}
+
+// Open implements [afero.Fs].
+func (f *TarFs) Open(name string) (afero.File, error) {
+	rel, err := f.resolve("open", name, true)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := f.lookup("open", name, rel)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &tarFile{name: name, header: e.header, fs: f}
+	if e.header.Typeflag == tar.TypeDir {
+		file.names = archivepath.Children(f.entries, rel)
+	} else {
+		file.r = io.NewSectionReader(f.r, e.offset, e.header.Size)
+	}
+
+	return file, nil
+}
+
+// OpenFile implements [afero.Fs].
+func (f *TarFs) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	if flag&(os.O_WRONLY|os.O_RDWR|os.O_CREATE|os.O_TRUNC|os.O_APPEND) != 0 {
+		return nil, syscall.EPERM
+	}
+
+	return f.Open(name)
+}
+
+// Stat implements [afero.Fs].
+func (f *TarFs) Stat(name string) (os.FileInfo, error) {
+	return f.stat("stat", name, true)
+}
+
+// LstatIfPossible implements [afero.Lstater].
+func (f *TarFs) LstatIfPossible(name string) (os.FileInfo, bool, error) {
+	info, err := f.stat("lstat", name, false)
+	return info, true, err
+}
+
+// ReadlinkIfPossible implements [afero.LinkReader].
+func (f *TarFs) ReadlinkIfPossible(name string) (string, error) {
+	rel, err := f.resolve("readlink", name, false)
+	if err != nil {
+		return "", err
+	}
+
+	e, ok := f.entries[rel]
+	if !ok {
+		return "", &fs.PathError{Op: "readlink", Path: name, Err: fs.ErrNotExist}
+	}
+	if e.header.Typeflag != tar.TypeSymlink {
+		return "", &fs.PathError{Op: "readlink", Path: name, Err: syscall.EINVAL}
+	}
+
+	return e.header.Linkname, nil
+}
+
+// SymlinkIfPossible implements [afero.Linker].
+func (f *TarFs) SymlinkIfPossible(oldname, newname string) error {
+	return &os.LinkError{Op: "symlink", Old: oldname, New: newname, Err: syscall.EPERM}
+}
+
+func (f *TarFs) stat(op, name string, follow bool) (os.FileInfo, error) {
+	rel, err := f.resolve(op, name, follow)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := f.lookup(op, name, rel)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.header.FileInfo(), nil
+}
+
+// resolve follows the symlinks in name, and in the last element of name
+// when follow is set, returning the path of the entry it refers to.
+func (f *TarFs) resolve(op, name string, follow bool) (string, error) {
+	rel, hops := archivepath.Clean(name), 0
+
+	for dir, rest := "", rel; rest != ""; {
+		var elem string
+		elem, rest, _ = strings.Cut(rest, "/")
+		current := archivepath.Join(dir, elem)
+
+		e, ok := f.entries[current]
+		if !ok || e.header.Typeflag != tar.TypeSymlink || (rest == "" && !follow) {
+			dir = current
+			continue
+		}
+		if hops++; hops > maxHops {
+			return "", &fs.PathError{Op: op, Path: name, Err: syscall.ELOOP}
+		}
+
+		target := e.header.Linkname
+		if !path.IsAbs(target) {
+			target = path.Join(dir, target)
+		}
+
+		rel = archivepath.Clean(path.Join(target, rest))
+		dir, rest = "", rel
+	}
+
+	return rel, nil
+}
+
+// lookup returns the entry at the resolved path rel, following hard links
+// and describing directories implied by the names of other entries.
+func (f *TarFs) lookup(op, name, rel string) (*tarEntry, error) {
+	if e, ok := f.entries[rel]; ok {
+		if e.header.Typeflag != tar.TypeLink {
+			return e, nil
+		}
+
+		target, ok := f.entries[archivepath.Clean(e.header.Linkname)]
+		if !ok || target.header.Typeflag == tar.TypeLink {
+			return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+		}
+
+		header := *target.header
+		header.Name = e.header.Name
+		return &tarEntry{&header, target.offset}, nil
+	}
+	if rel == "" || archivepath.Implied(f.entries, rel) {
+		return &tarEntry{header: &tar.Header{
+			Name:     rel + "/",
+			Typeflag: tar.TypeDir,
+			Mode:     0755,
+		}}, nil
+	}
+
+	return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+}
@@ -0,0 +1,152 @@
+package archive_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"os"
+	"syscall"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/archive"
+)
+
+// countingReader counts the bytes read through ReadAt
+type countingReader struct {
+	r *bytes.Reader
+	n int
+}
+
+func (c *countingReader) ReadAt(p []byte, off int64) (int, error) {
+	n, err := c.r.ReadAt(p, off)
+	c.n += n
+	return n, err
+}
+
+var _ = Describe("TarFs", func() {
+	var fsys *archive.TarFs
+
+	BeforeEach(func() {
+		buf := &bytes.Buffer{}
+		w := tar.NewWriter(buf)
+		for _, h := range []*tar.Header{
+			{Name: "dir/", Typeflag: tar.TypeDir, Mode: 0755},
+			{Name: "dir/test.txt", Typeflag: tar.TypeReg, Mode: 0644, Size: 7},
+			{Name: "dir/link", Typeflag: tar.TypeSymlink, Linkname: "test.txt"},
+			{Name: "hard", Typeflag: tar.TypeLink, Linkname: "dir/test.txt"},
+			{Name: "abs", Typeflag: tar.TypeSymlink, Linkname: "/dir"},
+			{Name: "loop", Typeflag: tar.TypeSymlink, Linkname: "loop"},
+			{Name: "implied/nested.txt", Typeflag: tar.TypeReg, Mode: 0644, Size: 6},
+		} {
+			Expect(w.WriteHeader(h)).To(Succeed())
+			if h.Size > 0 {
+				_, err := w.Write(bytes.Repeat([]byte("x"), int(h.Size)))
+				Expect(err).NotTo(HaveOccurred())
+			}
+		}
+		Expect(w.Close()).To(Succeed())
+
+		var err error
+		fsys, err = archive.NewTarFs(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should read files at random", func() {
+		file, err := fsys.Open("dir/test.txt")
+		Expect(err).NotTo(HaveOccurred())
+
+		p := make([]byte, 3)
+		_, err = file.ReadAt(p, 4)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(p)).To(Equal("xxx"))
+	})
+
+	It("should not read file contents while indexing", func() {
+		b := tarball("big.bin", string(bytes.Repeat([]byte("x"), 1<<20)))
+		r := &countingReader{r: bytes.NewReader(b)}
+
+		_, err := archive.NewTarFs(r, int64(len(b)))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.n).To(BeNumerically("<", 1<<20))
+	})
+
+	It("should describe implied directories", func() {
+		info, err := fsys.Stat("implied")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.IsDir()).To(BeTrue())
+		Expect(info.Name()).To(Equal("implied"))
+	})
+
+	It("should list directories", func() {
+		names, err := afero.ReadDir(fsys, "dir")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(HaveExactElements(
+			HaveField("Name()", "link"),
+			HaveField("Name()", "test.txt"),
+		))
+		Expect(names[0].Mode() & os.ModeSymlink).NotTo(BeZero())
+	})
+
+	It("should follow symlinks", func() {
+		Expect(afero.ReadFile(fsys, "dir/link")).To(BeEquivalentTo("xxxxxxx"))
+		Expect(afero.ReadFile(fsys, "abs/test.txt")).To(BeEquivalentTo("xxxxxxx"))
+
+		info, err := fsys.Stat("abs")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.IsDir()).To(BeTrue())
+	})
+
+	It("should not follow symlinks with Lstat", func() {
+		info, _, err := fsys.LstatIfPossible("dir/link")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode() & os.ModeSymlink).NotTo(BeZero())
+
+		target, err := fsys.ReadlinkIfPossible("dir/link")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(target).To(Equal("test.txt"))
+	})
+
+	It("should fail on symlink loops", func() {
+		_, err := fsys.Open("loop")
+
+		Expect(err).To(MatchError(syscall.ELOOP))
+	})
+
+	It("should follow hard links", func() {
+		info, err := fsys.Stat("hard")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Size()).To(BeEquivalentTo(7))
+		Expect(info.Name()).To(Equal("hard"))
+
+		Expect(afero.ReadFile(fsys, "hard")).To(BeEquivalentTo("xxxxxxx"))
+	})
+
+	It("should return ENOENT for missing files", func() {
+		_, err := fsys.Open("missing.txt")
+
+		Expect(err).To(MatchError(os.ErrNotExist))
+	})
+
+	It("should refuse writes", func() {
+		_, err := fsys.OpenFile("dir/test.txt", os.O_RDWR, 0)
+		Expect(err).To(MatchError(syscall.EPERM))
+
+		Expect(fsys.Remove("dir/test.txt")).To(MatchError(syscall.EPERM))
+	})
+
+	It("should return EISDIR when reading directories", func() {
+		file, err := fsys.Open("dir")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = io.ReadAll(file)
+
+		Expect(err).To(MatchError(syscall.EISDIR))
+	})
+})
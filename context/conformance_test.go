@@ -0,0 +1,16 @@
+package context_test
+
+import (
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/context"
+	"github.com/unmango/aferox/testing/conformance"
+)
+
+var _ = conformance.Describe("AccessorFs conformance", func(seed afero.Fs) (afero.Fs, error) {
+	return context.BackgroundFs(&context.WithSetterFs{Setter: noopSetter{}, Fs: seed}), nil
+}, conformance.Without(conformance.NoSymlink))
+
+var _ = conformance.Describe("Adapt conformance", func(seed afero.Fs) (afero.Fs, error) {
+	base := &context.WithSetterFs{Setter: noopSetter{}, Fs: seed}
+	return context.Adapt(base, context.AccessorFunc(context.Background)), nil
+}, conformance.Without(conformance.NoSymlink))
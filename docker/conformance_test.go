@@ -0,0 +1,50 @@
+//go:build conformance
+
+package docker_test
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/docker/docker/api/types/container"
+	. "github.com/onsi/ginkgo/v2"
+
+	"github.com/spf13/afero"
+	aferoxcontext "github.com/unmango/aferox/context"
+	"github.com/unmango/aferox/docker"
+	"github.com/unmango/aferox/testing/conformance"
+	"github.com/unmango/aferox/writer/tar"
+)
+
+// The docker Fs does not pass every check yet, so the conformance run is
+// opt in with the conformance build tag.
+var _ = Describe("Conformance", func() {
+	const root = "/tmp/aferox-conformance"
+
+	conformance.Describe("in a container", func(seed afero.Fs) (afero.Fs, error) {
+		ctx := context.Background()
+		fsys := docker.NewFs(testclient, ctr.GetContainerID())
+		if err := fsys.RemoveAll(ctx, root); err != nil {
+			return nil, err
+		}
+		if err := fsys.MkdirAll(ctx, root, 0755); err != nil {
+			return nil, err
+		}
+
+		buf := &bytes.Buffer{}
+		if err := tar.Export(seed, "", buf); err != nil {
+			return nil, err
+		}
+		err := testclient.CopyToContainer(ctx, ctr.GetContainerID(), root, buf,
+			container.CopyToContainerOptions{},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return aferoxcontext.BackgroundFs(fsys), nil
+	},
+		conformance.WithRoot(root),
+		conformance.Without(conformance.NoChtimes, conformance.NoSymlink),
+	)
+})
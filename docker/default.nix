@@ -12,6 +12,7 @@
         pname = "aferox-docker";
         version = "0.0.3";
         src = ./.;
+        pwd = ./.;
         modules = ./gomod2nix.toml;
         go = pkgs.go_1_26;
 
@@ -103,9 +103,9 @@ func (f *File) Readdirnames(n int) ([]string, error) {
 	return names, nil
 }
 
-// Seek implements afero.File.
+// Seek implements afero.File. Seeking is not supported.
 func (f *File) Seek(offset int64, whence int) (int64, error) {
-	panic("unimplemented")
+	return 0, &fs.PathError{Op: "seek", Path: f.name, Err: syscall.ENOSYS}
 }
 
 // Stat implements afero.File.
@@ -153,9 +153,9 @@ func (f *File) Write(p []byte) (n int, err error) {
 	return
 }
 
-// WriteAt implements afero.File.
+// WriteAt implements afero.File. Writing at an offset is not supported.
 func (f *File) WriteAt(p []byte, off int64) (n int, err error) {
-	panic("unimplemented")
+	return 0, &fs.PathError{Op: "writeat", Path: f.name, Err: syscall.ENOSYS}
 }
 
 // WriteString implements afero.File.
@@ -3,6 +3,7 @@ package docker
 import (
 	"fmt"
 	"io/fs"
+	"syscall"
 	"time"
 
 	"github.com/docker/docker/client"
@@ -28,9 +29,9 @@ func (f Fs) Chown(ctx context.Context, name string, uid int, gid int) error {
 	)
 }
 
-// Chtimes implements afero.Fs.
+// Chtimes implements afero.Fs. Changing times is not supported.
 func (f Fs) Chtimes(ctx context.Context, name string, atime time.Time, mtime time.Time) error {
-	panic("unimplemented")
+	return &fs.PathError{Op: "chtimes", Path: name, Err: syscall.ENOSYS}
 }
 
 // Create implements afero.Fs.
@@ -3,6 +3,9 @@ package docker_test
 import (
 	"context"
 	"io"
+	"io/fs"
+	"syscall"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -42,6 +45,14 @@ var _ = Describe("Fs", func() {
 		Expect(string(data)).To(Equal("bleh"))
 	})
 
+	It("should report that changing times is not supported", func(ctx context.Context) {
+		fsys := docker.NewFs(testclient, ctr.GetContainerID())
+
+		err := fsys.Chtimes(ctx, "test.txt", time.Now(), time.Now())
+
+		Expect(err).To(Equal(&fs.PathError{Op: "chtimes", Path: "test.txt", Err: syscall.ENOSYS}))
+	})
+
 	Describe("Create", func() {
 		It("should create a file", func(ctx context.Context) {
 			fsys := docker.NewFs(testclient, ctr.GetContainerID())
@@ -0,0 +1,245 @@
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/op"
+)
+
+// And returns a Filter that allows an operation when all filters allow it.
+// It returns the error of the first filter that denies the operation.
+func And(filters ...Filter) Filter {
+	return func(operation op.Operation) error {
+		for _, filter := range filters {
+			if err := filter(operation); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// Or returns a Filter that allows an operation when any of filters allow it.
+// It returns the error of the first filter when all of them deny the operation.
+func Or(filters ...Filter) Filter {
+	return func(operation op.Operation) error {
+		var first error
+		for _, filter := range filters {
+			if err := filter(operation); err == nil {
+				return nil
+			} else if first == nil {
+				first = err
+			}
+		}
+
+		return first
+	}
+}
+
+// Not returns a Filter that denies the operations allowed by filter,
+// and allows those it denies. Renames and symlinks are evaluated once
+// for each of their names and denied when filter allows either, so that
+// Not(PathGlob(...)) cannot be escaped by renaming into or out of a match.
+func Not(filter Filter) Filter {
+	return func(operation op.Operation) error {
+		for _, o := range split(operation) {
+			if filter(o) == nil {
+				return &Error{Operation: operation, Rule: "Not", Err: syscall.ENOENT}
+			}
+		}
+
+		return nil
+	}
+}
+
+// ForOps returns a Filter that applies filter to operations of type T
+// and allows all others.
+func ForOps[T op.Operation](filter Filter) Filter {
+	return func(operation op.Operation) error {
+		if _, ok := operation.(T); ok {
+			return filter(operation)
+		}
+
+		return nil
+	}
+}
+
+// PathGlob returns a Filter that allows operations on paths matching pattern.
+// In addition to the syntax of [filepath.Match], "**" matches any number of
+// directories. PathGlob panics if pattern is malformed.
+func PathGlob(pattern string) Filter {
+	re, err := compileGlob(pattern)
+	if err != nil {
+		panic(fmt.Sprintf("filter: PathGlob(%q): %s", pattern, err))
+	}
+
+	return pathFilter(fmt.Sprintf("PathGlob(%q)", pattern), re.MatchString)
+}
+
+// PathRegexp returns a Filter that allows operations on paths matching re.
+func PathRegexp(re *regexp.Regexp) Filter {
+	return pathFilter(fmt.Sprintf("PathRegexp(%q)", re), func(path string) bool {
+		return re.MatchString(path)
+	})
+}
+
+// UnderDir returns a Filter that allows operations on dir and paths beneath it.
+func UnderDir(dir string) Filter {
+	dir = slashPath(dir)
+	return pathFilter(fmt.Sprintf("UnderDir(%q)", dir), func(path string) bool {
+		return within(dir, path)
+	})
+}
+
+// ReadOnly returns a Filter that denies operations that modify the filesystem.
+func ReadOnly() Filter {
+	return func(operation op.Operation) error {
+		if !writes(operation) {
+			return nil
+		}
+
+		return &Error{Operation: operation, Rule: "ReadOnly", Err: syscall.EPERM}
+	}
+}
+
+// MaxFileSize returns a Filter that denies writes and truncations that would
+// grow a file in fsys beyond size bytes. Writes are assumed to append to the
+// file, so the check is conservative for writes that overwrite existing data.
+func MaxFileSize(fsys afero.Fs, size int64) Filter {
+	current := func(name string) (int64, error) {
+		info, err := fsys.Stat(name)
+		if errors.Is(err, fs.ErrNotExist) {
+			return 0, nil
+		} else if err != nil {
+			return 0, err
+		} else {
+			return info.Size(), nil
+		}
+	}
+
+	return func(operation op.Operation) error {
+		var projected int64
+		switch o := operation.(type) {
+		case op.Write:
+			n, err := current(o.Name)
+			if err != nil {
+				return err
+			}
+			projected = n + int64(len(o.Data))
+		case op.WriteAt:
+			n, err := current(o.Name)
+			if err != nil {
+				return err
+			}
+			projected = max(n, o.Offset+int64(len(o.Data)))
+		case op.Truncate:
+			projected = o.Size
+		default:
+			return nil
+		}
+		if projected <= size {
+			return nil
+		}
+
+		return &Error{
+			Operation: operation,
+			Rule:      fmt.Sprintf("MaxFileSize(%d)", size),
+			Err:       syscall.EFBIG,
+		}
+	}
+}
+
+// pathFilter returns a Filter allowing operations for which all
+// affected paths satisfy match, see paths.
+func pathFilter(rule string, match func(string) bool) Filter {
+	return func(operation op.Operation) error {
+		for _, path := range paths(operation) {
+			if !match(slashPath(path)) {
+				return &Error{Operation: operation, Rule: rule, Err: syscall.ENOENT}
+			}
+		}
+
+		return nil
+	}
+}
+
+// paths returns the paths affected by operation. Renames and symlinks affect
+// both of their names, so neither can be used to escape a path filter. The
+// target of a symlink is resolved against the directory of the link, see
+// linkTarget.
+func paths(operation op.Operation) []string {
+	switch o := operation.(type) {
+	case op.Rename:
+		return []string{o.Oldname, o.Newname}
+	case op.Symlink:
+		return []string{linkTarget(o.Oldname, o.Newname), o.Newname}
+	default:
+		return []string{operation.Path()}
+	}
+}
+
+// split returns an operation for each path affected by operation,
+// see paths. Renames and symlinks are split into operations whose
+// names are both set to one of the original names.
+func split(operation op.Operation) []op.Operation {
+	switch o := operation.(type) {
+	case op.Rename:
+		return []op.Operation{
+			op.Rename{Oldname: o.Oldname, Newname: o.Oldname},
+			op.Rename{Oldname: o.Newname, Newname: o.Newname},
+		}
+	case op.Symlink:
+		target := linkTarget(o.Oldname, o.Newname)
+		return []op.Operation{
+			op.Symlink{Oldname: target, Newname: target},
+			op.Symlink{Oldname: o.Newname, Newname: o.Newname},
+		}
+	default:
+		return []op.Operation{operation}
+	}
+}
+
+// linkTarget returns the path a symlink named newname pointing to oldname
+// resolves to.
+func linkTarget(oldname, newname string) string {
+	if filepath.IsAbs(oldname) {
+		return oldname
+	}
+
+	return filepath.Join(filepath.Dir(newname), oldname)
+}
+
+// writes reports whether operation modifies the filesystem.
+func writes(operation op.Operation) bool {
+	switch o := operation.(type) {
+	case op.Chmod, op.Chown, op.Chtimes, op.Create, op.Mkdir, op.MkdirAll,
+		op.Remove, op.RemoveAll, op.Rename, op.Symlink,
+		op.Write, op.WriteAt, op.Truncate:
+		return true
+	case op.OpenFile:
+		return o.Flag&(os.O_WRONLY|os.O_RDWR|os.O_CREATE|os.O_TRUNC|os.O_APPEND) != 0
+	default:
+		return false
+	}
+}
+
+// within reports whether the slash-separated path is root or is beneath root.
+func within(root, path string) bool {
+	switch {
+	case path == ".." || strings.HasPrefix(path, "../"):
+		return false
+	case root == "." || root == "/":
+		return true
+	default:
+		return path == root || strings.HasPrefix(path, root+"/")
+	}
+}
@@ -0,0 +1,210 @@
+package filter_test
+
+import (
+	"os"
+	"regexp"
+	"syscall"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/filter"
+	"github.com/unmango/aferox/op"
+)
+
+var _ = Describe("Combinators", func() {
+	allow := func(op.Operation) error { return nil }
+	deny := func(op.Operation) error { return syscall.EACCES }
+
+	Describe("And", func() {
+		It("should allow when all filters allow", func() {
+			Expect(filter.And(allow, allow)(op.Stat{Name: "a"})).To(Succeed())
+		})
+
+		It("should deny when any filter denies", func() {
+			Expect(filter.And(allow, deny)(op.Stat{Name: "a"})).To(MatchError(syscall.EACCES))
+		})
+	})
+
+	Describe("Or", func() {
+		It("should allow when any filter allows", func() {
+			Expect(filter.Or(deny, allow)(op.Stat{Name: "a"})).To(Succeed())
+		})
+
+		It("should deny when all filters deny", func() {
+			Expect(filter.Or(deny, deny)(op.Stat{Name: "a"})).To(MatchError(syscall.EACCES))
+		})
+	})
+
+	Describe("Not", func() {
+		It("should invert a filter", func() {
+			Expect(filter.Not(deny)(op.Stat{Name: "a"})).To(Succeed())
+			Expect(filter.Not(allow)(op.Stat{Name: "a"})).To(MatchError(syscall.ENOENT))
+		})
+
+		It("should deny renames when either name is allowed", func() {
+			f := filter.Not(filter.PathGlob("private/**"))
+
+			Expect(f(op.Rename{Oldname: "private/key", Newname: "public/key"})).To(MatchError(syscall.ENOENT))
+			Expect(f(op.Rename{Oldname: "public/key", Newname: "private/key"})).To(MatchError(syscall.ENOENT))
+			Expect(f(op.Rename{Oldname: "public/a", Newname: "public/b"})).To(Succeed())
+		})
+
+		It("should deny symlinks when either name is allowed", func() {
+			f := filter.Not(filter.PathGlob("private/**"))
+
+			Expect(f(op.Symlink{Oldname: "../private/key", Newname: "public/key"})).To(MatchError(syscall.ENOENT))
+			Expect(f(op.Symlink{Oldname: "key", Newname: "private/link"})).To(MatchError(syscall.ENOENT))
+			Expect(f(op.Symlink{Oldname: "private/key", Newname: "public/key"})).To(Succeed())
+		})
+
+		It("should resolve relative symlink targets against the link", func() {
+			f := filter.Not(filter.UnderDir("secret"))
+
+			Expect(f(op.Symlink{Oldname: "../secret/key", Newname: "pub/link"})).To(MatchError(syscall.ENOENT))
+			Expect(f(op.Symlink{Oldname: "../pub/key", Newname: "pub/link"})).To(Succeed())
+		})
+	})
+
+	Describe("ForOps", func() {
+		It("should only apply to operations of the given type", func() {
+			f := filter.ForOps[op.Write](deny)
+
+			Expect(f(op.Read{Name: "a"})).To(Succeed())
+			Expect(f(op.Write{Name: "a"})).To(MatchError(syscall.EACCES))
+		})
+	})
+
+	Describe("PathGlob", func() {
+		DescribeTable("should match paths",
+			func(pattern, path string, allowed bool) {
+				err := filter.PathGlob(pattern)(op.Stat{Name: path})
+
+				if allowed {
+					Expect(err).NotTo(HaveOccurred())
+				} else {
+					Expect(err).To(MatchError(syscall.ENOENT))
+				}
+			},
+			Entry(nil, "*.go", "main.go", true),
+			Entry(nil, "*.go", "dir/main.go", false),
+			Entry(nil, "**/*.go", "main.go", true),
+			Entry(nil, "**/*.go", "dir/sub/main.go", true),
+			Entry(nil, "src/**", "src/dir/main.go", true),
+			Entry(nil, "src/**", "other/main.go", false),
+			Entry(nil, "?.txt", "a.txt", true),
+			Entry(nil, "[a-c].txt", "b.txt", true),
+			Entry(nil, "[a-c].txt", "d.txt", false),
+			Entry(nil, "*.go", "./main.go", true),
+		)
+
+		It("should name the pattern in errors", func() {
+			err := filter.PathGlob("*.go")(op.Stat{Name: "test.txt"})
+
+			Expect(err).To(MatchError(`Stat test.txt: denied by PathGlob("*.go"): no such file or directory`))
+		})
+
+		It("should check both names of a rename", func() {
+			f := filter.PathGlob("src/**")
+
+			Expect(f(op.Rename{Oldname: "src/a", Newname: "src/b"})).To(Succeed())
+			Expect(f(op.Rename{Oldname: "src/a", Newname: "other/b"})).To(HaveOccurred())
+		})
+
+		It("should panic on malformed patterns", func() {
+			Expect(func() { filter.PathGlob("[") }).To(Panic())
+		})
+	})
+
+	Describe("PathRegexp", func() {
+		It("should match paths", func() {
+			f := filter.PathRegexp(regexp.MustCompile(`\.go$`))
+
+			Expect(f(op.Stat{Name: "main.go"})).To(Succeed())
+			Expect(f(op.Stat{Name: "main.rs"})).To(MatchError(syscall.ENOENT))
+		})
+	})
+
+	Describe("UnderDir", func() {
+		It("should allow paths beneath the directory", func() {
+			f := filter.UnderDir("src")
+
+			Expect(f(op.Stat{Name: "src"})).To(Succeed())
+			Expect(f(op.Stat{Name: "src/main.go"})).To(Succeed())
+			Expect(f(op.Stat{Name: "srcs/main.go"})).To(MatchError(syscall.ENOENT))
+			Expect(f(op.Stat{Name: "src/../main.go"})).To(MatchError(syscall.ENOENT))
+		})
+
+		It("should not allow escaping the current directory", func() {
+			Expect(filter.UnderDir(".")(op.Stat{Name: "../main.go"})).To(MatchError(syscall.ENOENT))
+		})
+
+		It("should not allow symlinks to outside the directory", func() {
+			f := filter.UnderDir("src")
+
+			Expect(f(op.Symlink{Oldname: "/etc/passwd", Newname: "src/passwd"})).To(HaveOccurred())
+		})
+	})
+
+	Describe("ReadOnly", func() {
+		DescribeTable("should deny writes",
+			func(operation op.Operation) {
+				Expect(filter.ReadOnly()(operation)).To(MatchError(syscall.EPERM))
+			},
+			Entry(nil, op.Create{Name: "a"}),
+			Entry(nil, op.Mkdir{Name: "a"}),
+			Entry(nil, op.Remove{Name: "a"}),
+			Entry(nil, op.Rename{Oldname: "a", Newname: "b"}),
+			Entry(nil, op.Write{Name: "a"}),
+			Entry(nil, op.WriteAt{Name: "a"}),
+			Entry(nil, op.Truncate{Name: "a"}),
+			Entry(nil, op.OpenFile{Name: "a", Flag: os.O_WRONLY}),
+		)
+
+		DescribeTable("should allow reads",
+			func(operation op.Operation) {
+				Expect(filter.ReadOnly()(operation)).To(Succeed())
+			},
+			Entry(nil, op.Open{Name: "a"}),
+			Entry(nil, op.Stat{Name: "a"}),
+			Entry(nil, op.Read{Name: "a"}),
+			Entry(nil, op.OpenFile{Name: "a", Flag: os.O_RDONLY}),
+		)
+	})
+
+	Describe("MaxFileSize", func() {
+		var fsys afero.Fs
+
+		BeforeEach(func() {
+			fsys = afero.NewMemMapFs()
+			Expect(afero.WriteFile(fsys, "test.txt", []byte("testing"), os.ModePerm)).To(Succeed())
+		})
+
+		It("should allow writes within the limit", func() {
+			f := filter.MaxFileSize(fsys, 10)
+
+			Expect(f(op.Write{Name: "test.txt", Data: []byte("123")})).To(Succeed())
+			Expect(f(op.WriteAt{Name: "test.txt", Offset: 0, Data: []byte("1234567890")})).To(Succeed())
+			Expect(f(op.Write{Name: "new.txt", Data: []byte("1234567890")})).To(Succeed())
+		})
+
+		It("should deny writes beyond the limit", func() {
+			f := filter.MaxFileSize(fsys, 10)
+
+			Expect(f(op.Write{Name: "test.txt", Data: []byte("1234")})).To(MatchError(syscall.EFBIG))
+			Expect(f(op.WriteAt{Name: "test.txt", Offset: 8, Data: []byte("123")})).To(MatchError(syscall.EFBIG))
+			Expect(f(op.Truncate{Name: "test.txt", Size: 11})).To(MatchError(syscall.EFBIG))
+		})
+
+		It("should limit files written through an Fs", func() {
+			filtered := filter.NewFs(fsys, filter.MaxFileSize(fsys, 10))
+			file, err := filtered.Open("test.txt")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = file.Write([]byte("1234"))
+
+			Expect(err).To(MatchError(syscall.EFBIG))
+		})
+	})
+})
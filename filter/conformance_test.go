@@ -0,0 +1,20 @@
+package filter_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/filter"
+	"github.com/unmango/aferox/op"
+	"github.com/unmango/aferox/testing/conformance"
+)
+
+var _ = Describe("Conformance", func() {
+	conformance.Describe("allowing everything", func(seed afero.Fs) (afero.Fs, error) {
+		return filter.NewFs(seed, func(op.Operation) error { return nil }), nil
+	}, conformance.Without(conformance.NoSymlink))
+
+	conformance.Describe("with ReadOnly", func(seed afero.Fs) (afero.Fs, error) {
+		return filter.NewFs(seed, filter.ReadOnly()), nil
+	}, conformance.Without(conformance.ReadOnly))
+})
@@ -0,0 +1,28 @@
+package filter
+
+import (
+	"fmt"
+
+	"github.com/unmango/aferox/op"
+)
+
+// Error reports an operation denied by a filter.
+// Err holds the underlying reason, such as [syscall.ENOENT].
+type Error struct {
+	Operation op.Operation
+	Rule      string
+	Err       error
+}
+
+func (e *Error) Error() string {
+	name := op.Name(e.Operation)
+	if name == "" {
+		name = fmt.Sprintf("%T", e.Operation)
+	}
+
+	return fmt.Sprintf("%s %s: denied by %s: %v", name, e.Operation.Path(), e.Rule, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
@@ -9,11 +9,14 @@ import (
 )
 
 type File struct {
-	file   afero.File
-	filter Filter
+	file afero.File
+	fs   *Fs
+	name string
+	dir  bool
 }
 
-// Close implements afero.File.
+// Close implements afero.File. Closing is never filtered,
+// so the underlying file is always released.
 func (f *File) Close() error {
 	return f.file.Close()
 }
@@ -25,11 +28,22 @@ func (f *File) Name() string {
 
 // Read implements afero.File.
 func (f *File) Read(p []byte) (n int, err error) {
+	if err := f.matches(op.Read{Name: f.name}); err != nil {
+		return 0, err
+	}
+
 	return f.file.Read(p)
 }
 
 // ReadAt implements afero.File.
 func (f *File) ReadAt(p []byte, off int64) (n int, err error) {
+	if err := f.matches(op.ReadAt{
+		Name:   f.name,
+		Offset: off,
+	}); err != nil {
+		return 0, err
+	}
+
 	return f.file.ReadAt(p, off)
 }
 
@@ -42,9 +56,11 @@ func (f *File) Readdir(count int) (res []fs.FileInfo, err error) {
 	}
 
 	for _, i := range infos {
-		path := filepath.Join(f.file.Name(), i.Name())
+		path := filepath.Join(f.name, i.Name())
 		operation := op.Readdir{Name: path, Count: count}
-		if i.IsDir() || f.filter(operation) == nil {
+		if i.IsDir() && f.fs.visible(operation) == nil {
+			res = append(res, i)
+		} else if !i.IsDir() && f.fs.matches(operation) == nil {
 			res = append(res, i)
 		}
 	}
@@ -68,6 +84,14 @@ func (f *File) Readdirnames(n int) (names []string, err error) {
 
 // Seek implements afero.File.
 func (f *File) Seek(offset int64, whence int) (int64, error) {
+	if err := f.matches(op.Seek{
+		Name:   f.name,
+		Offset: offset,
+		Whence: whence,
+	}); err != nil {
+		return 0, err
+	}
+
 	return f.file.Seek(offset, whence)
 }
 
@@ -78,25 +102,67 @@ func (f *File) Stat() (fs.FileInfo, error) {
 
 // Sync implements afero.File.
 func (f *File) Sync() error {
+	if err := f.matches(op.Sync{Name: f.name}); err != nil {
+		return err
+	}
+
 	return f.file.Sync()
 }
 
 // Truncate implements afero.File.
 func (f *File) Truncate(size int64) error {
+	if err := f.matches(op.Truncate{
+		Name: f.name,
+		Size: size,
+	}); err != nil {
+		return err
+	}
+
 	return f.file.Truncate(size)
 }
 
 // Write implements afero.File.
 func (f *File) Write(p []byte) (n int, err error) {
+	if err := f.matches(op.Write{
+		Name: f.name,
+		Data: p,
+	}); err != nil {
+		return 0, err
+	}
+
 	return f.file.Write(p)
 }
 
 // WriteAt implements afero.File.
 func (f *File) WriteAt(p []byte, off int64) (n int, err error) {
+	if err := f.matches(op.WriteAt{
+		Name:   f.name,
+		Offset: off,
+		Data:   p,
+	}); err != nil {
+		return 0, err
+	}
+
 	return f.file.WriteAt(p, off)
 }
 
 // WriteString implements afero.File.
 func (f *File) WriteString(s string) (ret int, err error) {
+	if err := f.matches(op.Write{
+		Name: f.name,
+		Data: []byte(s),
+	}); err != nil {
+		return 0, err
+	}
+
 	return f.file.WriteString(s)
 }
+
+// matches evaluates operation unless the file is a directory, as [Fs] does.
+func (f *File) matches(operation op.Operation) error {
+	if f.dir {
+		return nil
+	} else {
+		return f.fs.matches(operation)
+	}
+}
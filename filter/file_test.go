@@ -1,6 +1,7 @@
 package filter_test
 
 import (
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -228,4 +229,82 @@ var _ = Describe("File", func() {
 			Expect(err).To(MatchError(errToReturn))
 		})
 	})
+
+	Describe("Filtering file operations", func() {
+		var (
+			base     afero.Fs
+			filtered afero.Fs
+			seen     []op.Operation
+			file     afero.File
+		)
+
+		BeforeEach(func() {
+			base = afero.NewMemMapFs()
+			Expect(afero.WriteFile(base, "test.txt", []byte("hello"), os.ModePerm)).To(Succeed())
+			seen = nil
+
+			filtered = filter.NewFs(base, func(o op.Operation) error {
+				seen = append(seen, o)
+				switch o.(type) {
+				case op.Write, op.WriteAt, op.Truncate, op.Close:
+					return os.ErrPermission
+				default:
+					return nil
+				}
+			})
+
+			var err error
+			file, err = filtered.OpenFile("test.txt", os.O_RDWR, os.ModePerm)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should allow reads", func() {
+			buf := make([]byte, 5)
+			_, err := file.Read(buf)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = file.ReadAt(buf, 0)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(seen).To(ContainElements(
+				op.Read{Name: "test.txt"},
+				op.ReadAt{Name: "test.txt", Offset: 0},
+			))
+		})
+
+		It("should deny writes", func() {
+			_, err := file.Write([]byte("world"))
+			Expect(err).To(MatchError(os.ErrPermission))
+			_, err = file.WriteAt([]byte("world"), 5)
+			Expect(err).To(MatchError(os.ErrPermission))
+			_, err = file.WriteString("world")
+			Expect(err).To(MatchError(os.ErrPermission))
+			Expect(file.Truncate(0)).To(MatchError(os.ErrPermission))
+
+			Expect(afero.ReadFile(base, "test.txt")).To(Equal([]byte("hello")))
+			Expect(seen).To(ContainElements(
+				op.Write{Name: "test.txt", Data: []byte("world")},
+				op.WriteAt{Name: "test.txt", Offset: 5, Data: []byte("world")},
+				op.Truncate{Name: "test.txt", Size: 0},
+			))
+		})
+
+		It("should evaluate seek and sync", func() {
+			_, err := file.Seek(1, io.SeekStart)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(file.Sync()).To(Succeed())
+
+			Expect(seen).To(ContainElements(
+				op.Seek{Name: "test.txt", Offset: 1, Whence: io.SeekStart},
+				op.Sync{Name: "test.txt"},
+			))
+		})
+
+		It("should always close the underlying file", func() {
+			Expect(file.Close()).To(Succeed())
+
+			_, err := file.Read(make([]byte, 5))
+			Expect(err).To(MatchError(afero.ErrFileClosed))
+			Expect(seen).NotTo(ContainElement(BeAssignableToTypeOf(op.Close{})))
+		})
+	})
 })
@@ -1,13 +1,17 @@
 package filter
 
 import (
+	"errors"
 	"fmt"
 	"io/fs"
+	"os"
+	"path/filepath"
 	"syscall"
 	"time"
 
 	"github.com/spf13/afero"
 	"github.com/unmango/aferox/op"
+	"github.com/unmango/go/fopt"
 )
 
 type (
@@ -18,22 +22,48 @@ type (
 	Predicate func(op.Operation) bool
 )
 
-func FromPredicate(base afero.Fs, pred Predicate) afero.Fs {
+type options struct {
+	filterDirs    bool
+	hideEmptyDirs bool
+}
+
+type Option func(*options)
+
+// HideEmptyDirs hides directories whose contents are all filtered out, as
+// git does for directories containing only ignored files. Directories that
+// are actually empty remain visible. Checking a directory reads its contents
+// recursively, so this is best suited to small trees.
+func HideEmptyDirs(options *options) {
+	options.hideEmptyDirs = true
+}
+
+// FilterDirs evaluates operations on existing directories with the filter,
+// which otherwise only applies to files. Denied directories are omitted from
+// listings, while paths beneath them are still evaluated on their own.
+func FilterDirs(options *options) {
+	options.filterDirs = true
+}
+
+func FromPredicate(base afero.Fs, pred Predicate, options ...Option) afero.Fs {
 	return NewFs(base, func(operation op.Operation) error {
 		if pred(operation) {
 			return nil
 		}
 		return syscall.ENOENT
-	})
+	}, options...)
 }
 
-func NewFs(base afero.Fs, filter Filter) afero.Fs {
-	return &Fs{src: base, filter: filter}
+func NewFs(base afero.Fs, filter Filter, options ...Option) afero.Fs {
+	fs := &Fs{src: base, filter: filter}
+	fopt.ApplyAll(&fs.opts, options)
+
+	return fs
 }
 
 type Fs struct {
 	src    afero.Fs
 	filter Filter
+	opts   options
 }
 
 // Chmod implements afero.Fs.
@@ -77,16 +107,53 @@ func (f *Fs) Create(name string) (afero.File, error) {
 	if err := f.matches(op.Create{Name: name}); err != nil {
 		return nil, err
 	}
-	return f.src.Create(name)
+
+	file, err := f.src.Create(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &File{
+		file: file,
+		fs:   f,
+		name: name,
+	}, nil
+}
+
+// LstatIfPossible implements afero.Lstater.
+func (f *Fs) LstatIfPossible(name string) (fs.FileInfo, bool, error) {
+	if err := f.dirOrMatches(op.Lstat{Name: name}); err != nil {
+		return nil, false, err
+	}
+	if lstater, ok := f.src.(afero.Lstater); ok {
+		return lstater.LstatIfPossible(name)
+	}
+
+	info, err := f.src.Stat(name)
+	return info, false, err
 }
 
 // Mkdir implements afero.Fs.
 func (f *Fs) Mkdir(name string, perm fs.FileMode) error {
+	if err := f.matches(op.Mkdir{
+		Name: name,
+		Perm: perm,
+	}); err != nil {
+		return err
+	}
+
 	return f.src.Mkdir(name, perm)
 }
 
 // MkdirAll implements afero.Fs.
 func (f *Fs) MkdirAll(path string, perm fs.FileMode) error {
+	if err := f.matches(op.MkdirAll{
+		Name: path,
+		Perm: perm,
+	}); err != nil {
+		return err
+	}
+
 	return f.src.MkdirAll(path, perm)
 }
 
@@ -101,10 +168,15 @@ func (f *Fs) Open(name string) (afero.File, error) {
 	if err != nil {
 		return nil, err
 	}
-	if !dir {
-		if err := f.matches(op.Open{Name: name}); err != nil {
-			return nil, err
-		}
+
+	operation := op.Open{Name: name}
+	if dir {
+		err = f.visible(operation)
+	} else {
+		err = f.matches(operation)
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	file, err := f.src.Open(name)
@@ -113,21 +185,53 @@ func (f *Fs) Open(name string) (afero.File, error) {
 	}
 
 	return &File{
-		file:   file,
-		filter: f.filter,
+		file: file,
+		fs:   f,
+		name: name,
+		dir:  dir,
 	}, nil
 }
 
 // OpenFile implements afero.Fs.
 func (f *Fs) OpenFile(name string, flag int, perm fs.FileMode) (afero.File, error) {
-	if err := f.dirOrMatches(op.OpenFile{
-		Name: name,
-		Flag: flag,
-		Perm: perm,
-	}); err != nil {
+	dir, err := afero.IsDir(f.src, name)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
 		return nil, err
 	}
-	return f.src.OpenFile(name, flag, perm)
+
+	operation := op.OpenFile{Name: name, Flag: flag, Perm: perm}
+	if dir {
+		err = f.visible(operation)
+	} else {
+		err = f.matches(operation)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := f.src.OpenFile(name, flag, perm)
+	if err != nil {
+		return nil, err
+	}
+
+	return &File{
+		file: file,
+		fs:   f,
+		name: name,
+		dir:  dir,
+	}, nil
+}
+
+// ReadlinkIfPossible implements afero.LinkReader.
+func (f *Fs) ReadlinkIfPossible(name string) (string, error) {
+	if err := f.matches(op.Readlink{Name: name}); err != nil {
+		return "", err
+	}
+	if reader, ok := f.src.(afero.LinkReader); ok {
+		return reader.ReadlinkIfPossible(name)
+	}
+
+	return "", &fs.PathError{Op: "readlink", Path: name, Err: afero.ErrNoReadlink}
 }
 
 // Remove implements afero.Fs.
@@ -138,32 +242,56 @@ func (f *Fs) Remove(name string) error {
 	return f.src.Remove(name)
 }
 
-// RemoveAll implements afero.Fs.
+// RemoveAll implements afero.Fs. Directories are only removed when
+// the filter allows removing every file beneath them.
 func (f *Fs) RemoveAll(path string) error {
 	dir, err := afero.IsDir(f.src, path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
 	if err != nil {
 		return err
 	}
-	if !dir {
-		if err = f.matches(op.RemoveAll{Name: path}); err != nil {
-			return err
+	operation := op.RemoveAll{Name: path}
+	if dir {
+		err = f.visible(operation)
+		if err == nil {
+			err = f.removable(path)
 		}
+	} else {
+		err = f.matches(operation)
+	}
+	if err != nil {
+		return err
 	}
 
 	return f.src.RemoveAll(path)
 }
 
-// Rename implements afero.Fs.
+// Rename implements afero.Fs. Directories are only renamed when the
+// filter allows moving every file beneath them to its new path.
 func (f *Fs) Rename(oldname string, newname string) error {
-	dir, err := afero.IsDir(f.src, oldname)
+	info, err := f.src.Stat(oldname)
 	if err != nil {
 		return err
 	}
-	if dir {
-		return nil
+
+	// Renaming creates newname, so it must be allowed as if it were created
+	if info.IsDir() {
+		if err = f.visible(op.Rename{Oldname: oldname, Newname: newname}); err != nil {
+			return err
+		}
+		if err = f.matches(op.Mkdir{Name: newname, Perm: info.Mode().Perm()}); err != nil {
+			return err
+		}
+		err = f.renamable(oldname, newname)
+	} else {
+		if err = f.matches(op.Rename{Oldname: oldname, Newname: newname}); err != nil {
+			return err
+		}
+		err = f.matches(op.Create{Name: newname})
 	}
-	operation := op.Rename{Oldname: oldname, Newname: newname}
-	if err = f.matches(operation); err != nil {
+	if err != nil {
 		return err
 	}
 
@@ -179,18 +307,110 @@ func (f *Fs) Stat(name string) (fs.FileInfo, error) {
 	return f.src.Stat(name)
 }
 
+// SymlinkIfPossible implements afero.Linker.
+func (f *Fs) SymlinkIfPossible(oldname string, newname string) error {
+	if err := f.matches(op.Symlink{
+		Oldname: oldname,
+		Newname: newname,
+	}); err != nil {
+		return err
+	}
+	if linker, ok := f.src.(afero.Linker); ok {
+		return linker.SymlinkIfPossible(oldname, newname)
+	}
+
+	return &os.LinkError{Op: "symlink", Old: oldname, New: newname, Err: afero.ErrNoSymlink}
+}
+
 func (f *Fs) dirOrMatches(operation op.Operation) error {
 	dir, err := afero.IsDir(f.src, operation.Path())
 	if err != nil {
 		return err
 	}
 	if dir {
-		return nil
+		return f.visible(operation)
 	}
 
 	return f.matches(operation)
 }
 
+// visible returns an error when the directory operation applies to
+// is denied with [FilterDirs] or hidden by [HideEmptyDirs].
+func (f *Fs) visible(operation op.Operation) error {
+	if f.opts.filterDirs {
+		if err := f.matches(operation); err != nil {
+			return err
+		}
+	}
+	if !f.opts.hideEmptyDirs {
+		return nil
+	}
+	if hidden, err := f.hidden(operation.Path()); err != nil {
+		return err
+	} else if hidden {
+		return &Error{Operation: operation, Rule: "HideEmptyDirs", Err: syscall.ENOENT}
+	}
+
+	return nil
+}
+
+// hidden reports whether dir has contents, all of which are filtered out.
+func (f *Fs) hidden(dir string) (bool, error) {
+	infos, err := afero.ReadDir(f.src, dir)
+	if err != nil || len(infos) == 0 {
+		return false, err
+	}
+
+	for _, info := range infos {
+		path := filepath.Join(dir, info.Name())
+		operation := op.Readdir{Name: path, Count: -1}
+		if !info.IsDir() {
+			if f.matches(operation) == nil {
+				return false, nil
+			}
+		} else if f.opts.filterDirs && f.matches(operation) != nil {
+			continue
+		} else if hidden, err := f.hidden(path); err != nil || !hidden {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// removable returns an error when the filter denies removing a file beneath dir.
+func (f *Fs) removable(dir string) error {
+	return afero.Walk(f.src, dir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		return f.matches(op.RemoveAll{Name: path})
+	})
+}
+
+// renamable returns an error when the filter denies moving a file beneath
+// olddir to the same path beneath newdir.
+func (f *Fs) renamable(olddir, newdir string) error {
+	return afero.Walk(f.src, olddir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(olddir, path)
+		if err != nil {
+			return err
+		}
+
+		newpath := filepath.Join(newdir, rel)
+		if err := f.matches(op.Rename{Oldname: path, Newname: newpath}); err != nil {
+			return err
+		}
+
+		return f.matches(op.Create{Name: newpath})
+	})
+}
+
 func (f *Fs) matches(operation op.Operation) error {
 	if f.filter == nil {
 		return nil
@@ -198,3 +418,9 @@ func (f *Fs) matches(operation op.Operation) error {
 		return f.filter(operation)
 	}
 }
+
+var (
+	_ afero.Lstater    = (*Fs)(nil)
+	_ afero.Linker     = (*Fs)(nil)
+	_ afero.LinkReader = (*Fs)(nil)
+)
@@ -4,6 +4,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
@@ -210,6 +211,191 @@ var _ = Describe("Fs", func() {
 		})
 	})
 
+	Describe("Mkdir", func() {
+		It("should not create a filtered directory", func() {
+			base := afero.NewMemMapFs()
+			filtered := filter.FromPredicate(base, func(o op.Operation) bool {
+				return o.Path() != "testdir"
+			})
+
+			err := filtered.Mkdir("testdir", os.ModePerm)
+
+			Expect(err).To(MatchError(syscall.ENOENT))
+			Expect(afero.Exists(base, "testdir")).To(BeFalse())
+		})
+
+		It("should not create filtered nested directories", func() {
+			base := afero.NewMemMapFs()
+			filtered := filter.FromPredicate(base, func(o op.Operation) bool {
+				_, ok := o.(op.MkdirAll)
+				return !ok
+			})
+
+			err := filtered.MkdirAll("path/to/dir", os.ModePerm)
+
+			Expect(err).To(MatchError(syscall.ENOENT))
+			Expect(afero.Exists(base, "path")).To(BeFalse())
+		})
+	})
+
+	Describe("OpenFile", func() {
+		It("should filter directory entries", func() {
+			base := afero.NewMemMapFs()
+			Expect(afero.WriteFile(base, "dir/a.txt", []byte("test"), os.ModePerm)).To(Succeed())
+			Expect(afero.WriteFile(base, "dir/b.md", []byte("test"), os.ModePerm)).To(Succeed())
+			filtered := filter.FromPredicate(base, func(o op.Operation) bool {
+				return filepath.Ext(o.Path()) != ".md"
+			})
+
+			file, err := filtered.OpenFile("dir", os.O_RDONLY, 0)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(file.Readdirnames(-1)).To(ConsistOf("a.txt"))
+		})
+
+		It("should create new files", func() {
+			base := afero.NewMemMapFs()
+			filtered := filter.FromPredicate(base, func(o op.Operation) bool {
+				return true
+			})
+
+			file, err := filtered.OpenFile("new.txt", os.O_RDWR|os.O_CREATE, os.ModePerm)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(file.Close()).To(Succeed())
+
+			Expect(afero.Exists(base, "new.txt")).To(BeTrue())
+		})
+
+		It("should filter writes to opened files", func() {
+			base := afero.NewMemMapFs()
+			filtered := filter.NewFs(base, filter.ForOps[op.Write](func(op.Operation) error {
+				return syscall.EPERM
+			}))
+
+			file, err := filtered.OpenFile("new.txt", os.O_RDWR|os.O_CREATE, os.ModePerm)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = file.Write([]byte("testing"))
+			Expect(err).To(MatchError(syscall.EPERM))
+		})
+
+		It("should filter writes to created files", func() {
+			base := afero.NewMemMapFs()
+			filtered := filter.NewFs(base, filter.ForOps[op.Write](filter.ReadOnly()))
+
+			file, err := filtered.Create("new.txt")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = file.Write([]byte("testing"))
+			Expect(err).To(MatchError(syscall.EPERM))
+			Expect(afero.ReadFile(base, "new.txt")).To(BeEmpty())
+		})
+
+		It("should limit the size of created files", func() {
+			base := afero.NewMemMapFs()
+			filtered := filter.NewFs(base, filter.MaxFileSize(base, 4))
+
+			file, err := filtered.Create("new.txt")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = file.Write([]byte("0123456789"))
+			Expect(err).To(HaveOccurred())
+			Expect(afero.ReadFile(base, "new.txt")).To(BeEmpty())
+		})
+	})
+
+	Describe("HideEmptyDirs", func() {
+		var (
+			base     afero.Fs
+			filtered afero.Fs
+		)
+
+		BeforeEach(func() {
+			base = afero.NewMemMapFs()
+			Expect(afero.WriteFile(base, "src/main.go", []byte("test"), os.ModePerm)).To(Succeed())
+			Expect(afero.WriteFile(base, "bin/out/main", []byte("test"), os.ModePerm)).To(Succeed())
+			Expect(base.Mkdir("empty", os.ModePerm)).To(Succeed())
+			filtered = filter.FromPredicate(base, func(o op.Operation) bool {
+				return !strings.HasPrefix(o.Path(), "bin/")
+			}, filter.HideEmptyDirs)
+		})
+
+		It("should hide directories whose contents are filtered", func() {
+			_, err := filtered.Stat("bin")
+			Expect(err).To(MatchError(syscall.ENOENT))
+
+			_, err = filtered.Open("bin/out")
+			Expect(err).To(MatchError(syscall.ENOENT))
+		})
+
+		It("should show directories with unfiltered contents", func() {
+			_, err := filtered.Stat("src")
+
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should show empty directories", func() {
+			_, err := filtered.Stat("empty")
+
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should omit hidden directories from listings", func() {
+			Expect(afero.ReadDir(filtered, "")).To(ConsistOf(
+				HaveField("Name()", "src"),
+				HaveField("Name()", "empty"),
+			))
+		})
+
+		It("should not hide directories by default", func() {
+			filtered := filter.FromPredicate(base, func(o op.Operation) bool {
+				return !strings.HasPrefix(o.Path(), "bin/")
+			})
+
+			_, err := filtered.Stat("bin")
+
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("FilterDirs", func() {
+		var (
+			base     afero.Fs
+			filtered afero.Fs
+		)
+
+		BeforeEach(func() {
+			base = afero.NewMemMapFs()
+			Expect(afero.WriteFile(base, "src/main.go", []byte("test"), os.ModePerm)).To(Succeed())
+			Expect(base.Mkdir("bin", os.ModePerm)).To(Succeed())
+			filtered = filter.FromPredicate(base, func(o op.Operation) bool {
+				return o.Path() != "bin"
+			}, filter.FilterDirs)
+		})
+
+		It("should deny operations on directories", func() {
+			_, err := filtered.Stat("bin")
+			Expect(err).To(MatchError(syscall.ENOENT))
+
+			_, err = filtered.Open("bin")
+			Expect(err).To(MatchError(syscall.ENOENT))
+
+			Expect(filtered.RemoveAll("bin")).To(MatchError(syscall.ENOENT))
+		})
+
+		It("should allow operations on other directories", func() {
+			_, err := filtered.Stat("src")
+
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should omit denied directories from listings", func() {
+			Expect(afero.ReadDir(filtered, "")).To(ConsistOf(
+				HaveField("Name()", "src"),
+			))
+		})
+	})
+
 	Describe("MkdirAll", func() {
 		It("should create nested directories", func() {
 			base := afero.NewMemMapFs()
@@ -268,6 +454,28 @@ var _ = Describe("Fs", func() {
 
 			Expect(err).To(MatchError(syscall.ENOENT))
 		})
+
+		It("should ignore missing paths", func() {
+			filtered := filter.FromPredicate(afero.NewMemMapFs(), func(o op.Operation) bool {
+				return true
+			})
+
+			Expect(filtered.RemoveAll("missing")).To(Succeed())
+		})
+
+		It("should not remove directories containing filtered files", func() {
+			base := afero.NewMemMapFs()
+			Expect(afero.WriteFile(base, "testdir/keep.txt", []byte("test"), os.ModePerm)).To(Succeed())
+			Expect(afero.WriteFile(base, "testdir/other.txt", []byte("test"), os.ModePerm)).To(Succeed())
+			filtered := filter.FromPredicate(base, func(o op.Operation) bool {
+				return !strings.HasSuffix(o.Path(), "keep.txt")
+			})
+
+			err := filtered.RemoveAll("testdir")
+
+			Expect(err).To(MatchError(syscall.ENOENT))
+			Expect(afero.Exists(base, "testdir/other.txt")).To(BeTrue())
+		})
 	})
 
 	Describe("Rename", func() {
@@ -315,16 +523,68 @@ var _ = Describe("Fs", func() {
 			Expect(err).To(MatchError(syscall.ENOENT))
 		})
 
-		It("should allow renaming directories", func() {
+		It("should rename directories", func() {
 			base := afero.NewMemMapFs()
 			Expect(base.Mkdir("olddir", os.ModePerm)).To(Succeed())
 			filtered := filter.FromPredicate(base, func(o op.Operation) bool {
-				return false
+				_, ok := o.(op.Mkdir)
+				return ok
 			})
 
 			err := filtered.Rename("olddir", "newdir")
 
 			Expect(err).NotTo(HaveOccurred())
+			Expect(afero.DirExists(base, "newdir")).To(BeTrue())
+			Expect(afero.DirExists(base, "olddir")).To(BeFalse())
+		})
+
+		It("should not rename directories to a filtered name", func() {
+			base := afero.NewMemMapFs()
+			Expect(base.Mkdir("olddir", os.ModePerm)).To(Succeed())
+			filtered := filter.FromPredicate(base, func(o op.Operation) bool {
+				return o.Path() != "newdir"
+			})
+
+			err := filtered.Rename("olddir", "newdir")
+
+			Expect(err).To(MatchError(syscall.ENOENT))
+			Expect(afero.DirExists(base, "olddir")).To(BeTrue())
+		})
+
+		It("should not rename directories containing filtered files", func() {
+			base := afero.NewMemMapFs()
+			Expect(afero.WriteFile(base, "src/private/key.txt", []byte("secret"), os.ModePerm)).To(Succeed())
+			filtered := filter.NewFs(base, filter.Not(filter.PathGlob("src/private/**")))
+
+			err := filtered.Rename("src/private", "src/leaked")
+
+			Expect(err).To(HaveOccurred())
+			Expect(afero.Exists(base, "src/private/key.txt")).To(BeTrue())
+			Expect(afero.Exists(base, "src/leaked")).To(BeFalse())
+		})
+
+		It("should not rename directories when files would move to filtered paths", func() {
+			base := afero.NewMemMapFs()
+			Expect(afero.WriteFile(base, "src/public/key.txt", []byte("secret"), os.ModePerm)).To(Succeed())
+			filtered := filter.NewFs(base, filter.Not(filter.PathGlob("src/private/**")))
+
+			err := filtered.Rename("src/public", "src/private")
+
+			Expect(err).To(HaveOccurred())
+			Expect(afero.Exists(base, "src/public/key.txt")).To(BeTrue())
+		})
+
+		It("should filter by the new name", func() {
+			base := afero.NewMemMapFs()
+			Expect(afero.WriteFile(base, "old.txt", []byte("test"), os.ModePerm)).To(Succeed())
+			filtered := filter.FromPredicate(base, func(o op.Operation) bool {
+				return filepath.Ext(o.Path()) == ".txt"
+			})
+
+			err := filtered.Rename("old.txt", "new.md")
+
+			Expect(err).To(MatchError(syscall.ENOENT))
+			Expect(afero.Exists(base, "old.txt")).To(BeTrue())
 		})
 	})
 
@@ -407,4 +667,62 @@ var _ = Describe("Fs", func() {
 			Expect(err).To(MatchError(expectedErr))
 		})
 	})
+
+	Describe("Links", func() {
+		var (
+			dir      string
+			filtered afero.Fs
+		)
+
+		BeforeEach(func() {
+			dir = GinkgoT().TempDir()
+			base := afero.NewBasePathFs(afero.NewOsFs(), dir)
+			Expect(afero.WriteFile(base, "test.txt", []byte("testing"), os.ModePerm)).To(Succeed())
+			Expect(base.(afero.Linker).SymlinkIfPossible("test.txt", "allowed")).To(Succeed())
+			Expect(base.(afero.Linker).SymlinkIfPossible("test.txt", "denied")).To(Succeed())
+
+			filtered = filter.FromPredicate(base, func(o op.Operation) bool {
+				return o.Path() != "denied" && o.Path() != "new"
+			})
+		})
+
+		It("should lstat allowed links", func() {
+			info, lstat, err := filtered.(afero.Lstater).LstatIfPossible("allowed")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lstat).To(BeTrue())
+			Expect(info.Mode() & fs.ModeSymlink).NotTo(BeZero())
+		})
+
+		It("should not lstat denied links", func() {
+			_, _, err := filtered.(afero.Lstater).LstatIfPossible("denied")
+
+			Expect(err).To(MatchError(syscall.ENOENT))
+		})
+
+		It("should read allowed links", func() {
+			Expect(filtered.(afero.LinkReader).ReadlinkIfPossible("allowed")).To(HaveSuffix("test.txt"))
+		})
+
+		It("should not read denied links", func() {
+			_, err := filtered.(afero.LinkReader).ReadlinkIfPossible("denied")
+
+			Expect(err).To(MatchError(syscall.ENOENT))
+		})
+
+		It("should filter symlinks by the new name", func() {
+			linker := filtered.(afero.Linker)
+
+			Expect(linker.SymlinkIfPossible("test.txt", "new")).To(MatchError(syscall.ENOENT))
+			Expect(linker.SymlinkIfPossible("test.txt", "other")).To(Succeed())
+		})
+
+		It("should report when the base Fs cannot link", func() {
+			filtered := filter.NewFs(afero.NewMemMapFs(), nil)
+
+			err := filtered.(afero.Linker).SymlinkIfPossible("test.txt", "link")
+
+			Expect(err).To(MatchError(afero.ErrNoSymlink))
+		})
+	})
 })
@@ -0,0 +1,59 @@
+package filter
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// compileGlob converts a glob pattern to an anchored regular expression.
+// A "*" matches any sequence of characters other than "/", "**" matches
+// any sequence of path segments, "?" matches a single character other than
+// "/" and "[...]" matches a character class as in [filepath.Match].
+func compileGlob(pattern string) (*regexp.Regexp, error) {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					// "**/" matches zero or more leading directories
+					i++
+					b.WriteString("(?:.*/)?")
+				} else {
+					b.WriteString(".*")
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			b.WriteString(pattern[i : i+end+2])
+			i += end + 1
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+			}
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+
+	return regexp.Compile(b.String())
+}
+
+// slashPath returns the cleaned, slash-separated form of path
+// without a leading "./", for matching against patterns.
+func slashPath(path string) string {
+	return filepath.ToSlash(filepath.Clean(path))
+}
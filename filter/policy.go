@@ -0,0 +1,182 @@
+package filter
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"syscall"
+
+	"github.com/unmango/aferox/op"
+	"go.yaml.in/yaml/v3"
+)
+
+// Effect is the outcome of a policy rule.
+type Effect string
+
+const (
+	Allow Effect = "allow"
+	Deny  Effect = "deny"
+)
+
+// Policy is a declarative description of a Filter.
+// Rules are evaluated in order and the first rule matching an operation
+// decides its effect. Operations matching no rule receive the Default effect,
+// which is [Deny] when unset.
+//
+//	default: deny
+//	rules:
+//	  - name: sources
+//	    ops: [Open, Read, ReadAt, Stat]
+//	    path: "src/**/*.go"
+//	    effect: allow
+type Policy struct {
+	Default Effect `json:"default,omitempty" yaml:"default,omitempty"`
+	Rules   []Rule `json:"rules" yaml:"rules"`
+}
+
+// Rule matches operations by type and path.
+type Rule struct {
+	// Name identifies the rule in errors. Rules are identified
+	// by their index when Name is empty.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	// Ops lists the names of the operation types the rule applies to,
+	// as returned by [op.Name]. The rule applies to all operations when empty.
+	Ops []string `json:"ops,omitempty" yaml:"ops,omitempty"`
+
+	// Path is a glob pattern as accepted by [PathGlob].
+	// The rule applies to all paths when empty.
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+
+	Effect Effect `json:"effect" yaml:"effect"`
+}
+
+// ParsePolicy parses a YAML or JSON encoded policy.
+func ParsePolicy(data []byte) (*Policy, error) {
+	return ReadPolicy(bytes.NewReader(data))
+}
+
+// ReadPolicy reads a YAML or JSON encoded policy from r.
+func ReadPolicy(r io.Reader) (*Policy, error) {
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+
+	policy := &Policy{}
+	if err := dec.Decode(policy); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("filter: parsing policy: %w", err)
+	}
+
+	return policy, nil
+}
+
+// Compile returns a Filter enforcing the policy. Denied operations fail
+// with an [*Error] naming the rule that denied them and wrapping [syscall.EPERM].
+func (p *Policy) Compile() (Filter, error) {
+	def, err := effect(p.Default, Deny)
+	if err != nil {
+		return nil, fmt.Errorf("filter: policy default: %w", err)
+	}
+
+	rules := make([]compiledRule, len(p.Rules))
+	for i, r := range p.Rules {
+		c, err := r.compile(i)
+		if err != nil {
+			return nil, fmt.Errorf("filter: policy %s: %w", c.name, err)
+		}
+
+		rules[i] = c
+	}
+
+	return func(operation op.Operation) error {
+		for _, r := range rules {
+			if !r.matches(operation) {
+				continue
+			}
+			if r.effect == Allow {
+				return nil
+			}
+
+			return &Error{Operation: operation, Rule: r.name, Err: syscall.EPERM}
+		}
+		if def == Allow {
+			return nil
+		}
+
+		return &Error{Operation: operation, Rule: "policy default", Err: syscall.EPERM}
+	}, nil
+}
+
+type compiledRule struct {
+	name   string
+	ops    []string
+	path   *regexp.Regexp
+	effect Effect
+}
+
+func (r Rule) compile(index int) (c compiledRule, err error) {
+	if r.Name != "" {
+		c.name = fmt.Sprintf("rule %q", r.Name)
+	} else {
+		c.name = fmt.Sprintf("rule %d", index)
+	}
+
+	if c.effect, err = effect(r.Effect, ""); err != nil {
+		return c, err
+	}
+
+	names := op.Names()
+	for _, name := range r.Ops {
+		if !slices.Contains(names, name) {
+			return c, fmt.Errorf("unknown operation %q", name)
+		}
+	}
+	c.ops = r.Ops
+
+	if r.Path != "" {
+		if c.path, err = compileGlob(r.Path); err != nil {
+			return c, fmt.Errorf("path %q: %w", r.Path, err)
+		}
+	}
+
+	return c, nil
+}
+
+func (r compiledRule) matches(operation op.Operation) bool {
+	if len(r.ops) > 0 && !slices.Contains(r.ops, op.Name(operation)) {
+		return false
+	}
+	if r.path == nil {
+		return true
+	}
+
+	// Deny rules match when any path an operation affects matches, and allow
+	// rules when every path does, so renames cannot escape either kind of rule
+	matched := func(path string) bool {
+		return r.path.MatchString(slashPath(path))
+	}
+	if r.effect == Deny {
+		return slices.ContainsFunc(paths(operation), matched)
+	}
+	for _, path := range paths(operation) {
+		if !matched(path) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func effect(e, def Effect) (Effect, error) {
+	switch e {
+	case Allow, Deny:
+		return e, nil
+	case "":
+		if def != "" {
+			return def, nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid effect %q, expected %q or %q", e, Allow, Deny)
+}
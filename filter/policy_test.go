@@ -0,0 +1,165 @@
+package filter_test
+
+import (
+	"errors"
+	"os"
+	"syscall"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/filter"
+	"github.com/unmango/aferox/op"
+)
+
+var _ = Describe("Policy", func() {
+	const policyYAML = `
+default: deny
+rules:
+  - name: no-secrets
+    path: "**/*.key"
+    effect: deny
+  - name: read-sources
+    ops: [Open, Read, Stat, Close]
+    path: "src/**"
+    effect: allow
+  - ops: [Write]
+    path: "out/*"
+    effect: allow
+`
+
+	It("should parse YAML", func() {
+		policy, err := filter.ParsePolicy([]byte(policyYAML))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policy.Default).To(Equal(filter.Deny))
+		Expect(policy.Rules).To(HaveLen(3))
+		Expect(policy.Rules[1]).To(Equal(filter.Rule{
+			Name:   "read-sources",
+			Ops:    []string{"Open", "Read", "Stat", "Close"},
+			Path:   "src/**",
+			Effect: filter.Allow,
+		}))
+	})
+
+	It("should parse JSON", func() {
+		policy, err := filter.ParsePolicy([]byte(`{
+			"default": "allow",
+			"rules": [{"ops": ["Remove"], "effect": "deny"}]
+		}`))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policy.Default).To(Equal(filter.Allow))
+		Expect(policy.Rules).To(ConsistOf(filter.Rule{
+			Ops:    []string{"Remove"},
+			Effect: filter.Deny,
+		}))
+	})
+
+	It("should reject unknown fields", func() {
+		_, err := filter.ParsePolicy([]byte(`rules: [{effect: allow, paths: "*"}]`))
+
+		Expect(err).To(HaveOccurred())
+	})
+
+	Describe("Compile", func() {
+		var f filter.Filter
+
+		BeforeEach(func() {
+			policy, err := filter.ParsePolicy([]byte(policyYAML))
+			Expect(err).NotTo(HaveOccurred())
+			f, err = policy.Compile()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should allow operations matching an allow rule", func() {
+			Expect(f(op.Read{Name: "src/main.go"})).To(Succeed())
+			Expect(f(op.Write{Name: "out/main"})).To(Succeed())
+		})
+
+		It("should apply the first matching rule", func() {
+			err := f(op.Read{Name: "src/tls.key"})
+
+			Expect(err).To(MatchError(syscall.EPERM))
+			Expect(err).To(MatchError(ContainSubstring(`denied by rule "no-secrets"`)))
+		})
+
+		It("should apply the default to unmatched operations", func() {
+			err := f(op.Write{Name: "src/main.go"})
+
+			Expect(err).To(MatchError(os.ErrPermission))
+			Expect(err).To(MatchError(ContainSubstring("denied by policy default")))
+		})
+
+		It("should report the denying rule", func() {
+			err := f(op.Remove{Name: "src/tls.key"})
+
+			var filterErr *filter.Error
+			Expect(errors.As(err, &filterErr)).To(BeTrue())
+			Expect(filterErr.Rule).To(Equal(`rule "no-secrets"`))
+			Expect(filterErr.Operation).To(Equal(op.Remove{Name: "src/tls.key"}))
+		})
+
+		It("should deny renames when either name matches a deny rule", func() {
+			policy := &filter.Policy{Default: filter.Allow, Rules: []filter.Rule{
+				{Path: "**/*.key", Effect: filter.Deny},
+			}}
+			f, err := policy.Compile()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(f(op.Rename{Oldname: "tls.key", Newname: "tls.txt"})).To(MatchError(syscall.EPERM))
+			Expect(f(op.Rename{Oldname: "tls.txt", Newname: "tls.key"})).To(MatchError(syscall.EPERM))
+			Expect(f(op.Rename{Oldname: "a.txt", Newname: "b.txt"})).To(Succeed())
+		})
+
+		It("should resolve relative symlink targets against the link", func() {
+			policy := &filter.Policy{Default: filter.Allow, Rules: []filter.Rule{
+				{Path: "secret/**", Effect: filter.Deny},
+			}}
+			f, err := policy.Compile()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(f(op.Symlink{Oldname: "../secret/key", Newname: "pub/link"})).To(MatchError(syscall.EPERM))
+			Expect(f(op.Symlink{Oldname: "../pub/key", Newname: "pub/link"})).To(Succeed())
+		})
+
+		It("should identify unnamed rules by index", func() {
+			policy := &filter.Policy{Default: filter.Allow, Rules: []filter.Rule{
+				{Ops: []string{"Remove"}, Effect: filter.Deny},
+			}}
+			f, err := policy.Compile()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(f(op.Remove{Name: "a"})).To(MatchError(ContainSubstring("denied by rule 0")))
+		})
+
+		It("should sandbox an Fs", func() {
+			base := afero.NewMemMapFs()
+			Expect(afero.WriteFile(base, "src/main.go", []byte("package main"), os.ModePerm)).To(Succeed())
+			fsys := filter.NewFs(base, f)
+
+			Expect(afero.ReadFile(fsys, "src/main.go")).To(Equal([]byte("package main")))
+			Expect(fsys.Remove("src/main.go")).To(MatchError(syscall.EPERM))
+		})
+	})
+
+	DescribeTable("should reject invalid policies",
+		func(policy filter.Policy, message string) {
+			_, err := policy.Compile()
+
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("unknown operation", filter.Policy{Rules: []filter.Rule{
+			{Ops: []string{"Frobnicate"}, Effect: filter.Allow},
+		}}, `rule 0: unknown operation "Frobnicate"`),
+		Entry("invalid effect", filter.Policy{Rules: []filter.Rule{
+			{Name: "bad", Effect: "maybe"},
+		}}, `rule "bad": invalid effect "maybe"`),
+		Entry("missing effect", filter.Policy{Rules: []filter.Rule{{}}}, `invalid effect ""`),
+		Entry("invalid default", filter.Policy{Default: "maybe"}, "policy default"),
+		Entry("malformed path", filter.Policy{Rules: []filter.Rule{
+			{Path: "[", Effect: filter.Allow},
+		}}, `path "["`),
+	)
+})
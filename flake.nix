@@ -69,6 +69,7 @@
 
           devShells.default = pkgs.mkShell {
             packages = with pkgs; [
+              buf
               docker
               goEnv
               git
@@ -76,6 +77,8 @@
               gnumake
               go
               gomod2nix
+              protoc-gen-go
+              protoc-gen-go-grpc
             ];
           };
 
@@ -11,6 +11,6 @@ type (
 	Writer = writer.Fs
 )
 
-func NewWriter(w io.Writer) afero.Fs {
-	return writer.NewFs(w)
+func NewWriter(w io.Writer, options ...writer.Option) afero.Fs {
+	return writer.NewFs(w, options...)
 }
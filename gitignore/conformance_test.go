@@ -0,0 +1,11 @@
+package gitignore_test
+
+import (
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/gitignore"
+	"github.com/unmango/aferox/testing/conformance"
+)
+
+var _ = conformance.Describe("Conformance", func(seed afero.Fs) (afero.Fs, error) {
+	return gitignore.NewFsFromLines(seed, "*.log"), nil
+}, conformance.Without(conformance.NoSymlink))
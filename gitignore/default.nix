@@ -13,6 +13,7 @@
         pname = "aferox-gitignore";
         version = "0.0.1";
         src = ./.;
+        pwd = ./.;
         modules = ./gomod2nix.toml;
         go = pkgs.go_1_26;
 
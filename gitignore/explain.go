@@ -0,0 +1,103 @@
+package gitignore
+
+import (
+	"fmt"
+	"syscall"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/ignore"
+)
+
+// gitDir is the built-in pattern hiding the .git directory.
+var gitDir = &Pattern{Pattern: ".git"}
+
+// Explanation describes the pattern deciding whether a path is ignored.
+type Explanation struct {
+	// Path is the path being explained.
+	Path string
+
+	// Match is the path the pattern matched, either Path or one of its
+	// parent directories.
+	Match string
+
+	// Pattern is the last pattern matching Match, or nil when no pattern matches.
+	// Its Source and Line locate it, and Negate is true when it re-included Path.
+	Pattern *Pattern
+
+	// Ignored is true when Path is hidden by the Fs.
+	Ignored bool
+}
+
+// String describes the explanation, e.g. "ignored by .gitignore:3:build/ matching build".
+func (e *Explanation) String() string {
+	var s string
+	switch {
+	case e.Pattern == nil && e.Ignored:
+		return "ignored"
+	case e.Pattern == nil:
+		return "not ignored"
+	case e.Pattern == gitDir:
+		s = "ignored by the built-in pattern .git"
+	case e.Ignored:
+		s = fmt.Sprintf("ignored by %s", e.Pattern)
+	case e.Pattern.Negate:
+		s = fmt.Sprintf("re-included by %s", e.Pattern)
+	default:
+		s = fmt.Sprintf("included by %s", e.Pattern)
+	}
+	if e.Match != clean(e.Path) {
+		s += " matching " + e.Match
+	}
+
+	return s
+}
+
+// Error is returned by an [Fs] for ignored paths. It wraps [syscall.ENOENT],
+// so the path appears not to exist.
+type Error struct {
+	Op          string
+	Path        string
+	Explanation *Explanation
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s %s: %s", e.Op, e.Path, e.Explanation)
+}
+
+// Unwrap returns [syscall.ENOENT].
+func (e *Error) Unwrap() error {
+	return syscall.ENOENT
+}
+
+// Explain describes the pattern deciding whether path is ignored by fsys,
+// which must be an [*Fs], such as those returned by [NewFs], [NewFsFromLines],
+// [NewFsFromReader], [NewFsFromFile] and [OpenDefault], or an [*ignore.Fs],
+// such as those of the dialects of the ignore package. The Fs of
+// [NewFsFromIgnore] and of ignore.NewFs cannot be explained, as their
+// matchers do not report which pattern matched.
+func Explain(fsys afero.Fs, path string) (*Explanation, error) {
+	switch f := fsys.(type) {
+	case *Fs:
+		return f.Explain(path)
+	case *ignore.Fs:
+		e, err := f.Explain(path)
+		if err != nil {
+			return nil, err
+		}
+
+		return fromIgnore(e), nil
+	}
+
+	return nil, fmt.Errorf("explain %s: %s is not a gitignore Fs", path, fsys.Name())
+}
+
+// fromIgnore converts an explanation of the ignore package.
+func fromIgnore(e *ignore.Explanation) *Explanation {
+	explanation := &Explanation{Path: e.Path, Match: e.Match, Ignored: e.Ignored}
+	if r := e.Pattern; r != nil {
+		explanation.Pattern = &Pattern{Pattern: r.Pattern, Source: r.Source, Line: r.Line, Negate: r.Negate}
+	}
+
+	return explanation
+}
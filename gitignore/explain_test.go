@@ -0,0 +1,154 @@
+package gitignore_test
+
+import (
+	"errors"
+	"os"
+	"syscall"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/gitignore"
+	"github.com/unmango/aferox/ignore/dockerignore"
+)
+
+var _ = Describe("Explain", func() {
+	var (
+		base afero.Fs
+		fs   *gitignore.Fs
+	)
+
+	BeforeEach(func() {
+		base = afero.NewMemMapFs()
+		Expect(afero.WriteFile(base, ".gitignore", []byte("# logs\n*.log\n!keep.log\nbuild/\n"), os.ModePerm)).To(Succeed())
+		Expect(afero.WriteFile(base, "debug.log", []byte("testing"), os.ModePerm)).To(Succeed())
+		Expect(afero.WriteFile(base, "keep.log", []byte("testing"), os.ModePerm)).To(Succeed())
+		Expect(afero.WriteFile(base, "build/out.txt", []byte("testing"), os.ModePerm)).To(Succeed())
+		Expect(afero.WriteFile(base, "main.go", []byte("testing"), os.ModePerm)).To(Succeed())
+		fs = gitignore.NewFs(base)
+	})
+
+	It("should explain ignored files", func() {
+		e, err := gitignore.Explain(fs, "debug.log")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(e.Ignored).To(BeTrue())
+		Expect(e.Match).To(Equal("debug.log"))
+		Expect(e.Pattern.Pattern).To(Equal("*.log"))
+		Expect(e.Pattern.Source).To(Equal(".gitignore"))
+		Expect(e.Pattern.Line).To(Equal(2))
+		Expect(e.Pattern.Negate).To(BeFalse())
+		Expect(e.String()).To(Equal("ignored by .gitignore:2:*.log"))
+	})
+
+	It("should explain re-included files", func() {
+		e, err := gitignore.Explain(fs, "keep.log")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(e.Ignored).To(BeFalse())
+		Expect(e.Pattern.Negate).To(BeTrue())
+		Expect(e.Pattern.Line).To(Equal(3))
+		Expect(e.String()).To(Equal("re-included by .gitignore:3:!keep.log"))
+	})
+
+	It("should explain files in ignored directories", func() {
+		e, err := gitignore.Explain(fs, "build/out.txt")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(e.Ignored).To(BeTrue())
+		Expect(e.Match).To(Equal("build"))
+		Expect(e.String()).To(Equal("ignored by .gitignore:4:build/ matching build"))
+	})
+
+	It("should explain the .git directory", func() {
+		e, err := gitignore.Explain(fs, ".git/config")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(e.Ignored).To(BeTrue())
+		Expect(e.Pattern.Source).To(BeEmpty())
+		Expect(e.String()).To(Equal("ignored by the built-in pattern .git matching .git"))
+	})
+
+	It("should explain unmatched files", func() {
+		e, err := gitignore.Explain(fs, "main.go")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(e.Ignored).To(BeFalse())
+		Expect(e.Pattern).To(BeNil())
+		Expect(e.String()).To(Equal("not ignored"))
+	})
+
+	It("should fail for other filesystems", func() {
+		_, err := gitignore.Explain(base, "main.go")
+
+		Expect(err).To(MatchError(ContainSubstring("not a gitignore Fs")))
+	})
+
+	It("should explain the Fs of NewFsFromLines", func() {
+		fs := gitignore.NewFsFromLines(base, "*.log", "!keep.log")
+
+		e, err := gitignore.Explain(fs, "debug.log")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(e.Ignored).To(BeTrue())
+		Expect(e.Pattern.Line).To(Equal(1))
+		Expect(e.String()).To(Equal("ignored by *.log"))
+	})
+
+	It("should explain the Fs of OpenDefault", func() {
+		fs, err := gitignore.OpenDefault(base)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = fs.Stat("debug.log")
+
+		var ignored *gitignore.Error
+		Expect(errors.As(err, &ignored)).To(BeTrue())
+		Expect(err).To(MatchError("stat debug.log: ignored by .gitignore:2:*.log"))
+	})
+
+	It("should explain the Fs of NewFsFromFile", func() {
+		Expect(afero.WriteFile(base, "custom.ignore", []byte("main.go\n"), os.ModePerm)).To(Succeed())
+		fs, err := gitignore.NewFsFromFile(base, "custom.ignore")
+		Expect(err).NotTo(HaveOccurred())
+
+		e, err := gitignore.Explain(fs, "main.go")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(e.String()).To(Equal("ignored by custom.ignore:1:main.go"))
+	})
+
+	It("should explain the Fs of the ignore dialects", func() {
+		Expect(afero.WriteFile(base, ".dockerignore", []byte("# build output\nbuild\n"), os.ModePerm)).To(Succeed())
+		fs, err := dockerignore.OpenDefault(base)
+		Expect(err).NotTo(HaveOccurred())
+
+		e, err := gitignore.Explain(fs, "build/out.txt")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(e.Ignored).To(BeTrue())
+		Expect(e.Pattern.Source).To(Equal(".dockerignore"))
+		Expect(e.Pattern.Line).To(Equal(2))
+		Expect(e.String()).To(Equal("ignored by .dockerignore:2:build matching build"))
+	})
+
+	Describe("Error", func() {
+		It("should carry the explanation", func() {
+			_, err := fs.Open("build/out.txt")
+
+			var ignored *gitignore.Error
+			Expect(errors.As(err, &ignored)).To(BeTrue())
+			Expect(ignored.Op).To(Equal("open"))
+			Expect(ignored.Path).To(Equal("build/out.txt"))
+			Expect(ignored.Explanation.Pattern.Line).To(Equal(4))
+			Expect(err).To(MatchError("open build/out.txt: ignored by .gitignore:4:build/ matching build"))
+		})
+
+		It("should wrap ENOENT", func() {
+			_, err := fs.Stat("debug.log")
+
+			Expect(err).To(MatchError(syscall.ENOENT))
+			Expect(err).To(MatchError(os.ErrNotExist))
+		})
+	})
+})
@@ -0,0 +1,79 @@
+package gitignore
+
+import (
+	"io/fs"
+	"path"
+
+	"github.com/spf13/afero"
+)
+
+// File is a file opened through an [Fs]. Listing a directory omits ignored
+// entries, and writing to an ignore file invalidates the patterns cached by the Fs.
+type File struct {
+	afero.File
+	fs   *Fs
+	name string
+}
+
+// Close implements afero.File.
+func (f *File) Close() error {
+	defer f.fs.changed(f.name)
+	return f.File.Close()
+}
+
+// Readdir implements afero.File.
+func (f *File) Readdir(count int) (res []fs.FileInfo, err error) {
+	infos, err := f.File.Readdir(count)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, i := range infos {
+		name := path.Join(clean(f.name), i.Name())
+		if ignored, _, err := f.fs.Ignored(name, i.IsDir()); err != nil {
+			return nil, err
+		} else if !ignored {
+			res = append(res, i)
+		}
+	}
+
+	return res, nil
+}
+
+// Readdirnames implements afero.File.
+func (f *File) Readdirnames(n int) (names []string, err error) {
+	infos, err := f.Readdir(n)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, i := range infos {
+		names = append(names, i.Name())
+	}
+
+	return names, nil
+}
+
+// Truncate implements afero.File.
+func (f *File) Truncate(size int64) error {
+	defer f.fs.changed(f.name)
+	return f.File.Truncate(size)
+}
+
+// Write implements afero.File.
+func (f *File) Write(p []byte) (n int, err error) {
+	defer f.fs.changed(f.name)
+	return f.File.Write(p)
+}
+
+// WriteAt implements afero.File.
+func (f *File) WriteAt(p []byte, off int64) (n int, err error) {
+	defer f.fs.changed(f.name)
+	return f.File.WriteAt(p, off)
+}
+
+// WriteString implements afero.File.
+func (f *File) WriteString(s string) (ret int, err error) {
+	defer f.fs.changed(f.name)
+	return f.File.WriteString(s)
+}
@@ -1,9 +1,9 @@
 package gitignore
 
 import (
-	"bufio"
 	"fmt"
 	"io"
+	"strings"
 
 	ignore "github.com/sabhiram/go-gitignore"
 	"github.com/spf13/afero"
@@ -15,38 +15,54 @@ const DefaultFile = ".gitignore"
 
 type Ignore = ignore.IgnoreParser
 
+// NewFsFromLines returns an [*Fs] hiding the paths of base ignored by lines,
+// which are relative to the root of base. Unlike [NewFs], it does not read
+// the ignore files of the tree.
 func NewFsFromLines(base afero.Fs, lines ...string) afero.Fs {
-	return NewFsFromIgnore(base, ignore.CompileIgnoreLines(lines...))
+	patterns, _ := parsePatterns("", "", strings.NewReader(strings.Join(lines, "\n")))
+	return newPatternFs(base, patterns)
 }
 
+// NewFsFromIgnore returns an Fs hiding the paths of base matched by ignore.
+// As ignore cannot tell which pattern matched, the Fs does not support
+// [Explain] and denied operations fail with a plain ENOENT error.
 func NewFsFromIgnore(base afero.Fs, ignore Ignore) afero.Fs {
 	return filter.FromPredicate(base, func(op op.Operation) bool {
 		return !ignore.MatchesPath(op.Path())
 	})
 }
 
+// NewFsFromReader returns an [*Fs] hiding the paths of base ignored by the
+// lines of reader, see [NewFsFromLines].
 func NewFsFromReader(base afero.Fs, reader io.Reader) (afero.Fs, error) {
-	lines := []string{}
-	s := bufio.NewScanner(reader)
-	for s.Scan() {
-		lines = append(lines, s.Text())
-	}
-	if s.Err() != nil {
-		return nil, fmt.Errorf("scanning ignore lines: %w", s.Err())
+	patterns, err := parsePatterns("", "", reader)
+	if err != nil {
+		return nil, fmt.Errorf("scanning ignore lines: %w", err)
 	}
 
-	return NewFsFromLines(base, lines...), nil
+	return newPatternFs(base, patterns), nil
 }
 
+// NewFsFromFile returns an [*Fs] hiding the paths of base ignored by the
+// ignore file at path in base, see [NewFsFromLines]. Explanations locate
+// patterns by path and line.
 func NewFsFromFile(base afero.Fs, path string) (afero.Fs, error) {
 	f, err := base.Open(path)
 	if err != nil {
 		return nil, fmt.Errorf("opening ignore file: %w", err)
 	}
 	defer f.Close()
-	return NewFsFromReader(base, f)
+
+	patterns, err := parsePatterns(path, "", f)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	return newPatternFs(base, patterns), nil
 }
 
+// OpenDefault returns an [*Fs] hiding the paths of base ignored by the
+// .gitignore file at its root, see [NewFsFromFile].
 func OpenDefault(base afero.Fs) (afero.Fs, error) {
 	return NewFsFromFile(base, DefaultFile)
 }
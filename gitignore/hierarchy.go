@@ -0,0 +1,438 @@
+package gitignore
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/go/fopt"
+)
+
+// InfoExcludeFile is the repository-local excludes file, relative to the root of the Fs.
+const InfoExcludeFile = ".git/info/exclude"
+
+type excludesFile struct {
+	fs   afero.Fs
+	path string
+}
+
+type options struct {
+	excludes []excludesFile
+}
+
+type Option func(*options)
+
+// WithExcludesFile reads global patterns from path in fsys, as git does for
+// the core.excludesFile setting. A missing file is skipped.
+func WithExcludesFile(fsys afero.Fs, path string) Option {
+	return func(options *options) {
+		options.excludes = append(options.excludes, excludesFile{fsys, path})
+	}
+}
+
+// DefaultExcludesFile returns the path git reads global patterns from when
+// core.excludesFile is unset, i.e. $XDG_CONFIG_HOME/git/ignore or
+// $HOME/.config/git/ignore. It returns an empty string if neither is set.
+func DefaultExcludesFile() string {
+	if config := os.Getenv("XDG_CONFIG_HOME"); config != "" {
+		return filepath.Join(config, "git", "ignore")
+	}
+	if home := os.Getenv("HOME"); home != "" {
+		return filepath.Join(home, ".config", "git", "ignore")
+	}
+
+	return ""
+}
+
+// Fs hides the paths ignored by the .gitignore files of the tree beneath the
+// root of base, the way git status does.
+//
+// Patterns are read from, in increasing order of precedence, the configured
+// excludes files, .git/info/exclude, and the .gitignore file of each directory
+// from the root down to the directory containing a path. The last matching
+// pattern decides whether a path is ignored, and a path cannot be re-included
+// when one of its parent directories is ignored. The .git directory is always
+// hidden.
+//
+// Ignore files are read lazily and cached until they change through the Fs.
+type Fs struct {
+	base afero.Fs
+	opts options
+
+	// fixed is set for an Fs created from a list of patterns,
+	// which ignores only the paths matching excludes
+	fixed bool
+
+	mu       sync.Mutex
+	excludes []*Pattern
+	loaded   bool
+	dirs     map[string][]*Pattern
+}
+
+// NewFs returns an Fs filtering base with the ignore files found beneath its root.
+func NewFs(base afero.Fs, options ...Option) *Fs {
+	fs := &Fs{
+		base: base,
+		dirs: map[string][]*Pattern{},
+	}
+
+	fopt.ApplyAll(&fs.opts, options)
+	return fs
+}
+
+// newPatternFs returns an Fs hiding the paths of base matching patterns,
+// without reading any ignore files.
+func newPatternFs(base afero.Fs, patterns []*Pattern) *Fs {
+	return &Fs{
+		base:     base,
+		fixed:    true,
+		excludes: patterns,
+		loaded:   true,
+	}
+}
+
+// Chmod implements afero.Fs.
+func (f *Fs) Chmod(name string, mode fs.FileMode) error {
+	if err := f.check("chmod", name); err != nil {
+		return err
+	}
+
+	return f.base.Chmod(name, mode)
+}
+
+// Chown implements afero.Fs.
+func (f *Fs) Chown(name string, uid int, gid int) error {
+	if err := f.check("chown", name); err != nil {
+		return err
+	}
+
+	return f.base.Chown(name, uid, gid)
+}
+
+// Chtimes implements afero.Fs.
+func (f *Fs) Chtimes(name string, atime time.Time, mtime time.Time) error {
+	if err := f.check("chtimes", name); err != nil {
+		return err
+	}
+
+	return f.base.Chtimes(name, atime, mtime)
+}
+
+// Create implements afero.Fs.
+func (f *Fs) Create(name string) (afero.File, error) {
+	if err := f.checkAs("create", name, false); err != nil {
+		return nil, err
+	}
+
+	file, err := f.base.Create(name)
+	if err != nil {
+		return nil, err
+	}
+
+	f.changed(name)
+	return &File{file, f, name}, nil
+}
+
+// Mkdir implements afero.Fs.
+func (f *Fs) Mkdir(name string, perm fs.FileMode) error {
+	if err := f.checkAs("mkdir", name, true); err != nil {
+		return err
+	}
+
+	return f.base.Mkdir(name, perm)
+}
+
+// MkdirAll implements afero.Fs.
+func (f *Fs) MkdirAll(path string, perm fs.FileMode) error {
+	if err := f.checkAs("mkdir", path, true); err != nil {
+		return err
+	}
+
+	return f.base.MkdirAll(path, perm)
+}
+
+// Name implements afero.Fs.
+func (f *Fs) Name() string {
+	return "GitIgnoreFs"
+}
+
+// Open implements afero.Fs.
+func (f *Fs) Open(name string) (afero.File, error) {
+	return f.OpenFile(name, os.O_RDONLY, 0)
+}
+
+// OpenFile implements afero.Fs.
+func (f *Fs) OpenFile(name string, flag int, perm fs.FileMode) (afero.File, error) {
+	if err := f.check("open", name); err != nil {
+		return nil, err
+	}
+
+	file, err := f.base.OpenFile(name, flag, perm)
+	if err != nil {
+		return nil, err
+	}
+
+	if flag&(os.O_WRONLY|os.O_RDWR|os.O_TRUNC) != 0 {
+		f.changed(name)
+	}
+
+	return &File{file, f, name}, nil
+}
+
+// Remove implements afero.Fs.
+func (f *Fs) Remove(name string) error {
+	if err := f.check("remove", name); err != nil {
+		return err
+	}
+	if err := f.base.Remove(name); err != nil {
+		return err
+	}
+
+	f.changed(name)
+	return nil
+}
+
+// RemoveAll implements afero.Fs.
+func (f *Fs) RemoveAll(path string) error {
+	if err := f.check("remove", path); err != nil {
+		return err
+	}
+	if err := f.base.RemoveAll(path); err != nil {
+		return err
+	}
+
+	f.invalidate()
+	return nil
+}
+
+// Rename implements afero.Fs.
+func (f *Fs) Rename(oldname string, newname string) error {
+	if err := f.check("rename", oldname); err != nil {
+		return err
+	}
+	if err := f.checkAs("rename", newname, f.isDir(oldname)); err != nil {
+		return err
+	}
+	if err := f.base.Rename(oldname, newname); err != nil {
+		return err
+	}
+
+	f.invalidate()
+	return nil
+}
+
+// Stat implements afero.Fs.
+func (f *Fs) Stat(name string) (fs.FileInfo, error) {
+	if err := f.check("stat", name); err != nil {
+		return nil, err
+	}
+
+	return f.base.Stat(name)
+}
+
+// Ignored reports whether name is ignored, treating it as a directory when
+// isDir is true. It returns the pattern responsible.
+func (f *Fs) Ignored(name string, isDir bool) (bool, *Pattern, error) {
+	e, err := f.explain(name, isDir)
+	if err != nil {
+		return false, nil, err
+	}
+
+	return e.Ignored, e.Pattern, nil
+}
+
+// Explain describes the pattern deciding whether name is ignored.
+func (f *Fs) Explain(name string) (*Explanation, error) {
+	return f.explain(name, f.isDir(name))
+}
+
+func (f *Fs) explain(name string, isDir bool) (*Explanation, error) {
+	e := &Explanation{Path: name}
+	rel := clean(name)
+	if rel == "" {
+		return e, nil
+	}
+
+	// Check each parent first, as git does not descend into ignored directories
+	segments := strings.Split(rel, "/")
+	for i, segment := range segments {
+		match := strings.Join(segments[:i+1], "/")
+		if segment == ".git" && !f.fixed {
+			e.Match, e.Pattern, e.Ignored = match, gitDir, true
+			return e, nil
+		}
+
+		p, err := f.match(match, isDir || i < len(segments)-1)
+		if err != nil {
+			return nil, err
+		}
+		if p != nil && (!p.Negate || i == len(segments)-1) {
+			e.Match, e.Pattern, e.Ignored = match, p, !p.Negate
+		}
+		if e.Ignored {
+			return e, nil
+		}
+	}
+
+	return e, nil
+}
+
+// check fails with an [*Error] when name is ignored.
+func (f *Fs) check(op, name string) error {
+	return f.checkAs(op, name, f.isDir(name))
+}
+
+func (f *Fs) checkAs(op, name string, isDir bool) error {
+	if e, err := f.explain(name, isDir); err != nil {
+		return &fs.PathError{Op: op, Path: name, Err: err}
+	} else if e.Ignored {
+		return &Error{Op: op, Path: name, Explanation: e}
+	}
+
+	return nil
+}
+
+func (f *Fs) isDir(name string) bool {
+	info, err := f.base.Stat(name)
+	return err == nil && info.IsDir()
+}
+
+// match returns the last pattern matching the slash-separated path rel, if any.
+func (f *Fs) match(rel string, isDir bool) (*Pattern, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	patterns, err := f.globalPatterns()
+	if err != nil {
+		return nil, err
+	}
+
+	var match *Pattern
+	for _, p := range patterns {
+		if p.matches(rel, isDir) {
+			match = p
+		}
+	}
+
+	// Walk from the root down to the parent of rel, so deeper files take precedence
+	dir := ""
+	for _, segment := range strings.Split(rel, "/") {
+		patterns, err := f.dirPatterns(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range patterns {
+			if p.matches(rel, isDir) {
+				match = p
+			}
+		}
+
+		dir = path.Join(dir, segment)
+	}
+
+	return match, nil
+}
+
+// globalPatterns returns the patterns of the excludes files and .git/info/exclude.
+// The caller must hold f.mu.
+func (f *Fs) globalPatterns() ([]*Pattern, error) {
+	if f.loaded {
+		return f.excludes, nil
+	}
+
+	var excludes []*Pattern
+	for _, e := range f.opts.excludes {
+		patterns, err := readPatterns(e.fs, e.path, "")
+		if err != nil {
+			return nil, err
+		}
+
+		excludes = append(excludes, patterns...)
+	}
+
+	patterns, err := readPatterns(f.base, InfoExcludeFile, "")
+	if err != nil {
+		return nil, err
+	}
+
+	f.excludes, f.loaded = append(excludes, patterns...), true
+	return f.excludes, nil
+}
+
+// dirPatterns returns the patterns of the .gitignore file in dir.
+// The caller must hold f.mu.
+func (f *Fs) dirPatterns(dir string) ([]*Pattern, error) {
+	if f.fixed {
+		return nil, nil
+	}
+	if patterns, ok := f.dirs[dir]; ok {
+		return patterns, nil
+	}
+
+	patterns, err := readPatterns(f.base, path.Join(dir, DefaultFile), dir)
+	if err != nil {
+		return nil, err
+	}
+
+	f.dirs[dir] = patterns
+	return patterns, nil
+}
+
+// changed invalidates the cache when name is an ignore file.
+func (f *Fs) changed(name string) {
+	if isIgnoreFile(name) {
+		f.invalidate()
+	}
+}
+
+func (f *Fs) invalidate() {
+	if f.fixed {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.excludes, f.loaded = nil, false
+	clear(f.dirs)
+}
+
+func readPatterns(fsys afero.Fs, name, dir string) ([]*Pattern, error) {
+	file, err := fsys.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	patterns, err := parsePatterns(name, dir, file)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", name, err)
+	}
+
+	return patterns, nil
+}
+
+func isIgnoreFile(name string) bool {
+	rel := clean(name)
+	return path.Base(rel) == DefaultFile || rel == InfoExcludeFile
+}
+
+// clean returns name as a slash-separated path relative to the root of the Fs.
+func clean(name string) string {
+	rel := strings.TrimPrefix(filepath.ToSlash(filepath.Clean(name)), "/")
+	if rel == "." {
+		return ""
+	}
+
+	return rel
+}
@@ -0,0 +1,250 @@
+package gitignore_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/gitignore"
+)
+
+var _ = Describe("NewFs", func() {
+	var (
+		base afero.Fs
+		fs   *gitignore.Fs
+	)
+
+	write := func(name, content string) {
+		GinkgoHelper()
+		Expect(afero.WriteFile(base, name, []byte(content), os.ModePerm)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		base = afero.NewMemMapFs()
+		fs = gitignore.NewFs(base)
+	})
+
+	DescribeTable("patterns",
+		func(ignore, path string, isDir, expected bool) {
+			write(".gitignore", ignore)
+			if isDir {
+				Expect(base.MkdirAll(path, os.ModePerm)).To(Succeed())
+			} else {
+				write(path, "testing")
+			}
+
+			_, err := fs.Stat(path)
+
+			if expected {
+				Expect(err).To(MatchError(os.ErrNotExist))
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+		Entry("extension", "*.log", "debug.log", false, true),
+		Entry("extension in a subdirectory", "*.log", "a/b/debug.log", false, true),
+		Entry("other extension", "*.log", "debug.txt", false, false),
+		Entry("comment", "# *.log", "debug.log", false, false),
+		Entry("escaped hash", `\#file`, "#file", false, true),
+		Entry("escaped exclamation mark", `\!important.txt`, "!important.txt", false, true),
+		Entry("trailing spaces", "debug.log   ", "debug.log", false, true),
+		Entry("anchored", "/debug.log", "debug.log", false, true),
+		Entry("anchored in a subdirectory", "/debug.log", "a/debug.log", false, false),
+		Entry("middle slash", "a/debug.log", "a/debug.log", false, true),
+		Entry("middle slash in a subdirectory", "a/debug.log", "b/a/debug.log", false, false),
+		Entry("directory only matching a directory", "build/", "build", true, true),
+		Entry("directory only matching a file", "build/", "build", false, false),
+		Entry("leading double star", "**/logs", "a/b/logs", true, true),
+		Entry("trailing double star", "logs/**", "logs/debug.log", false, true),
+		Entry("middle double star", "a/**/b", "a/x/y/b", false, true),
+		Entry("middle double star matching no directories", "a/**/b", "a/b", false, true),
+		Entry("question mark", "debug?.log", "debug1.log", false, true),
+		Entry("character class", "debug[0-9].log", "debuga.log", false, false),
+		Entry("negated character class", "debug[!0-9].log", "debuga.log", false, true),
+		Entry("negation", "*.log\n!important.log", "important.log", false, false),
+		Entry("later pattern wins", "!important.log\n*.log", "important.log", false, true),
+	)
+
+	It("should hide the .git directory", func() {
+		Expect(base.MkdirAll(".git/objects", os.ModePerm)).To(Succeed())
+
+		_, err := fs.Stat(".git/objects")
+
+		Expect(err).To(MatchError(os.ErrNotExist))
+	})
+
+	It("should not hide the root", func() {
+		write(".gitignore", "*")
+
+		_, err := fs.Stat("")
+
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("nested ignore files", func() {
+		BeforeEach(func() {
+			write(".gitignore", "*.log\n")
+			write("a/.gitignore", "!keep.log\n/local.txt\n")
+			write("a/keep.log", "testing")
+			write("a/local.txt", "testing")
+			write("a/b/local.txt", "testing")
+			write("b/keep.log", "testing")
+		})
+
+		It("should apply patterns of parent directories", func() {
+			write("a/debug.log", "testing")
+
+			_, err := fs.Stat("a/debug.log")
+
+			Expect(err).To(MatchError(os.ErrNotExist))
+		})
+
+		It("should let deeper files re-include paths", func() {
+			_, err := fs.Stat("a/keep.log")
+
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should not apply patterns outside their directory", func() {
+			_, err := fs.Stat("b/keep.log")
+
+			Expect(err).To(MatchError(os.ErrNotExist))
+		})
+
+		It("should anchor patterns to their directory", func() {
+			_, err := fs.Stat("a/local.txt")
+			Expect(err).To(MatchError(os.ErrNotExist))
+
+			_, err = fs.Stat("a/b/local.txt")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should filter directory listings", func() {
+			names, err := afero.ReadDir(fs, "a")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names).To(HaveEach(WithTransform(os.FileInfo.Name,
+				BeElementOf(".gitignore", "keep.log", "b"),
+			)))
+			Expect(names).To(HaveLen(3))
+		})
+	})
+
+	Describe("ignored directories", func() {
+		BeforeEach(func() {
+			write(".gitignore", "build/\n!build/keep.txt\n")
+			write("build/keep.txt", "testing")
+			write("build/.gitignore", "!*")
+		})
+
+		It("should not re-include files beneath them", func() {
+			_, err := fs.Stat("build/keep.txt")
+
+			Expect(err).To(MatchError(os.ErrNotExist))
+		})
+
+		It("should hide them from listings", func() {
+			names, err := afero.ReadDir(fs, "")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names).To(HaveLen(1))
+			Expect(names[0].Name()).To(Equal(".gitignore"))
+		})
+
+		It("should refuse to create files in them", func() {
+			_, err := fs.Create("build/new.txt")
+
+			Expect(err).To(MatchError(os.ErrNotExist))
+		})
+	})
+
+	Describe("excludes files", func() {
+		BeforeEach(func() {
+			write("debug.log", "testing")
+			write("trace.log", "testing")
+		})
+
+		It("should read .git/info/exclude", func() {
+			write(".git/info/exclude", "debug.log")
+
+			_, err := fs.Stat("debug.log")
+
+			Expect(err).To(MatchError(os.ErrNotExist))
+		})
+
+		It("should read the configured excludes file", func() {
+			global := afero.NewMemMapFs()
+			Expect(afero.WriteFile(global, "ignore", []byte("*.log"), os.ModePerm)).To(Succeed())
+			fs = gitignore.NewFs(base, gitignore.WithExcludesFile(global, "ignore"))
+
+			_, err := fs.Stat("trace.log")
+
+			Expect(err).To(MatchError(os.ErrNotExist))
+		})
+
+		It("should skip a missing excludes file", func() {
+			fs = gitignore.NewFs(base, gitignore.WithExcludesFile(afero.NewMemMapFs(), "ignore"))
+
+			_, err := fs.Stat("trace.log")
+
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should let .gitignore override the excludes files", func() {
+			write(".git/info/exclude", "*.log")
+			write(".gitignore", "!trace.log")
+
+			_, err := fs.Stat("trace.log")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = fs.Stat("debug.log")
+			Expect(err).To(MatchError(os.ErrNotExist))
+		})
+	})
+
+	Describe("changes", func() {
+		BeforeEach(func() {
+			write("debug.log", "testing")
+			_, err := fs.Stat("debug.log")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should apply ignore files written through the Fs", func() {
+			Expect(afero.WriteFile(fs, ".gitignore", []byte("*.log"), os.ModePerm)).To(Succeed())
+
+			_, err := fs.Stat("debug.log")
+
+			Expect(err).To(MatchError(os.ErrNotExist))
+		})
+
+		It("should apply ignore files removed through the Fs", func() {
+			Expect(afero.WriteFile(fs, ".gitignore", []byte("*.log"), os.ModePerm)).To(Succeed())
+			Expect(fs.Remove(".gitignore")).To(Succeed())
+
+			_, err := fs.Stat("debug.log")
+
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should apply ignore files renamed through the Fs", func() {
+			write("ignore", "*.log")
+			Expect(fs.Rename("ignore", ".gitignore")).To(Succeed())
+
+			_, err := fs.Stat("debug.log")
+
+			Expect(err).To(MatchError(os.ErrNotExist))
+		})
+	})
+
+	It("should describe the ignoring pattern", func() {
+		write(".gitignore", "# logs\n*.log\n")
+
+		ignored, pattern, err := fs.Ignored("debug.log", false)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ignored).To(BeTrue())
+		Expect(pattern.String()).To(Equal(".gitignore:2:*.log"))
+	})
+})
@@ -0,0 +1,80 @@
+package gitignore
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/unmango/aferox/ignore"
+)
+
+// Pattern is a single line of an ignore file.
+type Pattern struct {
+	// Pattern is the line as written in Source, without trailing whitespace.
+	Pattern string
+
+	// Source is the path of the file containing the pattern,
+	// or empty for patterns that were not read from a file.
+	Source string
+
+	// Line is the 1-based line number of the pattern in Source.
+	Line int
+
+	// Negate is true for patterns starting with "!",
+	// which re-include paths excluded by earlier patterns.
+	Negate bool
+
+	dir      string
+	compiled *ignore.Pattern
+}
+
+// String returns the pattern and its location, e.g. ".gitignore:3:*.log".
+func (p *Pattern) String() string {
+	if p.Source == "" {
+		return p.Pattern
+	}
+
+	return fmt.Sprintf("%s:%d:%s", p.Source, p.Line, p.Pattern)
+}
+
+// matches reports whether the slash-separated path, relative to the root of
+// the Fs, matches p. Paths outside of the directory of p never match.
+func (p *Pattern) matches(path string, isDir bool) bool {
+	if p.dir != "" {
+		if !strings.HasPrefix(path, p.dir+"/") {
+			return false
+		}
+		path = path[len(p.dir)+1:]
+	}
+
+	return p.compiled.Matches(path, isDir)
+}
+
+// parsePatterns reads the patterns of the ignore file source, which applies
+// to paths beneath the slash-separated directory dir.
+func parsePatterns(source, dir string, r io.Reader) ([]*Pattern, error) {
+	var patterns []*Pattern
+	s := bufio.NewScanner(r)
+	for line := 1; s.Scan(); line++ {
+		// Like git, skip malformed patterns rather than failing
+		compiled, err := ignore.CompilePattern(s.Text())
+		if err != nil || compiled == nil {
+			continue
+		}
+
+		patterns = append(patterns, &Pattern{
+			Pattern:  compiled.Pattern,
+			Source:   source,
+			Line:     line,
+			Negate:   compiled.Negate,
+			dir:      dir,
+			compiled: compiled,
+		})
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	return patterns, nil
+}
@@ -0,0 +1,302 @@
+// Package dockerignore filters an afero.Fs with a .dockerignore file, selecting
+// the files docker build sends as the build context.
+//
+// Patterns follow the semantics of github.com/moby/patternmatcher: they are
+// always relative to the root of the context, a pattern excludes everything
+// beneath the directories it matches, the last matching pattern wins and
+// patterns starting with "!" are exceptions re-including paths.
+package dockerignore
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+	"text/scanner"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/ignore"
+)
+
+const DefaultFile = ".dockerignore"
+
+// Pattern is a single pattern of a .dockerignore file.
+type Pattern struct {
+	// Pattern is the cleaned pattern, without the leading "!" of exceptions.
+	Pattern string
+
+	// Exception is true for patterns starting with "!".
+	Exception bool
+
+	// Source is the path of the file containing the pattern, and Line its
+	// 1-based line number, see [ignore.Rule].
+	Source string
+	Line   int
+
+	re *regexp.Regexp
+}
+
+// Matcher matches paths against the patterns of a .dockerignore file.
+type Matcher struct {
+	patterns []*Pattern
+}
+
+// NewMatcher compiles patterns as read by [ReadAll].
+func NewMatcher(patterns ...string) (*Matcher, error) {
+	m := &Matcher{}
+	for i, p := range patterns {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+
+		pattern := &Pattern{Pattern: p, Line: i + 1}
+		if p[0] == '!' {
+			if len(p) == 1 {
+				return nil, errors.New(`illegal exclusion pattern: "!"`)
+			}
+			pattern.Exception = true
+			pattern.Pattern = p[1:]
+		}
+
+		re, err := compile(pattern.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("compiling pattern %q: %w", p, err)
+		}
+
+		pattern.re = re
+		m.patterns = append(m.patterns, pattern)
+	}
+
+	return m, nil
+}
+
+// Patterns returns the compiled patterns in the order they were given.
+func (m *Matcher) Patterns() []*Pattern {
+	return m.patterns
+}
+
+// MatchesPath implements [ignore.Ignore]. It reports whether path or one of
+// its parent directories is excluded, as patternmatcher's MatchesOrParentMatches does.
+func (m *Matcher) MatchesPath(path string) bool {
+	_, p := m.match(path)
+	return p != nil && !p.Exception
+}
+
+// Ignores implements [ignore.Matcher]. Excluded directories are still
+// walked when an exception could match something beneath them, as the
+// docker CLI does when sending the build context.
+func (m *Matcher) Ignores(path string, isDir bool) bool {
+	return m.Explain(path, isDir).Ignored
+}
+
+// Explain implements [ignore.Explainer].
+func (m *Matcher) Explain(path string, isDir bool) *ignore.Explanation {
+	e := &ignore.Explanation{Path: path}
+	match, p := m.match(path)
+	if p == nil {
+		return e
+	}
+
+	e.Match, e.Pattern = match, p.rule()
+	if p.Exception {
+		return e
+	}
+	if isDir {
+		dir := ignore.Clean(path) + "/"
+		for _, p := range m.patterns {
+			if p.Exception && strings.HasPrefix(p.Pattern+"/", dir) {
+				e.Match, e.Pattern = ignore.Clean(path), p.rule()
+				return e
+			}
+		}
+	}
+
+	e.Ignored = true
+	return e
+}
+
+// match returns the last pattern changing whether path is excluded, if
+// any, and the path or parent directory it matched.
+func (m *Matcher) match(path string) (match string, last *Pattern) {
+	path = ignore.Clean(path)
+	parents := strings.Split(path, "/")
+	parents = parents[:len(parents)-1]
+
+	matched := false
+	for _, p := range m.patterns {
+		// Only exceptions can change a match, and only exclusions can start one
+		if p.Exception != matched {
+			continue
+		}
+
+		name := path
+		ok := p.re.MatchString(path)
+		for i := 0; !ok && i < len(parents); i++ {
+			name = strings.Join(parents[:i+1], "/")
+			ok = p.re.MatchString(name)
+		}
+		if ok {
+			matched, match, last = !p.Exception, name, p
+		}
+	}
+
+	return match, last
+}
+
+func (p *Pattern) rule() *ignore.Rule {
+	pattern := p.Pattern
+	if p.Exception {
+		pattern = "!" + pattern
+	}
+
+	return &ignore.Rule{Pattern: pattern, Source: p.Source, Line: p.Line, Negate: p.Exception}
+}
+
+// ReadAll reads the patterns of a .dockerignore file, skipping comments and
+// blank lines and cleaning each pattern as the docker CLI does.
+func ReadAll(reader io.Reader) ([]string, error) {
+	patterns, _, err := read(reader)
+	return patterns, err
+}
+
+// read reads the patterns of a .dockerignore file with [ReadAll], along
+// with the line number of each.
+func read(reader io.Reader) (patterns []string, lines []int, err error) {
+	s := bufio.NewScanner(reader)
+	for n := 1; s.Scan(); n++ {
+		line := s.Bytes()
+		if n == 1 {
+			line = bytes.TrimPrefix(line, []byte("\xEF\xBB\xBF"))
+		}
+		if len(line) > 0 && line[0] == '#' {
+			continue
+		}
+
+		pattern := strings.TrimSpace(string(line))
+		if pattern == "" {
+			continue
+		}
+
+		exception := pattern[0] == '!'
+		if exception {
+			pattern = strings.TrimSpace(pattern[1:])
+		}
+		if pattern != "" {
+			pattern = path.Clean(pattern)
+			if len(pattern) > 1 && pattern[0] == '/' {
+				pattern = pattern[1:]
+			}
+		}
+		if exception {
+			pattern = "!" + pattern
+		}
+
+		patterns = append(patterns, pattern)
+		lines = append(lines, n)
+	}
+	if err := s.Err(); err != nil {
+		return nil, nil, fmt.Errorf("reading .dockerignore: %w", err)
+	}
+
+	return patterns, lines, nil
+}
+
+func NewFsFromLines(base afero.Fs, lines ...string) (afero.Fs, error) {
+	m, err := NewMatcher(lines...)
+	if err != nil {
+		return nil, err
+	}
+
+	return ignore.NewFsFromMatcher(base, m), nil
+}
+
+func NewFsFromReader(base afero.Fs, reader io.Reader) (afero.Fs, error) {
+	return newFs(base, "", reader)
+}
+
+// NewFsFromFile returns an Fs hiding the paths of base excluded by the
+// .dockerignore file at path in base. Explanations locate patterns by path and line.
+func NewFsFromFile(base afero.Fs, path string) (afero.Fs, error) {
+	f, err := base.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening ignore file: %w", err)
+	}
+	defer f.Close()
+	return newFs(base, path, f)
+}
+
+func OpenDefault(base afero.Fs) (afero.Fs, error) {
+	return NewFsFromFile(base, DefaultFile)
+}
+
+// newFs reads the patterns of the ignore file source from reader.
+func newFs(base afero.Fs, source string, reader io.Reader) (afero.Fs, error) {
+	patterns, lines, err := read(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := NewMatcher(patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every pattern read compiles to exactly one pattern of m
+	for i, p := range m.patterns {
+		p.Source, p.Line = source, lines[i]
+	}
+
+	return ignore.NewFsFromMatcher(base, m), nil
+}
+
+// compile converts a pattern to a regular expression as patternmatcher does.
+func compile(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+
+	var s scanner.Scanner
+	s.Init(strings.NewReader(pattern))
+	s.Mode = 0
+	s.Error = func(*scanner.Scanner, string) {}
+
+	for s.Peek() != scanner.EOF {
+		switch ch := s.Next(); ch {
+		case '*':
+			if s.Peek() != '*' {
+				b.WriteString("[^/]*")
+				break
+			}
+
+			s.Next()
+			for s.Peek() == '/' {
+				s.Next()
+			}
+			if s.Peek() == scanner.EOF {
+				// A trailing "**" matches everything
+				b.WriteString(".*")
+			} else {
+				// "**/" matches zero or more directories
+				b.WriteString("(.*/)?")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '\\':
+			if s.Peek() == scanner.EOF {
+				return nil, errors.New("trailing backslash")
+			}
+			b.WriteString(regexp.QuoteMeta(string(s.Next())))
+		case '.', '$', '(', ')', '|', '+', '^', '{', '}':
+			b.WriteString(`\` + string(ch))
+		default:
+			b.WriteRune(ch)
+		}
+	}
+
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
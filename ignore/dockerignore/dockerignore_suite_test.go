@@ -0,0 +1,13 @@
+package dockerignore_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestDockerignore(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Dockerignore Suite")
+}
@@ -0,0 +1,126 @@
+package dockerignore_test
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/ignore"
+	"github.com/unmango/aferox/ignore/dockerignore"
+)
+
+var _ = Describe("Dockerignore", func() {
+	DescribeTable("MatchesPath",
+		func(patterns []string, path string, expected bool) {
+			m, err := dockerignore.NewMatcher(patterns...)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(m.MatchesPath(path)).To(Equal(expected))
+		},
+		Entry("file", []string{"README.md"}, "README.md", true),
+		Entry("not relative to directories", []string{"README.md"}, "docs/README.md", false),
+		Entry("parent directory", []string{"docs"}, "docs/a/README.md", true),
+		Entry("star", []string{"*.md"}, "README.md", true),
+		Entry("star does not cross directories", []string{"*.md"}, "docs/README.md", false),
+		Entry("double star", []string{"**/*.md"}, "docs/a/README.md", true),
+		Entry("double star at the root", []string{"**/*.md"}, "README.md", true),
+		Entry("trailing double star", []string{"docs/**"}, "docs/a/b", true),
+		Entry("question mark", []string{"file?.txt"}, "file1.txt", true),
+		Entry("character class", []string{"file[0-9].txt"}, "filea.txt", false),
+		Entry("escaped", []string{`file\*.txt`}, "file*.txt", true),
+		Entry("escaped does not glob", []string{`file\*.txt`}, "file1.txt", false),
+		Entry("exception", []string{"*.md", "!README.md"}, "README.md", false),
+		Entry("exception beneath an excluded directory", []string{"docs", "!docs/README.md"}, "docs/README.md", false),
+		Entry("last match wins", []string{"!README.md", "*.md"}, "README.md", true),
+	)
+
+	It("should reject a lone exclamation mark", func() {
+		_, err := dockerignore.NewMatcher("!")
+
+		Expect(err).To(MatchError(ContainSubstring("illegal exclusion pattern")))
+	})
+
+	Describe("ReadAll", func() {
+		It("should clean patterns", func() {
+			patterns, err := dockerignore.ReadAll(strings.NewReader(
+				"\xEF\xBB\xBF# comment\n  /docs/../build/  \n\n! ./keep\n/\n",
+			))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(patterns).To(Equal([]string{"build", "!keep", "/"}))
+		})
+	})
+
+	Describe("NewFsFromFile", func() {
+		var base afero.Fs
+
+		BeforeEach(func() {
+			base = afero.NewMemMapFs()
+			Expect(afero.WriteFile(base, ".dockerignore", []byte("node_modules\ndocs\n!docs/README.md\n*.log\n"), os.ModePerm)).To(Succeed())
+			Expect(afero.WriteFile(base, "main.go", []byte("testing"), os.ModePerm)).To(Succeed())
+			Expect(afero.WriteFile(base, "debug.log", []byte("testing"), os.ModePerm)).To(Succeed())
+			Expect(afero.WriteFile(base, "sub/debug.log", []byte("testing"), os.ModePerm)).To(Succeed())
+			Expect(afero.WriteFile(base, "node_modules/pkg/index.js", []byte("testing"), os.ModePerm)).To(Succeed())
+			Expect(afero.WriteFile(base, "docs/README.md", []byte("testing"), os.ModePerm)).To(Succeed())
+			Expect(afero.WriteFile(base, "docs/guide.md", []byte("testing"), os.ModePerm)).To(Succeed())
+		})
+
+		It("should select the build context", func() {
+			fs, err := dockerignore.NewFsFromFile(base, ".dockerignore")
+			Expect(err).NotTo(HaveOccurred())
+
+			var paths []string
+			Expect(afero.Walk(fs, "", func(path string, info os.FileInfo, err error) error {
+				if err == nil && !info.IsDir() {
+					paths = append(paths, path)
+				}
+				return err
+			})).To(Succeed())
+
+			Expect(paths).To(ConsistOf(".dockerignore", "main.go", "sub/debug.log", "docs/README.md"))
+		})
+
+		It("should hide excluded directories without exceptions", func() {
+			fs, err := dockerignore.OpenDefault(base)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = fs.Stat("node_modules")
+
+			Expect(err).To(MatchError(os.ErrNotExist))
+		})
+
+		It("should explain excluded paths", func() {
+			fs, err := dockerignore.OpenDefault(base)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = fs.Stat("node_modules/pkg/index.js")
+
+			var ignored *ignore.Error
+			Expect(errors.As(err, &ignored)).To(BeTrue())
+			Expect(ignored.Explanation.Pattern.Line).To(Equal(1))
+			Expect(err).To(MatchError("stat node_modules/pkg/index.js: ignored by .dockerignore:1:node_modules matching node_modules"))
+			Expect(err).To(MatchError(os.ErrNotExist))
+		})
+
+		It("should explain exceptions", func() {
+			fs, err := dockerignore.OpenDefault(base)
+			Expect(err).NotTo(HaveOccurred())
+
+			e, err := fs.(*ignore.Fs).Explain("docs/README.md")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(e.Ignored).To(BeFalse())
+			Expect(e.String()).To(Equal("re-included by .dockerignore:3:!docs/README.md"))
+		})
+
+		It("should fail when the file is missing", func() {
+			_, err := dockerignore.NewFsFromFile(base, "missing")
+
+			Expect(err).To(MatchError(os.ErrNotExist))
+		})
+	})
+})
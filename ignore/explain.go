@@ -0,0 +1,99 @@
+package ignore
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// Explainer is implemented by matchers that can tell which pattern decides
+// whether a path is ignored.
+type Explainer interface {
+	Matcher
+
+	// Explain describes the pattern deciding whether the slash-separated
+	// path is ignored, agreeing with Ignores.
+	Explain(path string, isDir bool) *Explanation
+}
+
+// Rule is the pattern of an ignore file deciding whether a path is ignored.
+type Rule struct {
+	// Pattern is the pattern as written.
+	Pattern string
+
+	// Source is the path of the file containing the pattern,
+	// or empty for patterns that were not read from a file.
+	Source string
+
+	// Line is the 1-based line number of the pattern in Source, or among
+	// the lines it was parsed from when Source is empty.
+	Line int
+
+	// Negate is true for patterns re-including paths, such as those starting with "!".
+	Negate bool
+}
+
+// String returns the pattern and its location, e.g. ".dockerignore:3:*.log".
+func (r *Rule) String() string {
+	if r.Source == "" {
+		return r.Pattern
+	}
+
+	return fmt.Sprintf("%s:%d:%s", r.Source, r.Line, r.Pattern)
+}
+
+// Explanation describes the pattern deciding whether a path is ignored.
+type Explanation struct {
+	// Path is the path being explained.
+	Path string
+
+	// Match is the path the pattern matched, either Path or one of its
+	// parent directories.
+	Match string
+
+	// Pattern is the pattern deciding whether Path is ignored, or nil when
+	// no pattern matches.
+	Pattern *Rule
+
+	// Ignored is true when Path is hidden by the Fs.
+	Ignored bool
+}
+
+// String describes the explanation, e.g. "ignored by .dockerignore:3:build matching build".
+func (e *Explanation) String() string {
+	var s string
+	switch {
+	case e.Pattern == nil && e.Ignored:
+		return "ignored"
+	case e.Pattern == nil:
+		return "not ignored"
+	case e.Ignored:
+		s = fmt.Sprintf("ignored by %s", e.Pattern)
+	case e.Pattern.Negate:
+		s = fmt.Sprintf("re-included by %s", e.Pattern)
+	default:
+		s = fmt.Sprintf("included by %s", e.Pattern)
+	}
+	if e.Match != Clean(e.Path) {
+		s += " matching " + e.Match
+	}
+
+	return s
+}
+
+// Error is returned by the Fs of [NewFsFromMatcher] for paths ignored by an
+// [Explainer]. It wraps [syscall.ENOENT], so the path appears not to exist.
+type Error struct {
+	Op          string
+	Path        string
+	Explanation *Explanation
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s %s: %s", e.Op, e.Path, e.Explanation)
+}
+
+// Unwrap returns [syscall.ENOENT].
+func (e *Error) Unwrap() error {
+	return syscall.ENOENT
+}
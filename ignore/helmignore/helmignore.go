@@ -0,0 +1,220 @@
+// Package helmignore filters an afero.Fs with a .helmignore file, selecting
+// the files helm loads from a chart directory.
+//
+// Patterns follow the rules of helm's pkg/ignore: each is matched with
+// [path.Match], patterns without a separator match file names, patterns
+// starting with "/" or containing a separator match paths from the chart root,
+// a trailing "/" matches directories only and "**" is not supported.
+package helmignore
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/ignore"
+)
+
+const (
+	DefaultFile = ".helmignore"
+
+	// DefaultPattern is always applied, ignoring hidden files in templates.
+	DefaultPattern = "templates/.?*"
+)
+
+// Pattern is a single rule of a .helmignore file.
+type Pattern struct {
+	Pattern string
+	Negate  bool
+
+	// Source is the path of the file containing the pattern, and Line its
+	// 1-based line number, see [ignore.Rule].
+	Source string
+	Line   int
+
+	dirOnly bool
+	glob    string
+	base    bool
+}
+
+// Matcher matches paths against the rules of a .helmignore file.
+type Matcher struct {
+	patterns []*Pattern
+}
+
+// NewMatcher parses lines as rules, followed by [DefaultPattern].
+func NewMatcher(lines ...string) (*Matcher, error) {
+	return newMatcher("", lines)
+}
+
+// newMatcher parses the lines of the ignore file source.
+func newMatcher(source string, lines []string) (*Matcher, error) {
+	m := &Matcher{}
+	for i, line := range append(lines, DefaultPattern) {
+		p, err := parse(strings.TrimSpace(line))
+		if err != nil {
+			return nil, err
+		}
+		if p == nil {
+			continue
+		}
+		if i < len(lines) {
+			p.Source = source
+		}
+
+		p.Line = i + 1
+		m.patterns = append(m.patterns, p)
+	}
+
+	return m, nil
+}
+
+// Ignores implements [ignore.Matcher]. Helm does not descend into ignored
+// directories, so paths beneath them are ignored as well.
+func (m *Matcher) Ignores(path string, isDir bool) bool {
+	return m.Explain(path, isDir).Ignored
+}
+
+// Explain implements [ignore.Explainer].
+func (m *Matcher) Explain(path string, isDir bool) *ignore.Explanation {
+	e := &ignore.Explanation{Path: path}
+	path = ignore.Clean(path)
+	if path == "" {
+		return e
+	}
+
+	segments := strings.Split(path, "/")
+	for i := range segments {
+		match := strings.Join(segments[:i+1], "/")
+		if p := m.ignores(match, isDir || i < len(segments)-1); p != nil {
+			e.Match, e.Pattern, e.Ignored = match, p.rule(), true
+			return e
+		}
+	}
+
+	return e
+}
+
+// MatchesPath implements [ignore.Ignore], treating path as a file.
+func (m *Matcher) MatchesPath(path string) bool {
+	return m.Ignores(path, false)
+}
+
+// ignores mirrors helm's Rules.Ignore, returning the rule ignoring path.
+// Unlike gitignore, a negated rule ignores every path it does not match.
+func (m *Matcher) ignores(path string, isDir bool) *Pattern {
+	for _, p := range m.patterns {
+		if p.Negate {
+			if p.dirOnly && !isDir {
+				return p
+			}
+			if !p.matches(path) {
+				return p
+			}
+			continue
+		}
+
+		if p.dirOnly && !isDir {
+			continue
+		}
+		if p.matches(path) {
+			return p
+		}
+	}
+
+	return nil
+}
+
+func (p *Pattern) rule() *ignore.Rule {
+	return &ignore.Rule{Pattern: p.Pattern, Source: p.Source, Line: p.Line, Negate: p.Negate}
+}
+
+func (p *Pattern) matches(name string) bool {
+	if p.base {
+		name = path.Base(name)
+	}
+
+	ok, _ := path.Match(p.glob, name)
+	return ok
+}
+
+func parse(rule string) (*Pattern, error) {
+	if rule == "" || strings.HasPrefix(rule, "#") {
+		return nil, nil
+	}
+	if strings.Contains(rule, "**") {
+		return nil, errors.New("double-star (**) syntax is not supported")
+	}
+	if _, err := path.Match(rule, "abc"); err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", rule, err)
+	}
+
+	p := &Pattern{Pattern: rule}
+	if strings.HasPrefix(rule, "!") {
+		p.Negate = true
+		rule = rule[1:]
+	}
+	if strings.HasSuffix(rule, "/") {
+		p.dirOnly = true
+		rule = strings.TrimSuffix(rule, "/")
+	}
+
+	if strings.HasPrefix(rule, "/") {
+		p.glob = strings.TrimPrefix(rule, "/")
+	} else {
+		p.glob, p.base = rule, !strings.Contains(rule, "/")
+	}
+
+	return p, nil
+}
+
+func NewFsFromLines(base afero.Fs, lines ...string) (afero.Fs, error) {
+	m, err := NewMatcher(lines...)
+	if err != nil {
+		return nil, err
+	}
+
+	return ignore.NewFsFromMatcher(base, m), nil
+}
+
+func NewFsFromReader(base afero.Fs, reader io.Reader) (afero.Fs, error) {
+	return newFs(base, "", reader)
+}
+
+// NewFsFromFile returns an Fs ignoring the paths matched by the .helmignore
+// file at path in base. Explanations locate rules by path and line.
+func NewFsFromFile(base afero.Fs, path string) (afero.Fs, error) {
+	f, err := base.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening ignore file: %w", err)
+	}
+	defer f.Close()
+	return newFs(base, path, f)
+}
+
+func OpenDefault(base afero.Fs) (afero.Fs, error) {
+	return NewFsFromFile(base, DefaultFile)
+}
+
+// newFs reads the lines of the ignore file source from reader.
+func newFs(base afero.Fs, source string, reader io.Reader) (afero.Fs, error) {
+	lines := []string{}
+	s := bufio.NewScanner(reader)
+	for s.Scan() {
+		lines = append(lines, s.Text())
+	}
+	if s.Err() != nil {
+		return nil, fmt.Errorf("scanning ignore lines: %w", s.Err())
+	}
+
+	m, err := newMatcher(source, lines)
+	if err != nil {
+		return nil, err
+	}
+
+	return ignore.NewFsFromMatcher(base, m), nil
+}
@@ -0,0 +1,13 @@
+package helmignore_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestHelmignore(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Helmignore Suite")
+}
@@ -0,0 +1,74 @@
+package helmignore_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/ignore/helmignore"
+)
+
+var _ = Describe("Helmignore", func() {
+	DescribeTable("Ignores",
+		func(patterns []string, path string, isDir, expected bool) {
+			m, err := helmignore.NewMatcher(patterns...)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(m.Ignores(path, isDir)).To(Equal(expected))
+		},
+		Entry("file name", []string{"*.bak"}, "templates/deploy.bak", false, true),
+		Entry("rooted", []string{"/values.bak"}, "values.bak", false, true),
+		Entry("rooted in a subdirectory", []string{"/values.bak"}, "sub/values.bak", false, false),
+		Entry("structural", []string{"templates/*.bak"}, "templates/deploy.bak", false, true),
+		Entry("structural in a subdirectory", []string{"templates/*.bak"}, "sub/templates/deploy.bak", false, false),
+		Entry("directory only", []string{"ci/"}, "ci", true, true),
+		Entry("directory only matching a file", []string{"ci/"}, "ci", false, false),
+		Entry("beneath an ignored directory", []string{"ci/"}, "ci/values.yaml", false, true),
+		Entry("negation ignores non-matches", []string{"!*.yaml"}, "NOTES.txt", false, true),
+		Entry("negation keeps matches", []string{"!*.yaml"}, "values.yaml", false, false),
+		Entry("comment", []string{"# *.yaml"}, "values.yaml", false, false),
+		Entry("hidden templates", nil, "templates/.swp", false, true),
+	)
+
+	It("should reject double stars", func() {
+		_, err := helmignore.NewMatcher("**/*.bak")
+
+		Expect(err).To(MatchError(ContainSubstring("not supported")))
+	})
+
+	Describe("OpenDefault", func() {
+		It("should filter the chart", func() {
+			base := afero.NewMemMapFs()
+			Expect(afero.WriteFile(base, ".helmignore", []byte("*.bak\n.git/\n"), os.ModePerm)).To(Succeed())
+			Expect(afero.WriteFile(base, "Chart.yaml", []byte("testing"), os.ModePerm)).To(Succeed())
+			Expect(afero.WriteFile(base, "values.bak", []byte("testing"), os.ModePerm)).To(Succeed())
+			Expect(afero.WriteFile(base, ".git/HEAD", []byte("testing"), os.ModePerm)).To(Succeed())
+
+			fs, err := helmignore.OpenDefault(base)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(afero.ReadDir(fs, "")).To(ConsistOf(
+				HaveField("Name()", ".helmignore"),
+				HaveField("Name()", "Chart.yaml"),
+			))
+		})
+
+		It("should explain ignored paths", func() {
+			base := afero.NewMemMapFs()
+			Expect(afero.WriteFile(base, ".helmignore", []byte("# backups\n*.bak\n"), os.ModePerm)).To(Succeed())
+			Expect(afero.WriteFile(base, "values.bak", []byte("testing"), os.ModePerm)).To(Succeed())
+			Expect(afero.WriteFile(base, "templates/.swp", []byte("testing"), os.ModePerm)).To(Succeed())
+
+			fs, err := helmignore.OpenDefault(base)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = fs.Open("values.bak")
+			Expect(err).To(MatchError("open values.bak: ignored by .helmignore:2:*.bak"))
+			_, err = fs.Stat("templates/.swp")
+			Expect(err).To(MatchError("stat templates/.swp: ignored by templates/.?*"))
+			Expect(err).To(MatchError(os.ErrNotExist))
+		})
+	})
+})
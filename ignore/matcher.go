@@ -0,0 +1,105 @@
+package ignore
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/filter"
+	"github.com/unmango/aferox/op"
+)
+
+// Matcher is implemented by ignore files whose patterns distinguish
+// between files and directories.
+type Matcher interface {
+	// Ignores reports whether the slash-separated path, relative to the
+	// directory containing the ignore file, is ignored.
+	Ignores(path string, isDir bool) bool
+}
+
+// Fs hides the files and directories of base ignored by a [Matcher].
+type Fs struct {
+	*filter.Fs
+	base    afero.Fs
+	matcher Matcher
+}
+
+// NewFsFromMatcher returns an Fs hiding the files and directories of base ignored by m.
+// Ignored paths fail with an error wrapping ENOENT, which is an [*Error]
+// naming the pattern responsible when m is an [Explainer].
+func NewFsFromMatcher(base afero.Fs, m Matcher) afero.Fs {
+	fsys := &Fs{base: base, matcher: m}
+	fsys.Fs = filter.NewFs(base, func(operation op.Operation) error {
+		path := Clean(operation.Path())
+		if path == "" {
+			return nil
+		}
+
+		dir := isDir(base, operation)
+		if !m.Ignores(path, dir) {
+			return nil
+		}
+		if e, ok := m.(Explainer); ok {
+			explanation := e.Explain(path, dir)
+			explanation.Path = operation.Path()
+			return &Error{Op: opName(operation), Path: operation.Path(), Explanation: explanation}
+		}
+
+		return syscall.ENOENT
+	}, filter.FilterDirs).(*filter.Fs)
+
+	return fsys
+}
+
+// Explain describes the pattern deciding whether name is ignored.
+// It fails when the matcher of the Fs is not an [Explainer].
+func (f *Fs) Explain(name string) (*Explanation, error) {
+	e, ok := f.matcher.(Explainer)
+	if !ok {
+		return nil, fmt.Errorf("explain %s: %T does not report which pattern matched", name, f.matcher)
+	}
+
+	path := Clean(name)
+	if path == "" {
+		return &Explanation{Path: name}, nil
+	}
+
+	dir, err := afero.IsDir(f.base, name)
+	explanation := e.Explain(path, err == nil && dir)
+	explanation.Path = name
+	return explanation, nil
+}
+
+// Clean returns path as a slash-separated path without leading
+// separators or "./", or an empty string for the root.
+func Clean(path string) string {
+	path = strings.TrimLeft(filepath.ToSlash(filepath.Clean(path)), "/")
+	if path == "." {
+		return ""
+	}
+
+	return path
+}
+
+// opName returns the lowercase name of operation, e.g. "open".
+func opName(operation op.Operation) string {
+	if name := op.Name(operation); name != "" {
+		return strings.ToLower(name)
+	}
+
+	return fmt.Sprintf("%T", operation)
+}
+
+func isDir(base afero.Fs, operation op.Operation) bool {
+	switch operation.(type) {
+	case op.Mkdir, op.MkdirAll:
+		return true
+	case op.Create:
+		return false
+	}
+
+	dir, err := afero.IsDir(base, operation.Path())
+	return err == nil && dir
+}
@@ -0,0 +1,292 @@
+// Package npmignore filters an afero.Fs down to the files npm pack includes
+// in a package, using .npmignore files or the "files" field of package.json.
+package npmignore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/ignore"
+)
+
+const (
+	DefaultFile = ".npmignore"
+	PackageFile = "package.json"
+)
+
+// Defaults are the patterns npm always ignores.
+var Defaults = []string{
+	".npmignore",
+	".gitignore",
+	".git",
+	"CVS",
+	".svn",
+	".hg",
+	".lock-wscript",
+	".wafpickle-*",
+	".*.swp",
+	".DS_Store",
+	"._*",
+	"npm-debug.log",
+	".npmrc",
+	"/node_modules",
+	"config.gypi",
+	"*.orig",
+	"/package-lock.json",
+}
+
+// always matches the files npm includes regardless of ignore files.
+var always = regexp.MustCompile(`(?i)^(package\.json|(readme|license|licence|copying)(\..*)?)$`)
+
+var defaults = ignore.ParsePatterns(Defaults...)
+
+// Matcher decides which paths npm pack leaves out of a package.
+type Matcher struct {
+	ignore ignore.Patterns
+	files  []string
+	allow  ignore.Patterns
+}
+
+// NewIgnoreMatcher returns a Matcher ignoring paths matched by the patterns of an ignore file.
+func NewIgnoreMatcher(patterns ignore.Patterns) *Matcher {
+	return &Matcher{ignore: patterns}
+}
+
+// NewFilesMatcher returns a Matcher including only paths matched by files,
+// the entries of the "files" field of package.json.
+func NewFilesMatcher(files ...string) *Matcher {
+	if files == nil {
+		files = []string{}
+	}
+
+	return &Matcher{files: files, allow: ignore.ParsePatterns(files...)}
+}
+
+// Ignores implements [ignore.Matcher].
+func (m *Matcher) Ignores(path string, isDir bool) bool {
+	path = ignore.Clean(path)
+	if !isDir && always.MatchString(path) {
+		return false
+	}
+	if defaults.Ignores(path, isDir) {
+		return true
+	}
+	if m.files == nil {
+		return m.ignore.Ignores(path, isDir)
+	}
+	if m.allowed(path, isDir) {
+		return false
+	}
+
+	return !isDir || !m.mayContain(path)
+}
+
+// Explain implements [ignore.Explainer]. Paths left out of the entries of
+// the "files" field are ignored without a pattern, and files npm always
+// includes are not ignored without one.
+func (m *Matcher) Explain(path string, isDir bool) *ignore.Explanation {
+	path = ignore.Clean(path)
+	if !isDir && always.MatchString(path) {
+		return &ignore.Explanation{Path: path}
+	}
+	if defaults.Ignores(path, isDir) {
+		return defaults.Explain(path, isDir)
+	}
+	if m.files == nil {
+		return m.ignore.Explain(path, isDir)
+	}
+
+	e := &ignore.Explanation{Path: path}
+	if match, p := m.allowing(path, isDir); p != nil && !p.Negate {
+		e.Match, e.Pattern = match, p.Rule()
+		return e
+	} else if p != nil {
+		e.Match, e.Pattern = match, p.Rule()
+	}
+
+	e.Ignored = !isDir || !m.mayContain(path)
+	return e
+}
+
+// allowed reports whether path is matched by m.files. Paths inherit the
+// match of their parent directory unless an entry matches them directly,
+// so negated entries can exclude files from included directories.
+func (m *Matcher) allowed(path string, isDir bool) bool {
+	_, p := m.allowing(path, isDir)
+	return p != nil && !p.Negate
+}
+
+// allowing returns the entry of m.files deciding whether path is allowed, if
+// any, and the path it matched.
+func (m *Matcher) allowing(path string, isDir bool) (match string, last *ignore.Pattern) {
+	segments := strings.Split(path, "/")
+	for i := range segments {
+		name, dir := strings.Join(segments[:i+1], "/"), isDir || i < len(segments)-1
+		for _, p := range m.allow {
+			if p.Matches(name, dir) {
+				match, last = name, p
+			}
+		}
+	}
+
+	return match, last
+}
+
+// MatchesPath implements [ignore.Ignore], treating path as a file.
+func (m *Matcher) MatchesPath(path string) bool {
+	return m.Ignores(path, false)
+}
+
+// mayContain reports whether an entry of m.files could match beneath dir.
+func (m *Matcher) mayContain(dir string) bool {
+	segments := strings.Split(dir, "/")
+	for _, file := range m.files {
+		if strings.HasPrefix(file, "!") {
+			continue
+		}
+
+		// Like gitignore patterns, entries without a separator match at any depth
+		file = path.Clean(file)
+		if !strings.Contains(file, "/") {
+			return true
+		}
+
+		parts := strings.Split(strings.TrimPrefix(file, "/"), "/")
+		for i, segment := range segments {
+			if i >= len(parts)-1 {
+				break
+			}
+			if parts[i] == "**" {
+				return true
+			}
+			if ok, _ := path.Match(parts[i], segment); !ok {
+				break
+			}
+			if i == len(segments)-1 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+type packageJSON struct {
+	Files []string        `json:"files"`
+	Main  string          `json:"main"`
+	Bin   json.RawMessage `json:"bin"`
+}
+
+// ReadPackage reads the entries npm includes from a package.json file: the
+// "files" field along with the "main" and "bin" entries. It returns nil when
+// the "files" field is missing.
+func ReadPackage(reader io.Reader) ([]string, error) {
+	var pkg packageJSON
+	if err := json.NewDecoder(reader).Decode(&pkg); err != nil {
+		return nil, fmt.Errorf("decoding package.json: %w", err)
+	}
+	if pkg.Files == nil {
+		return nil, nil
+	}
+
+	files := append([]string{}, pkg.Files...)
+	if pkg.Main != "" {
+		files = append(files, pkg.Main)
+	}
+
+	// The bin field is either a path or a map of command names to paths
+	var bin string
+	var bins map[string]string
+	if json.Unmarshal(pkg.Bin, &bin) == nil && bin != "" {
+		files = append(files, bin)
+	} else if json.Unmarshal(pkg.Bin, &bins) == nil {
+		for _, b := range bins {
+			files = append(files, b)
+		}
+	}
+
+	return files, nil
+}
+
+func NewFsFromLines(base afero.Fs, lines ...string) afero.Fs {
+	return ignore.NewFsFromMatcher(base, NewIgnoreMatcher(ignore.ParsePatterns(lines...)))
+}
+
+// NewFsFromFiles returns an Fs including only the paths matched by files, as
+// with the "files" field of package.json.
+func NewFsFromFiles(base afero.Fs, files ...string) afero.Fs {
+	return ignore.NewFsFromMatcher(base, NewFilesMatcher(files...))
+}
+
+func NewFsFromReader(base afero.Fs, reader io.Reader) (afero.Fs, error) {
+	patterns, err := ignore.ReadPatterns(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return ignore.NewFsFromMatcher(base, NewIgnoreMatcher(patterns)), nil
+}
+
+// NewFsFromFile returns an Fs ignoring the paths matched by the ignore file
+// at path in base. Explanations locate patterns by path and line.
+func NewFsFromFile(base afero.Fs, path string) (afero.Fs, error) {
+	patterns, err := ignore.ReadPatternsFile(base, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ignore.NewFsFromMatcher(base, NewIgnoreMatcher(patterns)), nil
+}
+
+// NewFsFromPackage returns an Fs including the entries of the package.json file
+// at path, or an error if it has no "files" field.
+func NewFsFromPackage(base afero.Fs, path string) (afero.Fs, error) {
+	f, err := base.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening package file: %w", err)
+	}
+	defer f.Close()
+
+	files, err := ReadPackage(f)
+	if err != nil {
+		return nil, err
+	}
+	if files == nil {
+		return nil, fmt.Errorf("%s: no files field", path)
+	}
+
+	return NewFsFromFiles(base, files...), nil
+}
+
+// OpenDefault selects files as npm pack does: with the "files" field of
+// package.json when present, otherwise with .npmignore, falling back to
+// .gitignore, and otherwise with only the default patterns.
+func OpenDefault(base afero.Fs) (afero.Fs, error) {
+	if f, err := base.Open(PackageFile); err == nil {
+		files, err := ReadPackage(f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		if files != nil {
+			return NewFsFromFiles(base, files...), nil
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	for _, name := range []string{DefaultFile, ".gitignore"} {
+		if fsys, err := NewFsFromFile(base, name); !errors.Is(err, fs.ErrNotExist) {
+			return fsys, err
+		}
+	}
+
+	return NewFsFromLines(base), nil
+}
@@ -0,0 +1,13 @@
+package npmignore_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestNpmignore(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Npmignore Suite")
+}
@@ -0,0 +1,134 @@
+package npmignore_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/ignore"
+	"github.com/unmango/aferox/ignore/npmignore"
+)
+
+var _ = Describe("Npmignore", func() {
+	var base afero.Fs
+
+	write := func(name, content string) {
+		GinkgoHelper()
+		Expect(afero.WriteFile(base, name, []byte(content), os.ModePerm)).To(Succeed())
+	}
+
+	files := func(fs afero.Fs) []string {
+		GinkgoHelper()
+		var paths []string
+		Expect(afero.Walk(fs, "", func(path string, info os.FileInfo, err error) error {
+			if err == nil && !info.IsDir() {
+				paths = append(paths, path)
+			}
+			return err
+		})).To(Succeed())
+
+		return paths
+	}
+
+	BeforeEach(func() {
+		base = afero.NewMemMapFs()
+		write("README.md", "testing")
+		write("LICENSE", "testing")
+		write("lib/index.js", "testing")
+		write("lib/index.test.js", "testing")
+		write("bin/cli.js", "testing")
+		write("test/index.js", "testing")
+		write("node_modules/dep/index.js", "testing")
+		write("package-lock.json", "testing")
+		write(".npmrc", "testing")
+	})
+
+	Describe("NewFsFromFile", func() {
+		It("should apply the ignore file and the defaults", func() {
+			write(".npmignore", "test/\n*.test.js\nREADME.md\n")
+
+			fs, err := npmignore.NewFsFromFile(base, ".npmignore")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files(fs)).To(ConsistOf("README.md", "LICENSE", "lib/index.js", "bin/cli.js"))
+		})
+
+		It("should explain ignored paths", func() {
+			write(".npmignore", "# tests\n*.test.js\n")
+
+			fs, err := npmignore.NewFsFromFile(base, ".npmignore")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = fs.Stat("lib/index.test.js")
+			Expect(err).To(MatchError("stat lib/index.test.js: ignored by .npmignore:2:*.test.js"))
+			Expect(err).To(MatchError(os.ErrNotExist))
+			_, err = fs.Stat("node_modules/dep/index.js")
+			Expect(err).To(MatchError("stat node_modules/dep/index.js: ignored by /node_modules matching node_modules"))
+		})
+	})
+
+	Describe("NewFsFromPackage", func() {
+		It("should include only the listed files", func() {
+			write("package.json", `{"files": ["lib/", "!lib/*.test.js"], "bin": {"cli": "bin/cli.js"}}`)
+
+			fs, err := npmignore.NewFsFromPackage(base, "package.json")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files(fs)).To(ConsistOf("README.md", "LICENSE", "package.json", "lib/index.js", "bin/cli.js"))
+		})
+
+		It("should include the main file", func() {
+			write("package.json", `{"files": [], "main": "lib/index.js"}`)
+
+			fs, err := npmignore.NewFsFromPackage(base, "package.json")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files(fs)).To(ConsistOf("README.md", "LICENSE", "package.json", "lib/index.js"))
+		})
+
+		It("should fail without a files field", func() {
+			write("package.json", `{}`)
+
+			_, err := npmignore.NewFsFromPackage(base, "package.json")
+
+			Expect(err).To(MatchError(ContainSubstring("no files field")))
+		})
+
+		It("should explain paths left out of the files field", func() {
+			write("package.json", `{"files": ["lib/"]}`)
+
+			fs, err := npmignore.NewFsFromPackage(base, "package.json")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = fs.Stat("test/index.js")
+			Expect(err).To(MatchError("stat test/index.js: ignored"))
+			e, err := fs.(*ignore.Fs).Explain("lib/index.js")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(e.String()).To(Equal("included by lib/ matching lib"))
+		})
+	})
+
+	Describe("OpenDefault", func() {
+		It("should prefer the files field", func() {
+			write("package.json", `{"files": ["bin"]}`)
+			write(".npmignore", "bin/\n")
+
+			fs, err := npmignore.OpenDefault(base)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files(fs)).To(ConsistOf("README.md", "LICENSE", "package.json", "bin/cli.js"))
+		})
+
+		It("should fall back to .gitignore", func() {
+			write("package.json", `{}`)
+			write(".gitignore", "test\nlib\n")
+
+			fs, err := npmignore.OpenDefault(base)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files(fs)).To(ConsistOf("README.md", "LICENSE", "package.json", "bin/cli.js"))
+		})
+	})
+})
@@ -0,0 +1,272 @@
+package ignore
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// Patterns matches paths with the pattern format of gitignore(5), which
+// .npmignore and .prettierignore files share. Patterns are relative to the
+// directory containing the ignore file, the last matching pattern wins, and
+// paths beneath an ignored directory cannot be re-included.
+type Patterns []*Pattern
+
+// Pattern is a single line of an ignore file in the gitignore(5) format.
+type Pattern struct {
+	Pattern string
+	Negate  bool
+
+	// Source is the path of the file containing the pattern, and Line its
+	// 1-based line number, see [Rule].
+	Source string
+	Line   int
+
+	dirOnly bool
+	re      *regexp.Regexp
+}
+
+// ParsePatterns parses lines in the gitignore(5) format, skipping blank
+// lines, comments and malformed patterns. The Line of each pattern is its
+// position in lines.
+func ParsePatterns(lines ...string) Patterns {
+	var patterns Patterns
+	for i, line := range lines {
+		if p, err := CompilePattern(line); err == nil && p != nil {
+			p.Line = i + 1
+			patterns = append(patterns, p)
+		}
+	}
+
+	return patterns
+}
+
+// ReadPatterns parses the lines of r with [ParsePatterns].
+func ReadPatterns(r io.Reader) (Patterns, error) {
+	lines := []string{}
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		lines = append(lines, s.Text())
+	}
+	if s.Err() != nil {
+		return nil, fmt.Errorf("scanning ignore lines: %w", s.Err())
+	}
+
+	return ParsePatterns(lines...), nil
+}
+
+// ReadPatternsFile parses the ignore file at path in fsys with [ReadPatterns],
+// setting the Source of each pattern to path.
+func ReadPatternsFile(fsys afero.Fs, path string) (Patterns, error) {
+	f, err := fsys.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening ignore file: %w", err)
+	}
+	defer f.Close()
+
+	patterns, err := ReadPatterns(f)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range patterns {
+		p.Source = path
+	}
+
+	return patterns, nil
+}
+
+// Ignores implements [Matcher].
+func (p Patterns) Ignores(path string, isDir bool) bool {
+	path = Clean(path)
+	if path == "" {
+		return false
+	}
+
+	segments := strings.Split(path, "/")
+	for i := range segments {
+		dir := isDir || i < len(segments)-1
+		if p.match(strings.Join(segments[:i+1], "/"), dir) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MatchesPath implements [Ignore], treating path as a file.
+func (p Patterns) MatchesPath(path string) bool {
+	return p.Ignores(path, false)
+}
+
+// Explain implements [Explainer].
+func (p Patterns) Explain(path string, isDir bool) *Explanation {
+	e := &Explanation{Path: path}
+	path = Clean(path)
+	if path == "" {
+		return e
+	}
+
+	// Paths beneath an ignored directory cannot be re-included
+	segments := strings.Split(path, "/")
+	for i := range segments {
+		match := strings.Join(segments[:i+1], "/")
+		pattern := p.last(match, isDir || i < len(segments)-1)
+		if pattern != nil && (!pattern.Negate || i == len(segments)-1) {
+			e.Match, e.Pattern, e.Ignored = match, pattern.Rule(), !pattern.Negate
+		}
+		if e.Ignored {
+			return e
+		}
+	}
+
+	return e
+}
+
+// match reports whether the last pattern matching path ignores it.
+func (p Patterns) match(path string, isDir bool) bool {
+	pattern := p.last(path, isDir)
+	return pattern != nil && !pattern.Negate
+}
+
+// last returns the last pattern matching path, if any.
+func (p Patterns) last(path string, isDir bool) (last *Pattern) {
+	for _, pattern := range p {
+		if pattern.Matches(path, isDir) {
+			last = pattern
+		}
+	}
+
+	return last
+}
+
+// Rule returns the pattern as a [Rule].
+func (p *Pattern) Rule() *Rule {
+	return &Rule{Pattern: p.Pattern, Source: p.Source, Line: p.Line, Negate: p.Negate}
+}
+
+// Matches reports whether the slash-separated path matches p, ignoring negation.
+func (p *Pattern) Matches(path string, isDir bool) bool {
+	if p.dirOnly && !isDir {
+		return false
+	}
+
+	return p.re.MatchString(path)
+}
+
+// CompilePattern parses a line in the gitignore(5) format.
+// It returns nil for blank lines and comments.
+func CompilePattern(line string) (*Pattern, error) {
+	line = trimTrailingSpace(strings.TrimSuffix(line, "\r"))
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil, nil
+	}
+
+	p := &Pattern{Pattern: line}
+	glob := line
+	if strings.HasPrefix(glob, "!") {
+		p.Negate = true
+		glob = glob[1:]
+	}
+	if strings.HasPrefix(glob, `\`) {
+		glob = glob[1:]
+	}
+	if strings.HasSuffix(glob, "/") {
+		p.dirOnly = true
+		glob = strings.TrimRight(glob, "/")
+	}
+	if glob == "" {
+		return nil, nil
+	}
+
+	// A separator at the beginning or middle anchors the pattern to the ignore file
+	anchored := strings.Contains(glob, "/")
+	glob = strings.TrimPrefix(glob, "/")
+
+	expr, err := globRegexp(glob)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", line, err)
+	}
+	if !anchored {
+		expr = "(?:.*/)?" + expr
+	}
+
+	if p.re, err = regexp.Compile("^" + expr + "$"); err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", line, err)
+	}
+
+	return p, nil
+}
+
+// globRegexp converts a gitignore glob to a regular expression.
+func globRegexp(glob string) (string, error) {
+	if _, err := filepath.Match(glob, ""); err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	segments := strings.Split(glob, "/")
+	for i, segment := range segments {
+		last := i == len(segments)-1
+		if segment == "**" {
+			if last {
+				// A trailing "/**" matches everything inside
+				b.WriteString(".*")
+			} else {
+				// A leading "**/" or "/**/" matches zero or more directories
+				b.WriteString("(?:.*/)?")
+			}
+			continue
+		}
+
+		b.WriteString(segmentRegexp(segment))
+		if !last {
+			b.WriteString("/")
+		}
+	}
+
+	return b.String(), nil
+}
+
+// segmentRegexp converts a glob without separators to a regular expression.
+func segmentRegexp(segment string) string {
+	var b strings.Builder
+	for i := 0; i < len(segment); i++ {
+		switch c := segment[i]; c {
+		case '*':
+			b.WriteString("[^/]*")
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(segment[i+1:], ']')
+			class := segment[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(segment) {
+				i++
+			}
+			b.WriteString(regexp.QuoteMeta(segment[i : i+1]))
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	return b.String()
+}
+
+// trimTrailingSpace removes trailing spaces that are not escaped with a backslash.
+func trimTrailingSpace(line string) string {
+	for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, `\ `) {
+		line = line[:len(line)-1]
+	}
+
+	return line
+}
@@ -0,0 +1,123 @@
+package ignore_test
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"syscall"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/ignore"
+)
+
+var _ = Describe("Patterns", func() {
+	DescribeTable("Ignores",
+		func(lines, path string, isDir, expected bool) {
+			patterns := ignore.ParsePatterns(strings.Split(lines, "\n")...)
+
+			Expect(patterns.Ignores(path, isDir)).To(Equal(expected))
+			Expect(patterns.Explain(path, isDir).Ignored).To(Equal(expected))
+		},
+		Entry("extension", "*.log", "debug.log", false, true),
+		Entry("nested extension", "*.log", "a/b/debug.log", false, true),
+		Entry("comment", "# *.log", "debug.log", false, false),
+		Entry("escaped hash", `\#file`, "#file", false, true),
+		Entry("escaped negation", `\!file`, "!file", false, true),
+		Entry("anchored", "/debug.log", "a/debug.log", false, false),
+		Entry("middle slash", "a/debug.log", "a/debug.log", false, true),
+		Entry("directory only", "build/", "build", false, false),
+		Entry("directory contents", "build/", "build/out", false, true),
+		Entry("double star", "a/**/b", "a/x/y/b", false, true),
+		Entry("negation", "*.log\n!keep.log", "keep.log", false, false),
+		Entry("ignored parent", "build\n!build/keep.log", "build/keep.log", false, true),
+		Entry("leading separator", "*.log", "/debug.log", false, true),
+		Entry("root", "*", "", true, false),
+	)
+
+	It("should skip malformed patterns", func() {
+		Expect(ignore.ParsePatterns("[", "*.log")).To(HaveLen(1))
+	})
+
+	It("should read patterns", func() {
+		patterns, err := ignore.ReadPatterns(strings.NewReader("*.log\n\n# comment\n"))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(patterns).To(HaveLen(1))
+		Expect(patterns[0].Pattern).To(Equal("*.log"))
+	})
+
+	It("should explain the pattern ignoring a parent directory", func() {
+		patterns := ignore.ParsePatterns("# build output", "build/", "!build/keep.log")
+
+		e := patterns.Explain("build/keep.log", false)
+
+		Expect(e.Ignored).To(BeTrue())
+		Expect(e.Match).To(Equal("build"))
+		Expect(e.Pattern).To(Equal(&ignore.Rule{Pattern: "build/", Line: 2}))
+		Expect(e.String()).To(Equal("ignored by build/ matching build"))
+	})
+})
+
+var _ = Describe("NewFsFromMatcher", func() {
+	var base afero.Fs
+
+	BeforeEach(func() {
+		base = afero.NewMemMapFs()
+		Expect(afero.WriteFile(base, "build/out.txt", []byte("testing"), os.ModePerm)).To(Succeed())
+		Expect(afero.WriteFile(base, "src/main.go", []byte("testing"), os.ModePerm)).To(Succeed())
+	})
+
+	It("should hide ignored directories", func() {
+		fs := ignore.NewFsFromMatcher(base, ignore.ParsePatterns("build/"))
+
+		_, err := fs.Stat("build")
+		Expect(err).To(MatchError(os.ErrNotExist))
+
+		_, err = fs.Stat("build/out.txt")
+		Expect(err).To(MatchError(os.ErrNotExist))
+
+		Expect(afero.ReadDir(fs, "")).To(ConsistOf(HaveField("Name()", "src")))
+	})
+
+	It("should explain ignored paths", func() {
+		Expect(afero.WriteFile(base, ".ignore", []byte("build/\n"), os.ModePerm)).To(Succeed())
+		patterns, err := ignore.ReadPatternsFile(base, ".ignore")
+		Expect(err).NotTo(HaveOccurred())
+		fs := ignore.NewFsFromMatcher(base, patterns)
+
+		_, err = fs.Open("build/out.txt")
+
+		var ignored *ignore.Error
+		Expect(errors.As(err, &ignored)).To(BeTrue())
+		Expect(ignored.Op).To(Equal("open"))
+		Expect(ignored.Explanation.Pattern.Source).To(Equal(".ignore"))
+		Expect(err).To(MatchError("open build/out.txt: ignored by .ignore:1:build/ matching build"))
+		Expect(err).To(MatchError(syscall.ENOENT))
+	})
+
+	It("should fail to explain matchers that cannot tell which pattern matched", func() {
+		fs := ignore.NewFsFromMatcher(base, matcherFunc(func(string, bool) bool { return true }))
+
+		_, err := fs.Stat("src")
+		Expect(err).To(Equal(syscall.ENOENT))
+		_, err = fs.(*ignore.Fs).Explain("src")
+		Expect(err).To(MatchError(ContainSubstring("does not report which pattern matched")))
+	})
+
+	It("should treat created directories as directories", func() {
+		fs := ignore.NewFsFromMatcher(base, ignore.ParsePatterns("tmp/"))
+
+		Expect(fs.Mkdir("tmp", os.ModePerm)).To(MatchError(os.ErrNotExist))
+		_, err := fs.Create("tmp")
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
+
+type matcherFunc func(path string, isDir bool) bool
+
+func (f matcherFunc) Ignores(path string, isDir bool) bool {
+	return f(path, isDir)
+}
@@ -0,0 +1,76 @@
+// Package prettierignore filters an afero.Fs with a .prettierignore file,
+// selecting the files prettier formats.
+//
+// Patterns use the gitignore(5) format, relative to the root of the Fs.
+// Version control directories and node_modules are always ignored.
+package prettierignore
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"slices"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/ignore"
+)
+
+const DefaultFile = ".prettierignore"
+
+// Defaults are the patterns prettier always ignores.
+var Defaults = []string{
+	"**/.git",
+	"**/.sl",
+	"**/.svn",
+	"**/.hg",
+	"**/.jj",
+	"**/node_modules",
+}
+
+func NewFsFromLines(base afero.Fs, lines ...string) afero.Fs {
+	return ignore.NewFsFromMatcher(base,
+		ignore.ParsePatterns(append(slices.Clone(Defaults), lines...)...),
+	)
+}
+
+func NewFsFromReader(base afero.Fs, reader io.Reader) (afero.Fs, error) {
+	patterns, err := ignore.ReadPatterns(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return ignore.NewFsFromMatcher(base,
+		append(ignore.ParsePatterns(Defaults...), patterns...),
+	), nil
+}
+
+// NewFsFromFile returns an Fs ignoring the paths matched by the ignore file
+// at path in base. Explanations locate patterns by path and line.
+func NewFsFromFile(base afero.Fs, path string) (afero.Fs, error) {
+	patterns, err := ignore.ReadPatternsFile(base, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ignore.NewFsFromMatcher(base,
+		append(ignore.ParsePatterns(Defaults...), patterns...),
+	), nil
+}
+
+// OpenDefault reads .gitignore and .prettierignore from the root of base, as
+// prettier does by default. Either file may be missing.
+func OpenDefault(base afero.Fs) (afero.Fs, error) {
+	patterns := ignore.ParsePatterns(Defaults...)
+	for _, name := range []string{".gitignore", DefaultFile} {
+		p, err := ignore.ReadPatternsFile(base, name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		patterns = append(patterns, p...)
+	}
+
+	return ignore.NewFsFromMatcher(base, patterns), nil
+}
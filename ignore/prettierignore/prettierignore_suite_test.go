@@ -0,0 +1,13 @@
+package prettierignore_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestPrettierignore(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Prettierignore Suite")
+}
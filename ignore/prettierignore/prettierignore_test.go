@@ -0,0 +1,66 @@
+package prettierignore_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/ignore/prettierignore"
+)
+
+var _ = Describe("Prettierignore", func() {
+	var base afero.Fs
+
+	BeforeEach(func() {
+		base = afero.NewMemMapFs()
+		Expect(afero.WriteFile(base, "src/index.ts", []byte("testing"), os.ModePerm)).To(Succeed())
+		Expect(afero.WriteFile(base, "dist/index.js", []byte("testing"), os.ModePerm)).To(Succeed())
+		Expect(afero.WriteFile(base, "coverage/lcov.info", []byte("testing"), os.ModePerm)).To(Succeed())
+		Expect(afero.WriteFile(base, "packages/a/node_modules/b/index.js", []byte("testing"), os.ModePerm)).To(Succeed())
+	})
+
+	It("should always ignore node_modules", func() {
+		fs := prettierignore.NewFsFromLines(base)
+
+		_, err := fs.Stat("packages/a/node_modules/b/index.js")
+
+		Expect(err).To(MatchError(os.ErrNotExist))
+	})
+
+	It("should read the ignore file", func() {
+		Expect(afero.WriteFile(base, ".prettierignore", []byte("dist/\n"), os.ModePerm)).To(Succeed())
+
+		fs, err := prettierignore.NewFsFromFile(base, ".prettierignore")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = fs.Stat("dist/index.js")
+		Expect(err).To(MatchError(os.ErrNotExist))
+		Expect(err).To(MatchError("stat dist/index.js: ignored by .prettierignore:1:dist/ matching dist"))
+
+		_, err = fs.Stat("src/index.ts")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should combine .gitignore and .prettierignore", func() {
+		Expect(afero.WriteFile(base, ".gitignore", []byte("coverage\n"), os.ModePerm)).To(Succeed())
+		Expect(afero.WriteFile(base, ".prettierignore", []byte("dist\n"), os.ModePerm)).To(Succeed())
+
+		fs, err := prettierignore.OpenDefault(base)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(afero.ReadDir(fs, "")).To(ConsistOf(
+			HaveField("Name()", ".gitignore"),
+			HaveField("Name()", ".prettierignore"),
+			HaveField("Name()", "src"),
+			HaveField("Name()", "packages"),
+		))
+	})
+
+	It("should allow missing ignore files", func() {
+		_, err := prettierignore.OpenDefault(base)
+
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
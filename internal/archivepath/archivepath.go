@@ -0,0 +1,65 @@
+// Package archivepath handles the slash-separated entry names of archives,
+// which are relative to the root of the archive and imply the directories
+// containing them.
+package archivepath
+
+import (
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Clean returns name as a slash-separated path relative to the root of the archive.
+func Clean(name string) string {
+	rel := strings.TrimLeft(path.Clean(filepath.ToSlash(name)), "/")
+	if rel == "." {
+		return ""
+	}
+
+	return rel
+}
+
+// Join joins the cleaned names dir and name, where dir is empty for the root.
+func Join(dir, name string) string {
+	if dir == "" {
+		return name
+	}
+
+	return dir + "/" + name
+}
+
+// Implied reports whether an entry exists beneath dir.
+func Implied[V any](entries map[string]V, dir string) bool {
+	for name := range entries {
+		if strings.HasPrefix(name, dir+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Children returns the sorted names of the entries directly beneath dir.
+func Children[V any](entries map[string]V, dir string) []string {
+	seen := map[string]bool{}
+	for name := range entries {
+		if dir != "" {
+			if !strings.HasPrefix(name, dir+"/") {
+				continue
+			}
+			name = name[len(dir)+1:]
+		}
+
+		child, _, _ := strings.Cut(name, "/")
+		seen[child] = true
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,14 @@
+package mapped_test
+
+import (
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/mapped"
+	"github.com/unmango/aferox/testing/conformance"
+)
+
+var _ = conformance.Describe("Conformance", func(seed afero.Fs) (afero.Fs, error) {
+	return mapped.NewFs(map[string]afero.Fs{"mnt": seed}), nil
+},
+	conformance.WithRoot("mnt"),
+	conformance.Without(conformance.NoSymlink),
+)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"syscall"
 	"time"
 
 	"github.com/spf13/afero"
@@ -115,9 +116,22 @@ func (f Fs) RemoveAll(path string) error {
 	}
 }
 
-// Rename implements afero.Fs.
+// Rename implements afero.Fs. Files cannot be renamed across mapped filesystems.
 func (f Fs) Rename(oldname string, newname string) error {
-	panic("unimplemented")
+	oldk, oldp, err := f.split(oldname)
+	if err != nil {
+		return err
+	}
+
+	newk, newp, err := f.split(newname)
+	if err != nil {
+		return err
+	}
+	if oldk != newk {
+		return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: syscall.EXDEV}
+	}
+
+	return f[oldk].Rename(oldp, newp)
 }
 
 // Stat implements afero.Fs.
@@ -3,6 +3,7 @@ package mapped_test
 import (
 	"io"
 	"os"
+	"syscall"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -137,4 +138,32 @@ var _ = Describe("Fs", func() {
 
 		Expect(err).To(MatchError(os.ErrNotExist))
 	})
+
+	It("should rename files within a mapped fs", func() {
+		testFs := afero.NewMemMapFs()
+		err := afero.WriteFile(testFs, "old.txt", []byte("testing"), os.ModePerm)
+		Expect(err).NotTo(HaveOccurred())
+		fs := mapped.NewFs(map[string]afero.Fs{
+			"test": testFs,
+		})
+
+		err = fs.Rename("test/old.txt", "test/new.txt")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(afero.ReadFile(testFs, "new.txt")).To(BeEquivalentTo("testing"))
+	})
+
+	It("should not rename files across mapped filesystems", func() {
+		testFs := afero.NewMemMapFs()
+		err := afero.WriteFile(testFs, "old.txt", []byte("testing"), os.ModePerm)
+		Expect(err).NotTo(HaveOccurred())
+		fs := mapped.NewFs(map[string]afero.Fs{
+			"test":  testFs,
+			"other": afero.NewMemMapFs(),
+		})
+
+		err = fs.Rename("test/old.txt", "other/new.txt")
+
+		Expect(err).To(MatchError(syscall.EXDEV))
+	})
 })
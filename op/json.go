@@ -0,0 +1,177 @@
+package op
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+)
+
+var decoders = map[string]func([]byte) (Operation, error){
+	"Chmod":        decode[Chmod],
+	"Chown":        decode[Chown],
+	"Chtimes":      decode[Chtimes],
+	"Create":       decode[Create],
+	"Mkdir":        decode[Mkdir],
+	"MkdirAll":     decode[MkdirAll],
+	"Open":         decode[Open],
+	"OpenFile":     decode[OpenFile],
+	"Remove":       decode[Remove],
+	"RemoveAll":    decode[RemoveAll],
+	"Rename":       decode[Rename],
+	"Stat":         decode[Stat],
+	"Readdir":      decode[Readdir],
+	"Readdirnames": decode[Readdirnames],
+	"Read":         decode[Read],
+	"ReadAt":       decode[ReadAt],
+	"Seek":         decode[Seek],
+	"Write":        decode[Write],
+	"WriteAt":      decode[WriteAt],
+	"Truncate":     decode[Truncate],
+	"Sync":         decode[Sync],
+	"Close":        decode[Close],
+	"Symlink":      decode[Symlink],
+	"Readlink":     decode[Readlink],
+	"Lstat":        decode[Lstat],
+}
+
+// envelope is the JSON encoding of an Operation.
+type envelope struct {
+	Op        string          `json:"op"`
+	Handle    uint64          `json:"handle,omitempty"`
+	Operation json.RawMessage `json:"operation"`
+}
+
+// Name returns the name of operation's type, e.g. "Create", or of the
+// operation it tags when operation is a [Handle].
+// It returns an empty string for types not defined by this package.
+func Name(operation Operation) string {
+	if h, ok := operation.(Handle); ok {
+		operation = h.Operation
+	}
+	if operation == nil {
+		return ""
+	}
+
+	name := reflect.TypeOf(operation).Name()
+	if _, ok := decoders[name]; ok {
+		return name
+	} else {
+		return ""
+	}
+}
+
+// Names returns the names of the operation types defined by this package.
+func Names() []string {
+	return slices.Sorted(maps.Keys(decoders))
+}
+
+// Marshal returns the JSON encoding of operation, an object
+// holding the name of the operation and its fields. The ID of
+// a [Handle] is held alongside the operation it tags.
+//
+//	{"op":"Create","operation":{"Name":"test.txt"}}
+//	{"op":"Write","handle":1,"operation":{"Name":"test.txt","Data":"dGVzdA=="}}
+func Marshal(operation Operation) ([]byte, error) {
+	name := Name(operation)
+	if name == "" {
+		return nil, fmt.Errorf("op: unsupported operation type %T", operation)
+	}
+
+	env := envelope{Op: name}
+	if h, ok := operation.(Handle); ok {
+		env.Handle, operation = h.ID, h.Operation
+	}
+
+	data, err := json.Marshal(operation)
+	if err != nil {
+		return nil, err
+	}
+
+	env.Operation = data
+	return json.Marshal(env)
+}
+
+// Unmarshal parses an operation encoded by [Marshal].
+func Unmarshal(data []byte) (Operation, error) {
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, err
+	}
+
+	decode, ok := decoders[env.Op]
+	if !ok {
+		return nil, fmt.Errorf("op: unsupported operation %q", env.Op)
+	}
+	if len(env.Operation) == 0 {
+		env.Operation = []byte("{}")
+	}
+
+	operation, err := decode(env.Operation)
+	if err != nil || env.Handle == 0 {
+		return operation, err
+	}
+
+	return Handle{ID: env.Handle, Operation: operation}, nil
+}
+
+// Encoder writes operations to a stream as lines of JSON.
+type Encoder struct {
+	w io.Writer
+}
+
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Encode writes the JSON encoding of operation followed by a newline.
+func (e *Encoder) Encode(operation Operation) error {
+	data, err := Marshal(operation)
+	if err != nil {
+		return err
+	}
+
+	_, err = e.w.Write(append(data, '\n'))
+	return err
+}
+
+// Decoder reads operations written by an [Encoder].
+// Additional fields on each line, such as those written
+// by the record package, are ignored.
+type Decoder struct {
+	s *bufio.Scanner
+}
+
+func NewDecoder(r io.Reader) *Decoder {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 64<<20)
+
+	return &Decoder{s: s}
+}
+
+// Decode reads the next operation from the stream.
+// It returns [io.EOF] when there are no more operations.
+func (d *Decoder) Decode() (Operation, error) {
+	for d.s.Scan() {
+		if line := d.s.Bytes(); len(line) > 0 {
+			return Unmarshal(line)
+		}
+	}
+	if err := d.s.Err(); err != nil {
+		return nil, err
+	}
+
+	return nil, io.EOF
+}
+
+func decode[T Operation](data []byte) (Operation, error) {
+	var operation T
+	if err := json.Unmarshal(data, &operation); err != nil {
+		return nil, err
+	}
+
+	return operation, nil
+}
@@ -0,0 +1,97 @@
+package op_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/unmango/aferox/op"
+)
+
+var _ = Describe("JSON", func() {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	DescribeTable("should round-trip operations",
+		func(operation op.Operation) {
+			data, err := op.Marshal(operation)
+			Expect(err).NotTo(HaveOccurred())
+
+			result, err := op.Unmarshal(data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(operation))
+		},
+		Entry("Chmod", op.Chmod{Name: "test.txt", Mode: 0o755}),
+		Entry("Chown", op.Chown{Name: "test.txt", UID: 1000, GID: 1001}),
+		Entry("Chtimes", op.Chtimes{Name: "test.txt", Atime: now, Mtime: now}),
+		Entry("Create", op.Create{Name: "test.txt"}),
+		Entry("Mkdir", op.Mkdir{Name: "dir", Perm: 0o755}),
+		Entry("MkdirAll", op.MkdirAll{Name: "dir/sub", Perm: 0o755}),
+		Entry("Open", op.Open{Name: "test.txt"}),
+		Entry("OpenFile", op.OpenFile{Name: "test.txt", Flag: os.O_RDWR | os.O_CREATE, Perm: 0o644}),
+		Entry("Remove", op.Remove{Name: "test.txt"}),
+		Entry("RemoveAll", op.RemoveAll{Name: "dir"}),
+		Entry("Rename", op.Rename{Oldname: "old.txt", Newname: "new.txt"}),
+		Entry("Stat", op.Stat{Name: "test.txt"}),
+		Entry("Readdir", op.Readdir{Name: "dir", Count: -1}),
+		Entry("Readdirnames", op.Readdirnames{Name: "dir", Count: 10}),
+		Entry("Write", op.Write{Name: "test.txt", Data: []byte("testing")}),
+		Entry("Read", op.Read{Name: "test.txt", N: 7}),
+		Entry("ReadAt", op.ReadAt{Name: "test.txt", Offset: 4}),
+		Entry("Seek", op.Seek{Name: "test.txt", Offset: 4, Whence: io.SeekCurrent}),
+		Entry("WriteAt", op.WriteAt{Name: "test.txt", Offset: 4, Data: []byte("testing")}),
+		Entry("Truncate", op.Truncate{Name: "test.txt", Size: 4}),
+		Entry("Sync", op.Sync{Name: "test.txt"}),
+		Entry("Close", op.Close{Name: "test.txt"}),
+		Entry("Symlink", op.Symlink{Oldname: "test.txt", Newname: "link"}),
+		Entry("Readlink", op.Readlink{Name: "link"}),
+		Entry("Lstat", op.Lstat{Name: "link"}),
+		Entry("Handle", op.Handle{ID: 7, Operation: op.Write{Name: "test.txt", Data: []byte("testing")}}),
+	)
+
+	It("should include the operation name", func() {
+		data, err := op.Marshal(op.Create{Name: "test.txt"})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`{"op":"Create","operation":{"Name":"test.txt"}}`))
+	})
+
+	It("should include the handle alongside the operation", func() {
+		data, err := op.Marshal(op.Handle{ID: 1, Operation: op.Close{Name: "test.txt"}})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`{"op":"Close","handle":1,"operation":{"Name":"test.txt"}}`))
+	})
+
+	It("should reject unknown operation types", func() {
+		_, err := op.Marshal(unknown{})
+
+		Expect(err).To(MatchError(ContainSubstring("unsupported operation type")))
+	})
+
+	It("should reject unknown operation names", func() {
+		_, err := op.Unmarshal([]byte(`{"op":"Frobnicate"}`))
+
+		Expect(err).To(MatchError(ContainSubstring(`unsupported operation "Frobnicate"`)))
+	})
+
+	It("should stream operations as lines", func() {
+		buf := &bytes.Buffer{}
+		enc := op.NewEncoder(buf)
+		Expect(enc.Encode(op.Create{Name: "test.txt"})).To(Succeed())
+		Expect(enc.Encode(op.Write{Name: "test.txt", Data: []byte("testing")})).To(Succeed())
+
+		dec := op.NewDecoder(buf)
+		Expect(dec.Decode()).To(Equal(op.Create{Name: "test.txt"}))
+		Expect(dec.Decode()).To(Equal(op.Write{Name: "test.txt", Data: []byte("testing")}))
+		_, err := dec.Decode()
+		Expect(err).To(MatchError(io.EOF))
+	})
+})
+
+type unknown struct{}
+
+func (unknown) Path() string { return "" }
@@ -120,3 +120,103 @@ type Readdirnames struct {
 }
 
 func (o Readdirnames) Path() string { return o.Name }
+
+// Read represents a read from an open file.
+// N holds the number of bytes read, so that replaying the read
+// advances the offset of the file.
+type Read struct {
+	Name string
+	N    int
+}
+
+func (o Read) Path() string { return o.Name }
+
+// ReadAt represents a read from an open file at an offset.
+type ReadAt struct {
+	Name   string
+	Offset int64
+}
+
+func (o ReadAt) Path() string { return o.Name }
+
+// Seek represents a seek on an open file.
+type Seek struct {
+	Name   string
+	Offset int64
+	Whence int
+}
+
+func (o Seek) Path() string { return o.Name }
+
+// Write represents a write to an open file.
+// Data holds the bytes written, so that the write can be replayed.
+type Write struct {
+	Name string
+	Data []byte
+}
+
+func (o Write) Path() string { return o.Name }
+
+// WriteAt represents a write to an open file at an offset.
+type WriteAt struct {
+	Name   string
+	Offset int64
+	Data   []byte
+}
+
+func (o WriteAt) Path() string { return o.Name }
+
+// Truncate represents a truncate operation on an open file.
+type Truncate struct {
+	Name string
+	Size int64
+}
+
+func (o Truncate) Path() string { return o.Name }
+
+// Sync represents a sync operation on an open file.
+type Sync struct {
+	Name string
+}
+
+func (o Sync) Path() string { return o.Name }
+
+// Close represents closing an open file.
+type Close struct {
+	Name string
+}
+
+func (o Close) Path() string { return o.Name }
+
+// Symlink represents a symlink operation.
+// The path of the operation is the link being created.
+type Symlink struct {
+	Oldname string
+	Newname string
+}
+
+func (o Symlink) Path() string { return o.Newname }
+
+// Readlink represents a readlink operation.
+type Readlink struct {
+	Name string
+}
+
+func (o Readlink) Path() string { return o.Name }
+
+// Lstat represents a stat operation that does not follow symlinks.
+type Lstat struct {
+	Name string
+}
+
+func (o Lstat) Path() string { return o.Name }
+
+// Handle tags an operation made through an open file, or one that opened
+// a file, with an ID identifying that file. Files open at the same time
+// have different IDs, even when they share a name.
+type Handle struct {
+	ID        uint64
+	Operation Operation
+}
+
+func (o Handle) Path() string { return o.Operation.Path() }
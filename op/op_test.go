@@ -125,5 +125,48 @@ var _ = Describe("Op", func() {
 
 			Expect(operation.Path()).To(Equal("dir"))
 		})
+
+		It("should implement Operation for Write", func() {
+			var operation op.Operation = op.Write{
+				Name: "test.txt",
+				Data: []byte("testing"),
+			}
+
+			Expect(operation.Path()).To(Equal("test.txt"))
+		})
+
+		It("should implement Operation for WriteAt", func() {
+			var operation op.Operation = op.WriteAt{
+				Name:   "test.txt",
+				Offset: 4,
+				Data:   []byte("testing"),
+			}
+
+			Expect(operation.Path()).To(Equal("test.txt"))
+		})
+
+		It("should implement Operation for Truncate", func() {
+			var operation op.Operation = op.Truncate{
+				Name: "test.txt",
+				Size: 0,
+			}
+
+			Expect(operation.Path()).To(Equal("test.txt"))
+		})
+
+		It("should implement Operation for Symlink", func() {
+			var operation op.Operation = op.Symlink{
+				Oldname: "test.txt",
+				Newname: "link",
+			}
+
+			Expect(operation.Path()).To(Equal("link"))
+		})
+
+		It("should implement Operation for Lstat", func() {
+			var operation op.Operation = op.Lstat{Name: "link"}
+
+			Expect(operation.Path()).To(Equal("link"))
+		})
 	})
 })
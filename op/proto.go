@@ -0,0 +1,264 @@
+package op
+
+import (
+	"fmt"
+	"io/fs"
+
+	opv1alpha1 "github.com/unmango/aferox/op/v1alpha1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ToProto returns the protobuf encoding of operation.
+func ToProto(operation Operation) (*opv1alpha1.Operation, error) {
+	switch o := operation.(type) {
+	case Handle:
+		msg, err := ToProto(o.Operation)
+		if err != nil {
+			return nil, err
+		}
+
+		msg.Handle = o.ID
+		return msg, nil
+	case Chmod:
+		return &opv1alpha1.Operation{Op: &opv1alpha1.Operation_Chmod{Chmod: &opv1alpha1.Chmod{
+			Name: o.Name,
+			Mode: uint32(o.Mode),
+		}}}, nil
+	case Chown:
+		return &opv1alpha1.Operation{Op: &opv1alpha1.Operation_Chown{Chown: &opv1alpha1.Chown{
+			Name: o.Name,
+			Uid:  int64(o.UID),
+			Gid:  int64(o.GID),
+		}}}, nil
+	case Chtimes:
+		return &opv1alpha1.Operation{Op: &opv1alpha1.Operation_Chtimes{Chtimes: &opv1alpha1.Chtimes{
+			Name:  o.Name,
+			Atime: timestamppb.New(o.Atime),
+			Mtime: timestamppb.New(o.Mtime),
+		}}}, nil
+	case Create:
+		return &opv1alpha1.Operation{Op: &opv1alpha1.Operation_Create{Create: &opv1alpha1.Create{
+			Name: o.Name,
+		}}}, nil
+	case Mkdir:
+		return &opv1alpha1.Operation{Op: &opv1alpha1.Operation_Mkdir{Mkdir: &opv1alpha1.Mkdir{
+			Name: o.Name,
+			Perm: uint32(o.Perm),
+		}}}, nil
+	case MkdirAll:
+		return &opv1alpha1.Operation{Op: &opv1alpha1.Operation_MkdirAll{MkdirAll: &opv1alpha1.MkdirAll{
+			Name: o.Name,
+			Perm: uint32(o.Perm),
+		}}}, nil
+	case Open:
+		return &opv1alpha1.Operation{Op: &opv1alpha1.Operation_Open{Open: &opv1alpha1.Open{
+			Name: o.Name,
+		}}}, nil
+	case OpenFile:
+		return &opv1alpha1.Operation{Op: &opv1alpha1.Operation_OpenFile{OpenFile: &opv1alpha1.OpenFile{
+			Name: o.Name,
+			Flag: int64(o.Flag),
+			Perm: uint32(o.Perm),
+		}}}, nil
+	case Remove:
+		return &opv1alpha1.Operation{Op: &opv1alpha1.Operation_Remove{Remove: &opv1alpha1.Remove{
+			Name: o.Name,
+		}}}, nil
+	case RemoveAll:
+		return &opv1alpha1.Operation{Op: &opv1alpha1.Operation_RemoveAll{RemoveAll: &opv1alpha1.RemoveAll{
+			Name: o.Name,
+		}}}, nil
+	case Rename:
+		return &opv1alpha1.Operation{Op: &opv1alpha1.Operation_Rename{Rename: &opv1alpha1.Rename{
+			Oldname: o.Oldname,
+			Newname: o.Newname,
+		}}}, nil
+	case Stat:
+		return &opv1alpha1.Operation{Op: &opv1alpha1.Operation_Stat{Stat: &opv1alpha1.Stat{
+			Name: o.Name,
+		}}}, nil
+	case Readdir:
+		return &opv1alpha1.Operation{Op: &opv1alpha1.Operation_Readdir{Readdir: &opv1alpha1.Readdir{
+			Name:  o.Name,
+			Count: int64(o.Count),
+		}}}, nil
+	case Readdirnames:
+		return &opv1alpha1.Operation{Op: &opv1alpha1.Operation_Readdirnames{Readdirnames: &opv1alpha1.Readdirnames{
+			Name:  o.Name,
+			Count: int64(o.Count),
+		}}}, nil
+	case Write:
+		return &opv1alpha1.Operation{Op: &opv1alpha1.Operation_Write{Write: &opv1alpha1.Write{
+			Name: o.Name,
+			Data: o.Data,
+		}}}, nil
+	case Read:
+		return &opv1alpha1.Operation{Op: &opv1alpha1.Operation_Read{Read: &opv1alpha1.Read{
+			Name: o.Name,
+			N:    int64(o.N),
+		}}}, nil
+	case ReadAt:
+		return &opv1alpha1.Operation{Op: &opv1alpha1.Operation_ReadAt{ReadAt: &opv1alpha1.ReadAt{
+			Name:   o.Name,
+			Offset: o.Offset,
+		}}}, nil
+	case Seek:
+		return &opv1alpha1.Operation{Op: &opv1alpha1.Operation_Seek{Seek: &opv1alpha1.Seek{
+			Name:   o.Name,
+			Offset: o.Offset,
+			Whence: int64(o.Whence),
+		}}}, nil
+	case WriteAt:
+		return &opv1alpha1.Operation{Op: &opv1alpha1.Operation_WriteAt{WriteAt: &opv1alpha1.WriteAt{
+			Name:   o.Name,
+			Offset: o.Offset,
+			Data:   o.Data,
+		}}}, nil
+	case Truncate:
+		return &opv1alpha1.Operation{Op: &opv1alpha1.Operation_Truncate{Truncate: &opv1alpha1.Truncate{
+			Name: o.Name,
+			Size: o.Size,
+		}}}, nil
+	case Sync:
+		return &opv1alpha1.Operation{Op: &opv1alpha1.Operation_Sync{Sync: &opv1alpha1.Sync{
+			Name: o.Name,
+		}}}, nil
+	case Close:
+		return &opv1alpha1.Operation{Op: &opv1alpha1.Operation_Close{Close: &opv1alpha1.Close{
+			Name: o.Name,
+		}}}, nil
+	case Symlink:
+		return &opv1alpha1.Operation{Op: &opv1alpha1.Operation_Symlink{Symlink: &opv1alpha1.Symlink{
+			Oldname: o.Oldname,
+			Newname: o.Newname,
+		}}}, nil
+	case Readlink:
+		return &opv1alpha1.Operation{Op: &opv1alpha1.Operation_Readlink{Readlink: &opv1alpha1.Readlink{
+			Name: o.Name,
+		}}}, nil
+	case Lstat:
+		return &opv1alpha1.Operation{Op: &opv1alpha1.Operation_Lstat{Lstat: &opv1alpha1.Lstat{
+			Name: o.Name,
+		}}}, nil
+	default:
+		return nil, fmt.Errorf("op: unsupported operation type %T", operation)
+	}
+}
+
+// FromProto returns the operation encoded by msg.
+// It returns a [Handle] when msg has a handle.
+func FromProto(msg *opv1alpha1.Operation) (Operation, error) {
+	operation, err := fromProto(msg)
+	if err != nil || msg.GetHandle() == 0 {
+		return operation, err
+	}
+
+	return Handle{ID: msg.GetHandle(), Operation: operation}, nil
+}
+
+func fromProto(msg *opv1alpha1.Operation) (Operation, error) {
+	switch o := msg.GetOp().(type) {
+	case *opv1alpha1.Operation_Chmod:
+		return Chmod{
+			Name: o.Chmod.GetName(),
+			Mode: fs.FileMode(o.Chmod.GetMode()),
+		}, nil
+	case *opv1alpha1.Operation_Chown:
+		return Chown{
+			Name: o.Chown.GetName(),
+			UID:  int(o.Chown.GetUid()),
+			GID:  int(o.Chown.GetGid()),
+		}, nil
+	case *opv1alpha1.Operation_Chtimes:
+		return Chtimes{
+			Name:  o.Chtimes.GetName(),
+			Atime: o.Chtimes.GetAtime().AsTime(),
+			Mtime: o.Chtimes.GetMtime().AsTime(),
+		}, nil
+	case *opv1alpha1.Operation_Create:
+		return Create{Name: o.Create.GetName()}, nil
+	case *opv1alpha1.Operation_Mkdir:
+		return Mkdir{
+			Name: o.Mkdir.GetName(),
+			Perm: fs.FileMode(o.Mkdir.GetPerm()),
+		}, nil
+	case *opv1alpha1.Operation_MkdirAll:
+		return MkdirAll{
+			Name: o.MkdirAll.GetName(),
+			Perm: fs.FileMode(o.MkdirAll.GetPerm()),
+		}, nil
+	case *opv1alpha1.Operation_Open:
+		return Open{Name: o.Open.GetName()}, nil
+	case *opv1alpha1.Operation_OpenFile:
+		return OpenFile{
+			Name: o.OpenFile.GetName(),
+			Flag: int(o.OpenFile.GetFlag()),
+			Perm: fs.FileMode(o.OpenFile.GetPerm()),
+		}, nil
+	case *opv1alpha1.Operation_Remove:
+		return Remove{Name: o.Remove.GetName()}, nil
+	case *opv1alpha1.Operation_RemoveAll:
+		return RemoveAll{Name: o.RemoveAll.GetName()}, nil
+	case *opv1alpha1.Operation_Rename:
+		return Rename{
+			Oldname: o.Rename.GetOldname(),
+			Newname: o.Rename.GetNewname(),
+		}, nil
+	case *opv1alpha1.Operation_Stat:
+		return Stat{Name: o.Stat.GetName()}, nil
+	case *opv1alpha1.Operation_Readdir:
+		return Readdir{
+			Name:  o.Readdir.GetName(),
+			Count: int(o.Readdir.GetCount()),
+		}, nil
+	case *opv1alpha1.Operation_Readdirnames:
+		return Readdirnames{
+			Name:  o.Readdirnames.GetName(),
+			Count: int(o.Readdirnames.GetCount()),
+		}, nil
+	case *opv1alpha1.Operation_Write:
+		return Write{
+			Name: o.Write.GetName(),
+			Data: o.Write.GetData(),
+		}, nil
+	case *opv1alpha1.Operation_Read:
+		return Read{Name: o.Read.GetName(), N: int(o.Read.GetN())}, nil
+	case *opv1alpha1.Operation_ReadAt:
+		return ReadAt{
+			Name:   o.ReadAt.GetName(),
+			Offset: o.ReadAt.GetOffset(),
+		}, nil
+	case *opv1alpha1.Operation_Seek:
+		return Seek{
+			Name:   o.Seek.GetName(),
+			Offset: o.Seek.GetOffset(),
+			Whence: int(o.Seek.GetWhence()),
+		}, nil
+	case *opv1alpha1.Operation_WriteAt:
+		return WriteAt{
+			Name:   o.WriteAt.GetName(),
+			Offset: o.WriteAt.GetOffset(),
+			Data:   o.WriteAt.GetData(),
+		}, nil
+	case *opv1alpha1.Operation_Truncate:
+		return Truncate{
+			Name: o.Truncate.GetName(),
+			Size: o.Truncate.GetSize(),
+		}, nil
+	case *opv1alpha1.Operation_Sync:
+		return Sync{Name: o.Sync.GetName()}, nil
+	case *opv1alpha1.Operation_Close:
+		return Close{Name: o.Close.GetName()}, nil
+	case *opv1alpha1.Operation_Symlink:
+		return Symlink{
+			Oldname: o.Symlink.GetOldname(),
+			Newname: o.Symlink.GetNewname(),
+		}, nil
+	case *opv1alpha1.Operation_Readlink:
+		return Readlink{Name: o.Readlink.GetName()}, nil
+	case *opv1alpha1.Operation_Lstat:
+		return Lstat{Name: o.Lstat.GetName()}, nil
+	default:
+		return nil, fmt.Errorf("op: unsupported operation %T", o)
+	}
+}
@@ -0,0 +1,71 @@
+package op_test
+
+import (
+	"io"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/unmango/aferox/op"
+	opv1alpha1 "github.com/unmango/aferox/op/v1alpha1"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ = Describe("Proto", func() {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	DescribeTable("should round-trip operations",
+		func(operation op.Operation) {
+			msg, err := op.ToProto(operation)
+			Expect(err).NotTo(HaveOccurred())
+			data, err := proto.Marshal(msg)
+			Expect(err).NotTo(HaveOccurred())
+
+			decoded := &opv1alpha1.Operation{}
+			Expect(proto.Unmarshal(data, decoded)).To(Succeed())
+			result, err := op.FromProto(decoded)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(operation))
+		},
+		Entry("Chmod", op.Chmod{Name: "test.txt", Mode: 0o755}),
+		Entry("Chown", op.Chown{Name: "test.txt", UID: 1000, GID: 1001}),
+		Entry("Chtimes", op.Chtimes{Name: "test.txt", Atime: now, Mtime: now}),
+		Entry("Create", op.Create{Name: "test.txt"}),
+		Entry("Mkdir", op.Mkdir{Name: "dir", Perm: 0o755}),
+		Entry("MkdirAll", op.MkdirAll{Name: "dir/sub", Perm: 0o755}),
+		Entry("Open", op.Open{Name: "test.txt"}),
+		Entry("OpenFile", op.OpenFile{Name: "test.txt", Flag: os.O_RDWR | os.O_CREATE, Perm: 0o644}),
+		Entry("Remove", op.Remove{Name: "test.txt"}),
+		Entry("RemoveAll", op.RemoveAll{Name: "dir"}),
+		Entry("Rename", op.Rename{Oldname: "old.txt", Newname: "new.txt"}),
+		Entry("Stat", op.Stat{Name: "test.txt"}),
+		Entry("Readdir", op.Readdir{Name: "dir", Count: -1}),
+		Entry("Readdirnames", op.Readdirnames{Name: "dir", Count: 10}),
+		Entry("Write", op.Write{Name: "test.txt", Data: []byte("testing")}),
+		Entry("Read", op.Read{Name: "test.txt", N: 7}),
+		Entry("ReadAt", op.ReadAt{Name: "test.txt", Offset: 4}),
+		Entry("Seek", op.Seek{Name: "test.txt", Offset: 4, Whence: io.SeekCurrent}),
+		Entry("WriteAt", op.WriteAt{Name: "test.txt", Offset: 4, Data: []byte("testing")}),
+		Entry("Truncate", op.Truncate{Name: "test.txt", Size: 4}),
+		Entry("Sync", op.Sync{Name: "test.txt"}),
+		Entry("Close", op.Close{Name: "test.txt"}),
+		Entry("Symlink", op.Symlink{Oldname: "test.txt", Newname: "link"}),
+		Entry("Readlink", op.Readlink{Name: "link"}),
+		Entry("Lstat", op.Lstat{Name: "link"}),
+		Entry("Handle", op.Handle{ID: 7, Operation: op.Write{Name: "test.txt", Data: []byte("testing")}}),
+	)
+
+	It("should reject unknown operation types", func() {
+		_, err := op.ToProto(unknown{})
+
+		Expect(err).To(MatchError(ContainSubstring("unsupported operation type")))
+	})
+
+	It("should reject empty messages", func() {
+		_, err := op.FromProto(&opv1alpha1.Operation{})
+
+		Expect(err).To(HaveOccurred())
+	})
+})
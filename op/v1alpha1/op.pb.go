@@ -0,0 +1,2042 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: aferox/op/v1alpha1/op.proto
+
+package opv1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Operation is a filesystem operation, see the Go op package.
+type Operation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Op:
+	//
+	//	*Operation_Chmod
+	//	*Operation_Chown
+	//	*Operation_Chtimes
+	//	*Operation_Create
+	//	*Operation_Mkdir
+	//	*Operation_MkdirAll
+	//	*Operation_Open
+	//	*Operation_OpenFile
+	//	*Operation_Remove
+	//	*Operation_RemoveAll
+	//	*Operation_Rename
+	//	*Operation_Stat
+	//	*Operation_Readdir
+	//	*Operation_Readdirnames
+	//	*Operation_Write
+	//	*Operation_Read
+	//	*Operation_ReadAt
+	//	*Operation_Seek
+	//	*Operation_WriteAt
+	//	*Operation_Truncate
+	//	*Operation_Sync
+	//	*Operation_Close
+	//	*Operation_Symlink
+	//	*Operation_Readlink
+	//	*Operation_Lstat
+	Op isOperation_Op `protobuf_oneof:"op"`
+	// Handle identifies the open file the operation was made through,
+	// or the file it opened. Zero when the operation has no handle.
+	Handle        uint64 `protobuf:"varint,26,opt,name=handle,proto3" json:"handle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Operation) Reset() {
+	*x = Operation{}
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Operation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Operation) ProtoMessage() {}
+
+func (x *Operation) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Operation.ProtoReflect.Descriptor instead.
+func (*Operation) Descriptor() ([]byte, []int) {
+	return file_aferox_op_v1alpha1_op_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Operation) GetOp() isOperation_Op {
+	if x != nil {
+		return x.Op
+	}
+	return nil
+}
+
+func (x *Operation) GetChmod() *Chmod {
+	if x != nil {
+		if x, ok := x.Op.(*Operation_Chmod); ok {
+			return x.Chmod
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetChown() *Chown {
+	if x != nil {
+		if x, ok := x.Op.(*Operation_Chown); ok {
+			return x.Chown
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetChtimes() *Chtimes {
+	if x != nil {
+		if x, ok := x.Op.(*Operation_Chtimes); ok {
+			return x.Chtimes
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetCreate() *Create {
+	if x != nil {
+		if x, ok := x.Op.(*Operation_Create); ok {
+			return x.Create
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetMkdir() *Mkdir {
+	if x != nil {
+		if x, ok := x.Op.(*Operation_Mkdir); ok {
+			return x.Mkdir
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetMkdirAll() *MkdirAll {
+	if x != nil {
+		if x, ok := x.Op.(*Operation_MkdirAll); ok {
+			return x.MkdirAll
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetOpen() *Open {
+	if x != nil {
+		if x, ok := x.Op.(*Operation_Open); ok {
+			return x.Open
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetOpenFile() *OpenFile {
+	if x != nil {
+		if x, ok := x.Op.(*Operation_OpenFile); ok {
+			return x.OpenFile
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetRemove() *Remove {
+	if x != nil {
+		if x, ok := x.Op.(*Operation_Remove); ok {
+			return x.Remove
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetRemoveAll() *RemoveAll {
+	if x != nil {
+		if x, ok := x.Op.(*Operation_RemoveAll); ok {
+			return x.RemoveAll
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetRename() *Rename {
+	if x != nil {
+		if x, ok := x.Op.(*Operation_Rename); ok {
+			return x.Rename
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetStat() *Stat {
+	if x != nil {
+		if x, ok := x.Op.(*Operation_Stat); ok {
+			return x.Stat
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetReaddir() *Readdir {
+	if x != nil {
+		if x, ok := x.Op.(*Operation_Readdir); ok {
+			return x.Readdir
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetReaddirnames() *Readdirnames {
+	if x != nil {
+		if x, ok := x.Op.(*Operation_Readdirnames); ok {
+			return x.Readdirnames
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetWrite() *Write {
+	if x != nil {
+		if x, ok := x.Op.(*Operation_Write); ok {
+			return x.Write
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetRead() *Read {
+	if x != nil {
+		if x, ok := x.Op.(*Operation_Read); ok {
+			return x.Read
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetReadAt() *ReadAt {
+	if x != nil {
+		if x, ok := x.Op.(*Operation_ReadAt); ok {
+			return x.ReadAt
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetSeek() *Seek {
+	if x != nil {
+		if x, ok := x.Op.(*Operation_Seek); ok {
+			return x.Seek
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetWriteAt() *WriteAt {
+	if x != nil {
+		if x, ok := x.Op.(*Operation_WriteAt); ok {
+			return x.WriteAt
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetTruncate() *Truncate {
+	if x != nil {
+		if x, ok := x.Op.(*Operation_Truncate); ok {
+			return x.Truncate
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetSync() *Sync {
+	if x != nil {
+		if x, ok := x.Op.(*Operation_Sync); ok {
+			return x.Sync
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetClose() *Close {
+	if x != nil {
+		if x, ok := x.Op.(*Operation_Close); ok {
+			return x.Close
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetSymlink() *Symlink {
+	if x != nil {
+		if x, ok := x.Op.(*Operation_Symlink); ok {
+			return x.Symlink
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetReadlink() *Readlink {
+	if x != nil {
+		if x, ok := x.Op.(*Operation_Readlink); ok {
+			return x.Readlink
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetLstat() *Lstat {
+	if x != nil {
+		if x, ok := x.Op.(*Operation_Lstat); ok {
+			return x.Lstat
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetHandle() uint64 {
+	if x != nil {
+		return x.Handle
+	}
+	return 0
+}
+
+type isOperation_Op interface {
+	isOperation_Op()
+}
+
+type Operation_Chmod struct {
+	Chmod *Chmod `protobuf:"bytes,1,opt,name=chmod,proto3,oneof"`
+}
+
+type Operation_Chown struct {
+	Chown *Chown `protobuf:"bytes,2,opt,name=chown,proto3,oneof"`
+}
+
+type Operation_Chtimes struct {
+	Chtimes *Chtimes `protobuf:"bytes,3,opt,name=chtimes,proto3,oneof"`
+}
+
+type Operation_Create struct {
+	Create *Create `protobuf:"bytes,4,opt,name=create,proto3,oneof"`
+}
+
+type Operation_Mkdir struct {
+	Mkdir *Mkdir `protobuf:"bytes,5,opt,name=mkdir,proto3,oneof"`
+}
+
+type Operation_MkdirAll struct {
+	MkdirAll *MkdirAll `protobuf:"bytes,6,opt,name=mkdir_all,json=mkdirAll,proto3,oneof"`
+}
+
+type Operation_Open struct {
+	Open *Open `protobuf:"bytes,7,opt,name=open,proto3,oneof"`
+}
+
+type Operation_OpenFile struct {
+	OpenFile *OpenFile `protobuf:"bytes,8,opt,name=open_file,json=openFile,proto3,oneof"`
+}
+
+type Operation_Remove struct {
+	Remove *Remove `protobuf:"bytes,9,opt,name=remove,proto3,oneof"`
+}
+
+type Operation_RemoveAll struct {
+	RemoveAll *RemoveAll `protobuf:"bytes,10,opt,name=remove_all,json=removeAll,proto3,oneof"`
+}
+
+type Operation_Rename struct {
+	Rename *Rename `protobuf:"bytes,11,opt,name=rename,proto3,oneof"`
+}
+
+type Operation_Stat struct {
+	Stat *Stat `protobuf:"bytes,12,opt,name=stat,proto3,oneof"`
+}
+
+type Operation_Readdir struct {
+	Readdir *Readdir `protobuf:"bytes,13,opt,name=readdir,proto3,oneof"`
+}
+
+type Operation_Readdirnames struct {
+	Readdirnames *Readdirnames `protobuf:"bytes,14,opt,name=readdirnames,proto3,oneof"`
+}
+
+type Operation_Write struct {
+	Write *Write `protobuf:"bytes,15,opt,name=write,proto3,oneof"`
+}
+
+type Operation_Read struct {
+	Read *Read `protobuf:"bytes,16,opt,name=read,proto3,oneof"`
+}
+
+type Operation_ReadAt struct {
+	ReadAt *ReadAt `protobuf:"bytes,17,opt,name=read_at,json=readAt,proto3,oneof"`
+}
+
+type Operation_Seek struct {
+	Seek *Seek `protobuf:"bytes,18,opt,name=seek,proto3,oneof"`
+}
+
+type Operation_WriteAt struct {
+	WriteAt *WriteAt `protobuf:"bytes,19,opt,name=write_at,json=writeAt,proto3,oneof"`
+}
+
+type Operation_Truncate struct {
+	Truncate *Truncate `protobuf:"bytes,20,opt,name=truncate,proto3,oneof"`
+}
+
+type Operation_Sync struct {
+	Sync *Sync `protobuf:"bytes,21,opt,name=sync,proto3,oneof"`
+}
+
+type Operation_Close struct {
+	Close *Close `protobuf:"bytes,22,opt,name=close,proto3,oneof"`
+}
+
+type Operation_Symlink struct {
+	Symlink *Symlink `protobuf:"bytes,23,opt,name=symlink,proto3,oneof"`
+}
+
+type Operation_Readlink struct {
+	Readlink *Readlink `protobuf:"bytes,24,opt,name=readlink,proto3,oneof"`
+}
+
+type Operation_Lstat struct {
+	Lstat *Lstat `protobuf:"bytes,25,opt,name=lstat,proto3,oneof"`
+}
+
+func (*Operation_Chmod) isOperation_Op() {}
+
+func (*Operation_Chown) isOperation_Op() {}
+
+func (*Operation_Chtimes) isOperation_Op() {}
+
+func (*Operation_Create) isOperation_Op() {}
+
+func (*Operation_Mkdir) isOperation_Op() {}
+
+func (*Operation_MkdirAll) isOperation_Op() {}
+
+func (*Operation_Open) isOperation_Op() {}
+
+func (*Operation_OpenFile) isOperation_Op() {}
+
+func (*Operation_Remove) isOperation_Op() {}
+
+func (*Operation_RemoveAll) isOperation_Op() {}
+
+func (*Operation_Rename) isOperation_Op() {}
+
+func (*Operation_Stat) isOperation_Op() {}
+
+func (*Operation_Readdir) isOperation_Op() {}
+
+func (*Operation_Readdirnames) isOperation_Op() {}
+
+func (*Operation_Write) isOperation_Op() {}
+
+func (*Operation_Read) isOperation_Op() {}
+
+func (*Operation_ReadAt) isOperation_Op() {}
+
+func (*Operation_Seek) isOperation_Op() {}
+
+func (*Operation_WriteAt) isOperation_Op() {}
+
+func (*Operation_Truncate) isOperation_Op() {}
+
+func (*Operation_Sync) isOperation_Op() {}
+
+func (*Operation_Close) isOperation_Op() {}
+
+func (*Operation_Symlink) isOperation_Op() {}
+
+func (*Operation_Readlink) isOperation_Op() {}
+
+func (*Operation_Lstat) isOperation_Op() {}
+
+// Operations is a sequence of operations, such as a recorded change set.
+type Operations struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operations    []*Operation           `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Operations) Reset() {
+	*x = Operations{}
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Operations) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Operations) ProtoMessage() {}
+
+func (x *Operations) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Operations.ProtoReflect.Descriptor instead.
+func (*Operations) Descriptor() ([]byte, []int) {
+	return file_aferox_op_v1alpha1_op_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Operations) GetOperations() []*Operation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+type Chmod struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Mode holds the bits of a Go fs.FileMode.
+	Mode          uint32 `protobuf:"varint,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Chmod) Reset() {
+	*x = Chmod{}
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Chmod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Chmod) ProtoMessage() {}
+
+func (x *Chmod) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Chmod.ProtoReflect.Descriptor instead.
+func (*Chmod) Descriptor() ([]byte, []int) {
+	return file_aferox_op_v1alpha1_op_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Chmod) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Chmod) GetMode() uint32 {
+	if x != nil {
+		return x.Mode
+	}
+	return 0
+}
+
+type Chown struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Uid           int64                  `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	Gid           int64                  `protobuf:"varint,3,opt,name=gid,proto3" json:"gid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Chown) Reset() {
+	*x = Chown{}
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Chown) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Chown) ProtoMessage() {}
+
+func (x *Chown) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Chown.ProtoReflect.Descriptor instead.
+func (*Chown) Descriptor() ([]byte, []int) {
+	return file_aferox_op_v1alpha1_op_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Chown) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Chown) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *Chown) GetGid() int64 {
+	if x != nil {
+		return x.Gid
+	}
+	return 0
+}
+
+type Chtimes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Atime         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=atime,proto3" json:"atime,omitempty"`
+	Mtime         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=mtime,proto3" json:"mtime,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Chtimes) Reset() {
+	*x = Chtimes{}
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Chtimes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Chtimes) ProtoMessage() {}
+
+func (x *Chtimes) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Chtimes.ProtoReflect.Descriptor instead.
+func (*Chtimes) Descriptor() ([]byte, []int) {
+	return file_aferox_op_v1alpha1_op_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Chtimes) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Chtimes) GetAtime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Atime
+	}
+	return nil
+}
+
+func (x *Chtimes) GetMtime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Mtime
+	}
+	return nil
+}
+
+type Create struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Create) Reset() {
+	*x = Create{}
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Create) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Create) ProtoMessage() {}
+
+func (x *Create) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Create.ProtoReflect.Descriptor instead.
+func (*Create) Descriptor() ([]byte, []int) {
+	return file_aferox_op_v1alpha1_op_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Create) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type Mkdir struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Perm          uint32                 `protobuf:"varint,2,opt,name=perm,proto3" json:"perm,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Mkdir) Reset() {
+	*x = Mkdir{}
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Mkdir) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Mkdir) ProtoMessage() {}
+
+func (x *Mkdir) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Mkdir.ProtoReflect.Descriptor instead.
+func (*Mkdir) Descriptor() ([]byte, []int) {
+	return file_aferox_op_v1alpha1_op_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Mkdir) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Mkdir) GetPerm() uint32 {
+	if x != nil {
+		return x.Perm
+	}
+	return 0
+}
+
+type MkdirAll struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Perm          uint32                 `protobuf:"varint,2,opt,name=perm,proto3" json:"perm,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MkdirAll) Reset() {
+	*x = MkdirAll{}
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MkdirAll) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MkdirAll) ProtoMessage() {}
+
+func (x *MkdirAll) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MkdirAll.ProtoReflect.Descriptor instead.
+func (*MkdirAll) Descriptor() ([]byte, []int) {
+	return file_aferox_op_v1alpha1_op_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MkdirAll) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MkdirAll) GetPerm() uint32 {
+	if x != nil {
+		return x.Perm
+	}
+	return 0
+}
+
+type Open struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Open) Reset() {
+	*x = Open{}
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Open) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Open) ProtoMessage() {}
+
+func (x *Open) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Open.ProtoReflect.Descriptor instead.
+func (*Open) Descriptor() ([]byte, []int) {
+	return file_aferox_op_v1alpha1_op_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Open) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type OpenFile struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Flag holds Go os.O_* flags.
+	Flag          int64  `protobuf:"varint,2,opt,name=flag,proto3" json:"flag,omitempty"`
+	Perm          uint32 `protobuf:"varint,3,opt,name=perm,proto3" json:"perm,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpenFile) Reset() {
+	*x = OpenFile{}
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenFile) ProtoMessage() {}
+
+func (x *OpenFile) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenFile.ProtoReflect.Descriptor instead.
+func (*OpenFile) Descriptor() ([]byte, []int) {
+	return file_aferox_op_v1alpha1_op_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *OpenFile) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OpenFile) GetFlag() int64 {
+	if x != nil {
+		return x.Flag
+	}
+	return 0
+}
+
+func (x *OpenFile) GetPerm() uint32 {
+	if x != nil {
+		return x.Perm
+	}
+	return 0
+}
+
+type Remove struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Remove) Reset() {
+	*x = Remove{}
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Remove) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Remove) ProtoMessage() {}
+
+func (x *Remove) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Remove.ProtoReflect.Descriptor instead.
+func (*Remove) Descriptor() ([]byte, []int) {
+	return file_aferox_op_v1alpha1_op_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Remove) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type RemoveAll struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveAll) Reset() {
+	*x = RemoveAll{}
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveAll) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveAll) ProtoMessage() {}
+
+func (x *RemoveAll) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveAll.ProtoReflect.Descriptor instead.
+func (*RemoveAll) Descriptor() ([]byte, []int) {
+	return file_aferox_op_v1alpha1_op_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RemoveAll) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type Rename struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Oldname       string                 `protobuf:"bytes,1,opt,name=oldname,proto3" json:"oldname,omitempty"`
+	Newname       string                 `protobuf:"bytes,2,opt,name=newname,proto3" json:"newname,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rename) Reset() {
+	*x = Rename{}
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rename) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rename) ProtoMessage() {}
+
+func (x *Rename) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rename.ProtoReflect.Descriptor instead.
+func (*Rename) Descriptor() ([]byte, []int) {
+	return file_aferox_op_v1alpha1_op_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Rename) GetOldname() string {
+	if x != nil {
+		return x.Oldname
+	}
+	return ""
+}
+
+func (x *Rename) GetNewname() string {
+	if x != nil {
+		return x.Newname
+	}
+	return ""
+}
+
+type Stat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Stat) Reset() {
+	*x = Stat{}
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Stat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stat) ProtoMessage() {}
+
+func (x *Stat) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stat.ProtoReflect.Descriptor instead.
+func (*Stat) Descriptor() ([]byte, []int) {
+	return file_aferox_op_v1alpha1_op_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Stat) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type Readdir struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Readdir) Reset() {
+	*x = Readdir{}
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Readdir) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Readdir) ProtoMessage() {}
+
+func (x *Readdir) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Readdir.ProtoReflect.Descriptor instead.
+func (*Readdir) Descriptor() ([]byte, []int) {
+	return file_aferox_op_v1alpha1_op_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Readdir) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Readdir) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type Readdirnames struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Readdirnames) Reset() {
+	*x = Readdirnames{}
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Readdirnames) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Readdirnames) ProtoMessage() {}
+
+func (x *Readdirnames) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Readdirnames.ProtoReflect.Descriptor instead.
+func (*Readdirnames) Descriptor() ([]byte, []int) {
+	return file_aferox_op_v1alpha1_op_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Readdirnames) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Readdirnames) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type Write struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Write) Reset() {
+	*x = Write{}
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Write) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Write) ProtoMessage() {}
+
+func (x *Write) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Write.ProtoReflect.Descriptor instead.
+func (*Write) Descriptor() ([]byte, []int) {
+	return file_aferox_op_v1alpha1_op_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Write) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Write) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type Read struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	N             int64                  `protobuf:"varint,2,opt,name=n,proto3" json:"n,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Read) Reset() {
+	*x = Read{}
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Read) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Read) ProtoMessage() {}
+
+func (x *Read) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Read.ProtoReflect.Descriptor instead.
+func (*Read) Descriptor() ([]byte, []int) {
+	return file_aferox_op_v1alpha1_op_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Read) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Read) GetN() int64 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+type ReadAt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Offset        int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadAt) Reset() {
+	*x = ReadAt{}
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadAt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadAt) ProtoMessage() {}
+
+func (x *ReadAt) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadAt.ProtoReflect.Descriptor instead.
+func (*ReadAt) Descriptor() ([]byte, []int) {
+	return file_aferox_op_v1alpha1_op_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ReadAt) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReadAt) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type Seek struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Name   string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Offset int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Whence is one of the Go io.Seek* constants.
+	Whence        int64 `protobuf:"varint,3,opt,name=whence,proto3" json:"whence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Seek) Reset() {
+	*x = Seek{}
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Seek) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Seek) ProtoMessage() {}
+
+func (x *Seek) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Seek.ProtoReflect.Descriptor instead.
+func (*Seek) Descriptor() ([]byte, []int) {
+	return file_aferox_op_v1alpha1_op_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Seek) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Seek) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *Seek) GetWhence() int64 {
+	if x != nil {
+		return x.Whence
+	}
+	return 0
+}
+
+type WriteAt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Offset        int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteAt) Reset() {
+	*x = WriteAt{}
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteAt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteAt) ProtoMessage() {}
+
+func (x *WriteAt) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteAt.ProtoReflect.Descriptor instead.
+func (*WriteAt) Descriptor() ([]byte, []int) {
+	return file_aferox_op_v1alpha1_op_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *WriteAt) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WriteAt) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *WriteAt) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type Truncate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Truncate) Reset() {
+	*x = Truncate{}
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Truncate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Truncate) ProtoMessage() {}
+
+func (x *Truncate) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Truncate.ProtoReflect.Descriptor instead.
+func (*Truncate) Descriptor() ([]byte, []int) {
+	return file_aferox_op_v1alpha1_op_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Truncate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Truncate) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type Sync struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Sync) Reset() {
+	*x = Sync{}
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Sync) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sync) ProtoMessage() {}
+
+func (x *Sync) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sync.ProtoReflect.Descriptor instead.
+func (*Sync) Descriptor() ([]byte, []int) {
+	return file_aferox_op_v1alpha1_op_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Sync) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type Close struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Close) Reset() {
+	*x = Close{}
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Close) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Close) ProtoMessage() {}
+
+func (x *Close) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Close.ProtoReflect.Descriptor instead.
+func (*Close) Descriptor() ([]byte, []int) {
+	return file_aferox_op_v1alpha1_op_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Close) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type Symlink struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Oldname       string                 `protobuf:"bytes,1,opt,name=oldname,proto3" json:"oldname,omitempty"`
+	Newname       string                 `protobuf:"bytes,2,opt,name=newname,proto3" json:"newname,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Symlink) Reset() {
+	*x = Symlink{}
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Symlink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Symlink) ProtoMessage() {}
+
+func (x *Symlink) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Symlink.ProtoReflect.Descriptor instead.
+func (*Symlink) Descriptor() ([]byte, []int) {
+	return file_aferox_op_v1alpha1_op_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Symlink) GetOldname() string {
+	if x != nil {
+		return x.Oldname
+	}
+	return ""
+}
+
+func (x *Symlink) GetNewname() string {
+	if x != nil {
+		return x.Newname
+	}
+	return ""
+}
+
+type Readlink struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Readlink) Reset() {
+	*x = Readlink{}
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Readlink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Readlink) ProtoMessage() {}
+
+func (x *Readlink) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Readlink.ProtoReflect.Descriptor instead.
+func (*Readlink) Descriptor() ([]byte, []int) {
+	return file_aferox_op_v1alpha1_op_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Readlink) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type Lstat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Lstat) Reset() {
+	*x = Lstat{}
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Lstat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Lstat) ProtoMessage() {}
+
+func (x *Lstat) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_op_v1alpha1_op_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Lstat.ProtoReflect.Descriptor instead.
+func (*Lstat) Descriptor() ([]byte, []int) {
+	return file_aferox_op_v1alpha1_op_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Lstat) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+var File_aferox_op_v1alpha1_op_proto protoreflect.FileDescriptor
+
+const file_aferox_op_v1alpha1_op_proto_rawDesc = "" +
+	"\n" +
+	"\x1baferox/op/v1alpha1/op.proto\x12\x12aferox.op.v1alpha1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x83\v\n" +
+	"\tOperation\x121\n" +
+	"\x05chmod\x18\x01 \x01(\v2\x19.aferox.op.v1alpha1.ChmodH\x00R\x05chmod\x121\n" +
+	"\x05chown\x18\x02 \x01(\v2\x19.aferox.op.v1alpha1.ChownH\x00R\x05chown\x127\n" +
+	"\achtimes\x18\x03 \x01(\v2\x1b.aferox.op.v1alpha1.ChtimesH\x00R\achtimes\x124\n" +
+	"\x06create\x18\x04 \x01(\v2\x1a.aferox.op.v1alpha1.CreateH\x00R\x06create\x121\n" +
+	"\x05mkdir\x18\x05 \x01(\v2\x19.aferox.op.v1alpha1.MkdirH\x00R\x05mkdir\x12;\n" +
+	"\tmkdir_all\x18\x06 \x01(\v2\x1c.aferox.op.v1alpha1.MkdirAllH\x00R\bmkdirAll\x12.\n" +
+	"\x04open\x18\a \x01(\v2\x18.aferox.op.v1alpha1.OpenH\x00R\x04open\x12;\n" +
+	"\topen_file\x18\b \x01(\v2\x1c.aferox.op.v1alpha1.OpenFileH\x00R\bopenFile\x124\n" +
+	"\x06remove\x18\t \x01(\v2\x1a.aferox.op.v1alpha1.RemoveH\x00R\x06remove\x12>\n" +
+	"\n" +
+	"remove_all\x18\n" +
+	" \x01(\v2\x1d.aferox.op.v1alpha1.RemoveAllH\x00R\tremoveAll\x124\n" +
+	"\x06rename\x18\v \x01(\v2\x1a.aferox.op.v1alpha1.RenameH\x00R\x06rename\x12.\n" +
+	"\x04stat\x18\f \x01(\v2\x18.aferox.op.v1alpha1.StatH\x00R\x04stat\x127\n" +
+	"\areaddir\x18\r \x01(\v2\x1b.aferox.op.v1alpha1.ReaddirH\x00R\areaddir\x12F\n" +
+	"\freaddirnames\x18\x0e \x01(\v2 .aferox.op.v1alpha1.ReaddirnamesH\x00R\freaddirnames\x121\n" +
+	"\x05write\x18\x0f \x01(\v2\x19.aferox.op.v1alpha1.WriteH\x00R\x05write\x12.\n" +
+	"\x04read\x18\x10 \x01(\v2\x18.aferox.op.v1alpha1.ReadH\x00R\x04read\x125\n" +
+	"\aread_at\x18\x11 \x01(\v2\x1a.aferox.op.v1alpha1.ReadAtH\x00R\x06readAt\x12.\n" +
+	"\x04seek\x18\x12 \x01(\v2\x18.aferox.op.v1alpha1.SeekH\x00R\x04seek\x128\n" +
+	"\bwrite_at\x18\x13 \x01(\v2\x1b.aferox.op.v1alpha1.WriteAtH\x00R\awriteAt\x12:\n" +
+	"\btruncate\x18\x14 \x01(\v2\x1c.aferox.op.v1alpha1.TruncateH\x00R\btruncate\x12.\n" +
+	"\x04sync\x18\x15 \x01(\v2\x18.aferox.op.v1alpha1.SyncH\x00R\x04sync\x121\n" +
+	"\x05close\x18\x16 \x01(\v2\x19.aferox.op.v1alpha1.CloseH\x00R\x05close\x127\n" +
+	"\asymlink\x18\x17 \x01(\v2\x1b.aferox.op.v1alpha1.SymlinkH\x00R\asymlink\x12:\n" +
+	"\breadlink\x18\x18 \x01(\v2\x1c.aferox.op.v1alpha1.ReadlinkH\x00R\breadlink\x121\n" +
+	"\x05lstat\x18\x19 \x01(\v2\x19.aferox.op.v1alpha1.LstatH\x00R\x05lstat\x12\x16\n" +
+	"\x06handle\x18\x1a \x01(\x04R\x06handleB\x04\n" +
+	"\x02op\"K\n" +
+	"\n" +
+	"Operations\x12=\n" +
+	"\n" +
+	"operations\x18\x01 \x03(\v2\x1d.aferox.op.v1alpha1.OperationR\n" +
+	"operations\"/\n" +
+	"\x05Chmod\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\rR\x04mode\"?\n" +
+	"\x05Chown\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
+	"\x03uid\x18\x02 \x01(\x03R\x03uid\x12\x10\n" +
+	"\x03gid\x18\x03 \x01(\x03R\x03gid\"\x81\x01\n" +
+	"\aChtimes\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x120\n" +
+	"\x05atime\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05atime\x120\n" +
+	"\x05mtime\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05mtime\"\x1c\n" +
+	"\x06Create\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"/\n" +
+	"\x05Mkdir\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04perm\x18\x02 \x01(\rR\x04perm\"2\n" +
+	"\bMkdirAll\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04perm\x18\x02 \x01(\rR\x04perm\"\x1a\n" +
+	"\x04Open\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"F\n" +
+	"\bOpenFile\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04flag\x18\x02 \x01(\x03R\x04flag\x12\x12\n" +
+	"\x04perm\x18\x03 \x01(\rR\x04perm\"\x1c\n" +
+	"\x06Remove\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x1f\n" +
+	"\tRemoveAll\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"<\n" +
+	"\x06Rename\x12\x18\n" +
+	"\aoldname\x18\x01 \x01(\tR\aoldname\x12\x18\n" +
+	"\anewname\x18\x02 \x01(\tR\anewname\"\x1a\n" +
+	"\x04Stat\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"3\n" +
+	"\aReaddir\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"8\n" +
+	"\fReaddirnames\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"/\n" +
+	"\x05Write\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"(\n" +
+	"\x04Read\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\f\n" +
+	"\x01n\x18\x02 \x01(\x03R\x01n\"4\n" +
+	"\x06ReadAt\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\"J\n" +
+	"\x04Seek\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x16\n" +
+	"\x06whence\x18\x03 \x01(\x03R\x06whence\"I\n" +
+	"\aWriteAt\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\"2\n" +
+	"\bTruncate\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\"\x1a\n" +
+	"\x04Sync\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x1b\n" +
+	"\x05Close\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"=\n" +
+	"\aSymlink\x12\x18\n" +
+	"\aoldname\x18\x01 \x01(\tR\aoldname\x12\x18\n" +
+	"\anewname\x18\x02 \x01(\tR\anewname\"\x1e\n" +
+	"\bReadlink\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x1b\n" +
+	"\x05Lstat\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04nameB2Z0github.com/unmango/aferox/op/v1alpha1;opv1alpha1b\x06proto3"
+
+var (
+	file_aferox_op_v1alpha1_op_proto_rawDescOnce sync.Once
+	file_aferox_op_v1alpha1_op_proto_rawDescData []byte
+)
+
+func file_aferox_op_v1alpha1_op_proto_rawDescGZIP() []byte {
+	file_aferox_op_v1alpha1_op_proto_rawDescOnce.Do(func() {
+		file_aferox_op_v1alpha1_op_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_aferox_op_v1alpha1_op_proto_rawDesc), len(file_aferox_op_v1alpha1_op_proto_rawDesc)))
+	})
+	return file_aferox_op_v1alpha1_op_proto_rawDescData
+}
+
+var file_aferox_op_v1alpha1_op_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
+var file_aferox_op_v1alpha1_op_proto_goTypes = []any{
+	(*Operation)(nil),             // 0: aferox.op.v1alpha1.Operation
+	(*Operations)(nil),            // 1: aferox.op.v1alpha1.Operations
+	(*Chmod)(nil),                 // 2: aferox.op.v1alpha1.Chmod
+	(*Chown)(nil),                 // 3: aferox.op.v1alpha1.Chown
+	(*Chtimes)(nil),               // 4: aferox.op.v1alpha1.Chtimes
+	(*Create)(nil),                // 5: aferox.op.v1alpha1.Create
+	(*Mkdir)(nil),                 // 6: aferox.op.v1alpha1.Mkdir
+	(*MkdirAll)(nil),              // 7: aferox.op.v1alpha1.MkdirAll
+	(*Open)(nil),                  // 8: aferox.op.v1alpha1.Open
+	(*OpenFile)(nil),              // 9: aferox.op.v1alpha1.OpenFile
+	(*Remove)(nil),                // 10: aferox.op.v1alpha1.Remove
+	(*RemoveAll)(nil),             // 11: aferox.op.v1alpha1.RemoveAll
+	(*Rename)(nil),                // 12: aferox.op.v1alpha1.Rename
+	(*Stat)(nil),                  // 13: aferox.op.v1alpha1.Stat
+	(*Readdir)(nil),               // 14: aferox.op.v1alpha1.Readdir
+	(*Readdirnames)(nil),          // 15: aferox.op.v1alpha1.Readdirnames
+	(*Write)(nil),                 // 16: aferox.op.v1alpha1.Write
+	(*Read)(nil),                  // 17: aferox.op.v1alpha1.Read
+	(*ReadAt)(nil),                // 18: aferox.op.v1alpha1.ReadAt
+	(*Seek)(nil),                  // 19: aferox.op.v1alpha1.Seek
+	(*WriteAt)(nil),               // 20: aferox.op.v1alpha1.WriteAt
+	(*Truncate)(nil),              // 21: aferox.op.v1alpha1.Truncate
+	(*Sync)(nil),                  // 22: aferox.op.v1alpha1.Sync
+	(*Close)(nil),                 // 23: aferox.op.v1alpha1.Close
+	(*Symlink)(nil),               // 24: aferox.op.v1alpha1.Symlink
+	(*Readlink)(nil),              // 25: aferox.op.v1alpha1.Readlink
+	(*Lstat)(nil),                 // 26: aferox.op.v1alpha1.Lstat
+	(*timestamppb.Timestamp)(nil), // 27: google.protobuf.Timestamp
+}
+var file_aferox_op_v1alpha1_op_proto_depIdxs = []int32{
+	2,  // 0: aferox.op.v1alpha1.Operation.chmod:type_name -> aferox.op.v1alpha1.Chmod
+	3,  // 1: aferox.op.v1alpha1.Operation.chown:type_name -> aferox.op.v1alpha1.Chown
+	4,  // 2: aferox.op.v1alpha1.Operation.chtimes:type_name -> aferox.op.v1alpha1.Chtimes
+	5,  // 3: aferox.op.v1alpha1.Operation.create:type_name -> aferox.op.v1alpha1.Create
+	6,  // 4: aferox.op.v1alpha1.Operation.mkdir:type_name -> aferox.op.v1alpha1.Mkdir
+	7,  // 5: aferox.op.v1alpha1.Operation.mkdir_all:type_name -> aferox.op.v1alpha1.MkdirAll
+	8,  // 6: aferox.op.v1alpha1.Operation.open:type_name -> aferox.op.v1alpha1.Open
+	9,  // 7: aferox.op.v1alpha1.Operation.open_file:type_name -> aferox.op.v1alpha1.OpenFile
+	10, // 8: aferox.op.v1alpha1.Operation.remove:type_name -> aferox.op.v1alpha1.Remove
+	11, // 9: aferox.op.v1alpha1.Operation.remove_all:type_name -> aferox.op.v1alpha1.RemoveAll
+	12, // 10: aferox.op.v1alpha1.Operation.rename:type_name -> aferox.op.v1alpha1.Rename
+	13, // 11: aferox.op.v1alpha1.Operation.stat:type_name -> aferox.op.v1alpha1.Stat
+	14, // 12: aferox.op.v1alpha1.Operation.readdir:type_name -> aferox.op.v1alpha1.Readdir
+	15, // 13: aferox.op.v1alpha1.Operation.readdirnames:type_name -> aferox.op.v1alpha1.Readdirnames
+	16, // 14: aferox.op.v1alpha1.Operation.write:type_name -> aferox.op.v1alpha1.Write
+	17, // 15: aferox.op.v1alpha1.Operation.read:type_name -> aferox.op.v1alpha1.Read
+	18, // 16: aferox.op.v1alpha1.Operation.read_at:type_name -> aferox.op.v1alpha1.ReadAt
+	19, // 17: aferox.op.v1alpha1.Operation.seek:type_name -> aferox.op.v1alpha1.Seek
+	20, // 18: aferox.op.v1alpha1.Operation.write_at:type_name -> aferox.op.v1alpha1.WriteAt
+	21, // 19: aferox.op.v1alpha1.Operation.truncate:type_name -> aferox.op.v1alpha1.Truncate
+	22, // 20: aferox.op.v1alpha1.Operation.sync:type_name -> aferox.op.v1alpha1.Sync
+	23, // 21: aferox.op.v1alpha1.Operation.close:type_name -> aferox.op.v1alpha1.Close
+	24, // 22: aferox.op.v1alpha1.Operation.symlink:type_name -> aferox.op.v1alpha1.Symlink
+	25, // 23: aferox.op.v1alpha1.Operation.readlink:type_name -> aferox.op.v1alpha1.Readlink
+	26, // 24: aferox.op.v1alpha1.Operation.lstat:type_name -> aferox.op.v1alpha1.Lstat
+	0,  // 25: aferox.op.v1alpha1.Operations.operations:type_name -> aferox.op.v1alpha1.Operation
+	27, // 26: aferox.op.v1alpha1.Chtimes.atime:type_name -> google.protobuf.Timestamp
+	27, // 27: aferox.op.v1alpha1.Chtimes.mtime:type_name -> google.protobuf.Timestamp
+	28, // [28:28] is the sub-list for method output_type
+	28, // [28:28] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
+}
+
+func init() { file_aferox_op_v1alpha1_op_proto_init() }
+func file_aferox_op_v1alpha1_op_proto_init() {
+	if File_aferox_op_v1alpha1_op_proto != nil {
+		return
+	}
+	file_aferox_op_v1alpha1_op_proto_msgTypes[0].OneofWrappers = []any{
+		(*Operation_Chmod)(nil),
+		(*Operation_Chown)(nil),
+		(*Operation_Chtimes)(nil),
+		(*Operation_Create)(nil),
+		(*Operation_Mkdir)(nil),
+		(*Operation_MkdirAll)(nil),
+		(*Operation_Open)(nil),
+		(*Operation_OpenFile)(nil),
+		(*Operation_Remove)(nil),
+		(*Operation_RemoveAll)(nil),
+		(*Operation_Rename)(nil),
+		(*Operation_Stat)(nil),
+		(*Operation_Readdir)(nil),
+		(*Operation_Readdirnames)(nil),
+		(*Operation_Write)(nil),
+		(*Operation_Read)(nil),
+		(*Operation_ReadAt)(nil),
+		(*Operation_Seek)(nil),
+		(*Operation_WriteAt)(nil),
+		(*Operation_Truncate)(nil),
+		(*Operation_Sync)(nil),
+		(*Operation_Close)(nil),
+		(*Operation_Symlink)(nil),
+		(*Operation_Readlink)(nil),
+		(*Operation_Lstat)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_aferox_op_v1alpha1_op_proto_rawDesc), len(file_aferox_op_v1alpha1_op_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   27,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_aferox_op_v1alpha1_op_proto_goTypes,
+		DependencyIndexes: file_aferox_op_v1alpha1_op_proto_depIdxs,
+		MessageInfos:      file_aferox_op_v1alpha1_op_proto_msgTypes,
+	}.Build()
+	File_aferox_op_v1alpha1_op_proto = out.File
+	file_aferox_op_v1alpha1_op_proto_goTypes = nil
+	file_aferox_op_v1alpha1_op_proto_depIdxs = nil
+}
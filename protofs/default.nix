@@ -9,6 +9,7 @@
         pname = "aferox-protofs";
         version = "0.0.9";
         src = ./.;
+        pwd = ./.;
         modules = ./gomod2nix.toml;
         go = pkgs.go_1_26;
 
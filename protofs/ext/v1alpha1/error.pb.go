@@ -0,0 +1,247 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: aferox/protofs/ext/v1alpha1/error.proto
+
+package extv1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ErrorKind classifies an Error like the sentinel errors of the Go io/fs package.
+type ErrorKind int32
+
+const (
+	ErrorKind_ERROR_KIND_UNSPECIFIED ErrorKind = 0
+	ErrorKind_ERROR_KIND_NOT_EXIST   ErrorKind = 1
+	ErrorKind_ERROR_KIND_EXIST       ErrorKind = 2
+	ErrorKind_ERROR_KIND_PERMISSION  ErrorKind = 3
+	ErrorKind_ERROR_KIND_INVALID     ErrorKind = 4
+	ErrorKind_ERROR_KIND_CLOSED      ErrorKind = 5
+)
+
+// Enum value maps for ErrorKind.
+var (
+	ErrorKind_name = map[int32]string{
+		0: "ERROR_KIND_UNSPECIFIED",
+		1: "ERROR_KIND_NOT_EXIST",
+		2: "ERROR_KIND_EXIST",
+		3: "ERROR_KIND_PERMISSION",
+		4: "ERROR_KIND_INVALID",
+		5: "ERROR_KIND_CLOSED",
+	}
+	ErrorKind_value = map[string]int32{
+		"ERROR_KIND_UNSPECIFIED": 0,
+		"ERROR_KIND_NOT_EXIST":   1,
+		"ERROR_KIND_EXIST":       2,
+		"ERROR_KIND_PERMISSION":  3,
+		"ERROR_KIND_INVALID":     4,
+		"ERROR_KIND_CLOSED":      5,
+	}
+)
+
+func (x ErrorKind) Enum() *ErrorKind {
+	p := new(ErrorKind)
+	*p = x
+	return p
+}
+
+func (x ErrorKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErrorKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_aferox_protofs_ext_v1alpha1_error_proto_enumTypes[0].Descriptor()
+}
+
+func (ErrorKind) Type() protoreflect.EnumType {
+	return &file_aferox_protofs_ext_v1alpha1_error_proto_enumTypes[0]
+}
+
+func (x ErrorKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErrorKind.Descriptor instead.
+func (ErrorKind) EnumDescriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_error_proto_rawDescGZIP(), []int{0}
+}
+
+// Error describes a filesystem error.
+// Servers attach it to the details of the gRPC status they return
+// so clients can reconstruct the original error.
+type Error struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Op is the operation that failed, as in a Go fs.PathError.
+	Op   string `protobuf:"bytes,1,opt,name=op,proto3" json:"op,omitempty"`
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// NewPath is set when the error describes a rename, as in a Go os.LinkError.
+	NewPath string `protobuf:"bytes,3,opt,name=new_path,json=newPath,proto3" json:"new_path,omitempty"`
+	// Message is the text of the underlying error.
+	Message string    `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Kind    ErrorKind `protobuf:"varint,6,opt,name=kind,proto3,enum=aferox.protofs.ext.v1alpha1.ErrorKind" json:"kind,omitempty"`
+	// ErrnoName is the symbolic name of the underlying errno, e.g. "ENOTEMPTY".
+	// Errno values differ between operating systems, so clients map the
+	// name back to their own value.
+	ErrnoName     string `protobuf:"bytes,7,opt,name=errno_name,json=errnoName,proto3" json:"errno_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	mi := &file_aferox_protofs_ext_v1alpha1_error_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_error_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_error_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Error) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *Error) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Error) GetNewPath() string {
+	if x != nil {
+		return x.NewPath
+	}
+	return ""
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Error) GetKind() ErrorKind {
+	if x != nil {
+		return x.Kind
+	}
+	return ErrorKind_ERROR_KIND_UNSPECIFIED
+}
+
+func (x *Error) GetErrnoName() string {
+	if x != nil {
+		return x.ErrnoName
+	}
+	return ""
+}
+
+var File_aferox_protofs_ext_v1alpha1_error_proto protoreflect.FileDescriptor
+
+const file_aferox_protofs_ext_v1alpha1_error_proto_rawDesc = "" +
+	"\n" +
+	"'aferox/protofs/ext/v1alpha1/error.proto\x12\x1baferox.protofs.ext.v1alpha1\"\xc8\x01\n" +
+	"\x05Error\x12\x0e\n" +
+	"\x02op\x18\x01 \x01(\tR\x02op\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x19\n" +
+	"\bnew_path\x18\x03 \x01(\tR\anewPath\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12:\n" +
+	"\x04kind\x18\x06 \x01(\x0e2&.aferox.protofs.ext.v1alpha1.ErrorKindR\x04kind\x12\x1d\n" +
+	"\n" +
+	"errno_name\x18\a \x01(\tR\terrnoNameJ\x04\b\x05\x10\x06R\x05errno*\xa1\x01\n" +
+	"\tErrorKind\x12\x1a\n" +
+	"\x16ERROR_KIND_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14ERROR_KIND_NOT_EXIST\x10\x01\x12\x14\n" +
+	"\x10ERROR_KIND_EXIST\x10\x02\x12\x19\n" +
+	"\x15ERROR_KIND_PERMISSION\x10\x03\x12\x16\n" +
+	"\x12ERROR_KIND_INVALID\x10\x04\x12\x15\n" +
+	"\x11ERROR_KIND_CLOSED\x10\x05B<Z:github.com/unmango/aferox/protofs/ext/v1alpha1;extv1alpha1b\x06proto3"
+
+var (
+	file_aferox_protofs_ext_v1alpha1_error_proto_rawDescOnce sync.Once
+	file_aferox_protofs_ext_v1alpha1_error_proto_rawDescData []byte
+)
+
+func file_aferox_protofs_ext_v1alpha1_error_proto_rawDescGZIP() []byte {
+	file_aferox_protofs_ext_v1alpha1_error_proto_rawDescOnce.Do(func() {
+		file_aferox_protofs_ext_v1alpha1_error_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_aferox_protofs_ext_v1alpha1_error_proto_rawDesc), len(file_aferox_protofs_ext_v1alpha1_error_proto_rawDesc)))
+	})
+	return file_aferox_protofs_ext_v1alpha1_error_proto_rawDescData
+}
+
+var file_aferox_protofs_ext_v1alpha1_error_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_aferox_protofs_ext_v1alpha1_error_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_aferox_protofs_ext_v1alpha1_error_proto_goTypes = []any{
+	(ErrorKind)(0), // 0: aferox.protofs.ext.v1alpha1.ErrorKind
+	(*Error)(nil),  // 1: aferox.protofs.ext.v1alpha1.Error
+}
+var file_aferox_protofs_ext_v1alpha1_error_proto_depIdxs = []int32{
+	0, // 0: aferox.protofs.ext.v1alpha1.Error.kind:type_name -> aferox.protofs.ext.v1alpha1.ErrorKind
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_aferox_protofs_ext_v1alpha1_error_proto_init() }
+func file_aferox_protofs_ext_v1alpha1_error_proto_init() {
+	if File_aferox_protofs_ext_v1alpha1_error_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_aferox_protofs_ext_v1alpha1_error_proto_rawDesc), len(file_aferox_protofs_ext_v1alpha1_error_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_aferox_protofs_ext_v1alpha1_error_proto_goTypes,
+		DependencyIndexes: file_aferox_protofs_ext_v1alpha1_error_proto_depIdxs,
+		EnumInfos:         file_aferox_protofs_ext_v1alpha1_error_proto_enumTypes,
+		MessageInfos:      file_aferox_protofs_ext_v1alpha1_error_proto_msgTypes,
+	}.Build()
+	File_aferox_protofs_ext_v1alpha1_error_proto = out.File
+	file_aferox_protofs_ext_v1alpha1_error_proto_goTypes = nil
+	file_aferox_protofs_ext_v1alpha1_error_proto_depIdxs = nil
+}
@@ -0,0 +1,1478 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: aferox/protofs/ext/v1alpha1/handle.proto
+
+package extv1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type FileInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Size  int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	// Mode holds the bits of a Go fs.FileMode.
+	Mode          uint32                 `protobuf:"varint,3,opt,name=mode,proto3" json:"mode,omitempty"`
+	ModTime       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=mod_time,json=modTime,proto3" json:"mod_time,omitempty"`
+	IsDir         bool                   `protobuf:"varint,5,opt,name=is_dir,json=isDir,proto3" json:"is_dir,omitempty"`
+	Sys           *anypb.Any             `protobuf:"bytes,6,opt,name=sys,proto3" json:"sys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileInfo) Reset() {
+	*x = FileInfo{}
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileInfo) ProtoMessage() {}
+
+func (x *FileInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileInfo.ProtoReflect.Descriptor instead.
+func (*FileInfo) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FileInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FileInfo) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *FileInfo) GetMode() uint32 {
+	if x != nil {
+		return x.Mode
+	}
+	return 0
+}
+
+func (x *FileInfo) GetModTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ModTime
+	}
+	return nil
+}
+
+func (x *FileInfo) GetIsDir() bool {
+	if x != nil {
+		return x.IsDir
+	}
+	return false
+}
+
+func (x *FileInfo) GetSys() *anypb.Any {
+	if x != nil {
+		return x.Sys
+	}
+	return nil
+}
+
+type OpenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Flag          int64                  `protobuf:"varint,2,opt,name=flag,proto3" json:"flag,omitempty"`
+	Perm          uint32                 `protobuf:"varint,3,opt,name=perm,proto3" json:"perm,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpenRequest) Reset() {
+	*x = OpenRequest{}
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenRequest) ProtoMessage() {}
+
+func (x *OpenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenRequest.ProtoReflect.Descriptor instead.
+func (*OpenRequest) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OpenRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OpenRequest) GetFlag() int64 {
+	if x != nil {
+		return x.Flag
+	}
+	return 0
+}
+
+func (x *OpenRequest) GetPerm() uint32 {
+	if x != nil {
+		return x.Perm
+	}
+	return 0
+}
+
+type OpenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Handle        uint64                 `protobuf:"varint,1,opt,name=handle,proto3" json:"handle,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpenResponse) Reset() {
+	*x = OpenResponse{}
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenResponse) ProtoMessage() {}
+
+func (x *OpenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenResponse.ProtoReflect.Descriptor instead.
+func (*OpenResponse) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OpenResponse) GetHandle() uint64 {
+	if x != nil {
+		return x.Handle
+	}
+	return 0
+}
+
+func (x *OpenResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CloseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Handle        uint64                 `protobuf:"varint,1,opt,name=handle,proto3" json:"handle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseRequest) Reset() {
+	*x = CloseRequest{}
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseRequest) ProtoMessage() {}
+
+func (x *CloseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseRequest.ProtoReflect.Descriptor instead.
+func (*CloseRequest) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CloseRequest) GetHandle() uint64 {
+	if x != nil {
+		return x.Handle
+	}
+	return 0
+}
+
+type CloseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseResponse) Reset() {
+	*x = CloseResponse{}
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseResponse) ProtoMessage() {}
+
+func (x *CloseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseResponse.ProtoReflect.Descriptor instead.
+func (*CloseResponse) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescGZIP(), []int{4}
+}
+
+type ReadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Handle        uint64                 `protobuf:"varint,1,opt,name=handle,proto3" json:"handle,omitempty"`
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadRequest) Reset() {
+	*x = ReadRequest{}
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadRequest) ProtoMessage() {}
+
+func (x *ReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadRequest.ProtoReflect.Descriptor instead.
+func (*ReadRequest) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReadRequest) GetHandle() uint64 {
+	if x != nil {
+		return x.Handle
+	}
+	return 0
+}
+
+func (x *ReadRequest) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type ReadResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Data  []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// Eof is set when the read reached the end of the file.
+	Eof           bool `protobuf:"varint,2,opt,name=eof,proto3" json:"eof,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadResponse) Reset() {
+	*x = ReadResponse{}
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadResponse) ProtoMessage() {}
+
+func (x *ReadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadResponse.ProtoReflect.Descriptor instead.
+func (*ReadResponse) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReadResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ReadResponse) GetEof() bool {
+	if x != nil {
+		return x.Eof
+	}
+	return false
+}
+
+type ReadAtRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Handle        uint64                 `protobuf:"varint,1,opt,name=handle,proto3" json:"handle,omitempty"`
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Offset        int64                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadAtRequest) Reset() {
+	*x = ReadAtRequest{}
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadAtRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadAtRequest) ProtoMessage() {}
+
+func (x *ReadAtRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadAtRequest.ProtoReflect.Descriptor instead.
+func (*ReadAtRequest) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ReadAtRequest) GetHandle() uint64 {
+	if x != nil {
+		return x.Handle
+	}
+	return 0
+}
+
+func (x *ReadAtRequest) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ReadAtRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ReadAtResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Eof           bool                   `protobuf:"varint,2,opt,name=eof,proto3" json:"eof,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadAtResponse) Reset() {
+	*x = ReadAtResponse{}
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadAtResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadAtResponse) ProtoMessage() {}
+
+func (x *ReadAtResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadAtResponse.ProtoReflect.Descriptor instead.
+func (*ReadAtResponse) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReadAtResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ReadAtResponse) GetEof() bool {
+	if x != nil {
+		return x.Eof
+	}
+	return false
+}
+
+type ReaddirRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Handle        uint64                 `protobuf:"varint,1,opt,name=handle,proto3" json:"handle,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReaddirRequest) Reset() {
+	*x = ReaddirRequest{}
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReaddirRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReaddirRequest) ProtoMessage() {}
+
+func (x *ReaddirRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReaddirRequest.ProtoReflect.Descriptor instead.
+func (*ReaddirRequest) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ReaddirRequest) GetHandle() uint64 {
+	if x != nil {
+		return x.Handle
+	}
+	return 0
+}
+
+func (x *ReaddirRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ReaddirResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileInfos     []*FileInfo            `protobuf:"bytes,1,rep,name=file_infos,json=fileInfos,proto3" json:"file_infos,omitempty"`
+	Eof           bool                   `protobuf:"varint,2,opt,name=eof,proto3" json:"eof,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReaddirResponse) Reset() {
+	*x = ReaddirResponse{}
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReaddirResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReaddirResponse) ProtoMessage() {}
+
+func (x *ReaddirResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReaddirResponse.ProtoReflect.Descriptor instead.
+func (*ReaddirResponse) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ReaddirResponse) GetFileInfos() []*FileInfo {
+	if x != nil {
+		return x.FileInfos
+	}
+	return nil
+}
+
+func (x *ReaddirResponse) GetEof() bool {
+	if x != nil {
+		return x.Eof
+	}
+	return false
+}
+
+type ReaddirnamesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Handle        uint64                 `protobuf:"varint,1,opt,name=handle,proto3" json:"handle,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReaddirnamesRequest) Reset() {
+	*x = ReaddirnamesRequest{}
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReaddirnamesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReaddirnamesRequest) ProtoMessage() {}
+
+func (x *ReaddirnamesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReaddirnamesRequest.ProtoReflect.Descriptor instead.
+func (*ReaddirnamesRequest) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ReaddirnamesRequest) GetHandle() uint64 {
+	if x != nil {
+		return x.Handle
+	}
+	return 0
+}
+
+func (x *ReaddirnamesRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ReaddirnamesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Names         []string               `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+	Eof           bool                   `protobuf:"varint,2,opt,name=eof,proto3" json:"eof,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReaddirnamesResponse) Reset() {
+	*x = ReaddirnamesResponse{}
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReaddirnamesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReaddirnamesResponse) ProtoMessage() {}
+
+func (x *ReaddirnamesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReaddirnamesResponse.ProtoReflect.Descriptor instead.
+func (*ReaddirnamesResponse) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ReaddirnamesResponse) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *ReaddirnamesResponse) GetEof() bool {
+	if x != nil {
+		return x.Eof
+	}
+	return false
+}
+
+type SeekRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Handle        uint64                 `protobuf:"varint,1,opt,name=handle,proto3" json:"handle,omitempty"`
+	Offset        int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Whence        int32                  `protobuf:"varint,3,opt,name=whence,proto3" json:"whence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeekRequest) Reset() {
+	*x = SeekRequest{}
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeekRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeekRequest) ProtoMessage() {}
+
+func (x *SeekRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeekRequest.ProtoReflect.Descriptor instead.
+func (*SeekRequest) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SeekRequest) GetHandle() uint64 {
+	if x != nil {
+		return x.Handle
+	}
+	return 0
+}
+
+func (x *SeekRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *SeekRequest) GetWhence() int32 {
+	if x != nil {
+		return x.Whence
+	}
+	return 0
+}
+
+type SeekResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Offset        int64                  `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeekResponse) Reset() {
+	*x = SeekResponse{}
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeekResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeekResponse) ProtoMessage() {}
+
+func (x *SeekResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeekResponse.ProtoReflect.Descriptor instead.
+func (*SeekResponse) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SeekResponse) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type StatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Handle        uint64                 `protobuf:"varint,1,opt,name=handle,proto3" json:"handle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatRequest) Reset() {
+	*x = StatRequest{}
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatRequest) ProtoMessage() {}
+
+func (x *StatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatRequest.ProtoReflect.Descriptor instead.
+func (*StatRequest) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *StatRequest) GetHandle() uint64 {
+	if x != nil {
+		return x.Handle
+	}
+	return 0
+}
+
+type StatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileInfo      *FileInfo              `protobuf:"bytes,1,opt,name=file_info,json=fileInfo,proto3" json:"file_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatResponse) Reset() {
+	*x = StatResponse{}
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatResponse) ProtoMessage() {}
+
+func (x *StatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatResponse.ProtoReflect.Descriptor instead.
+func (*StatResponse) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *StatResponse) GetFileInfo() *FileInfo {
+	if x != nil {
+		return x.FileInfo
+	}
+	return nil
+}
+
+type SyncRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Handle        uint64                 `protobuf:"varint,1,opt,name=handle,proto3" json:"handle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncRequest) Reset() {
+	*x = SyncRequest{}
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncRequest) ProtoMessage() {}
+
+func (x *SyncRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncRequest.ProtoReflect.Descriptor instead.
+func (*SyncRequest) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SyncRequest) GetHandle() uint64 {
+	if x != nil {
+		return x.Handle
+	}
+	return 0
+}
+
+type SyncResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncResponse) Reset() {
+	*x = SyncResponse{}
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncResponse) ProtoMessage() {}
+
+func (x *SyncResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncResponse.ProtoReflect.Descriptor instead.
+func (*SyncResponse) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescGZIP(), []int{18}
+}
+
+type TruncateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Handle        uint64                 `protobuf:"varint,1,opt,name=handle,proto3" json:"handle,omitempty"`
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TruncateRequest) Reset() {
+	*x = TruncateRequest{}
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TruncateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TruncateRequest) ProtoMessage() {}
+
+func (x *TruncateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TruncateRequest.ProtoReflect.Descriptor instead.
+func (*TruncateRequest) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *TruncateRequest) GetHandle() uint64 {
+	if x != nil {
+		return x.Handle
+	}
+	return 0
+}
+
+func (x *TruncateRequest) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type TruncateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TruncateResponse) Reset() {
+	*x = TruncateResponse{}
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TruncateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TruncateResponse) ProtoMessage() {}
+
+func (x *TruncateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TruncateResponse.ProtoReflect.Descriptor instead.
+func (*TruncateResponse) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescGZIP(), []int{20}
+}
+
+type WriteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Handle        uint64                 `protobuf:"varint,1,opt,name=handle,proto3" json:"handle,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteRequest) Reset() {
+	*x = WriteRequest{}
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteRequest) ProtoMessage() {}
+
+func (x *WriteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteRequest.ProtoReflect.Descriptor instead.
+func (*WriteRequest) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *WriteRequest) GetHandle() uint64 {
+	if x != nil {
+		return x.Handle
+	}
+	return 0
+}
+
+func (x *WriteRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type WriteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	N             int64                  `protobuf:"varint,1,opt,name=n,proto3" json:"n,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteResponse) Reset() {
+	*x = WriteResponse{}
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteResponse) ProtoMessage() {}
+
+func (x *WriteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteResponse.ProtoReflect.Descriptor instead.
+func (*WriteResponse) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *WriteResponse) GetN() int64 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+type WriteAtRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Handle        uint64                 `protobuf:"varint,1,opt,name=handle,proto3" json:"handle,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Offset        int64                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteAtRequest) Reset() {
+	*x = WriteAtRequest{}
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteAtRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteAtRequest) ProtoMessage() {}
+
+func (x *WriteAtRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteAtRequest.ProtoReflect.Descriptor instead.
+func (*WriteAtRequest) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *WriteAtRequest) GetHandle() uint64 {
+	if x != nil {
+		return x.Handle
+	}
+	return 0
+}
+
+func (x *WriteAtRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *WriteAtRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type WriteAtResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	N             int64                  `protobuf:"varint,1,opt,name=n,proto3" json:"n,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteAtResponse) Reset() {
+	*x = WriteAtResponse{}
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteAtResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteAtResponse) ProtoMessage() {}
+
+func (x *WriteAtResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteAtResponse.ProtoReflect.Descriptor instead.
+func (*WriteAtResponse) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *WriteAtResponse) GetN() int64 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+var File_aferox_protofs_ext_v1alpha1_handle_proto protoreflect.FileDescriptor
+
+const file_aferox_protofs_ext_v1alpha1_handle_proto_rawDesc = "" +
+	"\n" +
+	"(aferox/protofs/ext/v1alpha1/handle.proto\x12\x1baferox.protofs.ext.v1alpha1\x1a\x19google/protobuf/any.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xbc\x01\n" +
+	"\bFileInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x12\n" +
+	"\x04mode\x18\x03 \x01(\rR\x04mode\x125\n" +
+	"\bmod_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\amodTime\x12\x15\n" +
+	"\x06is_dir\x18\x05 \x01(\bR\x05isDir\x12&\n" +
+	"\x03sys\x18\x06 \x01(\v2\x14.google.protobuf.AnyR\x03sys\"I\n" +
+	"\vOpenRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04flag\x18\x02 \x01(\x03R\x04flag\x12\x12\n" +
+	"\x04perm\x18\x03 \x01(\rR\x04perm\":\n" +
+	"\fOpenResponse\x12\x16\n" +
+	"\x06handle\x18\x01 \x01(\x04R\x06handle\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"&\n" +
+	"\fCloseRequest\x12\x16\n" +
+	"\x06handle\x18\x01 \x01(\x04R\x06handle\"\x0f\n" +
+	"\rCloseResponse\"9\n" +
+	"\vReadRequest\x12\x16\n" +
+	"\x06handle\x18\x01 \x01(\x04R\x06handle\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\"4\n" +
+	"\fReadResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12\x10\n" +
+	"\x03eof\x18\x02 \x01(\bR\x03eof\"S\n" +
+	"\rReadAtRequest\x12\x16\n" +
+	"\x06handle\x18\x01 \x01(\x04R\x06handle\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x03R\x06offset\"6\n" +
+	"\x0eReadAtResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12\x10\n" +
+	"\x03eof\x18\x02 \x01(\bR\x03eof\">\n" +
+	"\x0eReaddirRequest\x12\x16\n" +
+	"\x06handle\x18\x01 \x01(\x04R\x06handle\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"i\n" +
+	"\x0fReaddirResponse\x12D\n" +
+	"\n" +
+	"file_infos\x18\x01 \x03(\v2%.aferox.protofs.ext.v1alpha1.FileInfoR\tfileInfos\x12\x10\n" +
+	"\x03eof\x18\x02 \x01(\bR\x03eof\"C\n" +
+	"\x13ReaddirnamesRequest\x12\x16\n" +
+	"\x06handle\x18\x01 \x01(\x04R\x06handle\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\">\n" +
+	"\x14ReaddirnamesResponse\x12\x14\n" +
+	"\x05names\x18\x01 \x03(\tR\x05names\x12\x10\n" +
+	"\x03eof\x18\x02 \x01(\bR\x03eof\"U\n" +
+	"\vSeekRequest\x12\x16\n" +
+	"\x06handle\x18\x01 \x01(\x04R\x06handle\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x16\n" +
+	"\x06whence\x18\x03 \x01(\x05R\x06whence\"&\n" +
+	"\fSeekResponse\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x03R\x06offset\"%\n" +
+	"\vStatRequest\x12\x16\n" +
+	"\x06handle\x18\x01 \x01(\x04R\x06handle\"R\n" +
+	"\fStatResponse\x12B\n" +
+	"\tfile_info\x18\x01 \x01(\v2%.aferox.protofs.ext.v1alpha1.FileInfoR\bfileInfo\"%\n" +
+	"\vSyncRequest\x12\x16\n" +
+	"\x06handle\x18\x01 \x01(\x04R\x06handle\"\x0e\n" +
+	"\fSyncResponse\"=\n" +
+	"\x0fTruncateRequest\x12\x16\n" +
+	"\x06handle\x18\x01 \x01(\x04R\x06handle\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\"\x12\n" +
+	"\x10TruncateResponse\":\n" +
+	"\fWriteRequest\x12\x16\n" +
+	"\x06handle\x18\x01 \x01(\x04R\x06handle\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"\x1d\n" +
+	"\rWriteResponse\x12\f\n" +
+	"\x01n\x18\x01 \x01(\x03R\x01n\"T\n" +
+	"\x0eWriteAtRequest\x12\x16\n" +
+	"\x06handle\x18\x01 \x01(\x04R\x06handle\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x03R\x06offset\"\x1f\n" +
+	"\x0fWriteAtResponse\x12\f\n" +
+	"\x01n\x18\x01 \x01(\x03R\x01n2\xad\t\n" +
+	"\rHandleService\x12[\n" +
+	"\x04Open\x12(.aferox.protofs.ext.v1alpha1.OpenRequest\x1a).aferox.protofs.ext.v1alpha1.OpenResponse\x12^\n" +
+	"\x05Close\x12).aferox.protofs.ext.v1alpha1.CloseRequest\x1a*.aferox.protofs.ext.v1alpha1.CloseResponse\x12[\n" +
+	"\x04Read\x12(.aferox.protofs.ext.v1alpha1.ReadRequest\x1a).aferox.protofs.ext.v1alpha1.ReadResponse\x12a\n" +
+	"\x06ReadAt\x12*.aferox.protofs.ext.v1alpha1.ReadAtRequest\x1a+.aferox.protofs.ext.v1alpha1.ReadAtResponse\x12d\n" +
+	"\aReaddir\x12+.aferox.protofs.ext.v1alpha1.ReaddirRequest\x1a,.aferox.protofs.ext.v1alpha1.ReaddirResponse\x12s\n" +
+	"\fReaddirnames\x120.aferox.protofs.ext.v1alpha1.ReaddirnamesRequest\x1a1.aferox.protofs.ext.v1alpha1.ReaddirnamesResponse\x12[\n" +
+	"\x04Seek\x12(.aferox.protofs.ext.v1alpha1.SeekRequest\x1a).aferox.protofs.ext.v1alpha1.SeekResponse\x12[\n" +
+	"\x04Stat\x12(.aferox.protofs.ext.v1alpha1.StatRequest\x1a).aferox.protofs.ext.v1alpha1.StatResponse\x12[\n" +
+	"\x04Sync\x12(.aferox.protofs.ext.v1alpha1.SyncRequest\x1a).aferox.protofs.ext.v1alpha1.SyncResponse\x12g\n" +
+	"\bTruncate\x12,.aferox.protofs.ext.v1alpha1.TruncateRequest\x1a-.aferox.protofs.ext.v1alpha1.TruncateResponse\x12^\n" +
+	"\x05Write\x12).aferox.protofs.ext.v1alpha1.WriteRequest\x1a*.aferox.protofs.ext.v1alpha1.WriteResponse\x12d\n" +
+	"\aWriteAt\x12+.aferox.protofs.ext.v1alpha1.WriteAtRequest\x1a,.aferox.protofs.ext.v1alpha1.WriteAtResponseB<Z:github.com/unmango/aferox/protofs/ext/v1alpha1;extv1alpha1b\x06proto3"
+
+var (
+	file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescOnce sync.Once
+	file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescData []byte
+)
+
+func file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescGZIP() []byte {
+	file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescOnce.Do(func() {
+		file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_aferox_protofs_ext_v1alpha1_handle_proto_rawDesc), len(file_aferox_protofs_ext_v1alpha1_handle_proto_rawDesc)))
+	})
+	return file_aferox_protofs_ext_v1alpha1_handle_proto_rawDescData
+}
+
+var file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_aferox_protofs_ext_v1alpha1_handle_proto_goTypes = []any{
+	(*FileInfo)(nil),              // 0: aferox.protofs.ext.v1alpha1.FileInfo
+	(*OpenRequest)(nil),           // 1: aferox.protofs.ext.v1alpha1.OpenRequest
+	(*OpenResponse)(nil),          // 2: aferox.protofs.ext.v1alpha1.OpenResponse
+	(*CloseRequest)(nil),          // 3: aferox.protofs.ext.v1alpha1.CloseRequest
+	(*CloseResponse)(nil),         // 4: aferox.protofs.ext.v1alpha1.CloseResponse
+	(*ReadRequest)(nil),           // 5: aferox.protofs.ext.v1alpha1.ReadRequest
+	(*ReadResponse)(nil),          // 6: aferox.protofs.ext.v1alpha1.ReadResponse
+	(*ReadAtRequest)(nil),         // 7: aferox.protofs.ext.v1alpha1.ReadAtRequest
+	(*ReadAtResponse)(nil),        // 8: aferox.protofs.ext.v1alpha1.ReadAtResponse
+	(*ReaddirRequest)(nil),        // 9: aferox.protofs.ext.v1alpha1.ReaddirRequest
+	(*ReaddirResponse)(nil),       // 10: aferox.protofs.ext.v1alpha1.ReaddirResponse
+	(*ReaddirnamesRequest)(nil),   // 11: aferox.protofs.ext.v1alpha1.ReaddirnamesRequest
+	(*ReaddirnamesResponse)(nil),  // 12: aferox.protofs.ext.v1alpha1.ReaddirnamesResponse
+	(*SeekRequest)(nil),           // 13: aferox.protofs.ext.v1alpha1.SeekRequest
+	(*SeekResponse)(nil),          // 14: aferox.protofs.ext.v1alpha1.SeekResponse
+	(*StatRequest)(nil),           // 15: aferox.protofs.ext.v1alpha1.StatRequest
+	(*StatResponse)(nil),          // 16: aferox.protofs.ext.v1alpha1.StatResponse
+	(*SyncRequest)(nil),           // 17: aferox.protofs.ext.v1alpha1.SyncRequest
+	(*SyncResponse)(nil),          // 18: aferox.protofs.ext.v1alpha1.SyncResponse
+	(*TruncateRequest)(nil),       // 19: aferox.protofs.ext.v1alpha1.TruncateRequest
+	(*TruncateResponse)(nil),      // 20: aferox.protofs.ext.v1alpha1.TruncateResponse
+	(*WriteRequest)(nil),          // 21: aferox.protofs.ext.v1alpha1.WriteRequest
+	(*WriteResponse)(nil),         // 22: aferox.protofs.ext.v1alpha1.WriteResponse
+	(*WriteAtRequest)(nil),        // 23: aferox.protofs.ext.v1alpha1.WriteAtRequest
+	(*WriteAtResponse)(nil),       // 24: aferox.protofs.ext.v1alpha1.WriteAtResponse
+	(*timestamppb.Timestamp)(nil), // 25: google.protobuf.Timestamp
+	(*anypb.Any)(nil),             // 26: google.protobuf.Any
+}
+var file_aferox_protofs_ext_v1alpha1_handle_proto_depIdxs = []int32{
+	25, // 0: aferox.protofs.ext.v1alpha1.FileInfo.mod_time:type_name -> google.protobuf.Timestamp
+	26, // 1: aferox.protofs.ext.v1alpha1.FileInfo.sys:type_name -> google.protobuf.Any
+	0,  // 2: aferox.protofs.ext.v1alpha1.ReaddirResponse.file_infos:type_name -> aferox.protofs.ext.v1alpha1.FileInfo
+	0,  // 3: aferox.protofs.ext.v1alpha1.StatResponse.file_info:type_name -> aferox.protofs.ext.v1alpha1.FileInfo
+	1,  // 4: aferox.protofs.ext.v1alpha1.HandleService.Open:input_type -> aferox.protofs.ext.v1alpha1.OpenRequest
+	3,  // 5: aferox.protofs.ext.v1alpha1.HandleService.Close:input_type -> aferox.protofs.ext.v1alpha1.CloseRequest
+	5,  // 6: aferox.protofs.ext.v1alpha1.HandleService.Read:input_type -> aferox.protofs.ext.v1alpha1.ReadRequest
+	7,  // 7: aferox.protofs.ext.v1alpha1.HandleService.ReadAt:input_type -> aferox.protofs.ext.v1alpha1.ReadAtRequest
+	9,  // 8: aferox.protofs.ext.v1alpha1.HandleService.Readdir:input_type -> aferox.protofs.ext.v1alpha1.ReaddirRequest
+	11, // 9: aferox.protofs.ext.v1alpha1.HandleService.Readdirnames:input_type -> aferox.protofs.ext.v1alpha1.ReaddirnamesRequest
+	13, // 10: aferox.protofs.ext.v1alpha1.HandleService.Seek:input_type -> aferox.protofs.ext.v1alpha1.SeekRequest
+	15, // 11: aferox.protofs.ext.v1alpha1.HandleService.Stat:input_type -> aferox.protofs.ext.v1alpha1.StatRequest
+	17, // 12: aferox.protofs.ext.v1alpha1.HandleService.Sync:input_type -> aferox.protofs.ext.v1alpha1.SyncRequest
+	19, // 13: aferox.protofs.ext.v1alpha1.HandleService.Truncate:input_type -> aferox.protofs.ext.v1alpha1.TruncateRequest
+	21, // 14: aferox.protofs.ext.v1alpha1.HandleService.Write:input_type -> aferox.protofs.ext.v1alpha1.WriteRequest
+	23, // 15: aferox.protofs.ext.v1alpha1.HandleService.WriteAt:input_type -> aferox.protofs.ext.v1alpha1.WriteAtRequest
+	2,  // 16: aferox.protofs.ext.v1alpha1.HandleService.Open:output_type -> aferox.protofs.ext.v1alpha1.OpenResponse
+	4,  // 17: aferox.protofs.ext.v1alpha1.HandleService.Close:output_type -> aferox.protofs.ext.v1alpha1.CloseResponse
+	6,  // 18: aferox.protofs.ext.v1alpha1.HandleService.Read:output_type -> aferox.protofs.ext.v1alpha1.ReadResponse
+	8,  // 19: aferox.protofs.ext.v1alpha1.HandleService.ReadAt:output_type -> aferox.protofs.ext.v1alpha1.ReadAtResponse
+	10, // 20: aferox.protofs.ext.v1alpha1.HandleService.Readdir:output_type -> aferox.protofs.ext.v1alpha1.ReaddirResponse
+	12, // 21: aferox.protofs.ext.v1alpha1.HandleService.Readdirnames:output_type -> aferox.protofs.ext.v1alpha1.ReaddirnamesResponse
+	14, // 22: aferox.protofs.ext.v1alpha1.HandleService.Seek:output_type -> aferox.protofs.ext.v1alpha1.SeekResponse
+	16, // 23: aferox.protofs.ext.v1alpha1.HandleService.Stat:output_type -> aferox.protofs.ext.v1alpha1.StatResponse
+	18, // 24: aferox.protofs.ext.v1alpha1.HandleService.Sync:output_type -> aferox.protofs.ext.v1alpha1.SyncResponse
+	20, // 25: aferox.protofs.ext.v1alpha1.HandleService.Truncate:output_type -> aferox.protofs.ext.v1alpha1.TruncateResponse
+	22, // 26: aferox.protofs.ext.v1alpha1.HandleService.Write:output_type -> aferox.protofs.ext.v1alpha1.WriteResponse
+	24, // 27: aferox.protofs.ext.v1alpha1.HandleService.WriteAt:output_type -> aferox.protofs.ext.v1alpha1.WriteAtResponse
+	16, // [16:28] is the sub-list for method output_type
+	4,  // [4:16] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_aferox_protofs_ext_v1alpha1_handle_proto_init() }
+func file_aferox_protofs_ext_v1alpha1_handle_proto_init() {
+	if File_aferox_protofs_ext_v1alpha1_handle_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_aferox_protofs_ext_v1alpha1_handle_proto_rawDesc), len(file_aferox_protofs_ext_v1alpha1_handle_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   25,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_aferox_protofs_ext_v1alpha1_handle_proto_goTypes,
+		DependencyIndexes: file_aferox_protofs_ext_v1alpha1_handle_proto_depIdxs,
+		MessageInfos:      file_aferox_protofs_ext_v1alpha1_handle_proto_msgTypes,
+	}.Build()
+	File_aferox_protofs_ext_v1alpha1_handle_proto = out.File
+	file_aferox_protofs_ext_v1alpha1_handle_proto_goTypes = nil
+	file_aferox_protofs_ext_v1alpha1_handle_proto_depIdxs = nil
+}
@@ -0,0 +1,547 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.1
+// - protoc             (unknown)
+// source: aferox/protofs/ext/v1alpha1/handle.proto
+
+package extv1alpha1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	HandleService_Open_FullMethodName         = "/aferox.protofs.ext.v1alpha1.HandleService/Open"
+	HandleService_Close_FullMethodName        = "/aferox.protofs.ext.v1alpha1.HandleService/Close"
+	HandleService_Read_FullMethodName         = "/aferox.protofs.ext.v1alpha1.HandleService/Read"
+	HandleService_ReadAt_FullMethodName       = "/aferox.protofs.ext.v1alpha1.HandleService/ReadAt"
+	HandleService_Readdir_FullMethodName      = "/aferox.protofs.ext.v1alpha1.HandleService/Readdir"
+	HandleService_Readdirnames_FullMethodName = "/aferox.protofs.ext.v1alpha1.HandleService/Readdirnames"
+	HandleService_Seek_FullMethodName         = "/aferox.protofs.ext.v1alpha1.HandleService/Seek"
+	HandleService_Stat_FullMethodName         = "/aferox.protofs.ext.v1alpha1.HandleService/Stat"
+	HandleService_Sync_FullMethodName         = "/aferox.protofs.ext.v1alpha1.HandleService/Sync"
+	HandleService_Truncate_FullMethodName     = "/aferox.protofs.ext.v1alpha1.HandleService/Truncate"
+	HandleService_Write_FullMethodName        = "/aferox.protofs.ext.v1alpha1.HandleService/Write"
+	HandleService_WriteAt_FullMethodName      = "/aferox.protofs.ext.v1alpha1.HandleService/WriteAt"
+)
+
+// HandleServiceClient is the client API for HandleService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// HandleService exposes stateful file handles.
+// A handle is allocated by Open and keeps its offset and
+// directory cursor on the server until it is closed.
+type HandleServiceClient interface {
+	Open(ctx context.Context, in *OpenRequest, opts ...grpc.CallOption) (*OpenResponse, error)
+	Close(ctx context.Context, in *CloseRequest, opts ...grpc.CallOption) (*CloseResponse, error)
+	Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*ReadResponse, error)
+	ReadAt(ctx context.Context, in *ReadAtRequest, opts ...grpc.CallOption) (*ReadAtResponse, error)
+	Readdir(ctx context.Context, in *ReaddirRequest, opts ...grpc.CallOption) (*ReaddirResponse, error)
+	Readdirnames(ctx context.Context, in *ReaddirnamesRequest, opts ...grpc.CallOption) (*ReaddirnamesResponse, error)
+	Seek(ctx context.Context, in *SeekRequest, opts ...grpc.CallOption) (*SeekResponse, error)
+	Stat(ctx context.Context, in *StatRequest, opts ...grpc.CallOption) (*StatResponse, error)
+	Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (*SyncResponse, error)
+	Truncate(ctx context.Context, in *TruncateRequest, opts ...grpc.CallOption) (*TruncateResponse, error)
+	Write(ctx context.Context, in *WriteRequest, opts ...grpc.CallOption) (*WriteResponse, error)
+	WriteAt(ctx context.Context, in *WriteAtRequest, opts ...grpc.CallOption) (*WriteAtResponse, error)
+}
+
+type handleServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHandleServiceClient(cc grpc.ClientConnInterface) HandleServiceClient {
+	return &handleServiceClient{cc}
+}
+
+func (c *handleServiceClient) Open(ctx context.Context, in *OpenRequest, opts ...grpc.CallOption) (*OpenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OpenResponse)
+	err := c.cc.Invoke(ctx, HandleService_Open_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handleServiceClient) Close(ctx context.Context, in *CloseRequest, opts ...grpc.CallOption) (*CloseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CloseResponse)
+	err := c.cc.Invoke(ctx, HandleService_Close_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handleServiceClient) Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*ReadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReadResponse)
+	err := c.cc.Invoke(ctx, HandleService_Read_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handleServiceClient) ReadAt(ctx context.Context, in *ReadAtRequest, opts ...grpc.CallOption) (*ReadAtResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReadAtResponse)
+	err := c.cc.Invoke(ctx, HandleService_ReadAt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handleServiceClient) Readdir(ctx context.Context, in *ReaddirRequest, opts ...grpc.CallOption) (*ReaddirResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReaddirResponse)
+	err := c.cc.Invoke(ctx, HandleService_Readdir_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handleServiceClient) Readdirnames(ctx context.Context, in *ReaddirnamesRequest, opts ...grpc.CallOption) (*ReaddirnamesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReaddirnamesResponse)
+	err := c.cc.Invoke(ctx, HandleService_Readdirnames_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handleServiceClient) Seek(ctx context.Context, in *SeekRequest, opts ...grpc.CallOption) (*SeekResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SeekResponse)
+	err := c.cc.Invoke(ctx, HandleService_Seek_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handleServiceClient) Stat(ctx context.Context, in *StatRequest, opts ...grpc.CallOption) (*StatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatResponse)
+	err := c.cc.Invoke(ctx, HandleService_Stat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handleServiceClient) Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (*SyncResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SyncResponse)
+	err := c.cc.Invoke(ctx, HandleService_Sync_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handleServiceClient) Truncate(ctx context.Context, in *TruncateRequest, opts ...grpc.CallOption) (*TruncateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TruncateResponse)
+	err := c.cc.Invoke(ctx, HandleService_Truncate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handleServiceClient) Write(ctx context.Context, in *WriteRequest, opts ...grpc.CallOption) (*WriteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WriteResponse)
+	err := c.cc.Invoke(ctx, HandleService_Write_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handleServiceClient) WriteAt(ctx context.Context, in *WriteAtRequest, opts ...grpc.CallOption) (*WriteAtResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WriteAtResponse)
+	err := c.cc.Invoke(ctx, HandleService_WriteAt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HandleServiceServer is the server API for HandleService service.
+// All implementations must embed UnimplementedHandleServiceServer
+// for forward compatibility.
+//
+// HandleService exposes stateful file handles.
+// A handle is allocated by Open and keeps its offset and
+// directory cursor on the server until it is closed.
+type HandleServiceServer interface {
+	Open(context.Context, *OpenRequest) (*OpenResponse, error)
+	Close(context.Context, *CloseRequest) (*CloseResponse, error)
+	Read(context.Context, *ReadRequest) (*ReadResponse, error)
+	ReadAt(context.Context, *ReadAtRequest) (*ReadAtResponse, error)
+	Readdir(context.Context, *ReaddirRequest) (*ReaddirResponse, error)
+	Readdirnames(context.Context, *ReaddirnamesRequest) (*ReaddirnamesResponse, error)
+	Seek(context.Context, *SeekRequest) (*SeekResponse, error)
+	Stat(context.Context, *StatRequest) (*StatResponse, error)
+	Sync(context.Context, *SyncRequest) (*SyncResponse, error)
+	Truncate(context.Context, *TruncateRequest) (*TruncateResponse, error)
+	Write(context.Context, *WriteRequest) (*WriteResponse, error)
+	WriteAt(context.Context, *WriteAtRequest) (*WriteAtResponse, error)
+	mustEmbedUnimplementedHandleServiceServer()
+}
+
+// UnimplementedHandleServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedHandleServiceServer struct{}
+
+func (UnimplementedHandleServiceServer) Open(context.Context, *OpenRequest) (*OpenResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Open not implemented")
+}
+func (UnimplementedHandleServiceServer) Close(context.Context, *CloseRequest) (*CloseResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Close not implemented")
+}
+func (UnimplementedHandleServiceServer) Read(context.Context, *ReadRequest) (*ReadResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Read not implemented")
+}
+func (UnimplementedHandleServiceServer) ReadAt(context.Context, *ReadAtRequest) (*ReadAtResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReadAt not implemented")
+}
+func (UnimplementedHandleServiceServer) Readdir(context.Context, *ReaddirRequest) (*ReaddirResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Readdir not implemented")
+}
+func (UnimplementedHandleServiceServer) Readdirnames(context.Context, *ReaddirnamesRequest) (*ReaddirnamesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Readdirnames not implemented")
+}
+func (UnimplementedHandleServiceServer) Seek(context.Context, *SeekRequest) (*SeekResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Seek not implemented")
+}
+func (UnimplementedHandleServiceServer) Stat(context.Context, *StatRequest) (*StatResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Stat not implemented")
+}
+func (UnimplementedHandleServiceServer) Sync(context.Context, *SyncRequest) (*SyncResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Sync not implemented")
+}
+func (UnimplementedHandleServiceServer) Truncate(context.Context, *TruncateRequest) (*TruncateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Truncate not implemented")
+}
+func (UnimplementedHandleServiceServer) Write(context.Context, *WriteRequest) (*WriteResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Write not implemented")
+}
+func (UnimplementedHandleServiceServer) WriteAt(context.Context, *WriteAtRequest) (*WriteAtResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method WriteAt not implemented")
+}
+func (UnimplementedHandleServiceServer) mustEmbedUnimplementedHandleServiceServer() {}
+func (UnimplementedHandleServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeHandleServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HandleServiceServer will
+// result in compilation errors.
+type UnsafeHandleServiceServer interface {
+	mustEmbedUnimplementedHandleServiceServer()
+}
+
+func RegisterHandleServiceServer(s grpc.ServiceRegistrar, srv HandleServiceServer) {
+	// If the following call panics, it indicates UnimplementedHandleServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&HandleService_ServiceDesc, srv)
+}
+
+func _HandleService_Open_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandleServiceServer).Open(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HandleService_Open_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandleServiceServer).Open(ctx, req.(*OpenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HandleService_Close_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandleServiceServer).Close(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HandleService_Close_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandleServiceServer).Close(ctx, req.(*CloseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HandleService_Read_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandleServiceServer).Read(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HandleService_Read_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandleServiceServer).Read(ctx, req.(*ReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HandleService_ReadAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandleServiceServer).ReadAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HandleService_ReadAt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandleServiceServer).ReadAt(ctx, req.(*ReadAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HandleService_Readdir_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReaddirRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandleServiceServer).Readdir(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HandleService_Readdir_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandleServiceServer).Readdir(ctx, req.(*ReaddirRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HandleService_Readdirnames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReaddirnamesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandleServiceServer).Readdirnames(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HandleService_Readdirnames_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandleServiceServer).Readdirnames(ctx, req.(*ReaddirnamesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HandleService_Seek_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SeekRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandleServiceServer).Seek(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HandleService_Seek_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandleServiceServer).Seek(ctx, req.(*SeekRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HandleService_Stat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandleServiceServer).Stat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HandleService_Stat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandleServiceServer).Stat(ctx, req.(*StatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HandleService_Sync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandleServiceServer).Sync(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HandleService_Sync_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandleServiceServer).Sync(ctx, req.(*SyncRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HandleService_Truncate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TruncateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandleServiceServer).Truncate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HandleService_Truncate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandleServiceServer).Truncate(ctx, req.(*TruncateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HandleService_Write_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandleServiceServer).Write(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HandleService_Write_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandleServiceServer).Write(ctx, req.(*WriteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HandleService_WriteAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandleServiceServer).WriteAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HandleService_WriteAt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandleServiceServer).WriteAt(ctx, req.(*WriteAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// HandleService_ServiceDesc is the grpc.ServiceDesc for HandleService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var HandleService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "aferox.protofs.ext.v1alpha1.HandleService",
+	HandlerType: (*HandleServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Open",
+			Handler:    _HandleService_Open_Handler,
+		},
+		{
+			MethodName: "Close",
+			Handler:    _HandleService_Close_Handler,
+		},
+		{
+			MethodName: "Read",
+			Handler:    _HandleService_Read_Handler,
+		},
+		{
+			MethodName: "ReadAt",
+			Handler:    _HandleService_ReadAt_Handler,
+		},
+		{
+			MethodName: "Readdir",
+			Handler:    _HandleService_Readdir_Handler,
+		},
+		{
+			MethodName: "Readdirnames",
+			Handler:    _HandleService_Readdirnames_Handler,
+		},
+		{
+			MethodName: "Seek",
+			Handler:    _HandleService_Seek_Handler,
+		},
+		{
+			MethodName: "Stat",
+			Handler:    _HandleService_Stat_Handler,
+		},
+		{
+			MethodName: "Sync",
+			Handler:    _HandleService_Sync_Handler,
+		},
+		{
+			MethodName: "Truncate",
+			Handler:    _HandleService_Truncate_Handler,
+		},
+		{
+			MethodName: "Write",
+			Handler:    _HandleService_Write_Handler,
+		},
+		{
+			MethodName: "WriteAt",
+			Handler:    _HandleService_WriteAt_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "aferox/protofs/ext/v1alpha1/handle.proto",
+}
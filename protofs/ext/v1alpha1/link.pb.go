@@ -0,0 +1,466 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: aferox/protofs/ext/v1alpha1/link.proto
+
+package extv1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Sys holds the ownership and identity of a file.
+// Servers pack it into the sys field of a FileInfo when available.
+type Sys struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           uint32                 `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Gid           uint32                 `protobuf:"varint,2,opt,name=gid,proto3" json:"gid,omitempty"`
+	Ino           uint64                 `protobuf:"varint,3,opt,name=ino,proto3" json:"ino,omitempty"`
+	Nlink         uint64                 `protobuf:"varint,4,opt,name=nlink,proto3" json:"nlink,omitempty"`
+	Dev           uint64                 `protobuf:"varint,5,opt,name=dev,proto3" json:"dev,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Sys) Reset() {
+	*x = Sys{}
+	mi := &file_aferox_protofs_ext_v1alpha1_link_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Sys) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sys) ProtoMessage() {}
+
+func (x *Sys) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_link_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sys.ProtoReflect.Descriptor instead.
+func (*Sys) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_link_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Sys) GetUid() uint32 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *Sys) GetGid() uint32 {
+	if x != nil {
+		return x.Gid
+	}
+	return 0
+}
+
+func (x *Sys) GetIno() uint64 {
+	if x != nil {
+		return x.Ino
+	}
+	return 0
+}
+
+func (x *Sys) GetNlink() uint64 {
+	if x != nil {
+		return x.Nlink
+	}
+	return 0
+}
+
+func (x *Sys) GetDev() uint64 {
+	if x != nil {
+		return x.Dev
+	}
+	return 0
+}
+
+type LstatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LstatRequest) Reset() {
+	*x = LstatRequest{}
+	mi := &file_aferox_protofs_ext_v1alpha1_link_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LstatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LstatRequest) ProtoMessage() {}
+
+func (x *LstatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_link_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LstatRequest.ProtoReflect.Descriptor instead.
+func (*LstatRequest) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_link_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LstatRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type LstatResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	FileInfo *FileInfo              `protobuf:"bytes,1,opt,name=file_info,json=fileInfo,proto3" json:"file_info,omitempty"`
+	// Lstat is set when the served filesystem performed an lstat
+	// rather than falling back to stat.
+	Lstat         bool `protobuf:"varint,2,opt,name=lstat,proto3" json:"lstat,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LstatResponse) Reset() {
+	*x = LstatResponse{}
+	mi := &file_aferox_protofs_ext_v1alpha1_link_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LstatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LstatResponse) ProtoMessage() {}
+
+func (x *LstatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_link_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LstatResponse.ProtoReflect.Descriptor instead.
+func (*LstatResponse) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_link_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LstatResponse) GetFileInfo() *FileInfo {
+	if x != nil {
+		return x.FileInfo
+	}
+	return nil
+}
+
+func (x *LstatResponse) GetLstat() bool {
+	if x != nil {
+		return x.Lstat
+	}
+	return false
+}
+
+type SymlinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Oldname       string                 `protobuf:"bytes,1,opt,name=oldname,proto3" json:"oldname,omitempty"`
+	Newname       string                 `protobuf:"bytes,2,opt,name=newname,proto3" json:"newname,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SymlinkRequest) Reset() {
+	*x = SymlinkRequest{}
+	mi := &file_aferox_protofs_ext_v1alpha1_link_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SymlinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SymlinkRequest) ProtoMessage() {}
+
+func (x *SymlinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_link_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SymlinkRequest.ProtoReflect.Descriptor instead.
+func (*SymlinkRequest) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_link_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SymlinkRequest) GetOldname() string {
+	if x != nil {
+		return x.Oldname
+	}
+	return ""
+}
+
+func (x *SymlinkRequest) GetNewname() string {
+	if x != nil {
+		return x.Newname
+	}
+	return ""
+}
+
+type SymlinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SymlinkResponse) Reset() {
+	*x = SymlinkResponse{}
+	mi := &file_aferox_protofs_ext_v1alpha1_link_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SymlinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SymlinkResponse) ProtoMessage() {}
+
+func (x *SymlinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_link_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SymlinkResponse.ProtoReflect.Descriptor instead.
+func (*SymlinkResponse) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_link_proto_rawDescGZIP(), []int{4}
+}
+
+type ReadlinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadlinkRequest) Reset() {
+	*x = ReadlinkRequest{}
+	mi := &file_aferox_protofs_ext_v1alpha1_link_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadlinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadlinkRequest) ProtoMessage() {}
+
+func (x *ReadlinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_link_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadlinkRequest.ProtoReflect.Descriptor instead.
+func (*ReadlinkRequest) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_link_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReadlinkRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ReadlinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Target        string                 `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadlinkResponse) Reset() {
+	*x = ReadlinkResponse{}
+	mi := &file_aferox_protofs_ext_v1alpha1_link_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadlinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadlinkResponse) ProtoMessage() {}
+
+func (x *ReadlinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_link_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadlinkResponse.ProtoReflect.Descriptor instead.
+func (*ReadlinkResponse) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_link_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReadlinkResponse) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+var File_aferox_protofs_ext_v1alpha1_link_proto protoreflect.FileDescriptor
+
+const file_aferox_protofs_ext_v1alpha1_link_proto_rawDesc = "" +
+	"\n" +
+	"&aferox/protofs/ext/v1alpha1/link.proto\x12\x1baferox.protofs.ext.v1alpha1\x1a(aferox/protofs/ext/v1alpha1/handle.proto\"c\n" +
+	"\x03Sys\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\rR\x03uid\x12\x10\n" +
+	"\x03gid\x18\x02 \x01(\rR\x03gid\x12\x10\n" +
+	"\x03ino\x18\x03 \x01(\x04R\x03ino\x12\x14\n" +
+	"\x05nlink\x18\x04 \x01(\x04R\x05nlink\x12\x10\n" +
+	"\x03dev\x18\x05 \x01(\x04R\x03dev\"\"\n" +
+	"\fLstatRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"i\n" +
+	"\rLstatResponse\x12B\n" +
+	"\tfile_info\x18\x01 \x01(\v2%.aferox.protofs.ext.v1alpha1.FileInfoR\bfileInfo\x12\x14\n" +
+	"\x05lstat\x18\x02 \x01(\bR\x05lstat\"D\n" +
+	"\x0eSymlinkRequest\x12\x18\n" +
+	"\aoldname\x18\x01 \x01(\tR\aoldname\x12\x18\n" +
+	"\anewname\x18\x02 \x01(\tR\anewname\"\x11\n" +
+	"\x0fSymlinkResponse\"%\n" +
+	"\x0fReadlinkRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"*\n" +
+	"\x10ReadlinkResponse\x12\x16\n" +
+	"\x06target\x18\x01 \x01(\tR\x06target2\xbc\x02\n" +
+	"\vLinkService\x12^\n" +
+	"\x05Lstat\x12).aferox.protofs.ext.v1alpha1.LstatRequest\x1a*.aferox.protofs.ext.v1alpha1.LstatResponse\x12d\n" +
+	"\aSymlink\x12+.aferox.protofs.ext.v1alpha1.SymlinkRequest\x1a,.aferox.protofs.ext.v1alpha1.SymlinkResponse\x12g\n" +
+	"\bReadlink\x12,.aferox.protofs.ext.v1alpha1.ReadlinkRequest\x1a-.aferox.protofs.ext.v1alpha1.ReadlinkResponseB<Z:github.com/unmango/aferox/protofs/ext/v1alpha1;extv1alpha1b\x06proto3"
+
+var (
+	file_aferox_protofs_ext_v1alpha1_link_proto_rawDescOnce sync.Once
+	file_aferox_protofs_ext_v1alpha1_link_proto_rawDescData []byte
+)
+
+func file_aferox_protofs_ext_v1alpha1_link_proto_rawDescGZIP() []byte {
+	file_aferox_protofs_ext_v1alpha1_link_proto_rawDescOnce.Do(func() {
+		file_aferox_protofs_ext_v1alpha1_link_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_aferox_protofs_ext_v1alpha1_link_proto_rawDesc), len(file_aferox_protofs_ext_v1alpha1_link_proto_rawDesc)))
+	})
+	return file_aferox_protofs_ext_v1alpha1_link_proto_rawDescData
+}
+
+var file_aferox_protofs_ext_v1alpha1_link_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_aferox_protofs_ext_v1alpha1_link_proto_goTypes = []any{
+	(*Sys)(nil),              // 0: aferox.protofs.ext.v1alpha1.Sys
+	(*LstatRequest)(nil),     // 1: aferox.protofs.ext.v1alpha1.LstatRequest
+	(*LstatResponse)(nil),    // 2: aferox.protofs.ext.v1alpha1.LstatResponse
+	(*SymlinkRequest)(nil),   // 3: aferox.protofs.ext.v1alpha1.SymlinkRequest
+	(*SymlinkResponse)(nil),  // 4: aferox.protofs.ext.v1alpha1.SymlinkResponse
+	(*ReadlinkRequest)(nil),  // 5: aferox.protofs.ext.v1alpha1.ReadlinkRequest
+	(*ReadlinkResponse)(nil), // 6: aferox.protofs.ext.v1alpha1.ReadlinkResponse
+	(*FileInfo)(nil),         // 7: aferox.protofs.ext.v1alpha1.FileInfo
+}
+var file_aferox_protofs_ext_v1alpha1_link_proto_depIdxs = []int32{
+	7, // 0: aferox.protofs.ext.v1alpha1.LstatResponse.file_info:type_name -> aferox.protofs.ext.v1alpha1.FileInfo
+	1, // 1: aferox.protofs.ext.v1alpha1.LinkService.Lstat:input_type -> aferox.protofs.ext.v1alpha1.LstatRequest
+	3, // 2: aferox.protofs.ext.v1alpha1.LinkService.Symlink:input_type -> aferox.protofs.ext.v1alpha1.SymlinkRequest
+	5, // 3: aferox.protofs.ext.v1alpha1.LinkService.Readlink:input_type -> aferox.protofs.ext.v1alpha1.ReadlinkRequest
+	2, // 4: aferox.protofs.ext.v1alpha1.LinkService.Lstat:output_type -> aferox.protofs.ext.v1alpha1.LstatResponse
+	4, // 5: aferox.protofs.ext.v1alpha1.LinkService.Symlink:output_type -> aferox.protofs.ext.v1alpha1.SymlinkResponse
+	6, // 6: aferox.protofs.ext.v1alpha1.LinkService.Readlink:output_type -> aferox.protofs.ext.v1alpha1.ReadlinkResponse
+	4, // [4:7] is the sub-list for method output_type
+	1, // [1:4] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_aferox_protofs_ext_v1alpha1_link_proto_init() }
+func file_aferox_protofs_ext_v1alpha1_link_proto_init() {
+	if File_aferox_protofs_ext_v1alpha1_link_proto != nil {
+		return
+	}
+	file_aferox_protofs_ext_v1alpha1_handle_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_aferox_protofs_ext_v1alpha1_link_proto_rawDesc), len(file_aferox_protofs_ext_v1alpha1_link_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_aferox_protofs_ext_v1alpha1_link_proto_goTypes,
+		DependencyIndexes: file_aferox_protofs_ext_v1alpha1_link_proto_depIdxs,
+		MessageInfos:      file_aferox_protofs_ext_v1alpha1_link_proto_msgTypes,
+	}.Build()
+	File_aferox_protofs_ext_v1alpha1_link_proto = out.File
+	file_aferox_protofs_ext_v1alpha1_link_proto_goTypes = nil
+	file_aferox_protofs_ext_v1alpha1_link_proto_depIdxs = nil
+}
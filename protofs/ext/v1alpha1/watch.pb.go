@@ -0,0 +1,267 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: aferox/protofs/ext/v1alpha1/watch.proto
+
+package extv1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type WatchOp int32
+
+const (
+	WatchOp_WATCH_OP_UNSPECIFIED WatchOp = 0
+	WatchOp_WATCH_OP_CREATE      WatchOp = 1
+	WatchOp_WATCH_OP_REMOVE      WatchOp = 2
+	WatchOp_WATCH_OP_RENAME      WatchOp = 3
+	WatchOp_WATCH_OP_CHMOD       WatchOp = 4
+)
+
+// Enum value maps for WatchOp.
+var (
+	WatchOp_name = map[int32]string{
+		0: "WATCH_OP_UNSPECIFIED",
+		1: "WATCH_OP_CREATE",
+		2: "WATCH_OP_REMOVE",
+		3: "WATCH_OP_RENAME",
+		4: "WATCH_OP_CHMOD",
+	}
+	WatchOp_value = map[string]int32{
+		"WATCH_OP_UNSPECIFIED": 0,
+		"WATCH_OP_CREATE":      1,
+		"WATCH_OP_REMOVE":      2,
+		"WATCH_OP_RENAME":      3,
+		"WATCH_OP_CHMOD":       4,
+	}
+)
+
+func (x WatchOp) Enum() *WatchOp {
+	p := new(WatchOp)
+	*p = x
+	return p
+}
+
+func (x WatchOp) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WatchOp) Descriptor() protoreflect.EnumDescriptor {
+	return file_aferox_protofs_ext_v1alpha1_watch_proto_enumTypes[0].Descriptor()
+}
+
+func (WatchOp) Type() protoreflect.EnumType {
+	return &file_aferox_protofs_ext_v1alpha1_watch_proto_enumTypes[0]
+}
+
+func (x WatchOp) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WatchOp.Descriptor instead.
+func (WatchOp) EnumDescriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_watch_proto_rawDescGZIP(), []int{0}
+}
+
+type WatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_aferox_protofs_ext_v1alpha1_watch_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_watch_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_watch_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WatchRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type WatchResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Op    WatchOp                `protobuf:"varint,1,opt,name=op,proto3,enum=aferox.protofs.ext.v1alpha1.WatchOp" json:"op,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// NewName is set for WATCH_OP_RENAME when known.
+	NewName string `protobuf:"bytes,3,opt,name=new_name,json=newName,proto3" json:"new_name,omitempty"`
+	// Mode holds the bits of a Go fs.FileMode for WATCH_OP_CHMOD.
+	Mode          uint32 `protobuf:"varint,4,opt,name=mode,proto3" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchResponse) Reset() {
+	*x = WatchResponse{}
+	mi := &file_aferox_protofs_ext_v1alpha1_watch_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchResponse) ProtoMessage() {}
+
+func (x *WatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aferox_protofs_ext_v1alpha1_watch_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchResponse.ProtoReflect.Descriptor instead.
+func (*WatchResponse) Descriptor() ([]byte, []int) {
+	return file_aferox_protofs_ext_v1alpha1_watch_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WatchResponse) GetOp() WatchOp {
+	if x != nil {
+		return x.Op
+	}
+	return WatchOp_WATCH_OP_UNSPECIFIED
+}
+
+func (x *WatchResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WatchResponse) GetNewName() string {
+	if x != nil {
+		return x.NewName
+	}
+	return ""
+}
+
+func (x *WatchResponse) GetMode() uint32 {
+	if x != nil {
+		return x.Mode
+	}
+	return 0
+}
+
+var File_aferox_protofs_ext_v1alpha1_watch_proto protoreflect.FileDescriptor
+
+const file_aferox_protofs_ext_v1alpha1_watch_proto_rawDesc = "" +
+	"\n" +
+	"'aferox/protofs/ext/v1alpha1/watch.proto\x12\x1baferox.protofs.ext.v1alpha1\"\"\n" +
+	"\fWatchRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x88\x01\n" +
+	"\rWatchResponse\x124\n" +
+	"\x02op\x18\x01 \x01(\x0e2$.aferox.protofs.ext.v1alpha1.WatchOpR\x02op\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x19\n" +
+	"\bnew_name\x18\x03 \x01(\tR\anewName\x12\x12\n" +
+	"\x04mode\x18\x04 \x01(\rR\x04mode*v\n" +
+	"\aWatchOp\x12\x18\n" +
+	"\x14WATCH_OP_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fWATCH_OP_CREATE\x10\x01\x12\x13\n" +
+	"\x0fWATCH_OP_REMOVE\x10\x02\x12\x13\n" +
+	"\x0fWATCH_OP_RENAME\x10\x03\x12\x12\n" +
+	"\x0eWATCH_OP_CHMOD\x10\x042p\n" +
+	"\fWatchService\x12`\n" +
+	"\x05Watch\x12).aferox.protofs.ext.v1alpha1.WatchRequest\x1a*.aferox.protofs.ext.v1alpha1.WatchResponse0\x01B<Z:github.com/unmango/aferox/protofs/ext/v1alpha1;extv1alpha1b\x06proto3"
+
+var (
+	file_aferox_protofs_ext_v1alpha1_watch_proto_rawDescOnce sync.Once
+	file_aferox_protofs_ext_v1alpha1_watch_proto_rawDescData []byte
+)
+
+func file_aferox_protofs_ext_v1alpha1_watch_proto_rawDescGZIP() []byte {
+	file_aferox_protofs_ext_v1alpha1_watch_proto_rawDescOnce.Do(func() {
+		file_aferox_protofs_ext_v1alpha1_watch_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_aferox_protofs_ext_v1alpha1_watch_proto_rawDesc), len(file_aferox_protofs_ext_v1alpha1_watch_proto_rawDesc)))
+	})
+	return file_aferox_protofs_ext_v1alpha1_watch_proto_rawDescData
+}
+
+var file_aferox_protofs_ext_v1alpha1_watch_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_aferox_protofs_ext_v1alpha1_watch_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_aferox_protofs_ext_v1alpha1_watch_proto_goTypes = []any{
+	(WatchOp)(0),          // 0: aferox.protofs.ext.v1alpha1.WatchOp
+	(*WatchRequest)(nil),  // 1: aferox.protofs.ext.v1alpha1.WatchRequest
+	(*WatchResponse)(nil), // 2: aferox.protofs.ext.v1alpha1.WatchResponse
+}
+var file_aferox_protofs_ext_v1alpha1_watch_proto_depIdxs = []int32{
+	0, // 0: aferox.protofs.ext.v1alpha1.WatchResponse.op:type_name -> aferox.protofs.ext.v1alpha1.WatchOp
+	1, // 1: aferox.protofs.ext.v1alpha1.WatchService.Watch:input_type -> aferox.protofs.ext.v1alpha1.WatchRequest
+	2, // 2: aferox.protofs.ext.v1alpha1.WatchService.Watch:output_type -> aferox.protofs.ext.v1alpha1.WatchResponse
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_aferox_protofs_ext_v1alpha1_watch_proto_init() }
+func file_aferox_protofs_ext_v1alpha1_watch_proto_init() {
+	if File_aferox_protofs_ext_v1alpha1_watch_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_aferox_protofs_ext_v1alpha1_watch_proto_rawDesc), len(file_aferox_protofs_ext_v1alpha1_watch_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_aferox_protofs_ext_v1alpha1_watch_proto_goTypes,
+		DependencyIndexes: file_aferox_protofs_ext_v1alpha1_watch_proto_depIdxs,
+		EnumInfos:         file_aferox_protofs_ext_v1alpha1_watch_proto_enumTypes,
+		MessageInfos:      file_aferox_protofs_ext_v1alpha1_watch_proto_msgTypes,
+	}.Build()
+	File_aferox_protofs_ext_v1alpha1_watch_proto = out.File
+	file_aferox_protofs_ext_v1alpha1_watch_proto_goTypes = nil
+	file_aferox_protofs_ext_v1alpha1_watch_proto_depIdxs = nil
+}
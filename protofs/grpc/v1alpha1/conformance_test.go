@@ -0,0 +1,39 @@
+package protofsv1alpha1_test
+
+import (
+	"fmt"
+	"net"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+
+	"github.com/spf13/afero"
+	protofsv1alpha1 "github.com/unmango/aferox/protofs/grpc/v1alpha1"
+	"github.com/unmango/aferox/testing/conformance"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var _ = conformance.Describe("Conformance", func(seed afero.Fs) (afero.Fs, error) {
+	server := grpc.NewServer()
+	protofsv1alpha1.RegisterFsServer(server, seed)
+	protofsv1alpha1.RegisterFileServer(server, seed)
+
+	sock := filepath.Join(GinkgoT().TempDir(), "fs.sock")
+	lis, err := net.Listen("unix", sock)
+	if err != nil {
+		return nil, err
+	}
+
+	go server.Serve(lis)
+	DeferCleanup(server.GracefulStop)
+
+	conn, err := grpc.NewClient(fmt.Sprint("unix://", sock),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return protofsv1alpha1.NewFs(conn), nil
+}, conformance.Without(conformance.NoSymlink))
@@ -7,6 +7,8 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"testing/fstest"
+	"testing/iotest"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -81,4 +83,87 @@ var _ = Describe("E2e", func() {
 		Expect(err).NotTo(HaveOccurred())
 		Expect(string(data)).To(Equal("testing text"))
 	})
+
+	It("should read a file in chunks", func() {
+		err := afero.WriteFile(fs, "test.txt", []byte("testing text"), os.ModePerm)
+		Expect(err).NotTo(HaveOccurred())
+
+		file, err := client.Open("test.txt")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(file.Close)
+
+		Expect(iotest.TestReader(file, []byte("testing text"))).To(Succeed())
+	})
+
+	It("should seek within a file", func() {
+		err := afero.WriteFile(fs, "test.txt", []byte("testing text"), os.ModePerm)
+		Expect(err).NotTo(HaveOccurred())
+		file, err := client.Open("test.txt")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(file.Close)
+
+		offset, err := file.Seek(8, io.SeekStart)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(offset).To(Equal(int64(8)))
+
+		data, err := io.ReadAll(file)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("text"))
+	})
+
+	It("should read directories with a cursor", func() {
+		Expect(fs.MkdirAll("dir", os.ModePerm)).To(Succeed())
+		for _, name := range []string{"a", "b", "c"} {
+			err := afero.WriteFile(fs, filepath.Join("dir", name), nil, os.ModePerm)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		dir, err := client.Open("dir")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(dir.Close)
+
+		first, err := dir.Readdir(2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(first).To(HaveLen(2))
+
+		rest, err := dir.Readdirnames(2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rest).To(HaveLen(1))
+
+		_, err = dir.Readdir(1)
+		Expect(err).To(MatchError(io.EOF))
+	})
+
+	It("should fail on a closed file", func() {
+		file, err := client.Create("test.txt")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(file.Close()).To(Succeed())
+
+		_, err = file.Write([]byte("testing"))
+		Expect(err).To(MatchError(os.ErrClosed))
+		Expect(file.Close()).To(MatchError(os.ErrClosed))
+	})
+
+	It("should work with the afero helpers", func() {
+		Expect(afero.WriteFile(client, "test.txt", []byte("testing"), os.ModePerm)).To(Succeed())
+
+		data, err := afero.ReadFile(client, "test.txt")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("testing"))
+
+		Expect(client.MkdirAll("dir/sub", os.ModePerm)).To(Succeed())
+		Expect(afero.WriteFile(client, "dir/sub/file.txt", []byte("nested"), os.ModePerm)).To(Succeed())
+
+		infos, err := afero.ReadDir(client, "dir")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(infos).To(HaveLen(1))
+		Expect(infos[0].IsDir()).To(BeTrue())
+	})
+
+	It("should pass the io/fs conformance tests", func() {
+		Expect(fs.MkdirAll("dir/sub", os.ModePerm)).To(Succeed())
+		Expect(afero.WriteFile(fs, "dir/a.txt", []byte("a"), os.ModePerm)).To(Succeed())
+		Expect(afero.WriteFile(fs, "dir/sub/b.txt", []byte("bb"), os.ModePerm)).To(Succeed())
+
+		Expect(fstest.TestFS(afero.NewIOFS(client), "dir/a.txt", "dir/sub/b.txt")).To(Succeed())
+	})
 })
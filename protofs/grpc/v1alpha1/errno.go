@@ -0,0 +1,51 @@
+package protofsv1alpha1
+
+import "syscall"
+
+// errnos maps the portable names of errno values sent over the wire to the
+// values of the local operating system, which differ between platforms.
+var errnos = map[string]syscall.Errno{
+	"E2BIG":        syscall.E2BIG,
+	"EACCES":       syscall.EACCES,
+	"EAGAIN":       syscall.EAGAIN,
+	"EBADF":        syscall.EBADF,
+	"EBUSY":        syscall.EBUSY,
+	"EEXIST":       syscall.EEXIST,
+	"EFBIG":        syscall.EFBIG,
+	"EINTR":        syscall.EINTR,
+	"EINVAL":       syscall.EINVAL,
+	"EIO":          syscall.EIO,
+	"EISDIR":       syscall.EISDIR,
+	"ELOOP":        syscall.ELOOP,
+	"EMFILE":       syscall.EMFILE,
+	"EMLINK":       syscall.EMLINK,
+	"ENAMETOOLONG": syscall.ENAMETOOLONG,
+	"ENFILE":       syscall.ENFILE,
+	"ENODEV":       syscall.ENODEV,
+	"ENOENT":       syscall.ENOENT,
+	"ENOSPC":       syscall.ENOSPC,
+	"ENOSYS":       syscall.ENOSYS,
+	"ENOTDIR":      syscall.ENOTDIR,
+	"ENOTEMPTY":    syscall.ENOTEMPTY,
+	"ENXIO":        syscall.ENXIO,
+	"EPERM":        syscall.EPERM,
+	"EPIPE":        syscall.EPIPE,
+	"ERANGE":       syscall.ERANGE,
+	"EROFS":        syscall.EROFS,
+	"ESPIPE":       syscall.ESPIPE,
+	"ETIMEDOUT":    syscall.ETIMEDOUT,
+	"ETXTBSY":      syscall.ETXTBSY,
+	"EXDEV":        syscall.EXDEV,
+}
+
+// errnoName returns the portable name of errno, or an empty
+// string when it has none.
+func errnoName(errno syscall.Errno) string {
+	for name, e := range errnos {
+		if e == errno {
+			return name
+		}
+	}
+
+	return ""
+}
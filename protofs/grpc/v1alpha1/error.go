@@ -0,0 +1,217 @@
+package protofsv1alpha1
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"syscall"
+
+	"github.com/spf13/afero"
+	extv1alpha1 "github.com/unmango/aferox/protofs/ext/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ToStatus converts err to a gRPC status. The status code is chosen from
+// the [fs] sentinel errors and [syscall.Errno] wrapped by err, and the
+// details of the status describe err with an [extv1alpha1.Error].
+// Errno values are sent by name, since they differ between operating systems.
+// Errors that already carry a gRPC status are returned unchanged.
+// ToStatus returns nil when err is nil.
+func ToStatus(err error) *status.Status {
+	if err == nil {
+		return nil
+	}
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err)
+	}
+
+	detail := &extv1alpha1.Error{
+		Message: err.Error(),
+		Kind:    errorKind(err),
+	}
+
+	var pathErr *fs.PathError
+	var linkErr *os.LinkError
+	if errors.As(err, &pathErr) {
+		detail.Op = pathErr.Op
+		detail.Path = pathErr.Path
+		detail.Message = pathErr.Err.Error()
+	} else if errors.As(err, &linkErr) {
+		detail.Op = linkErr.Op
+		detail.Path = linkErr.Old
+		detail.NewPath = linkErr.New
+		detail.Message = linkErr.Err.Error()
+	}
+
+	var errno syscall.Errno
+	if errors.As(err, &errno) {
+		detail.ErrnoName = errnoName(errno)
+	}
+
+	st := status.New(errorCode(err), err.Error())
+	if withDetails, err := st.WithDetails(detail); err == nil {
+		return withDetails
+	} else {
+		return st
+	}
+}
+
+// FromStatus converts the gRPC status error err back to the error it describes.
+// Op and path are used when the details of the status do not name the operation,
+// and when the status has no [extv1alpha1.Error] details the returned
+// [fs.PathError] wraps the [fs] sentinel matching the status code.
+// When the underlying error is an [fs] sentinel or [syscall.Errno] with the
+// original message, it is used as is so helpers like [os.IsNotExist] behave
+// as they do locally. Otherwise the returned error retains the status,
+// so [status.Code] continues to work.
+// FromStatus returns err unchanged if it is not a gRPC status error.
+func FromStatus(op, path string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	for _, d := range st.Details() {
+		if detail, ok := d.(*extv1alpha1.Error); ok {
+			return fromDetail(op, path, st, detail)
+		}
+	}
+
+	return &fs.PathError{
+		Op:   op,
+		Path: path,
+		Err:  &remoteError{st.Message(), codeError(st.Code(), err), st},
+	}
+}
+
+// remoteError is the underlying error of a status returned by a server.
+// It keeps the message of the original error and wraps a local equivalent.
+type remoteError struct {
+	msg    string
+	err    error
+	status *status.Status
+}
+
+func (e *remoteError) Error() string {
+	return e.msg
+}
+
+func (e *remoteError) Unwrap() error {
+	return e.err
+}
+
+func (e *remoteError) GRPCStatus() *status.Status {
+	return e.status
+}
+
+func fromDetail(op, path string, st *status.Status, detail *extv1alpha1.Error) error {
+	err := detailError(detail)
+	if err == nil || err.Error() != detail.Message {
+		err = &remoteError{detail.Message, err, st}
+	}
+	if detail.NewPath != "" {
+		return &os.LinkError{
+			Op:  detail.Op,
+			Old: detail.Path,
+			New: detail.NewPath,
+			Err: err,
+		}
+	}
+	if detail.Op != "" {
+		op, path = detail.Op, detail.Path
+	}
+
+	return &fs.PathError{Op: op, Path: path, Err: err}
+}
+
+func detailError(detail *extv1alpha1.Error) error {
+	if errno, ok := errnos[detail.ErrnoName]; ok {
+		return errno
+	}
+
+	switch detail.Kind {
+	case extv1alpha1.ErrorKind_ERROR_KIND_NOT_EXIST:
+		return fs.ErrNotExist
+	case extv1alpha1.ErrorKind_ERROR_KIND_EXIST:
+		return fs.ErrExist
+	case extv1alpha1.ErrorKind_ERROR_KIND_PERMISSION:
+		return fs.ErrPermission
+	case extv1alpha1.ErrorKind_ERROR_KIND_INVALID:
+		return fs.ErrInvalid
+	case extv1alpha1.ErrorKind_ERROR_KIND_CLOSED:
+		return fs.ErrClosed
+	default:
+		return nil
+	}
+}
+
+func codeError(code codes.Code, err error) error {
+	switch code {
+	case codes.NotFound:
+		return fs.ErrNotExist
+	case codes.AlreadyExists:
+		return fs.ErrExist
+	case codes.PermissionDenied:
+		return fs.ErrPermission
+	case codes.InvalidArgument:
+		return fs.ErrInvalid
+	case codes.Canceled:
+		return context.Canceled
+	case codes.DeadlineExceeded:
+		return context.DeadlineExceeded
+	default:
+		return err
+	}
+}
+
+func errorCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, syscall.ENOTEMPTY),
+		errors.Is(err, syscall.ENOTDIR),
+		errors.Is(err, syscall.EISDIR),
+		errors.Is(err, fs.ErrClosed):
+		return codes.FailedPrecondition
+	case errors.Is(err, afero.ErrNoSymlink), errors.Is(err, afero.ErrNoReadlink):
+		return codes.Unimplemented
+	case errors.Is(err, syscall.EROFS):
+		return codes.PermissionDenied
+	case errors.Is(err, syscall.ENOSPC):
+		return codes.ResourceExhausted
+	case errors.Is(err, fs.ErrNotExist):
+		return codes.NotFound
+	case errors.Is(err, fs.ErrExist):
+		return codes.AlreadyExists
+	case errors.Is(err, fs.ErrPermission):
+		return codes.PermissionDenied
+	case errors.Is(err, fs.ErrInvalid), errors.Is(err, syscall.EINVAL):
+		return codes.InvalidArgument
+	default:
+		return codes.Unknown
+	}
+}
+
+func errorKind(err error) extv1alpha1.ErrorKind {
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return extv1alpha1.ErrorKind_ERROR_KIND_NOT_EXIST
+	case errors.Is(err, fs.ErrExist):
+		return extv1alpha1.ErrorKind_ERROR_KIND_EXIST
+	case errors.Is(err, fs.ErrPermission):
+		return extv1alpha1.ErrorKind_ERROR_KIND_PERMISSION
+	case errors.Is(err, fs.ErrInvalid):
+		return extv1alpha1.ErrorKind_ERROR_KIND_INVALID
+	case errors.Is(err, fs.ErrClosed):
+		return extv1alpha1.ErrorKind_ERROR_KIND_CLOSED
+	default:
+		return extv1alpha1.ErrorKind_ERROR_KIND_UNSPECIFIED
+	}
+}
@@ -0,0 +1,219 @@
+package protofsv1alpha1_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	extv1alpha1 "github.com/unmango/aferox/protofs/ext/v1alpha1"
+	protofsv1alpha1 "github.com/unmango/aferox/protofs/grpc/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Describe("Error", func() {
+	DescribeTable("ToStatus",
+		func(err error, code codes.Code) {
+			Expect(protofsv1alpha1.ToStatus(err).Code()).To(Equal(code))
+		},
+		Entry(nil, fs.ErrNotExist, codes.NotFound),
+		Entry(nil, fs.ErrExist, codes.AlreadyExists),
+		Entry(nil, fs.ErrPermission, codes.PermissionDenied),
+		Entry(nil, fs.ErrInvalid, codes.InvalidArgument),
+		Entry(nil, fs.ErrClosed, codes.FailedPrecondition),
+		Entry(nil, syscall.ENOENT, codes.NotFound),
+		Entry(nil, syscall.ENOTEMPTY, codes.FailedPrecondition),
+		Entry(nil, syscall.EROFS, codes.PermissionDenied),
+		Entry(nil, &fs.PathError{Op: "open", Path: "test.txt", Err: fs.ErrNotExist}, codes.NotFound),
+		Entry(nil, context.Canceled, codes.Canceled),
+		Entry(nil, context.DeadlineExceeded, codes.DeadlineExceeded),
+		Entry(nil, errors.New("test"), codes.Unknown),
+	)
+
+	It("should keep existing statuses", func() {
+		err := status.Error(codes.Unavailable, "test")
+
+		Expect(protofsv1alpha1.ToStatus(err).Code()).To(Equal(codes.Unavailable))
+	})
+
+	It("should return nil for nil errors", func() {
+		Expect(protofsv1alpha1.ToStatus(nil).Err()).To(Succeed())
+		Expect(protofsv1alpha1.FromStatus("open", "test.txt", nil)).To(Succeed())
+	})
+
+	It("should round trip a path error", func() {
+		err := &fs.PathError{Op: "open", Path: "test.txt", Err: syscall.ENOENT}
+
+		actual := protofsv1alpha1.FromStatus("stat", "other.txt", protofsv1alpha1.ToStatus(err).Err())
+
+		var pathErr *fs.PathError
+		Expect(errors.As(actual, &pathErr)).To(BeTrue())
+		Expect(pathErr.Op).To(Equal("open"))
+		Expect(pathErr.Path).To(Equal("test.txt"))
+		Expect(actual).To(MatchError(syscall.ENOENT))
+		Expect(actual.Error()).To(Equal(err.Error()))
+		Expect(os.IsNotExist(actual)).To(BeTrue())
+	})
+
+	It("should send errno values by name", func() {
+		st := protofsv1alpha1.ToStatus(&fs.PathError{Op: "remove", Path: "dir", Err: syscall.ENOTEMPTY})
+
+		Expect(st.Details()).To(ContainElement(
+			HaveField("ErrnoName", "ENOTEMPTY"),
+		))
+	})
+
+	It("should map errno names to local values", func() {
+		st, err := status.New(codes.FailedPrecondition, "remove dir: directory not empty").WithDetails(&extv1alpha1.Error{
+			Op:        "remove",
+			Path:      "dir",
+			Message:   syscall.ENOTEMPTY.Error(),
+			ErrnoName: "ENOTEMPTY",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		actual := protofsv1alpha1.FromStatus("remove", "dir", st.Err())
+
+		Expect(actual).To(MatchError(syscall.ENOTEMPTY))
+	})
+
+	It("should retain the status when the message differs", func() {
+		err := fmt.Errorf("custom: %w", fs.ErrPermission)
+
+		actual := protofsv1alpha1.FromStatus("open", "test.txt", protofsv1alpha1.ToStatus(err).Err())
+
+		Expect(actual).To(MatchError("open test.txt: custom: permission denied"))
+		Expect(actual).To(MatchError(fs.ErrPermission))
+		Expect(status.Code(actual)).To(Equal(codes.PermissionDenied))
+	})
+
+	It("should round trip a link error", func() {
+		err := &os.LinkError{Op: "rename", Old: "a.txt", New: "b.txt", Err: fs.ErrNotExist}
+
+		actual := protofsv1alpha1.FromStatus("rename", "a.txt", protofsv1alpha1.ToStatus(err).Err())
+
+		var linkErr *os.LinkError
+		Expect(errors.As(actual, &linkErr)).To(BeTrue())
+		Expect(linkErr.New).To(Equal("b.txt"))
+		Expect(actual).To(MatchError(fs.ErrNotExist))
+		Expect(actual.Error()).To(Equal(err.Error()))
+	})
+
+	It("should use the given op and path for statuses without details", func() {
+		err := status.Error(codes.NotFound, "not found")
+
+		actual := protofsv1alpha1.FromStatus("open", "test.txt", err)
+
+		Expect(actual).To(MatchError(fs.ErrNotExist))
+		Expect(actual).To(MatchError("open test.txt: not found"))
+	})
+
+	It("should map context statuses to context errors", func() {
+		err := status.Error(codes.Canceled, "canceled")
+
+		actual := protofsv1alpha1.FromStatus("open", "test.txt", err)
+
+		Expect(actual).To(MatchError(context.Canceled))
+		Expect(status.Code(actual)).To(Equal(codes.Canceled))
+	})
+
+	It("should return other errors unchanged", func() {
+		err := errors.New("test")
+
+		Expect(protofsv1alpha1.FromStatus("open", "test.txt", err)).To(BeIdenticalTo(err))
+	})
+
+	Describe("E2E", func() {
+		var (
+			local  afero.Fs
+			client afero.Fs
+		)
+
+		BeforeEach(func() {
+			local = afero.NewMemMapFs()
+		})
+
+		JustBeforeEach(func() {
+			server := grpc.NewServer()
+			protofsv1alpha1.RegisterFsServer(server, local)
+			protofsv1alpha1.RegisterFileServer(server, local)
+
+			sock := filepath.Join(GinkgoT().TempDir(), "fs.sock")
+			lis, err := net.Listen("unix", sock)
+			Expect(err).NotTo(HaveOccurred())
+
+			go server.Serve(lis)
+			DeferCleanup(server.Stop)
+
+			conn, err := grpc.NewClient(fmt.Sprint("unix://", sock),
+				grpc.WithTransportCredentials(insecure.NewCredentials()),
+			)
+			Expect(err).NotTo(HaveOccurred())
+			client = protofsv1alpha1.NewFs(conn)
+		})
+
+		It("should return the same error as the served Fs for missing files", func() {
+			_, expected := local.Stat("missing.txt")
+
+			_, err := client.Stat("missing.txt")
+
+			Expect(err).To(MatchError(fs.ErrNotExist))
+			Expect(err).To(MatchError(expected.Error()))
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+
+		It("should return an fs.PathError when opening missing files", func() {
+			_, err := client.Open("missing.txt")
+
+			var pathErr *fs.PathError
+			Expect(errors.As(err, &pathErr)).To(BeTrue())
+			Expect(pathErr.Path).To(Equal("missing.txt"))
+			Expect(err).To(MatchError(fs.ErrNotExist))
+		})
+
+		It("should return fs.ErrExist for existing directories", func() {
+			Expect(client.Mkdir("test", os.ModePerm)).To(Succeed())
+
+			err := client.Mkdir("test", os.ModePerm)
+
+			Expect(err).To(MatchError(fs.ErrExist))
+			Expect(os.IsExist(err)).To(BeTrue())
+		})
+
+		It("should return fs.ErrClosed for released handles", func() {
+			file, err := client.Create("test.txt")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(file.Close()).To(Succeed())
+
+			_, err = file.Stat()
+
+			Expect(err).To(MatchError(fs.ErrClosed))
+		})
+
+		When("serving an OsFs", func() {
+			BeforeEach(func() {
+				local = afero.NewBasePathFs(afero.NewOsFs(), GinkgoT().TempDir())
+			})
+
+			It("should return the syscall.Errno of the served Fs", func() {
+				Expect(local.MkdirAll("test/dir", os.ModePerm)).To(Succeed())
+
+				err := client.Remove("test")
+
+				Expect(err).To(MatchError(syscall.ENOTEMPTY))
+				Expect(os.IsExist(err)).To(BeTrue())
+			})
+		})
+	})
+})
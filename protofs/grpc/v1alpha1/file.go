@@ -1,167 +1,318 @@
 package protofsv1alpha1
 
 import (
-	"context"
 	"io"
+	"io/fs"
 	"os"
+	"sync"
+	"sync/atomic"
 
 	"buf.build/gen/go/unmango/protofs/grpc/go/dev/unmango/file/v1alpha1/filev1alpha1grpc"
 	filev1alpha1 "buf.build/gen/go/unmango/protofs/protocolbuffers/go/dev/unmango/file/v1alpha1"
 	"github.com/spf13/afero"
+	"github.com/unmango/aferox/context"
+	extv1alpha1 "github.com/unmango/aferox/protofs/ext/v1alpha1"
 	"github.com/unmango/aferox/protofs/internal"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"k8s.io/utils/ptr"
 )
 
+// File is a handle to a file opened on a remote [HandleServer].
 type File struct {
-	client filev1alpha1grpc.FileServiceClient
-	file   *filev1alpha1.File
-	flag   *int
-	perm   *os.FileMode
+	ctx    context.Context
+	client extv1alpha1.HandleServiceClient
+	handle uint64
+	name   string
+	closed atomic.Bool
+	mu     sync.Mutex
 }
 
 // Close implements afero.File.
-func (f File) Close() error {
-	return nil
+func (f *File) Close() error {
+	if f.closed.Swap(true) {
+		return f.error("close", fs.ErrClosed)
+	}
+
+	_, err := f.client.Close(f.context(), &extv1alpha1.CloseRequest{
+		Handle: f.handle,
+	})
+
+	return f.remote("close", err)
 }
 
 // Name implements afero.File.
-func (f File) Name() string {
-	return f.file.Name
+func (f *File) Name() string {
+	return f.name
 }
 
 // Read implements afero.File.
-func (f File) Read(p []byte) (n int, err error) {
-	res, err := f.client.Read(context.TODO(), &filev1alpha1.ReadRequest{
-		File: f.file,
+func (f *File) Read(p []byte) (n int, err error) {
+	if f.closed.Load() {
+		return 0, f.error("read", fs.ErrClosed)
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	res, err := f.client.Read(f.context(), &extv1alpha1.ReadRequest{
+		Handle: f.handle,
+		Size:   int64(min(len(p), maxChunkSize)),
 	})
 	if err != nil {
-		return 0, err
+		return 0, f.remote("read", err)
+	}
+
+	n = copy(p, res.Data)
+	if n == 0 && res.Eof {
+		return 0, io.EOF
 	}
 
-	return copy(p, res.Data), io.EOF
+	return n, nil
 }
 
 // ReadAt implements afero.File.
-func (f File) ReadAt(p []byte, off int64) (n int, err error) {
-	res, err := f.client.ReadAt(context.Background(), &filev1alpha1.ReadAtRequest{
-		File:   f.file,
-		Offset: off,
-	})
-	if err != nil {
-		return 0, err
+func (f *File) ReadAt(p []byte, off int64) (n int, err error) {
+	if f.closed.Load() {
+		return 0, f.error("read", fs.ErrClosed)
+	}
+
+	for n < len(p) {
+		res, err := f.client.ReadAt(f.context(), &extv1alpha1.ReadAtRequest{
+			Handle: f.handle,
+			Size:   int64(min(len(p)-n, maxChunkSize)),
+			Offset: off + int64(n),
+		})
+		if err != nil {
+			return n, f.remote("read", err)
+		}
+
+		n += copy(p[n:], res.Data)
+		if res.Eof || len(res.Data) == 0 {
+			break
+		}
+	}
+	if n < len(p) {
+		return n, io.EOF
 	}
 
-	return copy(p, res.Data), io.EOF
+	return n, nil
 }
 
 // Readdir implements afero.File.
-func (f File) Readdir(count int) (info []os.FileInfo, err error) {
-	res, err := f.client.Readdir(context.TODO(), &filev1alpha1.ReaddirRequest{})
+func (f *File) Readdir(count int) (infos []os.FileInfo, err error) {
+	if f.closed.Load() {
+		return nil, f.error("readdir", fs.ErrClosed)
+	}
+
+	res, err := f.client.Readdir(f.context(), &extv1alpha1.ReaddirRequest{
+		Handle: f.handle,
+		Count:  int32(count),
+	})
 	if err != nil {
-		return nil, err
+		return nil, f.remote("readdir", err)
 	}
 
 	for _, fi := range res.FileInfos {
-		info = append(info, FileInfo{fi})
+		infos = append(infos, extProtoFileInfo(fi))
+	}
+	if count > 0 && len(infos) == 0 && res.Eof {
+		return nil, io.EOF
 	}
 
-	return info, nil
+	return infos, nil
 }
 
 // Readdirnames implements afero.File.
-func (f File) Readdirnames(n int) ([]string, error) {
-	res, err := f.client.ReaddirNames(context.TODO(), &filev1alpha1.ReaddirNamesRequest{
-		File:  f.file,
-		Count: int32(n),
+func (f *File) Readdirnames(n int) ([]string, error) {
+	if f.closed.Load() {
+		return nil, f.error("readdirent", fs.ErrClosed)
+	}
+
+	res, err := f.client.Readdirnames(f.context(), &extv1alpha1.ReaddirnamesRequest{
+		Handle: f.handle,
+		Count:  int32(n),
 	})
 	if err != nil {
-		return nil, err
+		return nil, f.remote("readdirent", err)
+	}
+	if n > 0 && len(res.Names) == 0 && res.Eof {
+		return nil, io.EOF
 	}
 
 	return res.Names, nil
 }
 
 // Seek implements afero.File.
-func (f File) Seek(offset int64, whence int) (int64, error) {
-	panic("unimplemented")
+func (f *File) Seek(offset int64, whence int) (int64, error) {
+	if f.closed.Load() {
+		return 0, f.error("seek", fs.ErrClosed)
+	}
+
+	res, err := f.client.Seek(f.context(), &extv1alpha1.SeekRequest{
+		Handle: f.handle,
+		Offset: offset,
+		Whence: int32(whence),
+	})
+	if err != nil {
+		return 0, f.remote("seek", err)
+	}
+
+	return res.Offset, nil
 }
 
 // Stat implements afero.File.
-func (f File) Stat() (os.FileInfo, error) {
-	res, err := f.client.Stat(context.TODO(), &filev1alpha1.StatRequest{
-		File: f.file,
+func (f *File) Stat() (os.FileInfo, error) {
+	if f.closed.Load() {
+		return nil, f.error("stat", fs.ErrClosed)
+	}
+
+	res, err := f.client.Stat(f.context(), &extv1alpha1.StatRequest{
+		Handle: f.handle,
 	})
 	if err != nil {
-		return nil, err
+		return nil, f.remote("stat", err)
 	}
 
-	return FileInfo{res.FileInfo}, nil
+	return extProtoFileInfo(res.FileInfo), nil
 }
 
 // Sync implements afero.File.
-func (f File) Sync() error {
-	panic("unimplemented")
+func (f *File) Sync() error {
+	if f.closed.Load() {
+		return f.error("sync", fs.ErrClosed)
+	}
+
+	_, err := f.client.Sync(f.context(), &extv1alpha1.SyncRequest{
+		Handle: f.handle,
+	})
+
+	return f.remote("sync", err)
 }
 
 // Truncate implements afero.File.
-func (f File) Truncate(size int64) error {
-	_, err := f.client.Truncate(context.TODO(), &filev1alpha1.TruncateRequest{
-		File: f.file,
-		Size: size,
+func (f *File) Truncate(size int64) error {
+	if f.closed.Load() {
+		return f.error("truncate", fs.ErrClosed)
+	}
+
+	_, err := f.client.Truncate(f.context(), &extv1alpha1.TruncateRequest{
+		Handle: f.handle,
+		Size:   size,
 	})
 
-	return err
+	return f.remote("truncate", err)
 }
 
 // Write implements afero.File.
-func (f File) Write(p []byte) (n int, err error) {
-	_, err = f.client.Write(context.TODO(), &filev1alpha1.WriteRequest{
-		File: f.file,
-		Data: p,
-	})
-	if err != nil {
-		return 0, err
+func (f *File) Write(p []byte) (n int, err error) {
+	if f.closed.Load() {
+		return 0, f.error("write", fs.ErrClosed)
+	}
+
+	for n < len(p) {
+		res, err := f.client.Write(f.context(), &extv1alpha1.WriteRequest{
+			Handle: f.handle,
+			Data:   p[n:min(len(p), n+maxChunkSize)],
+		})
+		if err != nil {
+			return n, f.remote("write", err)
+		}
+		if res.N == 0 {
+			return n, io.ErrShortWrite
+		}
+
+		n += int(res.N)
 	}
 
-	return len(p), nil
+	return n, nil
 }
 
 // WriteAt implements afero.File.
-func (f File) WriteAt(p []byte, off int64) (n int, err error) {
-	_, err = f.client.WriteAt(context.TODO(), &filev1alpha1.WriteAtRequest{
-		File:   f.file,
-		Data:   p,
-		Offset: off,
-	})
-	if err != nil {
-		return 0, err
+func (f *File) WriteAt(p []byte, off int64) (n int, err error) {
+	if f.closed.Load() {
+		return 0, f.error("write", fs.ErrClosed)
+	}
+
+	for n < len(p) {
+		res, err := f.client.WriteAt(f.context(), &extv1alpha1.WriteAtRequest{
+			Handle: f.handle,
+			Data:   p[n:min(len(p), n+maxChunkSize)],
+			Offset: off + int64(n),
+		})
+		if err != nil {
+			return n, f.remote("write", err)
+		}
+		if res.N == 0 {
+			return n, io.ErrShortWrite
+		}
+
+		n += int(res.N)
 	}
 
-	return len(p), nil
+	return n, nil
 }
 
 // WriteString implements afero.File.
-func (f File) WriteString(s string) (ret int, err error) {
+func (f *File) WriteString(s string) (ret int, err error) {
 	return f.Write([]byte(s))
 }
 
+// SetContext implements context.Setter.
+// The given context is used for all subsequent operations on the file.
+func (f *File) SetContext(ctx context.Context) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.ctx = ctx
+}
+
+func (f *File) context() context.Context {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.ctx == nil {
+		return context.Background()
+	}
+
+	return f.ctx
+}
+
+func (f *File) error(op string, err error) error {
+	return &fs.PathError{Op: op, Path: f.name, Err: err}
+}
+
+// remote converts an error returned by the server using [FromStatus].
+func (f *File) remote(op string, err error) error {
+	return FromStatus(op, f.name, err)
+}
+
+var _ context.Setter = (*File)(nil)
+
 type FileServer struct {
 	filev1alpha1grpc.UnimplementedFileServiceServer
 
 	Fs afero.Fs
+
+	opts serverOptions
 }
 
-func (s *FileServer) Read(_ context.Context, req *filev1alpha1.ReadRequest) (*filev1alpha1.ReadResponse, error) {
-	file, err := s.open(req.File)
+// NewFileServer returns a FileServer serving fs with the given options.
+func NewFileServer(fs afero.Fs, options ...ServerOption) *FileServer {
+	opts := newServerOptions(options)
+
+	return &FileServer{Fs: opts.fs(fs), opts: opts}
+}
+
+func (s *FileServer) Read(ctx context.Context, req *filev1alpha1.ReadRequest) (*filev1alpha1.ReadResponse, error) {
+	file, err := s.open(ctx, req.File, false)
 	if err != nil {
-		return nil, err
+		return nil, ToStatus(err).Err()
 	}
 
 	data, err := io.ReadAll(file)
 	if err != nil {
-		return nil, err
+		return nil, ToStatus(err).Err()
 	}
 
 	return &filev1alpha1.ReadResponse{
@@ -169,21 +320,21 @@ func (s *FileServer) Read(_ context.Context, req *filev1alpha1.ReadRequest) (*fi
 	}, nil
 }
 
-func (s *FileServer) ReadAt(_ context.Context, req *filev1alpha1.ReadAtRequest) (*filev1alpha1.ReadAtResponse, error) {
-	file, err := s.open(req.File)
+func (s *FileServer) ReadAt(ctx context.Context, req *filev1alpha1.ReadAtRequest) (*filev1alpha1.ReadAtResponse, error) {
+	file, err := s.open(ctx, req.File, false)
 	if err != nil {
-		return nil, err
+		return nil, ToStatus(err).Err()
 	}
 
 	info, err := file.Stat()
 	if err != nil {
-		return nil, err
+		return nil, ToStatus(err).Err()
 	}
 
 	buf := make([]byte, 0, info.Size())
 	_, err = file.ReadAt(buf, req.Offset)
 	if err != nil {
-		return nil, err
+		return nil, ToStatus(err).Err()
 	}
 
 	return &filev1alpha1.ReadAtResponse{
@@ -191,40 +342,41 @@ func (s *FileServer) ReadAt(_ context.Context, req *filev1alpha1.ReadAtRequest)
 	}, nil
 }
 
-func (s *FileServer) Readdir(_ context.Context, req *filev1alpha1.ReaddirRequest) (*filev1alpha1.ReaddirResponse, error) {
-	file, err := s.open(req.File)
+func (s *FileServer) Readdir(ctx context.Context, req *filev1alpha1.ReaddirRequest) (*filev1alpha1.ReaddirResponse, error) {
+	file, err := s.open(ctx, req.File, false)
 	if err != nil {
-		return nil, err
+		return nil, ToStatus(err).Err()
 	}
 
 	info, err := file.Readdir(int(req.Count))
 	if err != nil {
-		return nil, err
+		return nil, ToStatus(err).Err()
 	}
 
 	res := &filev1alpha1.ReaddirResponse{}
-	for _, fi := range info {
+	for _, fi := range s.opts.readdir(req.File.Name, int(req.Count), info) {
 		res.FileInfos = append(res.FileInfos, &filev1alpha1.FileInfo{
 			Name:    fi.Name(),
 			Size:    fi.Size(),
 			Mode:    internal.ProtoFileMode(fi.Mode()),
 			ModTime: timestamppb.New(fi.ModTime()),
 			IsDir:   fi.IsDir(),
+			Sys:     protoSys(fi),
 		})
 	}
 
 	return res, nil
 }
 
-func (s *FileServer) ReaddirNames(_ context.Context, req *filev1alpha1.ReaddirNamesRequest) (*filev1alpha1.ReaddirNamesResponse, error) {
-	file, err := s.open(req.File)
+func (s *FileServer) ReaddirNames(ctx context.Context, req *filev1alpha1.ReaddirNamesRequest) (*filev1alpha1.ReaddirNamesResponse, error) {
+	file, err := s.open(ctx, req.File, false)
 	if err != nil {
-		return nil, err
+		return nil, ToStatus(err).Err()
 	}
 
 	names, err := file.Readdirnames(int(req.Count))
 	if err != nil {
-		return nil, err
+		return nil, ToStatus(err).Err()
 	}
 
 	return &filev1alpha1.ReaddirNamesResponse{
@@ -232,15 +384,15 @@ func (s *FileServer) ReaddirNames(_ context.Context, req *filev1alpha1.ReaddirNa
 	}, nil
 }
 
-func (s *FileServer) Stat(_ context.Context, req *filev1alpha1.StatRequest) (*filev1alpha1.StatResponse, error) {
-	file, err := s.open(req.File)
+func (s *FileServer) Stat(ctx context.Context, req *filev1alpha1.StatRequest) (*filev1alpha1.StatResponse, error) {
+	file, err := s.open(ctx, req.File, false)
 	if err != nil {
-		return nil, err
+		return nil, ToStatus(err).Err()
 	}
 
 	info, err := file.Stat()
 	if err != nil {
-		return nil, err
+		return nil, ToStatus(err).Err()
 	}
 
 	return &filev1alpha1.StatResponse{
@@ -250,50 +402,58 @@ func (s *FileServer) Stat(_ context.Context, req *filev1alpha1.StatRequest) (*fi
 			Mode:    internal.ProtoFileMode(info.Mode()),
 			ModTime: timestamppb.New(info.ModTime()),
 			IsDir:   info.IsDir(),
+			Sys:     protoSys(info),
 		},
 	}, nil
 }
 
-func (s *FileServer) Truncate(_ context.Context, req *filev1alpha1.TruncateRequest) (*filev1alpha1.TruncateResponse, error) {
-	file, err := s.open(req.File)
+func (s *FileServer) Truncate(ctx context.Context, req *filev1alpha1.TruncateRequest) (*filev1alpha1.TruncateResponse, error) {
+	file, err := s.open(ctx, req.File, true)
 	if err != nil {
-		return nil, err
+		return nil, ToStatus(err).Err()
 	}
 
 	if err := file.Truncate(req.Size); err != nil {
-		return nil, err
+		return nil, ToStatus(err).Err()
 	} else {
 		return &filev1alpha1.TruncateResponse{}, nil
 	}
 }
 
-func (s *FileServer) Write(_ context.Context, req *filev1alpha1.WriteRequest) (*filev1alpha1.WriteResponse, error) {
-	file, err := s.open(req.File)
+func (s *FileServer) Write(ctx context.Context, req *filev1alpha1.WriteRequest) (*filev1alpha1.WriteResponse, error) {
+	file, err := s.open(ctx, req.File, true)
 	if err != nil {
-		return nil, err
+		return nil, ToStatus(err).Err()
 	}
 
 	if _, err = file.Write(req.Data); err != nil {
-		return nil, err
+		return nil, ToStatus(err).Err()
 	} else {
 		return &filev1alpha1.WriteResponse{}, nil
 	}
 }
 
-func (s *FileServer) WriteAt(_ context.Context, req *filev1alpha1.WriteAtRequest) (*filev1alpha1.WriteAtResponse, error) {
-	file, err := s.open(req.File)
+func (s *FileServer) WriteAt(ctx context.Context, req *filev1alpha1.WriteAtRequest) (*filev1alpha1.WriteAtResponse, error) {
+	file, err := s.open(ctx, req.File, true)
 	if err != nil {
-		return nil, err
+		return nil, ToStatus(err).Err()
 	}
 
 	if _, err = file.WriteAt(req.Data, req.Offset); err != nil {
-		return nil, err
+		return nil, ToStatus(err).Err()
 	} else {
 		return &filev1alpha1.WriteAtResponse{}, nil
 	}
 }
 
-func (s *FileServer) open(file *filev1alpha1.File) (afero.File, error) {
+func (s *FileServer) open(ctx context.Context, file *filev1alpha1.File, writes bool) (afero.File, error) {
+	operation, opens := openOperation(file.Name,
+		int(ptr.Deref(file.Flag, 0)),
+		internal.OsFileMode(ptr.Deref(file.Perm, 0)),
+	)
+	if err := s.opts.authorize(ctx, operation, writes || opens); err != nil {
+		return nil, ToStatus(err).Err()
+	}
 	if file.Flag == nil && file.Perm == nil {
 		return s.Fs.Open(file.Name)
 	} else {
@@ -304,6 +464,14 @@ func (s *FileServer) open(file *filev1alpha1.File) (afero.File, error) {
 	}
 }
 
-func RegisterFileServer(s grpc.ServiceRegistrar, fs afero.Fs) {
-	filev1alpha1grpc.RegisterFileServiceServer(s, &FileServer{Fs: fs})
+// RegisterFileServer registers both the path based FileServer and a [HandleServer]
+// using [DefaultIdleTimeout] unless overridden by [WithIdleTimeout].
+// Use [RegisterHandleServer] to customize the HandleServer further.
+//
+// Handles registered this way are only released on Close or after the idle
+// timeout, because a [grpc.ServiceRegistrar] cannot observe connections.
+// Use [NewServer] to also release them when a connection drops.
+func RegisterFileServer(s grpc.ServiceRegistrar, fs afero.Fs, options ...ServerOption) {
+	filev1alpha1grpc.RegisterFileServiceServer(s, NewFileServer(fs, options...))
+	RegisterHandleServer(s, NewHandleServer(fs, options...))
 }
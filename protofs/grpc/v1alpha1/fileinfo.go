@@ -5,7 +5,9 @@ import (
 	"time"
 
 	filev1alpha1 "buf.build/gen/go/unmango/protofs/protocolbuffers/go/dev/unmango/file/v1alpha1"
+	extv1alpha1 "github.com/unmango/aferox/protofs/ext/v1alpha1"
 	"github.com/unmango/aferox/protofs/internal"
+	"google.golang.org/protobuf/types/known/anypb"
 )
 
 type FileInfo struct {
@@ -38,6 +40,42 @@ func (f FileInfo) Size() int64 {
 }
 
 // Sys implements fs.FileInfo.
+// It returns the [extv1alpha1.Sys] describing the ownership and identity
+// of the file when the server provided it, and nil otherwise.
 func (f FileInfo) Sys() any {
-	return f.Proto.Sys
+	if f.Proto.Sys == nil {
+		return nil
+	}
+
+	sys := &extv1alpha1.Sys{}
+	if err := f.Proto.Sys.UnmarshalTo(sys); err != nil {
+		return f.Proto.Sys
+	}
+
+	return sys
+}
+
+func extProtoFileInfo(info *extv1alpha1.FileInfo) FileInfo {
+	return FileInfo{&filev1alpha1.FileInfo{
+		Name:    info.Name,
+		Size:    info.Size,
+		Mode:    internal.ProtoFileMode(fs.FileMode(info.Mode)),
+		ModTime: info.ModTime,
+		IsDir:   info.IsDir,
+		Sys:     info.Sys,
+	}}
+}
+
+// protoSys packs the ownership and identity of info for the wire.
+func protoSys(info fs.FileInfo) *anypb.Any {
+	sys := internal.Sys(info)
+	if sys == nil {
+		return nil
+	}
+
+	if a, err := anypb.New(sys); err != nil {
+		return nil
+	} else {
+		return a
+	}
 }
@@ -1,292 +1,362 @@
 package protofsv1alpha1
 
 import (
-	"context"
+	"errors"
 	"os"
+	"path/filepath"
 	"time"
 
-	"buf.build/gen/go/unmango/protofs/grpc/go/dev/unmango/file/v1alpha1/filev1alpha1grpc"
 	"buf.build/gen/go/unmango/protofs/grpc/go/dev/unmango/fs/v1alpha1/fsv1alpha1grpc"
 	filev1alpha1 "buf.build/gen/go/unmango/protofs/protocolbuffers/go/dev/unmango/file/v1alpha1"
 	fsv1alpha1 "buf.build/gen/go/unmango/protofs/protocolbuffers/go/dev/unmango/fs/v1alpha1"
 	"github.com/spf13/afero"
+	"github.com/unmango/aferox/context"
+	"github.com/unmango/aferox/op"
+	extv1alpha1 "github.com/unmango/aferox/protofs/ext/v1alpha1"
 	"github.com/unmango/aferox/protofs/internal"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"k8s.io/utils/ptr"
 )
 
 type Fs struct {
-	conn   grpc.ClientConnInterface
-	client fsv1alpha1grpc.FsServiceClient
+	client  fsv1alpha1grpc.FsServiceClient
+	files   extv1alpha1.HandleServiceClient
+	links   extv1alpha1.LinkServiceClient
+	watches extv1alpha1.WatchServiceClient
 }
 
+// NewFs returns an [afero.Fs] that performs each operation
+// with [context.Background]. Use [NewContextFs] to control
+// deadlines, cancellation and metadata per operation.
+//
+// The returned Fs implements [afero.Lstater], [afero.Linker]
+// and [afero.LinkReader], see [Fs.LstatIfPossible].
 func NewFs(conn grpc.ClientConnInterface) afero.Fs {
+	fs := NewContextFs(conn)
+
+	return backgroundFs{context.BackgroundFs(fs), fs}
+}
+
+// NewContextFs returns a [context.Fs] that passes the context of
+// each operation on to the underlying gRPC call. Outgoing gRPC metadata
+// attached to the context, such as auth tokens, is sent with the call.
+//
+// Files opened by the returned Fs use the values of the context they were
+// opened with, but not its deadline or cancellation.
+// Use [File.SetContext] to change the context of subsequent file operations.
+func NewContextFs(conn grpc.ClientConnInterface) *Fs {
 	return &Fs{
-		conn:   conn,
-		client: fsv1alpha1grpc.NewFsServiceClient(conn),
+		client:  fsv1alpha1grpc.NewFsServiceClient(conn),
+		files:   extv1alpha1.NewHandleServiceClient(conn),
+		links:   extv1alpha1.NewLinkServiceClient(conn),
+		watches: extv1alpha1.NewWatchServiceClient(conn),
 	}
 }
 
-// Chmod implements afero.Fs.
-func (f *Fs) Chmod(name string, mode os.FileMode) error {
-	_, err := f.client.Chmod(context.TODO(), &fsv1alpha1.ChmodRequest{
+// Chmod implements context.Fs.
+func (f *Fs) Chmod(ctx context.Context, name string, mode os.FileMode) error {
+	_, err := f.client.Chmod(ctx, &fsv1alpha1.ChmodRequest{
 		Name: name,
 		Mode: internal.ProtoFileMode(mode),
 	})
 
-	return err
+	return FromStatus("chmod", name, err)
 }
 
-// Chown implements afero.Fs.
-func (f *Fs) Chown(name string, uid int, gid int) error {
-	_, err := f.client.Chown(context.TODO(), &fsv1alpha1.ChownRequest{
+// Chown implements context.Fs.
+func (f *Fs) Chown(ctx context.Context, name string, uid int, gid int) error {
+	_, err := f.client.Chown(ctx, &fsv1alpha1.ChownRequest{
 		Name: name,
 		Uid:  int32(uid),
 		Gid:  int32(gid),
 	})
 
-	return err
+	return FromStatus("chown", name, err)
 }
 
-// Chtimes implements afero.Fs.
-func (f *Fs) Chtimes(name string, atime time.Time, mtime time.Time) error {
-	_, err := f.client.Chtimes(context.TODO(), &fsv1alpha1.ChtimesRequest{
+// Chtimes implements context.Fs.
+func (f *Fs) Chtimes(ctx context.Context, name string, atime time.Time, mtime time.Time) error {
+	_, err := f.client.Chtimes(ctx, &fsv1alpha1.ChtimesRequest{
 		Name:  name,
 		Atime: timestamppb.New(atime),
 		Mtime: timestamppb.New(mtime),
 	})
 
-	return err
+	return FromStatus("chtimes", name, err)
 }
 
-// Create implements afero.Fs.
-func (f *Fs) Create(name string) (afero.File, error) {
-	res, err := f.client.Create(context.TODO(), &fsv1alpha1.CreateRequest{
-		Name: name,
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	return File{
-		client: filev1alpha1grpc.NewFileServiceClient(f.conn),
-		file:   res.File,
-		flag:   ptr.To(os.O_CREATE),
-	}, nil
+// Create implements context.Fs.
+func (f *Fs) Create(ctx context.Context, name string) (afero.File, error) {
+	return f.OpenFile(ctx, name, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o666)
 }
 
-// Mkdir implements afero.Fs.
-func (f *Fs) Mkdir(name string, perm os.FileMode) error {
-	_, err := f.client.Mkdir(context.TODO(), &fsv1alpha1.MkdirRequest{
+// Mkdir implements context.Fs.
+func (f *Fs) Mkdir(ctx context.Context, name string, perm os.FileMode) error {
+	_, err := f.client.Mkdir(ctx, &fsv1alpha1.MkdirRequest{
 		Name: name,
 		Perm: internal.ProtoFileMode(perm),
 	})
 
-	return err
+	return FromStatus("mkdir", name, err)
 }
 
-// MkdirAll implements afero.Fs.
-func (f *Fs) MkdirAll(path string, perm os.FileMode) error {
-	_, err := f.client.MkdirAll(context.TODO(), &fsv1alpha1.MkdirAllRequest{
+// MkdirAll implements context.Fs.
+func (f *Fs) MkdirAll(ctx context.Context, path string, perm os.FileMode) error {
+	_, err := f.client.MkdirAll(ctx, &fsv1alpha1.MkdirAllRequest{
 		Path: path,
 		Perm: internal.ProtoFileMode(perm),
 	})
 
-	return err
+	return FromStatus("mkdir", path, err)
 }
 
-// Name implements afero.Fs.
+// Name implements context.Fs.
 func (f *Fs) Name() string {
 	return "protofs"
 }
 
-// Open implements afero.Fs.
-func (f *Fs) Open(name string) (afero.File, error) {
-	res, err := f.client.Open(context.TODO(), &fsv1alpha1.OpenRequest{
-		Name: name,
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	return File{
-		client: filev1alpha1grpc.NewFileServiceClient(f.conn),
-		file:   res.File,
-	}, nil
+// Open implements context.Fs.
+func (f *Fs) Open(ctx context.Context, name string) (afero.File, error) {
+	return f.OpenFile(ctx, name, os.O_RDONLY, 0)
 }
 
-// OpenFile implements afero.Fs.
-func (f *Fs) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
-	res, err := f.client.OpenFile(context.TODO(), &fsv1alpha1.OpenFileRequest{
+// OpenFile implements context.Fs.
+func (f *Fs) OpenFile(ctx context.Context, name string, flag int, perm os.FileMode) (afero.File, error) {
+	res, err := f.files.Open(ctx, &extv1alpha1.OpenRequest{
 		Name: name,
 		Flag: int64(flag),
-		Perm: internal.ProtoFileMode(perm),
+		Perm: uint32(perm),
 	})
 	if err != nil {
-		return nil, err
+		return nil, FromStatus("open", name, err)
 	}
 
-	return File{
-		client: filev1alpha1grpc.NewFileServiceClient(f.conn),
-		file:   res.File,
-		flag:   ptr.To(flag),
-		perm:   ptr.To(perm),
+	return &File{
+		ctx:    context.WithoutCancel(ctx),
+		client: f.files,
+		handle: res.Handle,
+		name:   res.Name,
 	}, nil
 }
 
-// Remove implements afero.Fs.
-func (f *Fs) Remove(name string) error {
-	_, err := f.client.Remove(context.TODO(), &fsv1alpha1.RemoveRequest{
+// Remove implements context.Fs.
+func (f *Fs) Remove(ctx context.Context, name string) error {
+	_, err := f.client.Remove(ctx, &fsv1alpha1.RemoveRequest{
 		Name: name,
 	})
 
-	return err
+	return FromStatus("remove", name, err)
 }
 
-// RemoveAll implements afero.Fs.
-func (f *Fs) RemoveAll(path string) error {
-	_, err := f.client.RemoveAll(context.TODO(), &fsv1alpha1.RemoveAllRequest{
+// RemoveAll implements context.Fs.
+func (f *Fs) RemoveAll(ctx context.Context, path string) error {
+	_, err := f.client.RemoveAll(ctx, &fsv1alpha1.RemoveAllRequest{
 		Path: path,
 	})
 
-	return err
+	return FromStatus("removeall", path, err)
 }
 
-// Rename implements afero.Fs.
-func (f *Fs) Rename(oldname string, newname string) error {
-	_, err := f.client.Rename(context.TODO(), &fsv1alpha1.RenameRequest{
+// Rename implements context.Fs.
+func (f *Fs) Rename(ctx context.Context, oldname string, newname string) error {
+	_, err := f.client.Rename(ctx, &fsv1alpha1.RenameRequest{
 		Oldname: oldname,
 		Newname: newname,
 	})
 
-	return err
+	return FromStatus("rename", oldname, err)
 }
 
-// Stat implements afero.Fs.
-func (f *Fs) Stat(name string) (os.FileInfo, error) {
-	res, err := f.client.Stat(context.TODO(), &fsv1alpha1.StatRequest{
+// Stat implements context.Fs.
+func (f *Fs) Stat(ctx context.Context, name string) (os.FileInfo, error) {
+	res, err := f.client.Stat(ctx, &fsv1alpha1.StatRequest{
 		Name: name,
 	})
 	if err != nil {
-		return nil, err
+		return nil, FromStatus("stat", name, err)
 	}
 
 	return FileInfo{res.FileInfo}, nil
 }
 
+var _ context.Fs = (*Fs)(nil)
+
+// FsServer serves the FsService, LinkService and WatchService for the given Fs.
 type FsServer struct {
 	fsv1alpha1grpc.UnimplementedFsServiceServer
+	extv1alpha1.UnimplementedLinkServiceServer
+	extv1alpha1.UnimplementedWatchServiceServer
 
 	Fs afero.Fs
+
+	base afero.Fs
+	opts serverOptions
+}
+
+// NewFsServer returns an FsServer serving fs with the given options.
+func NewFsServer(fs afero.Fs, options ...ServerOption) *FsServer {
+	opts := newServerOptions(options)
+
+	return &FsServer{Fs: opts.fs(fs), base: fs, opts: opts}
 }
 
-func (s *FsServer) Chmod(_ context.Context, req *fsv1alpha1.ChmodRequest) (*fsv1alpha1.ChmodResponse, error) {
-	if err := s.Fs.Chmod(req.Name, internal.OsFileMode(req.Mode)); err != nil {
-		return nil, err
+func (s *FsServer) Chmod(ctx context.Context, req *fsv1alpha1.ChmodRequest) (*fsv1alpha1.ChmodResponse, error) {
+	if err := s.opts.authorize(ctx, op.Chmod{Name: req.Name, Mode: internal.OsFileMode(req.Mode)}, true); err != nil {
+		return nil, ToStatus(err).Err()
+	}
+	if err := s.fs().ChmodContext(ctx, req.Name, internal.OsFileMode(req.Mode)); err != nil {
+		return nil, ToStatus(err).Err()
 	} else {
 		return &fsv1alpha1.ChmodResponse{}, nil
 	}
 }
 
-func (s *FsServer) Chown(_ context.Context, req *fsv1alpha1.ChownRequest) (*fsv1alpha1.ChownResponse, error) {
-	if err := s.Fs.Chown(req.Name, int(req.Uid), int(req.Gid)); err != nil {
-		return nil, err
+func (s *FsServer) Chown(ctx context.Context, req *fsv1alpha1.ChownRequest) (*fsv1alpha1.ChownResponse, error) {
+	if err := s.opts.authorize(ctx, op.Chown{Name: req.Name, UID: int(req.Uid), GID: int(req.Gid)}, true); err != nil {
+		return nil, ToStatus(err).Err()
+	}
+	if err := s.fs().ChownContext(ctx, req.Name, int(req.Uid), int(req.Gid)); err != nil {
+		return nil, ToStatus(err).Err()
 	} else {
 		return &fsv1alpha1.ChownResponse{}, nil
 	}
 }
 
-func (s *FsServer) Chtimes(_ context.Context, req *fsv1alpha1.ChtimesRequest) (*fsv1alpha1.ChtimesResponse, error) {
-	if err := s.Fs.Chtimes(req.Name, req.Atime.AsTime(), req.Mtime.AsTime()); err != nil {
-		return nil, err
+func (s *FsServer) Chtimes(ctx context.Context, req *fsv1alpha1.ChtimesRequest) (*fsv1alpha1.ChtimesResponse, error) {
+	if err := s.opts.authorize(ctx, op.Chtimes{Name: req.Name, Atime: req.Atime.AsTime(), Mtime: req.Mtime.AsTime()}, true); err != nil {
+		return nil, ToStatus(err).Err()
+	}
+	if err := s.fs().ChtimesContext(ctx, req.Name, req.Atime.AsTime(), req.Mtime.AsTime()); err != nil {
+		return nil, ToStatus(err).Err()
 	} else {
 		return &fsv1alpha1.ChtimesResponse{}, nil
 	}
 }
 
-func (s *FsServer) Create(_ context.Context, req *fsv1alpha1.CreateRequest) (*fsv1alpha1.CreateResponse, error) {
-	if file, err := s.Fs.Create(req.Name); err != nil {
-		return nil, err
-	} else {
-		return &fsv1alpha1.CreateResponse{
-			File: &filev1alpha1.File{
-				Name: file.Name(),
-				Flag: ptr.To(int64(os.O_CREATE | os.O_RDWR)),
-			},
-		}, nil
+func (s *FsServer) Create(ctx context.Context, req *fsv1alpha1.CreateRequest) (*fsv1alpha1.CreateResponse, error) {
+	if err := s.opts.authorize(ctx, op.Create{Name: req.Name}, true); err != nil {
+		return nil, ToStatus(err).Err()
+	}
+	file, err := s.fs().CreateContext(ctx, req.Name)
+	if err != nil {
+		return nil, ToStatus(err).Err()
 	}
+	if err := file.Close(); err != nil {
+		return nil, ToStatus(err).Err()
+	}
+
+	return &fsv1alpha1.CreateResponse{
+		File: &filev1alpha1.File{
+			Name: file.Name(),
+			Flag: ptr.To(int64(os.O_CREATE | os.O_RDWR)),
+		},
+	}, nil
 }
 
-func (s *FsServer) Mkdir(_ context.Context, req *fsv1alpha1.MkdirRequest) (*fsv1alpha1.MkdirResponse, error) {
-	if err := s.Fs.Mkdir(req.Name, internal.OsFileMode(req.Perm)); err != nil {
-		return nil, err
+func (s *FsServer) Mkdir(ctx context.Context, req *fsv1alpha1.MkdirRequest) (*fsv1alpha1.MkdirResponse, error) {
+	if err := s.opts.authorize(ctx, op.Mkdir{Name: req.Name, Perm: internal.OsFileMode(req.Perm)}, true); err != nil {
+		return nil, ToStatus(err).Err()
+	}
+	if err := s.fs().MkdirContext(ctx, req.Name, internal.OsFileMode(req.Perm)); err != nil {
+		return nil, ToStatus(err).Err()
 	} else {
 		return &fsv1alpha1.MkdirResponse{}, nil
 	}
 }
 
-func (s *FsServer) MkdirAll(_ context.Context, req *fsv1alpha1.MkdirAllRequest) (*fsv1alpha1.MkdirAllResponse, error) {
-	if err := s.Fs.MkdirAll(req.Path, internal.OsFileMode(req.Perm)); err != nil {
-		return nil, err
+func (s *FsServer) MkdirAll(ctx context.Context, req *fsv1alpha1.MkdirAllRequest) (*fsv1alpha1.MkdirAllResponse, error) {
+	if err := s.opts.authorize(ctx, op.MkdirAll{Name: req.Path, Perm: internal.OsFileMode(req.Perm)}, true); err != nil {
+		return nil, ToStatus(err).Err()
+	}
+	if err := s.fs().MkdirAllContext(ctx, req.Path, internal.OsFileMode(req.Perm)); err != nil {
+		return nil, ToStatus(err).Err()
 	} else {
 		return &fsv1alpha1.MkdirAllResponse{}, nil
 	}
 }
 
-func (s *FsServer) Open(_ context.Context, req *fsv1alpha1.OpenRequest) (*fsv1alpha1.OpenResponse, error) {
-	if file, err := s.Fs.Open(req.Name); err != nil {
-		return nil, err
-	} else {
-		return &fsv1alpha1.OpenResponse{
-			File: &filev1alpha1.File{
-				Name: file.Name(),
-			},
-		}, nil
+func (s *FsServer) Open(ctx context.Context, req *fsv1alpha1.OpenRequest) (*fsv1alpha1.OpenResponse, error) {
+	if err := s.opts.authorize(ctx, op.Open{Name: req.Name}, false); err != nil {
+		return nil, ToStatus(err).Err()
 	}
+	file, err := s.fs().OpenContext(ctx, req.Name)
+	if err != nil {
+		return nil, ToStatus(err).Err()
+	}
+	if err := file.Close(); err != nil {
+		return nil, ToStatus(err).Err()
+	}
+
+	return &fsv1alpha1.OpenResponse{
+		File: &filev1alpha1.File{
+			Name: file.Name(),
+		},
+	}, nil
 }
 
-func (s *FsServer) OpenFile(_ context.Context, req *fsv1alpha1.OpenFileRequest) (*fsv1alpha1.OpenFileResponse, error) {
-	if file, err := s.Fs.OpenFile(req.Name, int(req.Flag), internal.OsFileMode(req.Perm)); err != nil {
-		return nil, err
-	} else {
-		return &fsv1alpha1.OpenFileResponse{
-			File: &filev1alpha1.File{
-				Name: file.Name(),
-				Flag: &req.Flag,
-				Perm: &req.Perm,
-			},
-		}, nil
+func (s *FsServer) OpenFile(ctx context.Context, req *fsv1alpha1.OpenFileRequest) (*fsv1alpha1.OpenFileResponse, error) {
+	operation, writes := openOperation(req.Name, int(req.Flag), internal.OsFileMode(req.Perm))
+	if err := s.opts.authorize(ctx, operation, writes); err != nil {
+		return nil, ToStatus(err).Err()
+	}
+	file, err := s.fs().OpenFileContext(ctx, req.Name, int(req.Flag), internal.OsFileMode(req.Perm))
+	if err != nil {
+		return nil, ToStatus(err).Err()
+	}
+	if err := file.Close(); err != nil {
+		return nil, ToStatus(err).Err()
 	}
+
+	return &fsv1alpha1.OpenFileResponse{
+		File: &filev1alpha1.File{
+			Name: file.Name(),
+			Flag: &req.Flag,
+			Perm: &req.Perm,
+		},
+	}, nil
 }
 
-func (s *FsServer) Remove(_ context.Context, req *fsv1alpha1.RemoveRequest) (*fsv1alpha1.RemoveResponse, error) {
-	if err := s.Fs.Remove(req.Name); err != nil {
-		return nil, err
+func (s *FsServer) Remove(ctx context.Context, req *fsv1alpha1.RemoveRequest) (*fsv1alpha1.RemoveResponse, error) {
+	if err := s.opts.authorize(ctx, op.Remove{Name: req.Name}, true); err != nil {
+		return nil, ToStatus(err).Err()
+	}
+	if err := s.fs().RemoveContext(ctx, req.Name); err != nil {
+		return nil, ToStatus(err).Err()
 	} else {
 		return &fsv1alpha1.RemoveResponse{}, nil
 	}
 }
 
-func (s *FsServer) RemoveAll(_ context.Context, req *fsv1alpha1.RemoveAllRequest) (*fsv1alpha1.RemoveAllResponse, error) {
-	if err := s.Fs.RemoveAll(req.Path); err != nil {
-		return nil, err
+func (s *FsServer) RemoveAll(ctx context.Context, req *fsv1alpha1.RemoveAllRequest) (*fsv1alpha1.RemoveAllResponse, error) {
+	if err := s.opts.authorize(ctx, op.RemoveAll{Name: req.Path}, true); err != nil {
+		return nil, ToStatus(err).Err()
+	}
+	if err := s.removeAll(ctx, req.Path); err != nil {
+		return nil, ToStatus(err).Err()
 	} else {
 		return &fsv1alpha1.RemoveAllResponse{}, nil
 	}
 }
 
-func (s *FsServer) Rename(_ context.Context, req *fsv1alpha1.RenameRequest) (*fsv1alpha1.RenameResponse, error) {
-	if err := s.Fs.Rename(req.Oldname, req.Newname); err != nil {
-		return nil, err
+func (s *FsServer) Rename(ctx context.Context, req *fsv1alpha1.RenameRequest) (*fsv1alpha1.RenameResponse, error) {
+	if err := s.opts.authorize(ctx, op.Rename{Oldname: req.Oldname, Newname: req.Newname}, true); err != nil {
+		return nil, ToStatus(err).Err()
+	}
+	if err := s.fs().RenameContext(ctx, req.Oldname, req.Newname); err != nil {
+		return nil, ToStatus(err).Err()
 	} else {
 		return &fsv1alpha1.RenameResponse{}, nil
 	}
 }
 
-func (s *FsServer) Stat(_ context.Context, req *fsv1alpha1.StatRequest) (*fsv1alpha1.StatResponse, error) {
-	if info, err := s.Fs.Stat(req.Name); err != nil {
-		return nil, err
+func (s *FsServer) Stat(ctx context.Context, req *fsv1alpha1.StatRequest) (*fsv1alpha1.StatResponse, error) {
+	if err := s.opts.authorize(ctx, op.Stat{Name: req.Name}, false); err != nil {
+		return nil, ToStatus(err).Err()
+	}
+	if info, err := s.fs().StatContext(ctx, req.Name); err != nil {
+		return nil, ToStatus(err).Err()
 	} else {
 		return &fsv1alpha1.StatResponse{
 			FileInfo: &filev1alpha1.FileInfo{
@@ -295,12 +365,73 @@ func (s *FsServer) Stat(_ context.Context, req *fsv1alpha1.StatRequest) (*fsv1al
 				Mode:    internal.ProtoFileMode(info.Mode()),
 				ModTime: timestamppb.New(info.ModTime()),
 				IsDir:   info.IsDir(),
-				// Sys:  info.Sys(), // TODO
+				Sys:     protoSys(info),
 			},
 		}, nil
 	}
 }
 
-func RegisterFsServer(s grpc.ServiceRegistrar, fs afero.Fs) {
-	fsv1alpha1grpc.RegisterFsServiceServer(s, &FsServer{Fs: fs})
+func (s *FsServer) fs() context.AferoFs {
+	if fs, ok := s.Fs.(context.AferoFs); ok {
+		return fs
+	} else {
+		return context.Discard(s.Fs)
+	}
+}
+
+// removeAll removes path and any children it contains. When the served Fs
+// is not context aware, ctx is checked before each removal so a cancelled
+// request stops removing files as soon as possible. Symlinks are removed
+// without following them, so a link to a directory outside the root never
+// removes its contents.
+func (s *FsServer) removeAll(ctx context.Context, path string) error {
+	if fs, ok := s.Fs.(context.AferoFs); ok {
+		return fs.RemoveAllContext(ctx, path)
+	}
+
+	return removeAll(ctx, s.Fs, path)
+}
+
+func removeAll(ctx context.Context, fs afero.Fs, path string) error {
+	if err := ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+
+	info, err := lstat(fs, path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		names, err := afero.ReadDir(fs, path)
+		if err != nil {
+			return err
+		}
+		for _, child := range names {
+			if err := removeAll(ctx, fs, filepath.Join(path, child.Name())); err != nil {
+				return err
+			}
+		}
+	}
+
+	return fs.Remove(path)
+}
+
+// lstat describes path without following a final symlink when fs can.
+func lstat(fs afero.Fs, path string) (os.FileInfo, error) {
+	if lstater, ok := fs.(afero.Lstater); ok {
+		info, _, err := lstater.LstatIfPossible(path)
+		return info, err
+	}
+
+	return fs.Stat(path)
+}
+
+func RegisterFsServer(s grpc.ServiceRegistrar, fs afero.Fs, options ...ServerOption) {
+	srv := NewFsServer(fs, options...)
+	fsv1alpha1grpc.RegisterFsServiceServer(s, srv)
+	extv1alpha1.RegisterLinkServiceServer(s, srv)
+	extv1alpha1.RegisterWatchServiceServer(s, srv)
 }
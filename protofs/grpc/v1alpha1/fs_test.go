@@ -6,6 +6,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"time"
 
 	"buf.build/gen/go/unmango/protofs/grpc/go/dev/unmango/fs/v1alpha1/fsv1alpha1grpc"
 	fsv1alpha1 "buf.build/gen/go/unmango/protofs/protocolbuffers/go/dev/unmango/fs/v1alpha1"
@@ -14,9 +15,14 @@ import (
 	"k8s.io/utils/ptr"
 
 	"github.com/spf13/afero"
+	aferoxcontext "github.com/unmango/aferox/context"
 	protofsv1alpha1 "github.com/unmango/aferox/protofs/grpc/v1alpha1"
+	"github.com/unmango/aferox/testing/mock"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 var _ = Describe("Fs", func() {
@@ -89,3 +95,154 @@ var _ = Describe("Fs", func() {
 		Expect(stat.IsDir()).To(BeTrueBecause("file is a directory"))
 	})
 })
+
+var _ = Describe("ContextFs", func() {
+	var (
+		fs       afero.Fs
+		client   *protofsv1alpha1.Fs
+		incoming []metadata.MD
+	)
+
+	BeforeEach(func() {
+		fs = afero.NewMemMapFs()
+		incoming = nil
+		server := grpc.NewServer(grpc.ChainUnaryInterceptor(
+			func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+				md, _ := metadata.FromIncomingContext(ctx)
+				incoming = append(incoming, md)
+				return handler(ctx, req)
+			},
+		))
+		protofsv1alpha1.RegisterFsServer(server, fs)
+		protofsv1alpha1.RegisterFileServer(server, fs)
+
+		sock := filepath.Join(GinkgoT().TempDir(), "fs.sock")
+		lis, err := net.Listen("unix", sock)
+		Expect(err).NotTo(HaveOccurred())
+
+		go server.Serve(lis)
+		DeferCleanup(server.GracefulStop)
+
+		conn, err := grpc.NewClient(fmt.Sprint("unix://", sock),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		client = protofsv1alpha1.NewContextFs(conn)
+	})
+
+	It("should fail when the context is cancelled", func(ctx context.Context) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		err := client.Mkdir(ctx, "testdir", os.ModePerm)
+
+		Expect(status.Code(err)).To(Equal(codes.Canceled))
+		Expect(afero.Exists(fs, "testdir")).To(BeFalse())
+	})
+
+	It("should fail when the deadline is exceeded", func(ctx context.Context) {
+		ctx, cancel := context.WithDeadline(ctx, time.Now().Add(-time.Second))
+		DeferCleanup(cancel)
+
+		_, err := client.Stat(ctx, "test.txt")
+
+		Expect(status.Code(err)).To(Equal(codes.DeadlineExceeded))
+	})
+
+	It("should send outgoing metadata", func(ctx context.Context) {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer token")
+
+		Expect(client.Mkdir(ctx, "testdir", os.ModePerm)).To(Succeed())
+
+		Expect(incoming).To(HaveLen(1))
+		Expect(incoming[0].Get("authorization")).To(ConsistOf("Bearer token"))
+	})
+
+	It("should send the metadata of the open context with file operations", func(ctx context.Context) {
+		ctx, cancel := context.WithCancel(ctx)
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer token")
+		file, err := client.Create(ctx, "test.txt")
+		Expect(err).NotTo(HaveOccurred())
+		cancel()
+
+		_, err = file.WriteString("testing")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(incoming).To(HaveLen(2))
+		Expect(incoming[1].Get("authorization")).To(ConsistOf("Bearer token"))
+	})
+
+	It("should use the context set on a file", func(ctx context.Context) {
+		file, err := client.Create(ctx, "test.txt")
+		Expect(err).NotTo(HaveOccurred())
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		file.(aferoxcontext.Setter).SetContext(ctx)
+		_, err = file.WriteString("testing")
+
+		Expect(status.Code(err)).To(Equal(codes.Canceled))
+	})
+})
+
+var _ = Describe("FsServer", func() {
+	It("should stop removing files when the request is cancelled", func(ctx context.Context) {
+		fs := afero.NewMemMapFs()
+		Expect(afero.WriteFile(fs, "dir/test.txt", []byte("testing"), os.ModePerm)).To(Succeed())
+		server := &protofsv1alpha1.FsServer{Fs: fs}
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := server.RemoveAll(ctx, &fsv1alpha1.RemoveAllRequest{Path: "dir"})
+
+		Expect(status.Code(err)).To(Equal(codes.Canceled))
+		Expect(afero.Exists(fs, "dir/test.txt")).To(BeTrue())
+	})
+
+	It("should remove nested files", func(ctx context.Context) {
+		fs := afero.NewMemMapFs()
+		Expect(afero.WriteFile(fs, "dir/sub/test.txt", []byte("testing"), os.ModePerm)).To(Succeed())
+		server := &protofsv1alpha1.FsServer{Fs: fs}
+
+		_, err := server.RemoveAll(ctx, &fsv1alpha1.RemoveAllRequest{Path: "dir"})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(afero.Exists(fs, "dir")).To(BeFalse())
+	})
+
+	It("should close the files it opens to answer a request", func(ctx context.Context) {
+		fsys := mock.NewFs(afero.NewMemMapFs())
+		server := &protofsv1alpha1.FsServer{
+			Fs: aferoxcontext.Adapt(fsys, aferoxcontext.AccessorFunc(aferoxcontext.Background)),
+		}
+
+		_, err := server.Create(ctx, &fsv1alpha1.CreateRequest{Name: "test.txt"})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = server.Open(ctx, &fsv1alpha1.OpenRequest{Name: "test.txt"})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = server.OpenFile(ctx, &fsv1alpha1.OpenFileRequest{Name: "test.txt", Flag: int64(os.O_RDWR)})
+		Expect(err).NotTo(HaveOccurred())
+
+		methods := []string{}
+		for _, call := range fsys.Calls() {
+			methods = append(methods, call.Method)
+		}
+		Expect(methods).To(Equal([]string{"Create", "Close", "Open", "Close", "OpenFile", "Close"}))
+	})
+
+	It("should not follow symlinks out of the root", func(ctx context.Context) {
+		tmp := GinkgoT().TempDir()
+		root, outside := filepath.Join(tmp, "root"), filepath.Join(tmp, "outside")
+		Expect(os.MkdirAll(filepath.Join(root, "dir"), os.ModePerm)).To(Succeed())
+		Expect(os.MkdirAll(outside, os.ModePerm)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(outside, "keep.txt"), []byte("testing"), os.ModePerm)).To(Succeed())
+		Expect(os.Symlink(outside, filepath.Join(root, "dir", "link"))).To(Succeed())
+		server := protofsv1alpha1.NewFsServer(afero.NewOsFs(), protofsv1alpha1.WithRoot(root))
+
+		_, err := server.RemoveAll(ctx, &fsv1alpha1.RemoveAllRequest{Path: "dir"})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filepath.Join(root, "dir")).NotTo(BeAnExistingFile())
+		Expect(filepath.Join(outside, "keep.txt")).To(BeAnExistingFile())
+	})
+})
@@ -0,0 +1,507 @@
+package protofsv1alpha1
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"buf.build/gen/go/unmango/protofs/grpc/go/dev/unmango/file/v1alpha1/filev1alpha1grpc"
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/context"
+	"github.com/unmango/aferox/op"
+	extv1alpha1 "github.com/unmango/aferox/protofs/ext/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// DefaultIdleTimeout is the idle timeout used by [RegisterFileServer].
+	DefaultIdleTimeout = 10 * time.Minute
+
+	// maxChunkSize limits the size of a single read to stay well
+	// below the default gRPC message size limit.
+	maxChunkSize = 1 << 20
+)
+
+// HandleServer serves stateful file handles for the given Fs.
+// Handles are released when closed by the client, when the connection
+// that opened them ends, or after being idle for IdleTimeout.
+//
+// Handle IDs are random, and a handle can only be used by the connection
+// that opened it. Without a stats handler, connections are told apart by
+// their peer address. Every call on a handle is authorized again with the
+// configured [ServerOption] values, as the corresponding [op.Operation].
+//
+// To release handles when a connection drops, pass the HandleServer
+// to [grpc.StatsHandler] when creating the server, as [NewServer] does.
+type HandleServer struct {
+	extv1alpha1.UnimplementedHandleServiceServer
+
+	Fs afero.Fs
+
+	// IdleTimeout is the duration after which an unused handle is released.
+	// A zero value disables idle timeouts.
+	IdleTimeout time.Duration
+
+	opts    serverOptions
+	mu      sync.Mutex
+	handles map[uint64]*handle
+	conns   atomic.Uint64
+}
+
+// NewHandleServer returns a HandleServer serving fs with the given options.
+func NewHandleServer(fs afero.Fs, options ...ServerOption) *HandleServer {
+	opts := newServerOptions(options)
+
+	return &HandleServer{
+		Fs:          opts.fs(fs),
+		IdleTimeout: opts.idleTimeout,
+		opts:        opts,
+	}
+}
+
+type handle struct {
+	file  afero.File
+	name  string
+	conn  uint64
+	owner string
+	timer *time.Timer
+}
+
+type connKey struct{}
+
+// owner identifies the connection of the caller of ctx. It is the ID
+// assigned by [HandleServer.TagConn] when the HandleServer is a stats handler,
+// and the address of the gRPC peer otherwise.
+func owner(ctx context.Context) string {
+	if conn, ok := ctx.Value(connKey{}).(uint64); ok {
+		return fmt.Sprint("conn:", conn)
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.Network() + ":" + p.Addr.String()
+	}
+
+	return ""
+}
+
+// Open implements extv1alpha1.HandleServiceServer.
+func (s *HandleServer) Open(ctx context.Context, req *extv1alpha1.OpenRequest) (*extv1alpha1.OpenResponse, error) {
+	operation, writes := openOperation(req.Name, int(req.Flag), os.FileMode(req.Perm))
+	if err := s.opts.authorize(ctx, operation, writes); err != nil {
+		return nil, ToStatus(err).Err()
+	}
+
+	file, err := s.fs().OpenFileContext(ctx, req.Name, int(req.Flag), os.FileMode(req.Perm))
+	if err != nil {
+		return nil, ToStatus(err).Err()
+	}
+
+	return &extv1alpha1.OpenResponse{
+		Handle: s.allocate(ctx, req.Name, file),
+		Name:   file.Name(),
+	}, nil
+}
+
+// Close implements extv1alpha1.HandleServiceServer.
+func (s *HandleServer) Close(ctx context.Context, req *extv1alpha1.CloseRequest) (*extv1alpha1.CloseResponse, error) {
+	file, err := s.release(ctx, req.Handle)
+	if err != nil {
+		return nil, ToStatus(err).Err()
+	}
+	if err := file.Close(); err != nil {
+		return nil, ToStatus(err).Err()
+	}
+
+	return &extv1alpha1.CloseResponse{}, nil
+}
+
+// Read implements extv1alpha1.HandleServiceServer.
+func (s *HandleServer) Read(ctx context.Context, req *extv1alpha1.ReadRequest) (*extv1alpha1.ReadResponse, error) {
+	file, err := s.lookup(ctx, req.Handle, false, func(name string) op.Operation {
+		return op.Read{Name: name}
+	})
+	if err != nil {
+		return nil, ToStatus(err).Err()
+	}
+
+	buf := make([]byte, min(req.Size, maxChunkSize))
+	n, err := file.Read(buf)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, ToStatus(err).Err()
+	}
+
+	return &extv1alpha1.ReadResponse{
+		Data: buf[:n],
+		Eof:  errors.Is(err, io.EOF),
+	}, nil
+}
+
+// ReadAt implements extv1alpha1.HandleServiceServer.
+func (s *HandleServer) ReadAt(ctx context.Context, req *extv1alpha1.ReadAtRequest) (*extv1alpha1.ReadAtResponse, error) {
+	file, err := s.lookup(ctx, req.Handle, false, func(name string) op.Operation {
+		return op.ReadAt{Name: name, Offset: req.Offset}
+	})
+	if err != nil {
+		return nil, ToStatus(err).Err()
+	}
+
+	buf := make([]byte, min(req.Size, maxChunkSize))
+	n, err := file.ReadAt(buf, req.Offset)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, ToStatus(err).Err()
+	}
+
+	return &extv1alpha1.ReadAtResponse{
+		Data: buf[:n],
+		Eof:  errors.Is(err, io.EOF),
+	}, nil
+}
+
+// Readdir implements extv1alpha1.HandleServiceServer.
+func (s *HandleServer) Readdir(ctx context.Context, req *extv1alpha1.ReaddirRequest) (*extv1alpha1.ReaddirResponse, error) {
+	h, err := s.handle(ctx, req.Handle, false, func(name string) op.Operation {
+		return op.Readdir{Name: name, Count: int(req.Count)}
+	})
+	if err != nil {
+		return nil, ToStatus(err).Err()
+	}
+
+	infos, err := h.file.Readdir(int(req.Count))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, ToStatus(err).Err()
+	}
+
+	res := &extv1alpha1.ReaddirResponse{Eof: errors.Is(err, io.EOF)}
+	for _, fi := range s.opts.readdir(h.name, int(req.Count), infos) {
+		res.FileInfos = append(res.FileInfos, extFileInfo(fi))
+	}
+
+	return res, nil
+}
+
+// Readdirnames implements extv1alpha1.HandleServiceServer.
+func (s *HandleServer) Readdirnames(ctx context.Context, req *extv1alpha1.ReaddirnamesRequest) (*extv1alpha1.ReaddirnamesResponse, error) {
+	h, err := s.handle(ctx, req.Handle, false, func(name string) op.Operation {
+		return op.Readdirnames{Name: name, Count: int(req.Count)}
+	})
+	if err != nil {
+		return nil, ToStatus(err).Err()
+	}
+	if s.opts.filter != nil {
+		return s.readdirnames(h, int(req.Count))
+	}
+
+	names, err := h.file.Readdirnames(int(req.Count))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, ToStatus(err).Err()
+	}
+
+	return &extv1alpha1.ReaddirnamesResponse{
+		Names: names,
+		Eof:   errors.Is(err, io.EOF),
+	}, nil
+}
+
+// readdirnames lists the names of the entries of h allowed by the filter.
+func (s *HandleServer) readdirnames(h *handle, count int) (*extv1alpha1.ReaddirnamesResponse, error) {
+	infos, err := h.file.Readdir(count)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, ToStatus(err).Err()
+	}
+
+	res := &extv1alpha1.ReaddirnamesResponse{Eof: errors.Is(err, io.EOF)}
+	for _, fi := range s.opts.readdir(h.name, count, infos) {
+		res.Names = append(res.Names, fi.Name())
+	}
+
+	return res, nil
+}
+
+// Seek implements extv1alpha1.HandleServiceServer.
+func (s *HandleServer) Seek(ctx context.Context, req *extv1alpha1.SeekRequest) (*extv1alpha1.SeekResponse, error) {
+	file, err := s.lookup(ctx, req.Handle, false, func(name string) op.Operation {
+		return op.Seek{Name: name, Offset: req.Offset, Whence: int(req.Whence)}
+	})
+	if err != nil {
+		return nil, ToStatus(err).Err()
+	}
+
+	if offset, err := file.Seek(req.Offset, int(req.Whence)); err != nil {
+		return nil, ToStatus(err).Err()
+	} else {
+		return &extv1alpha1.SeekResponse{Offset: offset}, nil
+	}
+}
+
+// Stat implements extv1alpha1.HandleServiceServer.
+func (s *HandleServer) Stat(ctx context.Context, req *extv1alpha1.StatRequest) (*extv1alpha1.StatResponse, error) {
+	file, err := s.lookup(ctx, req.Handle, false, func(name string) op.Operation {
+		return op.Stat{Name: name}
+	})
+	if err != nil {
+		return nil, ToStatus(err).Err()
+	}
+
+	if info, err := file.Stat(); err != nil {
+		return nil, ToStatus(err).Err()
+	} else {
+		return &extv1alpha1.StatResponse{FileInfo: extFileInfo(info)}, nil
+	}
+}
+
+// Sync implements extv1alpha1.HandleServiceServer.
+func (s *HandleServer) Sync(ctx context.Context, req *extv1alpha1.SyncRequest) (*extv1alpha1.SyncResponse, error) {
+	file, err := s.lookup(ctx, req.Handle, false, func(name string) op.Operation {
+		return op.Sync{Name: name}
+	})
+	if err != nil {
+		return nil, ToStatus(err).Err()
+	}
+
+	if err := file.Sync(); err != nil {
+		return nil, ToStatus(err).Err()
+	} else {
+		return &extv1alpha1.SyncResponse{}, nil
+	}
+}
+
+// Truncate implements extv1alpha1.HandleServiceServer.
+func (s *HandleServer) Truncate(ctx context.Context, req *extv1alpha1.TruncateRequest) (*extv1alpha1.TruncateResponse, error) {
+	file, err := s.lookup(ctx, req.Handle, true, func(name string) op.Operation {
+		return op.Truncate{Name: name, Size: req.Size}
+	})
+	if err != nil {
+		return nil, ToStatus(err).Err()
+	}
+
+	if err := file.Truncate(req.Size); err != nil {
+		return nil, ToStatus(err).Err()
+	} else {
+		return &extv1alpha1.TruncateResponse{}, nil
+	}
+}
+
+// Write implements extv1alpha1.HandleServiceServer.
+func (s *HandleServer) Write(ctx context.Context, req *extv1alpha1.WriteRequest) (*extv1alpha1.WriteResponse, error) {
+	file, err := s.lookup(ctx, req.Handle, true, func(name string) op.Operation {
+		return op.Write{Name: name, Data: req.Data}
+	})
+	if err != nil {
+		return nil, ToStatus(err).Err()
+	}
+
+	if n, err := file.Write(req.Data); err != nil {
+		return nil, ToStatus(err).Err()
+	} else {
+		return &extv1alpha1.WriteResponse{N: int64(n)}, nil
+	}
+}
+
+// WriteAt implements extv1alpha1.HandleServiceServer.
+func (s *HandleServer) WriteAt(ctx context.Context, req *extv1alpha1.WriteAtRequest) (*extv1alpha1.WriteAtResponse, error) {
+	file, err := s.lookup(ctx, req.Handle, true, func(name string) op.Operation {
+		return op.WriteAt{Name: name, Offset: req.Offset, Data: req.Data}
+	})
+	if err != nil {
+		return nil, ToStatus(err).Err()
+	}
+
+	if n, err := file.WriteAt(req.Data, req.Offset); err != nil {
+		return nil, ToStatus(err).Err()
+	} else {
+		return &extv1alpha1.WriteAtResponse{N: int64(n)}, nil
+	}
+}
+
+// TagConn implements stats.Handler.
+func (s *HandleServer) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return context.WithValue(ctx, connKey{}, s.conns.Add(1))
+}
+
+// HandleConn implements stats.Handler.
+func (s *HandleServer) HandleConn(ctx context.Context, st stats.ConnStats) {
+	if _, ok := st.(*stats.ConnEnd); !ok {
+		return
+	}
+	if conn, ok := ctx.Value(connKey{}).(uint64); ok {
+		s.releaseConn(conn)
+	}
+}
+
+// TagRPC implements stats.Handler.
+func (s *HandleServer) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+// HandleRPC implements stats.Handler.
+func (s *HandleServer) HandleRPC(context.Context, stats.RPCStats) {}
+
+func (s *HandleServer) fs() context.AferoFs {
+	if fs, ok := s.Fs.(context.AferoFs); ok {
+		return fs
+	} else {
+		return context.Discard(s.Fs)
+	}
+}
+
+// Len returns the number of open handles.
+func (s *HandleServer) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.handles)
+}
+
+func (s *HandleServer) allocate(ctx context.Context, name string, file afero.File) uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.handles == nil {
+		s.handles = map[uint64]*handle{}
+	}
+
+	id := s.id()
+	h := &handle{file: file, name: name, owner: owner(ctx)}
+	if conn, ok := ctx.Value(connKey{}).(uint64); ok {
+		h.conn = conn
+	}
+	if s.IdleTimeout > 0 {
+		h.timer = time.AfterFunc(s.IdleTimeout, func() {
+			s.expire(id)
+		})
+	}
+
+	s.handles[id] = h
+	return id
+}
+
+// id returns an unused, unguessable handle ID. The caller must hold s.mu.
+func (s *HandleServer) id() uint64 {
+	for {
+		var b [8]byte
+		_, _ = rand.Read(b[:])
+		if id := binary.LittleEndian.Uint64(b[:]); id != 0 && s.handles[id] == nil {
+			return id
+		}
+	}
+}
+
+// lookup returns the file of the handle id after authorizing the
+// operation performed on it, see [HandleServer.handle].
+func (s *HandleServer) lookup(ctx context.Context, id uint64, writes bool, operation func(name string) op.Operation) (afero.File, error) {
+	if h, err := s.handle(ctx, id, writes, operation); err != nil {
+		return nil, err
+	} else {
+		return h.file, nil
+	}
+}
+
+// handle returns the handle id opened by the caller of ctx, after
+// authorizing the operation performed on it. Writes is true when the
+// operation modifies the file.
+func (s *HandleServer) handle(ctx context.Context, id uint64, writes bool, operation func(name string) op.Operation) (*handle, error) {
+	s.mu.Lock()
+	h, ok := s.handles[id]
+	if ok && h.owner != owner(ctx) {
+		ok = false
+	}
+	if ok && h.timer != nil {
+		h.timer.Reset(s.IdleTimeout)
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("handle %d: %w", id, fs.ErrClosed)
+	}
+	if err := s.opts.authorize(ctx, operation(h.name), writes); err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+// release removes the handle id opened by the caller of ctx.
+func (s *HandleServer) release(ctx context.Context, id uint64) (afero.File, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h, ok := s.handles[id]
+	if !ok || h.owner != owner(ctx) {
+		return nil, fmt.Errorf("handle %d: %w", id, fs.ErrClosed)
+	}
+	if h.timer != nil {
+		h.timer.Stop()
+	}
+
+	delete(s.handles, id)
+	return h.file, nil
+}
+
+// expire closes the handle id after it was idle for IdleTimeout.
+func (s *HandleServer) expire(id uint64) {
+	s.mu.Lock()
+	h, ok := s.handles[id]
+	delete(s.handles, id)
+	s.mu.Unlock()
+
+	if ok {
+		_ = h.file.Close()
+	}
+}
+
+func (s *HandleServer) releaseConn(conn uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, h := range s.handles {
+		if h.conn != conn {
+			continue
+		}
+		if h.timer != nil {
+			h.timer.Stop()
+		}
+
+		_ = h.file.Close()
+		delete(s.handles, id)
+	}
+}
+
+func extFileInfo(info fs.FileInfo) *extv1alpha1.FileInfo {
+	return &extv1alpha1.FileInfo{
+		Name:    info.Name(),
+		Size:    info.Size(),
+		Mode:    uint32(info.Mode()),
+		ModTime: timestamppb.New(info.ModTime()),
+		IsDir:   info.IsDir(),
+		Sys:     protoSys(info),
+	}
+}
+
+func RegisterHandleServer(s grpc.ServiceRegistrar, srv *HandleServer) {
+	extv1alpha1.RegisterHandleServiceServer(s, srv)
+}
+
+// NewServer returns a gRPC server serving fs with the FsService, FileService
+// and HandleService. Its [HandleServer] is installed as a [grpc.StatsHandler],
+// so handles are also released when the connection that opened them drops.
+// Use [WithGrpcOptions] to configure the gRPC server itself.
+func NewServer(fs afero.Fs, options ...ServerOption) *grpc.Server {
+	opts := newServerOptions(options)
+	handles := NewHandleServer(fs, options...)
+
+	server := grpc.NewServer(append(opts.grpcOptions, grpc.StatsHandler(handles))...)
+	RegisterFsServer(server, fs, options...)
+	filev1alpha1grpc.RegisterFileServiceServer(server, NewFileServer(fs, options...))
+	RegisterHandleServer(server, handles)
+
+	return server
+}
@@ -0,0 +1,178 @@
+package protofsv1alpha1_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	extv1alpha1 "github.com/unmango/aferox/protofs/ext/v1alpha1"
+	protofsv1alpha1 "github.com/unmango/aferox/protofs/grpc/v1alpha1"
+	aferox "github.com/unmango/aferox/testing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var _ = Describe("HandleServer", func() {
+	var (
+		fs      afero.Fs
+		handles *protofsv1alpha1.HandleServer
+		conn    *grpc.ClientConn
+		client  afero.Fs
+	)
+
+	BeforeEach(func() {
+		fs = afero.NewMemMapFs()
+		Expect(afero.WriteFile(fs, "test.txt", []byte("testing"), os.ModePerm)).To(Succeed())
+		handles = &protofsv1alpha1.HandleServer{Fs: fs}
+	})
+
+	JustBeforeEach(func() {
+		server := grpc.NewServer(grpc.StatsHandler(handles))
+		protofsv1alpha1.RegisterFsServer(server, fs)
+		protofsv1alpha1.RegisterHandleServer(server, handles)
+
+		sock := filepath.Join(GinkgoT().TempDir(), "fs.sock")
+		lis, err := net.Listen("unix", sock)
+		Expect(err).NotTo(HaveOccurred())
+
+		go server.Serve(lis)
+		DeferCleanup(server.Stop)
+
+		conn, err = grpc.NewClient(fmt.Sprint("unix://", sock),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		client = protofsv1alpha1.NewFs(conn)
+	})
+
+	It("should allocate a handle on open", func() {
+		_, err := client.Open("test.txt")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(handles.Len()).To(Equal(1))
+	})
+
+	It("should release the handle on close", func() {
+		file, err := client.Open("test.txt")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(file.Close()).To(Succeed())
+		Expect(handles.Len()).To(Equal(0))
+	})
+
+	It("should not serve handles to other connections", func(ctx context.Context) {
+		res, err := extv1alpha1.NewHandleServiceClient(conn).Open(ctx, &extv1alpha1.OpenRequest{
+			Name: "test.txt",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		other, err := grpc.NewClient(conn.Target(),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(other.Close)
+
+		_, err = extv1alpha1.NewHandleServiceClient(other).Read(ctx, &extv1alpha1.ReadRequest{
+			Handle: res.Handle,
+			Size:   4,
+		})
+		Expect(err).To(MatchError(ContainSubstring("file already closed")))
+
+		_, err = extv1alpha1.NewHandleServiceClient(other).Close(ctx, &extv1alpha1.CloseRequest{
+			Handle: res.Handle,
+		})
+		Expect(err).To(MatchError(ContainSubstring("file already closed")))
+		Expect(handles.Len()).To(Equal(1))
+	})
+
+	It("should release handles when the connection drops", func() {
+		_, err := client.Open("test.txt")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(conn.Close()).To(Succeed())
+		Eventually(handles.Len).Should(Equal(0))
+	})
+
+	When("an idle timeout is configured", func() {
+		BeforeEach(func() {
+			handles.IdleTimeout = 50 * time.Millisecond
+		})
+
+		It("should release idle handles", func() {
+			file, err := client.Open("test.txt")
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(handles.Len).Should(Equal(0))
+			_, err = file.Stat()
+			Expect(err).To(MatchError(ContainSubstring("file already closed")))
+		})
+	})
+
+	When("the server makes no progress writing", func() {
+		BeforeEach(func() {
+			handles.Fs = &aferox.Fs{
+				Fs: fs,
+				OpenFileFunc: func(name string, flag int, perm os.FileMode) (afero.File, error) {
+					return &aferox.File{
+						NameFunc:    func() string { return name },
+						CloseFunc:   func() error { return nil },
+						WriteFunc:   func([]byte) (int, error) { return 0, nil },
+						WriteAtFunc: func([]byte, int64) (int, error) { return 0, nil },
+					}, nil
+				},
+			}
+		})
+
+		It("should return a short write", func() {
+			file, err := client.Create("test.txt")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = file.Write([]byte("testing"))
+			Expect(err).To(MatchError(io.ErrShortWrite))
+
+			_, err = file.WriteAt([]byte("testing"), 0)
+			Expect(err).To(MatchError(io.ErrShortWrite))
+		})
+	})
+})
+
+var _ = Describe("NewServer", func() {
+	It("should release handles when the connection drops", func() {
+		closed := make(chan struct{})
+		fs := &aferox.Fs{
+			OpenFileFunc: func(name string, flag int, perm os.FileMode) (afero.File, error) {
+				return &aferox.File{
+					NameFunc:  func() string { return name },
+					CloseFunc: func() error { close(closed); return nil },
+				}, nil
+			},
+		}
+		server := protofsv1alpha1.NewServer(fs)
+
+		sock := filepath.Join(GinkgoT().TempDir(), "fs.sock")
+		lis, err := net.Listen("unix", sock)
+		Expect(err).NotTo(HaveOccurred())
+
+		go server.Serve(lis)
+		DeferCleanup(server.Stop)
+
+		conn, err := grpc.NewClient(fmt.Sprint("unix://", sock),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = protofsv1alpha1.NewFs(conn).Open("test.txt")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(conn.Close()).To(Succeed())
+		Eventually(closed).Should(BeClosed())
+	})
+})
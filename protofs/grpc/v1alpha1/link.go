@@ -0,0 +1,165 @@
+package protofsv1alpha1
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/context"
+	"github.com/unmango/aferox/op"
+	extv1alpha1 "github.com/unmango/aferox/protofs/ext/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// LstatIfPossible performs an lstat on the server when the served Fs implements
+// [afero.Lstater], and falls back to a stat otherwise. The returned bool
+// reports whether an lstat was performed.
+func (f *Fs) LstatIfPossible(ctx context.Context, name string) (os.FileInfo, bool, error) {
+	res, err := f.links.Lstat(ctx, &extv1alpha1.LstatRequest{
+		Name: name,
+	})
+	if status.Code(err) == codes.Unimplemented {
+		info, err := f.Stat(ctx, name)
+		return info, false, err
+	}
+	if err != nil {
+		return nil, false, FromStatus("lstat", name, err)
+	}
+
+	return extProtoFileInfo(res.FileInfo), res.Lstat, nil
+}
+
+// SymlinkIfPossible creates newname as a symbolic link to oldname on the server.
+// It returns an [os.LinkError] wrapping [afero.ErrNoSymlink] when the served Fs
+// does not implement [afero.Linker].
+func (f *Fs) SymlinkIfPossible(ctx context.Context, oldname, newname string) error {
+	_, err := f.links.Symlink(ctx, &extv1alpha1.SymlinkRequest{
+		Oldname: oldname,
+		Newname: newname,
+	})
+	if status.Code(err) == codes.Unimplemented {
+		return &os.LinkError{Op: "symlink", Old: oldname, New: newname, Err: afero.ErrNoSymlink}
+	}
+
+	return FromStatus("symlink", newname, err)
+}
+
+// ReadlinkIfPossible returns the destination of the symbolic link name on the server.
+// It returns an [os.PathError] wrapping [afero.ErrNoReadlink] when the served Fs
+// does not implement [afero.LinkReader].
+func (f *Fs) ReadlinkIfPossible(ctx context.Context, name string) (string, error) {
+	res, err := f.links.Readlink(ctx, &extv1alpha1.ReadlinkRequest{
+		Name: name,
+	})
+	if status.Code(err) == codes.Unimplemented {
+		return "", &os.PathError{Op: "readlink", Path: name, Err: afero.ErrNoReadlink}
+	}
+	if err != nil {
+		return "", FromStatus("readlink", name, err)
+	}
+
+	return res.Target, nil
+}
+
+// backgroundFs adapts an Fs to [afero.Fs] including the link
+// interfaces, performing each operation with [context.Background].
+type backgroundFs struct {
+	afero.Fs
+	fs *Fs
+}
+
+// LstatIfPossible implements afero.Lstater.
+func (f backgroundFs) LstatIfPossible(name string) (os.FileInfo, bool, error) {
+	return f.fs.LstatIfPossible(context.Background(), name)
+}
+
+// SymlinkIfPossible implements afero.Linker.
+func (f backgroundFs) SymlinkIfPossible(oldname, newname string) error {
+	return f.fs.SymlinkIfPossible(context.Background(), oldname, newname)
+}
+
+// ReadlinkIfPossible implements afero.LinkReader.
+func (f backgroundFs) ReadlinkIfPossible(name string) (string, error) {
+	return f.fs.ReadlinkIfPossible(context.Background(), name)
+}
+
+var (
+	_ afero.Lstater    = backgroundFs{}
+	_ afero.Linker     = backgroundFs{}
+	_ afero.LinkReader = backgroundFs{}
+)
+
+// Lstat implements extv1alpha1.LinkServiceServer.
+func (s *FsServer) Lstat(ctx context.Context, req *extv1alpha1.LstatRequest) (*extv1alpha1.LstatResponse, error) {
+	if err := s.opts.authorize(ctx, op.Lstat{Name: req.Name}, false); err != nil {
+		return nil, ToStatus(err).Err()
+	}
+
+	var (
+		info  os.FileInfo
+		lstat bool
+		err   error
+	)
+	if lstater, ok := s.Fs.(afero.Lstater); ok {
+		info, lstat, err = lstater.LstatIfPossible(req.Name)
+	} else {
+		info, err = s.fs().StatContext(ctx, req.Name)
+	}
+	if err != nil {
+		return nil, ToStatus(err).Err()
+	}
+
+	return &extv1alpha1.LstatResponse{
+		FileInfo: extFileInfo(info),
+		Lstat:    lstat,
+	}, nil
+}
+
+// Symlink implements extv1alpha1.LinkServiceServer.
+func (s *FsServer) Symlink(ctx context.Context, req *extv1alpha1.SymlinkRequest) (*extv1alpha1.SymlinkResponse, error) {
+	linker, ok := s.Fs.(afero.Linker)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "symlink %s %s: %s", req.Oldname, req.Newname, afero.ErrNoSymlink)
+	}
+	// The link exposes its target, so the caller must be allowed to open it too.
+	if err := s.opts.authorize(ctx, op.Symlink{Oldname: req.Oldname, Newname: req.Newname}, true); err != nil {
+		return nil, ToStatus(err).Err()
+	}
+	if err := s.opts.authorize(ctx, op.Open{Name: linkTarget(req.Oldname, req.Newname)}, false); err != nil {
+		return nil, ToStatus(err).Err()
+	}
+
+	if err := linker.SymlinkIfPossible(req.Oldname, req.Newname); err != nil {
+		return nil, ToStatus(err).Err()
+	} else {
+		return &extv1alpha1.SymlinkResponse{}, nil
+	}
+}
+
+// Readlink implements extv1alpha1.LinkServiceServer.
+func (s *FsServer) Readlink(ctx context.Context, req *extv1alpha1.ReadlinkRequest) (*extv1alpha1.ReadlinkResponse, error) {
+	reader, ok := s.Fs.(afero.LinkReader)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "readlink %s: %s", req.Name, afero.ErrNoReadlink)
+	}
+	if err := s.opts.authorize(ctx, op.Readlink{Name: req.Name}, false); err != nil {
+		return nil, ToStatus(err).Err()
+	}
+
+	if target, err := reader.ReadlinkIfPossible(req.Name); err != nil {
+		return nil, ToStatus(err).Err()
+	} else {
+		return &extv1alpha1.ReadlinkResponse{Target: target}, nil
+	}
+}
+
+// linkTarget returns the path a symlink named newname pointing to oldname
+// resolves to.
+func linkTarget(oldname, newname string) string {
+	if filepath.IsAbs(oldname) {
+		return oldname
+	}
+
+	return filepath.Join(filepath.Dir(newname), oldname)
+}
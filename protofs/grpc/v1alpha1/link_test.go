@@ -0,0 +1,176 @@
+package protofsv1alpha1_test
+
+import (
+	"fmt"
+	iofs "io/fs"
+	"net"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/op"
+	extv1alpha1 "github.com/unmango/aferox/protofs/ext/v1alpha1"
+	protofsv1alpha1 "github.com/unmango/aferox/protofs/grpc/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var _ = Describe("Links", func() {
+	var (
+		fs      afero.Fs
+		options []protofsv1alpha1.ServerOption
+		client  afero.Fs
+	)
+
+	BeforeEach(func() {
+		options = nil
+		fs = afero.NewBasePathFs(afero.NewOsFs(), GinkgoT().TempDir())
+		Expect(afero.WriteFile(fs, "test.txt", []byte("testing"), os.ModePerm)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		server := grpc.NewServer()
+		protofsv1alpha1.RegisterFsServer(server, fs, options...)
+		protofsv1alpha1.RegisterFileServer(server, fs, options...)
+
+		sock := filepath.Join(GinkgoT().TempDir(), "fs.sock")
+		lis, err := net.Listen("unix", sock)
+		Expect(err).NotTo(HaveOccurred())
+
+		go server.Serve(lis)
+		DeferCleanup(server.Stop)
+
+		conn, err := grpc.NewClient(fmt.Sprint("unix://", sock),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		client = protofsv1alpha1.NewFs(conn)
+	})
+
+	It("should create a symlink", func() {
+		linker, ok := client.(afero.Linker)
+		Expect(ok).To(BeTrue())
+
+		Expect(linker.SymlinkIfPossible("test.txt", "link.txt")).To(Succeed())
+
+		target, err := fs.(afero.LinkReader).ReadlinkIfPossible("link.txt")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(target).To(HaveSuffix("test.txt"))
+	})
+
+	It("should read a symlink", func() {
+		Expect(fs.(afero.Linker).SymlinkIfPossible("test.txt", "link.txt")).To(Succeed())
+		reader, ok := client.(afero.LinkReader)
+		Expect(ok).To(BeTrue())
+
+		target, err := reader.ReadlinkIfPossible("link.txt")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(target).To(HaveSuffix("test.txt"))
+	})
+
+	It("should lstat a symlink", func() {
+		Expect(fs.(afero.Linker).SymlinkIfPossible("test.txt", "link.txt")).To(Succeed())
+		lstater, ok := client.(afero.Lstater)
+		Expect(ok).To(BeTrue())
+
+		info, lstat, err := lstater.LstatIfPossible("link.txt")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lstat).To(BeTrue())
+		Expect(info.Mode() & os.ModeSymlink).NotTo(BeZero())
+	})
+
+	It("should return ownership in Sys", func() {
+		info, err := client.Stat("test.txt")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(info.Sys()).To(BeAssignableToTypeOf(&extv1alpha1.Sys{}))
+		sys := info.Sys().(*extv1alpha1.Sys)
+		Expect(sys.Uid).To(BeEquivalentTo(os.Getuid()))
+		Expect(sys.Gid).To(BeEquivalentTo(os.Getgid()))
+		Expect(sys.Ino).NotTo(BeZero())
+	})
+
+	It("should return ownership in Sys for open files", func() {
+		file, err := client.Open("test.txt")
+		Expect(err).NotTo(HaveOccurred())
+
+		info, err := file.Stat()
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Sys()).To(BeAssignableToTypeOf(&extv1alpha1.Sys{}))
+	})
+
+	When("a filter is configured", func() {
+		var operations []op.Operation
+
+		BeforeEach(func() {
+			operations = nil
+			Expect(afero.WriteFile(fs, "secret.txt", []byte("secret"), os.ModePerm)).To(Succeed())
+			options = append(options, protofsv1alpha1.WithFilter(func(o op.Operation) error {
+				operations = append(operations, o)
+				if o.Path() == "secret.txt" {
+					return &iofs.PathError{Op: "filter", Path: o.Path(), Err: iofs.ErrNotExist}
+				}
+
+				return nil
+			}))
+		})
+
+		It("should authorize the link and its target", func() {
+			Expect(client.(afero.Linker).SymlinkIfPossible("test.txt", "link.txt")).To(Succeed())
+
+			Expect(operations).To(ContainElements(
+				op.Symlink{Oldname: "test.txt", Newname: "link.txt"},
+				op.Open{Name: "test.txt"},
+			))
+		})
+
+		It("should deny links to denied targets", func() {
+			err := client.(afero.Linker).SymlinkIfPossible("secret.txt", "link.txt")
+
+			Expect(err).To(MatchError(iofs.ErrNotExist))
+			Expect(afero.Exists(fs, "link.txt")).To(BeFalse())
+		})
+
+		It("should resolve relative targets from the link", func() {
+			Expect(fs.Mkdir("dir", os.ModePerm)).To(Succeed())
+
+			err := client.(afero.Linker).SymlinkIfPossible("../secret.txt", "dir/link.txt")
+
+			Expect(err).To(MatchError(iofs.ErrNotExist))
+		})
+	})
+
+	When("the served Fs does not support links", func() {
+		BeforeEach(func() {
+			fs = afero.NewMemMapFs()
+			Expect(afero.WriteFile(fs, "test.txt", []byte("testing"), os.ModePerm)).To(Succeed())
+		})
+
+		It("should return afero.ErrNoSymlink", func() {
+			err := client.(afero.Linker).SymlinkIfPossible("test.txt", "link.txt")
+
+			Expect(err).To(MatchError(afero.ErrNoSymlink))
+			Expect(err).To(BeAssignableToTypeOf(&os.LinkError{}))
+		})
+
+		It("should return afero.ErrNoReadlink", func() {
+			_, err := client.(afero.LinkReader).ReadlinkIfPossible("test.txt")
+
+			Expect(err).To(MatchError(afero.ErrNoReadlink))
+		})
+
+		It("should fall back to stat", func() {
+			info, _, err := client.(afero.Lstater).LstatIfPossible("test.txt")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Name()).To(Equal("test.txt"))
+			Expect(info.Sys()).To(BeNil())
+		})
+	})
+})
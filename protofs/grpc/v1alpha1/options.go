@@ -0,0 +1,166 @@
+package protofsv1alpha1
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"syscall"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/context"
+	"github.com/unmango/aferox/filter"
+	"github.com/unmango/aferox/op"
+	"github.com/unmango/go/fopt"
+	"google.golang.org/grpc"
+)
+
+// Authorizer decides whether the caller of method may perform operation.
+// The gRPC peer and incoming metadata of the caller are available from ctx
+// via [peer.FromContext] and [metadata.FromIncomingContext].
+// Returning a non-nil error denies the call.
+//
+// [peer.FromContext]: https://pkg.go.dev/google.golang.org/grpc/peer#FromContext
+// [metadata.FromIncomingContext]: https://pkg.go.dev/google.golang.org/grpc/metadata#FromIncomingContext
+type Authorizer func(ctx context.Context, method string, operation op.Operation) error
+
+type serverOptions struct {
+	root            string
+	filter          filter.Filter
+	authorizer      Authorizer
+	readOnly        bool
+	readOnlyMethods []string
+	idleTimeout     time.Duration
+	grpcOptions     []grpc.ServerOption
+}
+
+type ServerOption func(*serverOptions)
+
+// WithRoot jails all requests under root, similar to [afero.BasePathFs].
+func WithRoot(root string) ServerOption {
+	return func(options *serverOptions) {
+		options.root = root
+	}
+}
+
+// WithFilter evaluates filter for each operation, including operations on
+// directories, and denies the request when it returns an error.
+// Directory entries denied by filter are omitted from Readdir results.
+func WithFilter(filter filter.Filter) ServerOption {
+	return func(options *serverOptions) {
+		options.filter = filter
+	}
+}
+
+// WithAuthorizer evaluates authorizer for each operation
+// and denies the request when it returns an error.
+func WithAuthorizer(authorizer Authorizer) ServerOption {
+	return func(options *serverOptions) {
+		options.authorizer = authorizer
+	}
+}
+
+// WithReadOnly denies operations that modify the filesystem with [syscall.EROFS].
+// When methods are given, only calls to those full gRPC method names are denied,
+// e.g. [fsv1alpha1grpc.FsService_Remove_FullMethodName].
+//
+// [fsv1alpha1grpc.FsService_Remove_FullMethodName]: https://pkg.go.dev/buf.build/gen/go/unmango/protofs/grpc/go/dev/unmango/fs/v1alpha1/fsv1alpha1grpc
+func WithReadOnly(methods ...string) ServerOption {
+	return func(options *serverOptions) {
+		options.readOnly = true
+		options.readOnlyMethods = append(options.readOnlyMethods, methods...)
+	}
+}
+
+// WithIdleTimeout sets the IdleTimeout of the [HandleServer].
+func WithIdleTimeout(timeout time.Duration) ServerOption {
+	return func(options *serverOptions) {
+		options.idleTimeout = timeout
+	}
+}
+
+// WithGrpcOptions passes options on to [grpc.NewServer] when creating a server with [NewServer].
+func WithGrpcOptions(options ...grpc.ServerOption) ServerOption {
+	return func(opts *serverOptions) {
+		opts.grpcOptions = append(opts.grpcOptions, options...)
+	}
+}
+
+func newServerOptions(options []ServerOption) serverOptions {
+	opts := serverOptions{idleTimeout: DefaultIdleTimeout}
+	fopt.ApplyAll(&opts, options)
+
+	return opts
+}
+
+func (o serverOptions) fs(base afero.Fs) afero.Fs {
+	if o.root == "" {
+		return base
+	} else {
+		return afero.NewBasePathFs(base, o.root)
+	}
+}
+
+// authorize evaluates the configured checks for operation. Writes is
+// true when operation modifies the filesystem. Unlike [filter.Fs], the
+// filter is applied to directories too.
+func (o serverOptions) authorize(ctx context.Context, operation op.Operation, writes bool) error {
+	method, _ := grpc.Method(ctx)
+	if writes {
+		if err := o.write(method, operation.Path()); err != nil {
+			return err
+		}
+	}
+	if o.authorizer != nil {
+		if err := o.authorizer(ctx, method, operation); err != nil {
+			return err
+		}
+	}
+
+	return o.match(operation)
+}
+
+// match evaluates the configured filter for operation.
+func (o serverOptions) match(operation op.Operation) error {
+	if o.filter == nil {
+		return nil
+	}
+
+	return o.filter(operation)
+}
+
+// write denies modifications of name by method when the server is read-only.
+func (o serverOptions) write(method, name string) error {
+	if !o.readOnly {
+		return nil
+	}
+	if len(o.readOnlyMethods) > 0 && !slices.Contains(o.readOnlyMethods, method) {
+		return nil
+	}
+
+	return &fs.PathError{Op: method, Path: name, Err: syscall.EROFS}
+}
+
+// readdir omits the entries of dir denied by the configured filter.
+func (o serverOptions) readdir(dir string, count int, infos []fs.FileInfo) []fs.FileInfo {
+	if o.filter == nil {
+		return infos
+	}
+
+	return slices.DeleteFunc(infos, func(info fs.FileInfo) bool {
+		return o.filter(op.Readdir{
+			Name:  filepath.Join(dir, info.Name()),
+			Count: count,
+		}) != nil
+	})
+}
+
+func openOperation(name string, flag int, perm fs.FileMode) (op.Operation, bool) {
+	writes := flag&(os.O_WRONLY|os.O_RDWR|os.O_APPEND|os.O_CREATE|os.O_TRUNC) != 0
+	if flag == os.O_RDONLY && perm == 0 {
+		return op.Open{Name: name}, writes
+	} else {
+		return op.OpenFile{Name: name, Flag: flag, Perm: perm}, writes
+	}
+}
@@ -0,0 +1,249 @@
+package protofsv1alpha1_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	iofs "io/fs"
+	"net"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"buf.build/gen/go/unmango/protofs/grpc/go/dev/unmango/fs/v1alpha1/fsv1alpha1grpc"
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/op"
+	extv1alpha1 "github.com/unmango/aferox/protofs/ext/v1alpha1"
+	protofsv1alpha1 "github.com/unmango/aferox/protofs/grpc/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+var _ = Describe("ServerOption", func() {
+	var (
+		fs      afero.Fs
+		options []protofsv1alpha1.ServerOption
+		client  *protofsv1alpha1.Fs
+	)
+
+	BeforeEach(func() {
+		fs = afero.NewMemMapFs()
+		Expect(fs.MkdirAll("root/dir", os.ModePerm)).To(Succeed())
+		Expect(afero.WriteFile(fs, "root/test.txt", []byte("testing"), os.ModePerm)).To(Succeed())
+		Expect(afero.WriteFile(fs, "root/test.md", []byte("# testing"), os.ModePerm)).To(Succeed())
+		Expect(afero.WriteFile(fs, "secret.txt", []byte("secret"), os.ModePerm)).To(Succeed())
+		Expect(afero.WriteFile(fs, "root/private/key.txt", []byte("secret"), os.ModePerm)).To(Succeed())
+		options = nil
+	})
+
+	JustBeforeEach(func() {
+		server := grpc.NewServer()
+		protofsv1alpha1.RegisterFsServer(server, fs, options...)
+		protofsv1alpha1.RegisterFileServer(server, fs, options...)
+
+		sock := filepath.Join(GinkgoT().TempDir(), "fs.sock")
+		lis, err := net.Listen("unix", sock)
+		Expect(err).NotTo(HaveOccurred())
+
+		go server.Serve(lis)
+		DeferCleanup(server.Stop)
+
+		conn, err := grpc.NewClient(fmt.Sprint("unix://", sock),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		client = protofsv1alpha1.NewContextFs(conn)
+	})
+
+	Describe("WithRoot", func() {
+		BeforeEach(func() {
+			options = append(options, protofsv1alpha1.WithRoot("root"))
+		})
+
+		It("should serve paths relative to the root", func(ctx context.Context) {
+			file, err := client.Open(ctx, "test.txt")
+			Expect(err).NotTo(HaveOccurred())
+
+			data, err := afero.ReadAll(file)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("testing"))
+		})
+
+		It("should not escape the root", func(ctx context.Context) {
+			_, err := client.Stat(ctx, "../secret.txt")
+
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should create files under the root", func(ctx context.Context) {
+			Expect(client.Mkdir(ctx, "new", os.ModePerm)).To(Succeed())
+
+			Expect(afero.DirExists(fs, "root/new")).To(BeTrue())
+		})
+	})
+
+	Describe("WithFilter", func() {
+		BeforeEach(func() {
+			options = append(options, protofsv1alpha1.WithFilter(
+				func(o op.Operation) error {
+					if filepath.Base(o.Path()) == "private" {
+						return &iofs.PathError{Op: "filter", Path: o.Path(), Err: iofs.ErrNotExist}
+					}
+					if ext := filepath.Ext(o.Path()); ext == ".txt" || ext == "" {
+						return nil
+					} else {
+						return &iofs.PathError{Op: "filter", Path: o.Path(), Err: iofs.ErrNotExist}
+					}
+				},
+			))
+		})
+
+		It("should allow matching paths", func(ctx context.Context) {
+			_, err := client.Stat(ctx, "root/test.txt")
+
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should deny other paths", func(ctx context.Context) {
+			_, err := client.Stat(ctx, "root/test.md")
+
+			Expect(err).To(MatchError(ContainSubstring("file does not exist")))
+		})
+
+		It("should deny opening other paths", func(ctx context.Context) {
+			_, err := client.Open(ctx, "root/test.md")
+
+			Expect(err).To(MatchError(ContainSubstring("file does not exist")))
+		})
+
+		It("should omit denied directory entries", func(ctx context.Context) {
+			dir, err := client.Open(ctx, "root")
+			Expect(err).NotTo(HaveOccurred())
+
+			names, err := dir.Readdirnames(-1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names).To(ConsistOf("dir", "test.txt"))
+		})
+
+		It("should deny operations on denied directories", func(ctx context.Context) {
+			Expect(client.RemoveAll(ctx, "root/private")).To(MatchError(ContainSubstring("file does not exist")))
+			Expect(client.Rename(ctx, "root/private", "root/public")).To(MatchError(ContainSubstring("file does not exist")))
+			Expect(client.Chmod(ctx, "root/private", 0o777)).To(MatchError(ContainSubstring("file does not exist")))
+			Expect(client.Mkdir(ctx, "root/dir/private", os.ModePerm)).To(MatchError(ContainSubstring("file does not exist")))
+			Expect(afero.Exists(fs, "root/private/key.txt")).To(BeTrue())
+		})
+	})
+
+	Describe("WithReadOnly", func() {
+		When("no methods are given", func() {
+			BeforeEach(func() {
+				options = append(options, protofsv1alpha1.WithReadOnly())
+			})
+
+			It("should allow reads", func(ctx context.Context) {
+				_, err := client.Stat(ctx, "root/test.txt")
+
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should deny writes", func(ctx context.Context) {
+				err := client.Remove(ctx, "root/test.txt")
+
+				Expect(err).To(MatchError(ContainSubstring("read-only file system")))
+				Expect(afero.Exists(fs, "root/test.txt")).To(BeTrue())
+			})
+
+			It("should deny opening files for writing", func(ctx context.Context) {
+				_, err := client.OpenFile(ctx, "root/test.txt", os.O_WRONLY, 0)
+
+				Expect(err).To(MatchError(ContainSubstring("read-only file system")))
+			})
+		})
+
+		When("methods are given", func() {
+			BeforeEach(func() {
+				options = append(options, protofsv1alpha1.WithReadOnly(
+					fsv1alpha1grpc.FsService_Remove_FullMethodName,
+					extv1alpha1.HandleService_Write_FullMethodName,
+				))
+			})
+
+			It("should deny the given methods", func(ctx context.Context) {
+				err := client.Remove(ctx, "root/test.txt")
+
+				Expect(err).To(MatchError(ContainSubstring("read-only file system")))
+			})
+
+			It("should allow other methods", func(ctx context.Context) {
+				Expect(client.Mkdir(ctx, "new", os.ModePerm)).To(Succeed())
+			})
+
+			It("should deny writes to open handles", func(ctx context.Context) {
+				file, err := client.OpenFile(ctx, "root/test.txt", os.O_RDWR, 0)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = file.Write([]byte("denied"))
+				Expect(err).To(MatchError(ContainSubstring("read-only file system")))
+			})
+		})
+	})
+
+	Describe("WithAuthorizer", func() {
+		var methods []string
+
+		BeforeEach(func() {
+			methods = nil
+			options = append(options, protofsv1alpha1.WithAuthorizer(
+				func(ctx context.Context, method string, _ op.Operation) error {
+					methods = append(methods, method)
+					md, _ := metadata.FromIncomingContext(ctx)
+					if len(md.Get("authorization")) == 0 {
+						return errors.New("unauthorized")
+					}
+
+					return nil
+				},
+			))
+		})
+
+		It("should deny unauthorized callers", func(ctx context.Context) {
+			_, err := client.Stat(ctx, "root/test.txt")
+
+			Expect(err).To(MatchError(ContainSubstring("unauthorized")))
+			Expect(methods).To(ConsistOf(fsv1alpha1grpc.FsService_Stat_FullMethodName))
+		})
+
+		It("should allow authorized callers", func(ctx context.Context) {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer token")
+
+			_, err := client.Stat(ctx, "root/test.txt")
+
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should authorize opening handles", func(ctx context.Context) {
+			_, err := client.Open(ctx, "root/test.txt")
+
+			Expect(err).To(MatchError(ContainSubstring("unauthorized")))
+			Expect(methods).To(ConsistOf(extv1alpha1.HandleService_Open_FullMethodName))
+		})
+
+		It("should authorize each call on a handle", func(ctx context.Context) {
+			authorized := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer token")
+			file, err := client.Open(authorized, "root/test.txt")
+			Expect(err).NotTo(HaveOccurred())
+
+			file.(*protofsv1alpha1.File).SetContext(ctx)
+			_, err = file.Read(make([]byte, 4))
+
+			Expect(err).To(MatchError(ContainSubstring("unauthorized")))
+			Expect(methods).To(HaveExactElements(
+				extv1alpha1.HandleService_Open_FullMethodName,
+				extv1alpha1.HandleService_Read_FullMethodName,
+			))
+		})
+	})
+})
@@ -0,0 +1,166 @@
+package protofsv1alpha1
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/unmango/aferox"
+	"github.com/unmango/aferox/context"
+	"github.com/unmango/aferox/op"
+	extv1alpha1 "github.com/unmango/aferox/protofs/ext/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Watch returns a channel that receives an operation for each change to name
+// on the server, or to anything beneath name when it is a directory.
+// The channel is closed once ctx is done or the stream ends.
+func (f *Fs) Watch(ctx context.Context, name string) (<-chan op.Operation, error) {
+	stream, err := f.watches.Watch(ctx, &extv1alpha1.WatchRequest{
+		Name: name,
+	})
+	if err != nil {
+		return nil, FromStatus("watch", name, err)
+	}
+
+	// Wait for the server to establish the watch
+	if md, err := stream.Header(); err != nil {
+		return nil, FromStatus("watch", name, err)
+	} else if md == nil {
+		if _, err := stream.Recv(); errors.Is(err, io.EOF) {
+			return nil, &fs.PathError{Op: "watch", Path: name, Err: io.ErrUnexpectedEOF}
+		} else {
+			return nil, FromStatus("watch", name, err)
+		}
+	}
+
+	ch := make(chan op.Operation)
+	go func() {
+		defer close(ch)
+
+		for {
+			res, err := stream.Recv()
+			if err != nil {
+				return
+			}
+
+			select {
+			case ch <- watchOperation(res):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// Watch returns the changes to name on the server, see [Fs.Watch].
+func (f backgroundFs) Watch(ctx context.Context, name string) (<-chan op.Operation, error) {
+	return f.fs.Watch(ctx, name)
+}
+
+// Watch implements extv1alpha1.WatchServiceServer.
+func (s *FsServer) Watch(req *extv1alpha1.WatchRequest, stream grpc.ServerStreamingServer[extv1alpha1.WatchResponse]) error {
+	ctx := stream.Context()
+	if err := s.opts.authorize(ctx, op.Stat{Name: req.Name}, false); err != nil {
+		return ToStatus(err).Err()
+	}
+
+	// Watch the unwrapped Fs, as the root jail hides its Watch method
+	fsys, name := s.Fs, req.Name
+	if s.opts.root != "" && s.base != nil {
+		fsys, name = s.base, filepath.Join(s.opts.root, req.Name)
+	}
+
+	w, ok := fsys.(aferox.Watcher)
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "watch %s: not supported", req.Name)
+	}
+
+	events, err := w.Watch(ctx, name)
+	if err != nil {
+		return ToStatus(err).Err()
+	}
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for operation := range events {
+		operation = s.unroot(operation)
+		if s.opts.match(operation) != nil {
+			continue
+		}
+		if err := stream.Send(watchResponse(operation)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// unroot makes the paths of operation relative to the configured root.
+func (s *FsServer) unroot(operation op.Operation) op.Operation {
+	if s.opts.root == "" {
+		return operation
+	}
+
+	rel := func(path string) string {
+		if path == "" {
+			return path
+		}
+		if rel, err := filepath.Rel(s.opts.root, path); err == nil {
+			return rel
+		} else {
+			return path
+		}
+	}
+
+	switch o := operation.(type) {
+	case op.Create:
+		return op.Create{Name: rel(o.Name)}
+	case op.Remove:
+		return op.Remove{Name: rel(o.Name)}
+	case op.Rename:
+		return op.Rename{Oldname: rel(o.Oldname), Newname: rel(o.Newname)}
+	case op.Chmod:
+		return op.Chmod{Name: rel(o.Name), Mode: o.Mode}
+	default:
+		return operation
+	}
+}
+
+func watchResponse(operation op.Operation) *extv1alpha1.WatchResponse {
+	switch o := operation.(type) {
+	case op.Create:
+		return &extv1alpha1.WatchResponse{Op: extv1alpha1.WatchOp_WATCH_OP_CREATE, Name: o.Name}
+	case op.Remove:
+		return &extv1alpha1.WatchResponse{Op: extv1alpha1.WatchOp_WATCH_OP_REMOVE, Name: o.Name}
+	case op.Rename:
+		return &extv1alpha1.WatchResponse{Op: extv1alpha1.WatchOp_WATCH_OP_RENAME, Name: o.Oldname, NewName: o.Newname}
+	case op.Chmod:
+		return &extv1alpha1.WatchResponse{Op: extv1alpha1.WatchOp_WATCH_OP_CHMOD, Name: o.Name, Mode: uint32(o.Mode)}
+	default:
+		return &extv1alpha1.WatchResponse{Name: operation.Path()}
+	}
+}
+
+func watchOperation(res *extv1alpha1.WatchResponse) op.Operation {
+	switch res.Op {
+	case extv1alpha1.WatchOp_WATCH_OP_CREATE:
+		return op.Create{Name: res.Name}
+	case extv1alpha1.WatchOp_WATCH_OP_REMOVE:
+		return op.Remove{Name: res.Name}
+	case extv1alpha1.WatchOp_WATCH_OP_RENAME:
+		return op.Rename{Oldname: res.Name, Newname: res.NewName}
+	case extv1alpha1.WatchOp_WATCH_OP_CHMOD:
+		return op.Chmod{Name: res.Name, Mode: fs.FileMode(res.Mode)}
+	default:
+		// Unknown to this client, report that something changed
+		return op.Stat{Name: res.Name}
+	}
+}
@@ -0,0 +1,152 @@
+package protofsv1alpha1_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox"
+	"github.com/unmango/aferox/op"
+	protofsv1alpha1 "github.com/unmango/aferox/protofs/grpc/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Describe("Watch", func() {
+	var (
+		fs      afero.Fs
+		watchFs *aferox.WatchFs
+		options []protofsv1alpha1.ServerOption
+		conn    *grpc.ClientConn
+		client  *protofsv1alpha1.Fs
+	)
+
+	BeforeEach(func() {
+		base := afero.NewMemMapFs()
+		Expect(base.MkdirAll("root/dir", os.ModePerm)).To(Succeed())
+		watchFs = aferox.NewWatchFs(base)
+		fs = watchFs
+		options = nil
+	})
+
+	JustBeforeEach(func() {
+		server := grpc.NewServer()
+		protofsv1alpha1.RegisterFsServer(server, fs, options...)
+
+		sock := filepath.Join(GinkgoT().TempDir(), "fs.sock")
+		lis, err := net.Listen("unix", sock)
+		Expect(err).NotTo(HaveOccurred())
+
+		go server.Serve(lis)
+		DeferCleanup(server.Stop)
+
+		conn, err = grpc.NewClient(fmt.Sprint("unix://", sock),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		client = protofsv1alpha1.NewContextFs(conn)
+	})
+
+	It("should stream operations", func(ctx context.Context) {
+		ch, err := client.Watch(ctx, "root")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = watchFs.Create("root/test.txt")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(watchFs.Rename("root/test.txt", "root/new.txt")).To(Succeed())
+		Expect(watchFs.Chmod("root/new.txt", 0o600)).To(Succeed())
+		info, err := watchFs.Stat("root/new.txt")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(watchFs.Remove("root/new.txt")).To(Succeed())
+
+		Eventually(ch).Should(Receive(Equal(op.Create{Name: "root/test.txt"})))
+		Eventually(ch).Should(Receive(Equal(op.Rename{Oldname: "root/test.txt", Newname: "root/new.txt"})))
+		Eventually(ch).Should(Receive(Equal(op.Chmod{Name: "root/new.txt", Mode: info.Mode()})))
+		Eventually(ch).Should(Receive(Equal(op.Remove{Name: "root/new.txt"})))
+	})
+
+	It("should close the channel when the context is done", func(ctx context.Context) {
+		ctx, cancel := context.WithCancel(ctx)
+		ch, err := client.Watch(ctx, "root")
+		Expect(err).NotTo(HaveOccurred())
+
+		cancel()
+
+		Eventually(ch).Should(BeClosed())
+	})
+
+	It("should fail for missing paths", func(ctx context.Context) {
+		_, err := client.Watch(ctx, "missing")
+
+		Expect(err).To(MatchError(os.ErrNotExist))
+	})
+
+	It("should watch through NewFs", func(ctx context.Context) {
+		watcher, ok := protofsv1alpha1.NewFs(conn).(aferox.Watcher)
+		Expect(ok).To(BeTrue())
+
+		_, err := watcher.Watch(ctx, "root")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	When("a root is configured", func() {
+		BeforeEach(func() {
+			options = append(options, protofsv1alpha1.WithRoot("root"))
+		})
+
+		It("should watch beneath the root", func(ctx context.Context) {
+			ch, err := client.Watch(ctx, "dir")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(watchFs.Mkdir("root/other", os.ModePerm)).To(Succeed())
+			Expect(watchFs.Mkdir("root/dir/sub", os.ModePerm)).To(Succeed())
+
+			Eventually(ch).Should(Receive(Equal(op.Create{Name: "dir/sub"})))
+			Consistently(ch).ShouldNot(Receive())
+		})
+	})
+
+	When("a filter is configured", func() {
+		BeforeEach(func() {
+			options = append(options, protofsv1alpha1.WithFilter(func(o op.Operation) error {
+				if filepath.Ext(o.Path()) == ".md" {
+					return os.ErrNotExist
+				}
+				return nil
+			}))
+		})
+
+		It("should omit denied operations", func(ctx context.Context) {
+			ch, err := client.Watch(ctx, "root")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = watchFs.Create("root/test.md")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = watchFs.Create("root/test.txt")
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(ch).Should(Receive(Equal(op.Create{Name: "root/test.txt"})))
+			Consistently(ch).ShouldNot(Receive())
+		})
+	})
+
+	When("the served Fs cannot watch", func() {
+		BeforeEach(func() {
+			fs = afero.NewMemMapFs()
+		})
+
+		It("should return Unimplemented", func(ctx context.Context) {
+			_, err := client.Watch(ctx, "")
+
+			Expect(status.Code(err)).To(Equal(codes.Unimplemented))
+		})
+	})
+})
@@ -0,0 +1,17 @@
+package internal
+
+import (
+	"io/fs"
+
+	extv1alpha1 "github.com/unmango/aferox/protofs/ext/v1alpha1"
+)
+
+// Sys returns the ownership and identity of info,
+// or nil when the platform or filesystem does not provide them.
+func Sys(info fs.FileInfo) *extv1alpha1.Sys {
+	if sys, ok := info.Sys().(*extv1alpha1.Sys); ok {
+		return sys
+	} else {
+		return statSys(info)
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !unix
+
+package internal
+
+import (
+	"io/fs"
+
+	extv1alpha1 "github.com/unmango/aferox/protofs/ext/v1alpha1"
+)
+
+func statSys(fs.FileInfo) *extv1alpha1.Sys {
+	return nil
+}
@@ -0,0 +1,25 @@
+//go:build unix
+
+package internal
+
+import (
+	"io/fs"
+	"syscall"
+
+	extv1alpha1 "github.com/unmango/aferox/protofs/ext/v1alpha1"
+)
+
+func statSys(info fs.FileInfo) *extv1alpha1.Sys {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil
+	}
+
+	return &extv1alpha1.Sys{
+		Uid:   stat.Uid,
+		Gid:   stat.Gid,
+		Ino:   uint64(stat.Ino),
+		Nlink: uint64(stat.Nlink),
+		Dev:   uint64(stat.Dev),
+	}
+}
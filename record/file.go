@@ -0,0 +1,171 @@
+package record
+
+import (
+	"io/fs"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/op"
+)
+
+type File struct {
+	file    afero.File
+	sink    Sink
+	payload Payload
+	handle  uint64
+}
+
+// Close implements afero.File.
+func (f *File) Close() error {
+	done := f.start(op.Close{Name: f.file.Name()})
+	err := f.file.Close()
+	done(err, 0)
+
+	return err
+}
+
+// Name implements afero.File.
+func (f *File) Name() string {
+	return f.file.Name()
+}
+
+// Read implements afero.File.
+func (f *File) Read(p []byte) (n int, err error) {
+	begin := time.Now()
+	n, err = f.file.Read(p)
+	record(f.sink, Entry{
+		Operation: op.Read{Name: f.file.Name(), N: n},
+		Handle:    f.handle,
+		Err:       err,
+		Start:     begin,
+		N:         n,
+	})
+
+	return n, err
+}
+
+// ReadAt implements afero.File.
+func (f *File) ReadAt(p []byte, off int64) (n int, err error) {
+	done := f.start(op.ReadAt{Name: f.file.Name(), Offset: off})
+	n, err = f.file.ReadAt(p, off)
+	done(err, n)
+
+	return n, err
+}
+
+// Readdir implements afero.File.
+func (f *File) Readdir(count int) ([]fs.FileInfo, error) {
+	done := f.start(op.Readdir{Name: f.file.Name(), Count: count})
+	infos, err := f.file.Readdir(count)
+	done(err, len(infos))
+
+	return infos, err
+}
+
+// Readdirnames implements afero.File.
+func (f *File) Readdirnames(n int) ([]string, error) {
+	done := f.start(op.Readdirnames{Name: f.file.Name(), Count: n})
+	names, err := f.file.Readdirnames(n)
+	done(err, len(names))
+
+	return names, err
+}
+
+// Seek implements afero.File.
+func (f *File) Seek(offset int64, whence int) (int64, error) {
+	done := f.start(op.Seek{Name: f.file.Name(), Offset: offset, Whence: whence})
+	ret, err := f.file.Seek(offset, whence)
+	done(err, 0)
+
+	return ret, err
+}
+
+// Stat implements afero.File.
+func (f *File) Stat() (fs.FileInfo, error) {
+	done := f.start(op.Stat{Name: f.file.Name()})
+	info, err := f.file.Stat()
+	done(err, 0)
+
+	return info, err
+}
+
+// Sync implements afero.File.
+func (f *File) Sync() error {
+	done := f.start(op.Sync{Name: f.file.Name()})
+	err := f.file.Sync()
+	done(err, 0)
+
+	return err
+}
+
+// Truncate implements afero.File.
+func (f *File) Truncate(size int64) error {
+	done := f.start(op.Truncate{Name: f.file.Name(), Size: size})
+	err := f.file.Truncate(size)
+	done(err, 0)
+
+	return err
+}
+
+// Write implements afero.File.
+func (f *File) Write(p []byte) (n int, err error) {
+	operation := op.Write{Name: f.file.Name()}
+	begin := time.Now()
+	n, err = f.file.Write(p)
+	data, digest := capture(f.payload, p[:n])
+	operation.Data = data
+	record(f.sink, Entry{
+		Operation: operation,
+		Handle:    f.handle,
+		Err:       err,
+		Start:     begin,
+		N:         n,
+		Digest:    digest,
+	})
+
+	return n, err
+}
+
+// WriteAt implements afero.File.
+func (f *File) WriteAt(p []byte, off int64) (n int, err error) {
+	operation := op.WriteAt{Name: f.file.Name(), Offset: off}
+	begin := time.Now()
+	n, err = f.file.WriteAt(p, off)
+	data, digest := capture(f.payload, p[:n])
+	operation.Data = data
+	record(f.sink, Entry{
+		Operation: operation,
+		Handle:    f.handle,
+		Err:       err,
+		Start:     begin,
+		N:         n,
+		Digest:    digest,
+	})
+
+	return n, err
+}
+
+// WriteString implements afero.File.
+func (f *File) WriteString(s string) (ret int, err error) {
+	operation := op.Write{Name: f.file.Name()}
+	begin := time.Now()
+	ret, err = f.file.WriteString(s)
+	data, digest := capture(f.payload, s[:ret])
+	operation.Data = data
+	record(f.sink, Entry{
+		Operation: operation,
+		Handle:    f.handle,
+		Err:       err,
+		Start:     begin,
+		N:         ret,
+		Digest:    digest,
+	})
+
+	return ret, err
+}
+
+func (f *File) start(operation op.Operation) func(error, int) {
+	return start(f.sink, operation, f.handle)
+}
+
+var _ afero.File = (*File)(nil)
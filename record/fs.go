@@ -0,0 +1,231 @@
+package record
+
+import (
+	"io/fs"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/op"
+	"github.com/unmango/go/fopt"
+)
+
+// NewFs returns an [afero.Fs] that records every call made to base,
+// including calls made to the files it opens, to sink.
+func NewFs(base afero.Fs, sink Sink, options ...Option) afero.Fs {
+	opts := defaultOptions(sink)
+	fopt.ApplyAll(&opts, options)
+
+	return &Fs{src: base, sink: sink, payload: opts.payload}
+}
+
+type Fs struct {
+	src     afero.Fs
+	sink    Sink
+	payload Payload
+	handles atomic.Uint64
+}
+
+func defaultOptions(sink Sink) options {
+	if p, ok := sink.(payloader); ok {
+		return options{payload: p.payload()}
+	} else {
+		return options{payload: Data}
+	}
+}
+
+// Chmod implements afero.Fs.
+func (f *Fs) Chmod(name string, mode fs.FileMode) error {
+	done := f.start(op.Chmod{Name: name, Mode: mode})
+	err := f.src.Chmod(name, mode)
+	done(err, 0)
+
+	return err
+}
+
+// Chown implements afero.Fs.
+func (f *Fs) Chown(name string, uid int, gid int) error {
+	done := f.start(op.Chown{Name: name, UID: uid, GID: gid})
+	err := f.src.Chown(name, uid, gid)
+	done(err, 0)
+
+	return err
+}
+
+// Chtimes implements afero.Fs.
+func (f *Fs) Chtimes(name string, atime time.Time, mtime time.Time) error {
+	done := f.start(op.Chtimes{Name: name, Atime: atime, Mtime: mtime})
+	err := f.src.Chtimes(name, atime, mtime)
+	done(err, 0)
+
+	return err
+}
+
+// Create implements afero.Fs.
+func (f *Fs) Create(name string) (afero.File, error) {
+	handle := f.handles.Add(1)
+	done := start(f.sink, op.Create{Name: name}, handle)
+	file, err := f.src.Create(name)
+	done(err, 0)
+
+	return f.file(file, handle, err)
+}
+
+// LstatIfPossible implements afero.Lstater.
+func (f *Fs) LstatIfPossible(name string) (info fs.FileInfo, lstat bool, err error) {
+	done := f.start(op.Lstat{Name: name})
+	if lstater, ok := f.src.(afero.Lstater); ok {
+		info, lstat, err = lstater.LstatIfPossible(name)
+	} else {
+		info, err = f.src.Stat(name)
+	}
+	done(err, 0)
+
+	return info, lstat, err
+}
+
+// Mkdir implements afero.Fs.
+func (f *Fs) Mkdir(name string, perm fs.FileMode) error {
+	done := f.start(op.Mkdir{Name: name, Perm: perm})
+	err := f.src.Mkdir(name, perm)
+	done(err, 0)
+
+	return err
+}
+
+// MkdirAll implements afero.Fs.
+func (f *Fs) MkdirAll(path string, perm fs.FileMode) error {
+	done := f.start(op.MkdirAll{Name: path, Perm: perm})
+	err := f.src.MkdirAll(path, perm)
+	done(err, 0)
+
+	return err
+}
+
+// Name implements afero.Fs.
+func (f *Fs) Name() string {
+	return "record: " + f.src.Name()
+}
+
+// Open implements afero.Fs.
+func (f *Fs) Open(name string) (afero.File, error) {
+	handle := f.handles.Add(1)
+	done := start(f.sink, op.Open{Name: name}, handle)
+	file, err := f.src.Open(name)
+	done(err, 0)
+
+	return f.file(file, handle, err)
+}
+
+// OpenFile implements afero.Fs.
+func (f *Fs) OpenFile(name string, flag int, perm fs.FileMode) (afero.File, error) {
+	handle := f.handles.Add(1)
+	done := start(f.sink, op.OpenFile{Name: name, Flag: flag, Perm: perm}, handle)
+	file, err := f.src.OpenFile(name, flag, perm)
+	done(err, 0)
+
+	return f.file(file, handle, err)
+}
+
+// ReadlinkIfPossible implements afero.LinkReader.
+func (f *Fs) ReadlinkIfPossible(name string) (link string, err error) {
+	done := f.start(op.Readlink{Name: name})
+	if reader, ok := f.src.(afero.LinkReader); ok {
+		link, err = reader.ReadlinkIfPossible(name)
+	} else {
+		err = &fs.PathError{Op: "readlink", Path: name, Err: afero.ErrNoReadlink}
+	}
+	done(err, 0)
+
+	return link, err
+}
+
+// Remove implements afero.Fs.
+func (f *Fs) Remove(name string) error {
+	done := f.start(op.Remove{Name: name})
+	err := f.src.Remove(name)
+	done(err, 0)
+
+	return err
+}
+
+// RemoveAll implements afero.Fs.
+func (f *Fs) RemoveAll(path string) error {
+	done := f.start(op.RemoveAll{Name: path})
+	err := f.src.RemoveAll(path)
+	done(err, 0)
+
+	return err
+}
+
+// Rename implements afero.Fs.
+func (f *Fs) Rename(oldname string, newname string) error {
+	done := f.start(op.Rename{Oldname: oldname, Newname: newname})
+	err := f.src.Rename(oldname, newname)
+	done(err, 0)
+
+	return err
+}
+
+// Stat implements afero.Fs.
+func (f *Fs) Stat(name string) (fs.FileInfo, error) {
+	done := f.start(op.Stat{Name: name})
+	info, err := f.src.Stat(name)
+	done(err, 0)
+
+	return info, err
+}
+
+// SymlinkIfPossible implements afero.Linker.
+func (f *Fs) SymlinkIfPossible(oldname string, newname string) (err error) {
+	done := f.start(op.Symlink{Oldname: oldname, Newname: newname})
+	if linker, ok := f.src.(afero.Linker); ok {
+		err = linker.SymlinkIfPossible(oldname, newname)
+	} else {
+		err = &os.LinkError{Op: "symlink", Old: oldname, New: newname, Err: afero.ErrNoSymlink}
+	}
+	done(err, 0)
+
+	return err
+}
+
+func (f *Fs) file(file afero.File, handle uint64, err error) (afero.File, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	return &File{file: file, sink: f.sink, payload: f.payload, handle: handle}, nil
+}
+
+func (f *Fs) start(operation op.Operation) func(error, int) {
+	return start(f.sink, operation, 0)
+}
+
+// start begins timing operation and returns a function that records
+// its result to sink.
+func start(sink Sink, operation op.Operation, handle uint64) func(error, int) {
+	begin := time.Now()
+	return func(err error, n int) {
+		record(sink, Entry{
+			Operation: operation,
+			Handle:    handle,
+			Err:       err,
+			Start:     begin,
+			N:         n,
+		})
+	}
+}
+
+// record sends e to sink once the duration of the call is known.
+func record(sink Sink, e Entry) {
+	e.Duration = time.Since(e.Start)
+	sink.Record(e)
+}
+
+var (
+	_ afero.Fs         = (*Fs)(nil)
+	_ afero.Lstater    = (*Fs)(nil)
+	_ afero.Linker     = (*Fs)(nil)
+	_ afero.LinkReader = (*Fs)(nil)
+)
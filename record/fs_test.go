@@ -0,0 +1,175 @@
+package record_test
+
+import (
+	"crypto/sha256"
+	"io"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/op"
+	"github.com/unmango/aferox/record"
+)
+
+var _ = Describe("Fs", func() {
+	var (
+		base afero.Fs
+		sink *record.Memory
+		fsys afero.Fs
+	)
+
+	BeforeEach(func() {
+		base = afero.NewMemMapFs()
+		sink = &record.Memory{}
+		fsys = record.NewFs(base, sink)
+	})
+
+	It("should record operations", func() {
+		Expect(fsys.MkdirAll("dir", os.ModePerm)).To(Succeed())
+		Expect(fsys.Chmod("dir", 0o700)).To(Succeed())
+		Expect(fsys.Rename("dir", "other")).To(Succeed())
+		Expect(fsys.RemoveAll("other")).To(Succeed())
+
+		Expect(sink.Operations()).To(Equal([]op.Operation{
+			op.MkdirAll{Name: "dir", Perm: os.ModePerm},
+			op.Chmod{Name: "dir", Mode: 0o700},
+			op.Rename{Oldname: "dir", Newname: "other"},
+			op.RemoveAll{Name: "other"},
+		}))
+	})
+
+	It("should record errors", func() {
+		_, err := fsys.Stat("missing")
+		Expect(err).To(HaveOccurred())
+
+		entries := sink.Entries()
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].Operation).To(Equal(op.Stat{Name: "missing"}))
+		Expect(entries[0].Err).To(MatchError(os.ErrNotExist))
+	})
+
+	It("should record timing", func() {
+		Expect(fsys.Mkdir("dir", os.ModePerm)).To(Succeed())
+
+		entries := sink.Entries()
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].Start).NotTo(BeZero())
+		Expect(entries[0].Duration).To(BeNumerically(">=", 0))
+	})
+
+	It("should record byte counts of file calls", func() {
+		file, err := fsys.Create("test.txt")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = file.WriteString("testing")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(file.Close()).To(Succeed())
+
+		file, err = fsys.Open("test.txt")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = io.ReadAll(file)
+		Expect(err).NotTo(HaveOccurred())
+
+		entries := sink.Entries()
+		Expect(entries).To(ContainElement(And(
+			HaveField("Operation", Equal(op.Write{Name: "test.txt", Data: []byte("testing")})),
+			HaveField("N", 7),
+		)))
+		Expect(entries).To(ContainElement(And(
+			HaveField("Operation", Equal(op.Read{Name: "test.txt", N: 7})),
+			HaveField("N", 7),
+		)))
+		Expect(entries).To(ContainElement(And(
+			HaveField("Operation", Equal(op.Read{Name: "test.txt"})),
+			HaveField("Err", MatchError(io.EOF)),
+		)))
+	})
+
+	It("should record the handle of file calls", func() {
+		first, err := fsys.Create("test.txt")
+		Expect(err).NotTo(HaveOccurred())
+		second, err := fsys.Open("test.txt")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(second.Close()).To(Succeed())
+		Expect(first.Close()).To(Succeed())
+
+		Expect(sink.Operations()).To(Equal([]op.Operation{
+			op.Handle{ID: 1, Operation: op.Create{Name: "test.txt"}},
+			op.Handle{ID: 2, Operation: op.Open{Name: "test.txt"}},
+			op.Handle{ID: 2, Operation: op.Close{Name: "test.txt"}},
+			op.Handle{ID: 1, Operation: op.Close{Name: "test.txt"}},
+		}))
+	})
+
+	It("should record directory listings", func() {
+		Expect(base.MkdirAll("dir/a", os.ModePerm)).To(Succeed())
+		Expect(base.MkdirAll("dir/b", os.ModePerm)).To(Succeed())
+		dir, err := fsys.Open("dir")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = dir.Readdirnames(-1)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(sink.Entries()).To(ContainElement(And(
+			HaveField("Operation", Equal(op.Readdirnames{Name: "dir", Count: -1})),
+			HaveField("N", 2),
+		)))
+	})
+
+	It("should not wrap failed opens", func() {
+		file, err := fsys.Open("missing")
+
+		Expect(err).To(MatchError(os.ErrNotExist))
+		Expect(file).To(BeNil())
+	})
+
+	It("should record links", func() {
+		err := fsys.(afero.Linker).SymlinkIfPossible("test.txt", "link")
+		Expect(err).To(MatchError(afero.ErrNoSymlink))
+
+		Expect(sink.Entries()).To(ConsistOf(And(
+			HaveField("Operation", Equal(op.Symlink{Oldname: "test.txt", Newname: "link"})),
+			HaveField("Err", MatchError(afero.ErrNoSymlink)),
+		)))
+	})
+
+	It("should record only the length of writes", func() {
+		fsys = record.NewFs(base, sink, record.WithPayload(record.Length))
+		file, err := fsys.Create("test.txt")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = file.Write([]byte("testing"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(sink.Entries()).To(ContainElement(And(
+			HaveField("Operation", Equal(op.Write{Name: "test.txt"})),
+			HaveField("N", 7),
+			HaveField("Digest", BeNil()),
+		)))
+	})
+
+	It("should record the digest of writes", func() {
+		fsys = record.NewFs(base, sink, record.WithPayload(record.Digest))
+		file, err := fsys.Create("test.txt")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = file.WriteString("testing")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = file.WriteAt([]byte("testing"), 0)
+		Expect(err).NotTo(HaveOccurred())
+
+		sum := sha256.Sum256([]byte("testing"))
+		Expect(sink.Entries()).To(ContainElements(
+			And(
+				HaveField("Operation", Equal(op.Write{Name: "test.txt"})),
+				HaveField("Digest", Equal(sum[:])),
+			),
+			And(
+				HaveField("Operation", Equal(op.WriteAt{Name: "test.txt"})),
+				HaveField("Digest", Equal(sum[:])),
+			),
+		))
+	})
+})
@@ -0,0 +1,53 @@
+package record
+
+import (
+	"crypto/sha256"
+)
+
+// Payload selects what an [Fs] records of the data passed to Write,
+// WriteAt and WriteString.
+type Payload int
+
+const (
+	// Data records a copy of the written bytes in the operation.
+	Data Payload = iota
+
+	// Length records only the number of bytes written, in [Entry.N].
+	Length
+
+	// Digest records the SHA-256 digest of the written bytes in [Entry.Digest].
+	Digest
+)
+
+type options struct {
+	payload Payload
+}
+
+type Option func(*options)
+
+// WithPayload sets what is recorded of written data. When the option is not
+// given, an [Fs] uses the payload given to sinks that take one, such as
+// [JSONLines], and [Data] otherwise.
+func WithPayload(payload Payload) Option {
+	return func(options *options) {
+		options.payload = payload
+	}
+}
+
+// payloader is implemented by sinks that prefer a [Payload].
+type payloader interface {
+	payload() Payload
+}
+
+// capture returns what payload records of data.
+func capture[T string | []byte](payload Payload, data T) (clone, digest []byte) {
+	switch payload {
+	case Data:
+		return append([]byte{}, data...), nil
+	case Digest:
+		sum := sha256.Sum256([]byte(data))
+		return nil, sum[:]
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,200 @@
+package record
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/unmango/aferox/op"
+	"github.com/unmango/go/fopt"
+)
+
+// Entry describes a single call made through an [Fs] or [File].
+type Entry struct {
+	Operation op.Operation
+
+	// Handle identifies the file a call was made through, or the file
+	// opened by a Create, Open or OpenFile. It is zero for other calls.
+	Handle uint64
+
+	Err      error
+	Start    time.Time
+	Duration time.Duration
+
+	// N is the number of bytes read or written,
+	// or the number of directory entries returned.
+	N int
+
+	// Digest is the SHA-256 digest of the bytes written,
+	// when the [Fs] records the [Digest] payload.
+	Digest []byte
+}
+
+// Op returns the name of the recorded operation, e.g. "Create" or "Read".
+func (e Entry) Op() string {
+	return reflect.TypeOf(e.Operation).Name()
+}
+
+// Sink receives the entries recorded by an [Fs].
+// Sinks must be safe for concurrent use.
+type Sink interface {
+	Record(Entry)
+}
+
+// SinkFunc adapts a function to a [Sink].
+type SinkFunc func(Entry)
+
+// Record implements Sink.
+func (f SinkFunc) Record(e Entry) {
+	f(e)
+}
+
+// Memory is a [Sink] that keeps recorded entries in memory.
+type Memory struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// Record implements Sink.
+func (m *Memory) Record(e Entry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries = append(m.entries, e)
+}
+
+// Entries returns a copy of the recorded entries.
+func (m *Memory) Entries() []Entry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return slices.Clone(m.entries)
+}
+
+// Operations returns the operations of the recorded entries, so that they
+// can be replayed by aferox.Replay. Operations with a handle are tagged with
+// an [op.Handle], and calls that failed without reading or writing any bytes
+// are left out.
+func (m *Memory) Operations() []op.Operation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ops := make([]op.Operation, 0, len(m.entries))
+	for _, e := range m.entries {
+		if e.Err != nil && e.N == 0 {
+			continue
+		}
+		if e.Handle == 0 {
+			ops = append(ops, e.Operation)
+		} else {
+			ops = append(ops, op.Handle{ID: e.Handle, Operation: e.Operation})
+		}
+	}
+
+	return ops
+}
+
+// Reset discards the recorded entries.
+func (m *Memory) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries = nil
+}
+
+// Slog returns a [Sink] that logs each entry to logger.
+// Failed operations are logged at [slog.LevelError], others at [slog.LevelDebug].
+func Slog(logger *slog.Logger) Sink {
+	return SinkFunc(func(e Entry) {
+		level := slog.LevelDebug
+		attrs := []slog.Attr{
+			slog.String("op", e.Op()),
+			slog.String("path", e.Operation.Path()),
+			slog.Duration("duration", e.Duration),
+		}
+		if e.N > 0 {
+			attrs = append(attrs, slog.Int("n", e.N))
+		}
+		if e.Err != nil {
+			level = slog.LevelError
+			attrs = append(attrs, slog.Any("error", e.Err))
+		}
+
+		logger.LogAttrs(context.Background(), level, "fs operation", attrs...)
+	})
+}
+
+// JSONLines returns a [Sink] that writes each entry to w as a line of JSON,
+// which [op.NewDecoder] reads back for replaying. Errors writing to w are
+// ignored. An [Fs] recording to the sink records a copy of written data,
+// unless [WithPayload] is given here or to [NewFs]. Logs recording the
+// [Length] or [Digest] of written data cannot be replayed.
+func JSONLines(w io.Writer, options ...Option) Sink {
+	s := &jsonLines{
+		enc:  json.NewEncoder(w),
+		opts: jsonOptions(),
+	}
+
+	fopt.ApplyAll(&s.opts, options)
+	return s
+}
+
+type jsonLines struct {
+	mu   sync.Mutex
+	enc  *json.Encoder
+	opts options
+}
+
+// Record implements Sink.
+func (s *jsonLines) Record(e Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_ = s.enc.Encode(jsonEntry(e))
+}
+
+func (s *jsonLines) payload() Payload {
+	return s.opts.payload
+}
+
+func jsonOptions() options {
+	return options{payload: Data}
+}
+
+type jsonLine struct {
+	Time      time.Time     `json:"time"`
+	Op        string        `json:"op"`
+	Handle    uint64        `json:"handle,omitempty"`
+	Path      string        `json:"path"`
+	Operation op.Operation  `json:"operation"`
+	Duration  time.Duration `json:"duration"`
+	N         int           `json:"n,omitempty"`
+	Digest    string        `json:"digest,omitempty"`
+	Error     string        `json:"error,omitempty"`
+}
+
+func jsonEntry(e Entry) jsonLine {
+	line := jsonLine{
+		Time:      e.Start,
+		Op:        e.Op(),
+		Handle:    e.Handle,
+		Path:      e.Operation.Path(),
+		Operation: e.Operation,
+		Duration:  e.Duration,
+		N:         e.N,
+	}
+	if e.Digest != nil {
+		line.Digest = "sha256:" + hex.EncodeToString(e.Digest)
+	}
+	if e.Err != nil {
+		line.Error = e.Err.Error()
+	}
+
+	return line
+}
@@ -0,0 +1,116 @@
+package record_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox"
+	"github.com/unmango/aferox/op"
+	"github.com/unmango/aferox/record"
+)
+
+var _ = Describe("Sink", func() {
+	var buf *bytes.Buffer
+
+	BeforeEach(func() {
+		buf = &bytes.Buffer{}
+	})
+
+	Describe("JSONLines", func() {
+		It("should write a line per operation", func() {
+			fsys := record.NewFs(afero.NewMemMapFs(), record.JSONLines(buf))
+
+			Expect(fsys.Mkdir("dir", os.ModePerm)).To(Succeed())
+			_, err := fsys.Stat("missing")
+			Expect(err).To(HaveOccurred())
+
+			lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+			Expect(lines).To(HaveLen(2))
+
+			var first, second map[string]any
+			Expect(json.Unmarshal(lines[0], &first)).To(Succeed())
+			Expect(json.Unmarshal(lines[1], &second)).To(Succeed())
+			Expect(first).To(HaveKeyWithValue("op", "Mkdir"))
+			Expect(first).To(HaveKeyWithValue("path", "dir"))
+			Expect(first).NotTo(HaveKey("error"))
+			Expect(second).To(HaveKeyWithValue("op", "Stat"))
+			Expect(second).To(HaveKeyWithValue("error", ContainSubstring("file does not exist")))
+		})
+
+		It("should write the digest of written data when asked to", func() {
+			sink := record.JSONLines(buf, record.WithPayload(record.Digest))
+			fsys := record.NewFs(afero.NewMemMapFs(), sink)
+			file, err := fsys.Create("test.txt")
+			Expect(err).NotTo(HaveOccurred())
+
+			buf.Reset()
+			_, err = file.Write([]byte("testing"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var line map[string]any
+			Expect(json.Unmarshal(buf.Bytes(), &line)).To(Succeed())
+			Expect(line).To(HaveKeyWithValue("digest",
+				"sha256:cf80cd8aed482d5d1527d7dc72fceff84e6326592848447d2dc0b0e87dfc9a90",
+			))
+			Expect(line).To(HaveKeyWithValue("operation", HaveKeyWithValue("Data", BeNil())))
+		})
+
+		It("should write data by default", func() {
+			fsys := record.NewFs(afero.NewMemMapFs(), record.JSONLines(buf))
+			file, err := fsys.Create("test.txt")
+			Expect(err).NotTo(HaveOccurred())
+
+			buf.Reset()
+			_, err = file.Write([]byte("testing"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var line map[string]any
+			Expect(json.Unmarshal(buf.Bytes(), &line)).To(Succeed())
+			Expect(line).NotTo(HaveKey("digest"))
+			Expect(line).To(HaveKeyWithValue("operation", HaveKeyWithValue("Data", "dGVzdGluZw==")))
+		})
+
+		It("should write lines that can be replayed", func() {
+			fsys := record.NewFs(afero.NewMemMapFs(), record.JSONLines(buf))
+			Expect(afero.WriteFile(fsys, "test.txt", []byte("testing"), os.ModePerm)).To(Succeed())
+
+			ops := []op.Operation{}
+			dec := op.NewDecoder(buf)
+			for {
+				o, err := dec.Decode()
+				if err == io.EOF {
+					break
+				}
+				Expect(err).NotTo(HaveOccurred())
+				ops = append(ops, o)
+			}
+
+			replayed := afero.NewMemMapFs()
+			Expect(aferox.Replay(replayed, ops)).To(Succeed())
+			Expect(afero.ReadFile(replayed, "test.txt")).To(Equal([]byte("testing")))
+		})
+	})
+
+	Describe("Slog", func() {
+		It("should log each operation", func() {
+			logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+				Level: slog.LevelDebug,
+			}))
+			fsys := record.NewFs(afero.NewMemMapFs(), record.Slog(logger))
+
+			Expect(fsys.Mkdir("dir", os.ModePerm)).To(Succeed())
+			_, err := fsys.Stat("missing")
+			Expect(err).To(HaveOccurred())
+
+			Expect(buf.String()).To(ContainSubstring("level=DEBUG msg=\"fs operation\" op=Mkdir path=dir"))
+			Expect(buf.String()).To(ContainSubstring("level=ERROR msg=\"fs operation\" op=Stat path=missing"))
+		})
+	})
+})
@@ -0,0 +1,159 @@
+package aferox
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/op"
+)
+
+// Replay applies ops to fsys in order, such as those recorded from
+// another filesystem. Files opened by an [op.Create], [op.Open] or
+// [op.OpenFile] stay open until an [op.Close] or the end of the replay.
+// File operations tagged with an [op.Handle] apply to the file opened by
+// the operation with the same handle, so files open at the same time are
+// kept apart. Untagged file operations apply to the file last opened under
+// their name by an untagged operation. File operations on a file that is
+// not open fail with [fs.ErrClosed]. An [op.Read] advances the offset of
+// its file by the bytes it read, and other operations without side effects,
+// such as [op.Stat], are skipped. Replay stops at the first operation that
+// fails, so ops should only hold the operations that succeeded when they
+// were recorded.
+func Replay(fsys afero.Fs, ops []op.Operation) (err error) {
+	files := map[handleKey]afero.File{}
+	defer func() {
+		for _, file := range files {
+			err = errors.Join(err, file.Close())
+		}
+	}()
+
+	for i, operation := range ops {
+		key := handleKey{name: operation.Path()}
+		if h, ok := operation.(op.Handle); ok {
+			key, operation = handleKey{id: h.ID}, h.Operation
+		}
+
+		open := func(file afero.File, err error) error {
+			if err != nil {
+				return err
+			}
+			if prev, ok := files[key]; ok {
+				if err := prev.Close(); err != nil {
+					return err
+				}
+			}
+
+			files[key] = file
+			return nil
+		}
+
+		// handle returns the open file the operation is made through
+		handle := func() (afero.File, error) {
+			if file, ok := files[key]; ok {
+				return file, nil
+			}
+
+			return nil, &fs.PathError{
+				Op:   strings.ToLower(op.Name(operation)),
+				Path: operation.Path(),
+				Err:  fs.ErrClosed,
+			}
+		}
+
+		var err error
+		switch o := operation.(type) {
+		case op.Chmod:
+			err = fsys.Chmod(o.Name, o.Mode)
+		case op.Chown:
+			err = fsys.Chown(o.Name, o.UID, o.GID)
+		case op.Chtimes:
+			err = fsys.Chtimes(o.Name, o.Atime, o.Mtime)
+		case op.Create:
+			err = open(fsys.Create(o.Name))
+		case op.Mkdir:
+			err = fsys.Mkdir(o.Name, o.Perm)
+		case op.MkdirAll:
+			err = fsys.MkdirAll(o.Name, o.Perm)
+		case op.Open:
+			err = open(fsys.Open(o.Name))
+		case op.OpenFile:
+			err = open(fsys.OpenFile(o.Name, o.Flag, o.Perm))
+		case op.Remove:
+			err = fsys.Remove(o.Name)
+		case op.RemoveAll:
+			err = fsys.RemoveAll(o.Name)
+		case op.Rename:
+			if err = fsys.Rename(o.Oldname, o.Newname); err == nil {
+				old := handleKey{name: o.Oldname}
+				if file, ok := files[old]; ok {
+					delete(files, old)
+					err = open(file, nil)
+				}
+			}
+		case op.Write:
+			var file afero.File
+			if file, err = handle(); err == nil {
+				_, err = file.Write(o.Data)
+			}
+		case op.WriteAt:
+			var file afero.File
+			if file, err = handle(); err == nil {
+				_, err = file.WriteAt(o.Data, o.Offset)
+			}
+		case op.Truncate:
+			var file afero.File
+			if file, err = handle(); err == nil {
+				err = file.Truncate(o.Size)
+			}
+		case op.Seek:
+			var file afero.File
+			if file, err = handle(); err == nil {
+				_, err = file.Seek(o.Offset, o.Whence)
+			}
+		case op.Sync:
+			var file afero.File
+			if file, err = handle(); err == nil {
+				err = file.Sync()
+			}
+		case op.Close:
+			var file afero.File
+			if file, err = handle(); err == nil {
+				delete(files, key)
+				err = file.Close()
+			}
+		case op.Read:
+			var file afero.File
+			if file, err = handle(); err == nil && o.N > 0 {
+				_, err = file.Seek(int64(o.N), io.SeekCurrent)
+			}
+		case op.Symlink:
+			if linker, ok := fsys.(afero.Linker); ok {
+				err = linker.SymlinkIfPossible(o.Oldname, o.Newname)
+			} else {
+				err = &os.LinkError{Op: "symlink", Old: o.Oldname, New: o.Newname, Err: afero.ErrNoSymlink}
+			}
+		case op.Stat, op.Lstat, op.Readdir, op.Readdirnames,
+			op.ReadAt, op.Readlink:
+			continue
+		default:
+			err = fmt.Errorf("unsupported operation type %T", operation)
+		}
+		if err != nil {
+			return fmt.Errorf("replay %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// handleKey identifies a file opened during a replay, either by the
+// ID of its [op.Handle] or by the name it was opened under.
+type handleKey struct {
+	id   uint64
+	name string
+}
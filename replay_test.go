@@ -0,0 +1,168 @@
+package aferox_test
+
+import (
+	"io"
+	"io/fs"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox"
+	"github.com/unmango/aferox/op"
+	"github.com/unmango/aferox/record"
+)
+
+var _ = Describe("Replay", func() {
+	var fsys afero.Fs
+
+	BeforeEach(func() {
+		fsys = afero.NewMemMapFs()
+	})
+
+	It("should apply operations", func() {
+		err := aferox.Replay(fsys, []op.Operation{
+			op.MkdirAll{Name: "dir/sub", Perm: os.ModePerm},
+			op.Create{Name: "dir/test.txt"},
+			op.Write{Name: "dir/test.txt", Data: []byte("test")},
+			op.Write{Name: "dir/test.txt", Data: []byte("ing")},
+			op.Rename{Oldname: "dir/test.txt", Newname: "dir/sub/new.txt"},
+			op.Chmod{Name: "dir/sub/new.txt", Mode: 0o600},
+			op.Stat{Name: "dir/sub/new.txt"},
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		data, err := afero.ReadFile(fsys, "dir/sub/new.txt")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("testing"))
+		info, err := fsys.Stat("dir/sub/new.txt")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o600)))
+	})
+
+	DescribeTable("should fail for files that are not open",
+		func(operation op.Operation) {
+			Expect(afero.WriteFile(fsys, "test.txt", []byte("test"), os.ModePerm)).To(Succeed())
+
+			err := aferox.Replay(fsys, []op.Operation{
+				op.Handle{ID: 1, Operation: op.Open{Name: "test.txt"}},
+				operation,
+			})
+
+			Expect(err).To(MatchError(fs.ErrClosed))
+			Expect(err).To(MatchError(ContainSubstring("replay 1")))
+			Expect(afero.ReadFile(fsys, "test.txt")).To(Equal([]byte("test")))
+		},
+		Entry("Write", op.Write{Name: "test.txt", Data: []byte("ing")}),
+		Entry("WriteAt", op.Handle{ID: 2, Operation: op.WriteAt{Name: "test.txt", Data: []byte("ing")}}),
+		Entry("Truncate", op.Truncate{Name: "test.txt"}),
+		Entry("Seek", op.Handle{ID: 2, Operation: op.Seek{Name: "test.txt"}}),
+		Entry("Sync", op.Sync{Name: "test.txt"}),
+		Entry("Close", op.Handle{ID: 2, Operation: op.Close{Name: "test.txt"}}),
+	)
+
+	It("should keep files open at the same time apart", func() {
+		err := aferox.Replay(fsys, []op.Operation{
+			op.Handle{ID: 1, Operation: op.Create{Name: "test.txt"}},
+			op.Handle{ID: 2, Operation: op.OpenFile{Name: "test.txt", Flag: os.O_WRONLY}},
+			op.Handle{ID: 1, Operation: op.Write{Name: "test.txt", Data: []byte("testing")}},
+			op.Handle{ID: 2, Operation: op.Write{Name: "test.txt", Data: []byte("T")}},
+			op.Handle{ID: 2, Operation: op.Close{Name: "test.txt"}},
+			op.Handle{ID: 1, Operation: op.Write{Name: "test.txt", Data: []byte("!")}},
+			op.Handle{ID: 1, Operation: op.Close{Name: "test.txt"}},
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(afero.ReadFile(fsys, "test.txt")).To(Equal([]byte("Testing!")))
+	})
+
+	It("should apply file operations", func() {
+		err := aferox.Replay(fsys, []op.Operation{
+			op.OpenFile{Name: "test.txt", Flag: os.O_RDWR | os.O_CREATE, Perm: 0o644},
+			op.Write{Name: "test.txt", Data: []byte("testing")},
+			op.WriteAt{Name: "test.txt", Offset: 0, Data: []byte("T")},
+			op.Truncate{Name: "test.txt", Size: 4},
+			op.Seek{Name: "test.txt", Offset: 0, Whence: io.SeekEnd},
+			op.Write{Name: "test.txt", Data: []byte("ed")},
+			op.Sync{Name: "test.txt"},
+			op.Close{Name: "test.txt"},
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(afero.ReadFile(fsys, "test.txt")).To(Equal([]byte("Tested")))
+	})
+
+	It("should stop at the first failure", func() {
+		err := aferox.Replay(fsys, []op.Operation{
+			op.Remove{Name: "missing"},
+			op.Mkdir{Name: "dir", Perm: os.ModePerm},
+		})
+
+		Expect(err).To(MatchError(os.ErrNotExist))
+		Expect(err).To(MatchError(ContainSubstring("replay 0")))
+		Expect(afero.DirExists(fsys, "dir")).To(BeFalse())
+	})
+
+	It("should replay recorded operations", func() {
+		sink := &record.Memory{}
+		src := record.NewFs(afero.NewMemMapFs(), sink)
+		Expect(src.MkdirAll("dir", os.ModePerm)).To(Succeed())
+		Expect(afero.WriteFile(src, "dir/test.txt", []byte("testing"), 0o644)).To(Succeed())
+
+		Expect(aferox.Replay(fsys, sink.Operations())).To(Succeed())
+
+		Expect(afero.ReadFile(fsys, "dir/test.txt")).To(Equal([]byte("testing")))
+	})
+
+	It("should replay writes made after reading a file", func() {
+		base := afero.NewMemMapFs()
+		Expect(afero.WriteFile(base, "test.txt", []byte("0123456789"), os.ModePerm)).To(Succeed())
+		Expect(afero.WriteFile(fsys, "test.txt", []byte("0123456789"), os.ModePerm)).To(Succeed())
+		sink := &record.Memory{}
+		src := record.NewFs(base, sink)
+		file, err := src.OpenFile("test.txt", os.O_RDWR, 0)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = file.Read(make([]byte, 5))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = file.Write([]byte("XX"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(file.Close()).To(Succeed())
+
+		Expect(aferox.Replay(fsys, sink.Operations())).To(Succeed())
+
+		Expect(afero.ReadFile(fsys, "test.txt")).To(Equal([]byte("01234XX789")))
+	})
+
+	It("should replay recorded operations that succeeded", func() {
+		sink := &record.Memory{}
+		src := record.NewFs(afero.NewMemMapFs(), sink)
+		Expect(src.Remove("missing")).NotTo(Succeed())
+		Expect(afero.WriteFile(src, "test.txt", []byte("testing"), os.ModePerm)).To(Succeed())
+		_, err := afero.ReadFile(src, "test.txt")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(aferox.Replay(fsys, sink.Operations())).To(Succeed())
+
+		Expect(afero.ReadFile(fsys, "test.txt")).To(Equal([]byte("testing")))
+	})
+
+	It("should replay files recorded open at the same time", func() {
+		sink := &record.Memory{}
+		src := record.NewFs(afero.NewMemMapFs(), sink)
+		first, err := src.Create("test.txt")
+		Expect(err).NotTo(HaveOccurred())
+		second, err := src.OpenFile("test.txt", os.O_WRONLY, 0)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = first.Write([]byte("testing"))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = second.Write([]byte("T"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second.Close()).To(Succeed())
+		Expect(first.Close()).To(Succeed())
+
+		Expect(aferox.Replay(fsys, sink.Operations())).To(Succeed())
+
+		Expect(afero.ReadFile(fsys, "test.txt")).To(Equal([]byte("Testing")))
+	})
+})
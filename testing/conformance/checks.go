@@ -0,0 +1,420 @@
+package conformance
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+type check struct {
+	name string
+	run  func(*env)
+
+	// only runs the check when the Fs lacks one of these capabilities
+	only Capability
+	// unless skips the check when the Fs lacks one of these capabilities
+	unless Capability
+}
+
+var checks = []check{
+	{name: "Name returns a name", run: func(e *env) {
+		if e.fs.Name() == "" {
+			e.Errorf("Name: expected a name")
+		}
+	}},
+	{name: "Stat describes files", run: func(e *env) {
+		info := e.stat("hello.txt")
+		e.equal(info.Name(), "hello.txt", "Name", "hello.txt")
+		e.equal(info.Size(), int64(13), "Size", "hello.txt")
+		e.equal(info.IsDir(), false, "IsDir", "hello.txt")
+		e.equal(info.Mode().IsRegular(), true, "Mode().IsRegular", "hello.txt")
+	}},
+	{name: "Stat describes directories", run: func(e *env) {
+		info := e.stat("dir")
+		e.equal(info.Name(), "dir", "Name", "dir")
+		e.equal(info.IsDir(), true, "IsDir", "dir")
+		e.equal(e.stat("empty").IsDir(), true, "IsDir", "empty")
+	}},
+	{name: "Stat reports missing files", run: func(e *env) {
+		_, err := e.fs.Stat(e.path("missing.txt"))
+		e.is(err, fs.ErrNotExist, "Stat", "missing.txt")
+	}},
+	{name: "Open reads files", run: func(e *env) {
+		f := e.open("hello.txt")
+		defer f.Close()
+
+		data, err := io.ReadAll(f)
+		e.ok(err, "Read", "hello.txt")
+		e.equal(string(data), fixture["hello.txt"], "content", "hello.txt")
+
+		n, err := f.Read(make([]byte, 1))
+		e.equal(n, 0, "Read at EOF", "hello.txt")
+		e.equal(err, io.EOF, "Read error at EOF", "hello.txt")
+	}},
+	{name: "Open reads nested files", run: func(e *env) {
+		e.equal(e.read("dir/sub/c.txt"), fixture["dir/sub/c.txt"], "content", "dir/sub/c.txt")
+	}},
+	{name: "Open reports missing files", run: func(e *env) {
+		_, err := e.fs.Open(e.path("missing.txt"))
+		e.is(err, fs.ErrNotExist, "Open", "missing.txt")
+
+		_, err = e.fs.Open(e.path("missing/a.txt"))
+		e.is(err, fs.ErrNotExist, "Open", "missing/a.txt")
+	}},
+	{name: "File.Stat describes the file", run: func(e *env) {
+		f := e.open("hello.txt")
+		defer f.Close()
+
+		info, err := f.Stat()
+		e.ok(err, "File.Stat", "hello.txt")
+		e.equal(info.Name(), "hello.txt", "Name", "hello.txt")
+		e.equal(info.Size(), int64(13), "Size", "hello.txt")
+		e.equal(filepath.Base(f.Name()), "hello.txt", "File.Name", "hello.txt")
+	}},
+	{name: "Seek moves the read offset", run: func(e *env) {
+		f := e.open("hello.txt")
+		defer f.Close()
+
+		buf := make([]byte, 5)
+		off, err := f.Seek(7, io.SeekStart)
+		e.ok(err, "Seek", "hello.txt")
+		e.equal(off, int64(7), "Seek offset", "hello.txt")
+		_, err = io.ReadFull(f, buf)
+		e.ok(err, "Read", "hello.txt")
+		e.equal(string(buf), "world", "content at 7", "hello.txt")
+
+		off, err = f.Seek(0, io.SeekCurrent)
+		e.ok(err, "Seek", "hello.txt")
+		e.equal(off, int64(12), "Seek offset", "hello.txt")
+
+		off, err = f.Seek(-6, io.SeekEnd)
+		e.ok(err, "Seek", "hello.txt")
+		e.equal(off, int64(7), "Seek offset", "hello.txt")
+		_, err = io.ReadFull(f, buf)
+		e.ok(err, "Read", "hello.txt")
+		e.equal(string(buf), "world", "content at 7", "hello.txt")
+	}},
+	{name: "ReadAt reads without moving the offset", run: func(e *env) {
+		f := e.open("hello.txt")
+		defer f.Close()
+
+		buf := make([]byte, 5)
+		_, err := f.ReadAt(buf, 7)
+		e.ok(err, "ReadAt", "hello.txt")
+		e.equal(string(buf), "world", "content at 7", "hello.txt")
+
+		_, err = io.ReadFull(f, buf)
+		e.ok(err, "Read", "hello.txt")
+		e.equal(string(buf), "hello", "content at 0", "hello.txt")
+
+		_, err = f.ReadAt(buf, 13)
+		e.equal(err, io.EOF, "ReadAt error at EOF", "hello.txt")
+	}},
+	{name: "Readdirnames lists directories", run: func(e *env) {
+		f := e.open("dir")
+		defer f.Close()
+
+		names := e.names("dir", f)
+		slices.Sort(names)
+		e.equal(slices.Equal(names, []string{"a.txt", "b.txt", "sub"}), true, "Readdirnames", "dir")
+	}},
+	{name: "Readdir describes entries", run: func(e *env) {
+		f := e.open("dir")
+		defer f.Close()
+
+		infos, err := f.Readdir(-1)
+		e.ok(err, "Readdir", "dir")
+		for _, info := range infos {
+			switch info.Name() {
+			case "a.txt":
+				e.equal(info.Size(), int64(1), "Size", "dir/a.txt")
+			case "b.txt":
+				e.equal(info.Size(), int64(2), "Size", "dir/b.txt")
+			case "sub":
+				e.equal(info.IsDir(), true, "IsDir", "dir/sub")
+			default:
+				e.Errorf("Readdir dir: unexpected entry %s", info.Name())
+			}
+		}
+		e.equal(len(infos), 3, "entries", "dir")
+	}},
+	{name: "Readdir returns entries in batches", run: func(e *env) {
+		f := e.open("dir")
+		defer f.Close()
+
+		seen := map[string]bool{}
+		for range 3 {
+			infos, err := f.Readdir(1)
+			e.ok(err, "Readdir", "dir")
+			e.equal(len(infos), 1, "batch", "dir")
+			for _, info := range infos {
+				if seen[info.Name()] {
+					e.Errorf("Readdir dir: %s listed twice", info.Name())
+				}
+
+				seen[info.Name()] = true
+			}
+		}
+
+		infos, err := f.Readdir(1)
+		e.equal(len(infos), 0, "batch at end", "dir")
+		e.equal(err, io.EOF, "Readdir error at end", "dir")
+
+		names, err := f.Readdirnames(-1)
+		e.ok(err, "Readdirnames", "dir")
+		e.equal(len(names), 0, "entries at end", "dir")
+	}},
+	{name: "Readdirnames continues where Readdir stopped", run: func(e *env) {
+		f := e.open("dir")
+		defer f.Close()
+
+		infos, err := f.Readdir(1)
+		e.ok(err, "Readdir", "dir")
+		names := e.names("dir", f)
+		names = append(names, infos[0].Name())
+		slices.Sort(names)
+		e.equal(slices.Equal(names, []string{"a.txt", "b.txt", "sub"}), true, "Readdirnames", "dir")
+	}},
+	{name: "ReadDir sorts entries by name", run: func(e *env) {
+		infos, err := afero.ReadDir(e.fs, e.path(""))
+		e.ok(err, "ReadDir", "root")
+
+		names := []string{}
+		for _, info := range infos {
+			names = append(names, info.Name())
+		}
+		e.equal(slices.Equal(names, []string{"dir", "empty", "hello.txt"}), true, "ReadDir", "root")
+	}},
+	{name: "Readdirnames lists empty directories", run: func(e *env) {
+		f := e.open("empty")
+		defer f.Close()
+
+		e.equal(len(e.names("empty", f)), 0, "entries", "empty")
+	}},
+	{name: "Walk visits every file", run: func(e *env) {
+		files := []string{}
+		err := afero.Walk(e.fs, e.path(""), func(path string, info fs.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() {
+				rel, err := filepath.Rel(e.path(""), path)
+				if err != nil {
+					return err
+				}
+
+				files = append(files, filepath.ToSlash(rel))
+			}
+
+			return nil
+		})
+		e.ok(err, "Walk", "root")
+		e.equal(slices.Equal(files, []string{"dir/a.txt", "dir/b.txt", "dir/sub/c.txt", "hello.txt"}), true, "Walk", "root")
+	}},
+
+	{name: "Create writes new files", unless: ReadOnly, run: func(e *env) {
+		f, err := e.fs.Create(e.path("new.txt"))
+		e.ok(err, "Create", "new.txt")
+		_, err = f.WriteString("new")
+		e.ok(err, "Write", "new.txt")
+		e.ok(f.Close(), "Close", "new.txt")
+
+		e.equal(e.read("new.txt"), "new", "content", "new.txt")
+		e.equal(e.stat("new.txt").Size(), int64(3), "Size", "new.txt")
+	}},
+	{name: "Create truncates existing files", unless: ReadOnly, run: func(e *env) {
+		f, err := e.fs.Create(e.path("hello.txt"))
+		e.ok(err, "Create", "hello.txt")
+		_, err = f.WriteString("bye")
+		e.ok(err, "Write", "hello.txt")
+		e.ok(f.Close(), "Close", "hello.txt")
+
+		e.equal(e.read("hello.txt"), "bye", "content", "hello.txt")
+	}},
+	{name: "OpenFile appends with O_APPEND", unless: ReadOnly, run: func(e *env) {
+		e.write("dir/a.txt", os.O_WRONLY|os.O_APPEND, "b")
+		e.equal(e.read("dir/a.txt"), "ab", "content", "dir/a.txt")
+	}},
+	{name: "OpenFile with O_EXCL reports existing files", unless: ReadOnly, run: func(e *env) {
+		_, err := e.fs.OpenFile(e.path("hello.txt"), os.O_RDWR|os.O_CREATE|os.O_EXCL, 0644)
+		e.is(err, fs.ErrExist, "OpenFile", "hello.txt")
+	}},
+	{name: "OpenFile without O_CREATE reports missing files", unless: ReadOnly, run: func(e *env) {
+		_, err := e.fs.OpenFile(e.path("missing.txt"), os.O_RDWR, 0644)
+		e.is(err, fs.ErrNotExist, "OpenFile", "missing.txt")
+	}},
+	{name: "OpenFile reads back writes through the same handle", unless: ReadOnly, run: func(e *env) {
+		f, err := e.fs.OpenFile(e.path("new.txt"), os.O_RDWR|os.O_CREATE, 0644)
+		e.ok(err, "OpenFile", "new.txt")
+		defer f.Close()
+
+		_, err = f.WriteString("abc")
+		e.ok(err, "Write", "new.txt")
+		_, err = f.Seek(0, io.SeekStart)
+		e.ok(err, "Seek", "new.txt")
+		data, err := io.ReadAll(f)
+		e.ok(err, "Read", "new.txt")
+		e.equal(string(data), "abc", "content", "new.txt")
+	}},
+	{name: "WriteAt writes at offsets", unless: ReadOnly, run: func(e *env) {
+		f, err := e.fs.OpenFile(e.path("hello.txt"), os.O_RDWR, 0644)
+		e.ok(err, "OpenFile", "hello.txt")
+		_, err = f.WriteAt([]byte("WORLD"), 7)
+		e.ok(err, "WriteAt", "hello.txt")
+		e.ok(f.Close(), "Close", "hello.txt")
+
+		e.equal(e.read("hello.txt"), "hello, WORLD\n", "content", "hello.txt")
+	}},
+	{name: "Truncate shrinks files", unless: ReadOnly, run: func(e *env) {
+		f, err := e.fs.OpenFile(e.path("hello.txt"), os.O_RDWR, 0644)
+		e.ok(err, "OpenFile", "hello.txt")
+		e.ok(f.Truncate(5), "Truncate", "hello.txt")
+		e.ok(f.Close(), "Close", "hello.txt")
+
+		e.equal(e.read("hello.txt"), "hello", "content", "hello.txt")
+	}},
+	{name: "Write fails on files opened for reading", unless: ReadOnly, run: func(e *env) {
+		f := e.open("hello.txt")
+		defer f.Close()
+
+		_, err := f.WriteString("bye")
+		e.fails(err, "Write", "hello.txt")
+	}},
+	{name: "Mkdir creates directories", unless: ReadOnly, run: func(e *env) {
+		e.ok(e.fs.Mkdir(e.path("new"), 0755), "Mkdir", "new")
+		e.equal(e.stat("new").IsDir(), true, "IsDir", "new")
+	}},
+	{name: "Mkdir reports existing directories", unless: ReadOnly, run: func(e *env) {
+		e.is(e.fs.Mkdir(e.path("dir"), 0755), fs.ErrExist, "Mkdir", "dir")
+	}},
+	{name: "MkdirAll creates parents", unless: ReadOnly, run: func(e *env) {
+		e.ok(e.fs.MkdirAll(e.path("new/nested"), 0755), "MkdirAll", "new/nested")
+		e.equal(e.stat("new").IsDir(), true, "IsDir", "new")
+		e.equal(e.stat("new/nested").IsDir(), true, "IsDir", "new/nested")
+		e.ok(e.fs.MkdirAll(e.path("dir/sub"), 0755), "MkdirAll", "dir/sub")
+	}},
+	{name: "Remove deletes files", unless: ReadOnly, run: func(e *env) {
+		e.ok(e.fs.Remove(e.path("dir/a.txt")), "Remove", "dir/a.txt")
+
+		_, err := e.fs.Stat(e.path("dir/a.txt"))
+		e.is(err, fs.ErrNotExist, "Stat", "dir/a.txt")
+		e.equal(e.read("dir/b.txt"), fixture["dir/b.txt"], "content", "dir/b.txt")
+	}},
+	{name: "Remove deletes empty directories", unless: ReadOnly, run: func(e *env) {
+		e.ok(e.fs.Remove(e.path("empty")), "Remove", "empty")
+
+		_, err := e.fs.Stat(e.path("empty"))
+		e.is(err, fs.ErrNotExist, "Stat", "empty")
+	}},
+	{name: "Remove reports missing files", unless: ReadOnly, run: func(e *env) {
+		e.is(e.fs.Remove(e.path("missing.txt")), fs.ErrNotExist, "Remove", "missing.txt")
+	}},
+	{name: "RemoveAll deletes trees", unless: ReadOnly, run: func(e *env) {
+		e.ok(e.fs.RemoveAll(e.path("dir")), "RemoveAll", "dir")
+
+		_, err := e.fs.Stat(e.path("dir/sub/c.txt"))
+		e.is(err, fs.ErrNotExist, "Stat", "dir/sub/c.txt")
+		_, err = e.fs.Stat(e.path("dir"))
+		e.is(err, fs.ErrNotExist, "Stat", "dir")
+		e.equal(e.read("hello.txt"), fixture["hello.txt"], "content", "hello.txt")
+	}},
+	{name: "RemoveAll ignores missing paths", unless: ReadOnly, run: func(e *env) {
+		e.ok(e.fs.RemoveAll(e.path("missing")), "RemoveAll", "missing")
+	}},
+	{name: "Rename moves files", unless: ReadOnly | NoRename, run: func(e *env) {
+		e.ok(e.fs.Rename(e.path("dir/a.txt"), e.path("dir/renamed.txt")), "Rename", "dir/a.txt")
+
+		e.equal(e.read("dir/renamed.txt"), fixture["dir/a.txt"], "content", "dir/renamed.txt")
+		_, err := e.fs.Stat(e.path("dir/a.txt"))
+		e.is(err, fs.ErrNotExist, "Stat", "dir/a.txt")
+	}},
+	{name: "Rename replaces existing files", unless: ReadOnly | NoRename, run: func(e *env) {
+		e.ok(e.fs.Rename(e.path("dir/a.txt"), e.path("dir/b.txt")), "Rename", "dir/a.txt")
+		e.equal(e.read("dir/b.txt"), fixture["dir/a.txt"], "content", "dir/b.txt")
+	}},
+	{name: "Rename moves directories", unless: ReadOnly | NoRename, run: func(e *env) {
+		e.ok(e.fs.Rename(e.path("dir"), e.path("moved")), "Rename", "dir")
+
+		e.equal(e.read("moved/sub/c.txt"), fixture["dir/sub/c.txt"], "content", "moved/sub/c.txt")
+		_, err := e.fs.Stat(e.path("dir"))
+		e.is(err, fs.ErrNotExist, "Stat", "dir")
+	}},
+	{name: "Rename reports missing files", unless: ReadOnly | NoRename, run: func(e *env) {
+		err := e.fs.Rename(e.path("missing.txt"), e.path("new.txt"))
+		e.is(err, fs.ErrNotExist, "Rename", "missing.txt")
+	}},
+	{name: "Chmod changes permissions", unless: ReadOnly | NoChmod, run: func(e *env) {
+		e.ok(e.fs.Chmod(e.path("hello.txt"), 0600), "Chmod", "hello.txt")
+		e.equal(e.stat("hello.txt").Mode().Perm(), fs.FileMode(0600), "Mode().Perm", "hello.txt")
+	}},
+	{name: "Chmod reports missing files", unless: ReadOnly | NoChmod, run: func(e *env) {
+		e.is(e.fs.Chmod(e.path("missing.txt"), 0600), fs.ErrNotExist, "Chmod", "missing.txt")
+	}},
+	{name: "Chown keeps the current owner", unless: ReadOnly | NoChown, run: func(e *env) {
+		e.ok(e.fs.Chown(e.path("hello.txt"), os.Getuid(), os.Getgid()), "Chown", "hello.txt")
+	}},
+	{name: "Chown reports missing files", unless: ReadOnly | NoChown, run: func(e *env) {
+		e.is(e.fs.Chown(e.path("missing.txt"), os.Getuid(), os.Getgid()), fs.ErrNotExist, "Chown", "missing.txt")
+	}},
+	{name: "Chtimes changes modification times", unless: ReadOnly | NoChtimes, run: func(e *env) {
+		mtime := time.Date(2020, 2, 2, 2, 2, 2, 0, time.UTC)
+		e.ok(e.fs.Chtimes(e.path("hello.txt"), mtime, mtime), "Chtimes", "hello.txt")
+		e.equal(e.stat("hello.txt").ModTime().Equal(mtime), true, "ModTime", "hello.txt")
+	}},
+	{name: "Chtimes reports missing files", unless: ReadOnly | NoChtimes, run: func(e *env) {
+		e.is(e.fs.Chtimes(e.path("missing.txt"), modTime, modTime), fs.ErrNotExist, "Chtimes", "missing.txt")
+	}},
+	{name: "Symlinks can be created and read", unless: ReadOnly | NoSymlink, run: func(e *env) {
+		linker, ok := e.fs.(afero.Linker)
+		if !ok {
+			e.Fatalf("expected an afero.Linker, got %T", e.fs)
+		}
+		e.ok(linker.SymlinkIfPossible("hello.txt", e.path("link")), "Symlink", "link")
+
+		reader, ok := e.fs.(afero.LinkReader)
+		if !ok {
+			e.Fatalf("expected an afero.LinkReader, got %T", e.fs)
+		}
+		target, err := reader.ReadlinkIfPossible(e.path("link"))
+		e.ok(err, "Readlink", "link")
+		e.equal(target, "hello.txt", "target", "link")
+
+		if lstater, ok := e.fs.(afero.Lstater); ok {
+			info, _, err := lstater.LstatIfPossible(e.path("link"))
+			e.ok(err, "Lstat", "link")
+			e.equal(info.Mode().Type(), fs.ModeSymlink, "Mode().Type", "link")
+		}
+
+		e.equal(e.read("link"), fixture["hello.txt"], "content", "link")
+	}},
+
+	{name: "Create fails", only: ReadOnly, run: func(e *env) {
+		_, err := e.fs.Create(e.path("new.txt"))
+		e.fails(err, "Create", "new.txt")
+	}},
+	{name: "OpenFile for writing fails", only: ReadOnly, run: func(e *env) {
+		_, err := e.fs.OpenFile(e.path("hello.txt"), os.O_WRONLY, 0644)
+		e.fails(err, "OpenFile", "hello.txt")
+		e.equal(e.read("hello.txt"), fixture["hello.txt"], "content", "hello.txt")
+	}},
+	{name: "Mkdir fails", only: ReadOnly, run: func(e *env) {
+		e.fails(e.fs.Mkdir(e.path("new"), 0755), "Mkdir", "new")
+		e.fails(e.fs.MkdirAll(e.path("new/nested"), 0755), "MkdirAll", "new/nested")
+	}},
+	{name: "Remove fails", only: ReadOnly, run: func(e *env) {
+		e.fails(e.fs.Remove(e.path("hello.txt")), "Remove", "hello.txt")
+		e.fails(e.fs.RemoveAll(e.path("dir")), "RemoveAll", "dir")
+		e.equal(e.read("dir/a.txt"), fixture["dir/a.txt"], "content", "dir/a.txt")
+	}},
+	{name: "Rename fails", only: ReadOnly, run: func(e *env) {
+		e.fails(e.fs.Rename(e.path("hello.txt"), e.path("new.txt")), "Rename", "hello.txt")
+	}},
+	{name: "Chmod fails", only: ReadOnly, run: func(e *env) {
+		e.fails(e.fs.Chmod(e.path("hello.txt"), 0600), "Chmod", "hello.txt")
+	}},
+}
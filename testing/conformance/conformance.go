@@ -0,0 +1,184 @@
+// Package conformance checks that [afero.Fs] implementations behave like the
+// OS filesystem, in the spirit of [testing/fstest.TestFS].
+//
+// Every check starts from a fresh Fs holding the same fixture tree:
+//
+//	hello.txt      "hello, world\n"
+//	dir/a.txt      "a"
+//	dir/b.txt      "bb"
+//	dir/sub/c.txt  "ccc"
+//	empty/
+//
+// Implementations declare the [Capability] values they lack to skip
+// the checks that do not apply to them.
+package conformance
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/spf13/afero"
+	"github.com/unmango/go/fopt"
+)
+
+// Capability describes a behavior an Fs does not support.
+type Capability int
+
+const (
+	// ReadOnly Fs reject every modification, so the write checks are
+	// replaced with checks that modifications fail.
+	ReadOnly Capability = 1 << iota
+	NoChmod
+	NoChown
+	NoChtimes
+	NoRename
+	NoSymlink
+
+	// NoWrite Fs neither support every modification nor reject them all,
+	// such as archive writers, so only the checks reading the fixture
+	// tree run.
+	NoWrite
+)
+
+// Factory returns the Fs under test holding the files of seed. seed is an
+// in-memory Fs holding the fixture tree, which implementations may return
+// as is, wrap, or copy.
+type Factory func(seed afero.Fs) (afero.Fs, error)
+
+// T is the subset of [testing.T] used by the checks, also satisfied by
+// [ginkgo.GinkgoT].
+type T interface {
+	Helper()
+	Errorf(format string, args ...any)
+	Fatalf(format string, args ...any)
+}
+
+type options struct {
+	caps Capability
+	root string
+}
+
+type Option func(*options)
+
+// Without declares the capabilities the Fs lacks.
+func Without(caps ...Capability) Option {
+	return func(options *options) {
+		for _, c := range caps {
+			options.caps |= c
+		}
+	}
+}
+
+// WithRoot checks the fixture tree beneath root in the Fs returned by the
+// factory, for implementations that cannot hold it at the top level.
+func WithRoot(root string) Option {
+	return func(options *options) {
+		options.root = root
+	}
+}
+
+// Describe registers a Ginkgo container named text with a spec for every check.
+//
+//	var _ = conformance.Describe("MemMapFs", func(seed afero.Fs) (afero.Fs, error) {
+//		return seed, nil
+//	}, conformance.Without(conformance.NoSymlink))
+func Describe(text string, factory Factory, options ...Option) bool {
+	opts := defaultOptions()
+	fopt.ApplyAll(&opts, options)
+
+	return ginkgo.Describe(text, func() {
+		for _, c := range checks {
+			ginkgo.It(c.name, func() {
+				if reason, skip := opts.skip(c); skip {
+					ginkgo.Skip(reason)
+				}
+
+				c.run(newEnv(ginkgo.GinkgoT(), factory, opts))
+			})
+		}
+	})
+}
+
+// Test runs every check as a subtest of t.
+//
+//	func TestFs(t *testing.T) {
+//		conformance.Test(t, func(seed afero.Fs) (afero.Fs, error) {
+//			return seed, nil
+//		}, conformance.Without(conformance.NoSymlink))
+//	}
+func Test(t *testing.T, factory Factory, options ...Option) {
+	t.Helper()
+	opts := defaultOptions()
+	fopt.ApplyAll(&opts, options)
+
+	for _, c := range checks {
+		t.Run(c.name, func(t *testing.T) {
+			if reason, skip := opts.skip(c); skip {
+				t.Skip(reason)
+			}
+
+			c.run(newEnv(t, factory, opts))
+		})
+	}
+}
+
+func defaultOptions() options {
+	return options{}
+}
+
+// skip reports whether c does not apply to an Fs with opts.
+func (opts options) skip(c check) (string, bool) {
+	if c.only != 0 && opts.caps&c.only == 0 {
+		return "the Fs is not read-only", true
+	}
+	if opts.caps&c.unless != 0 {
+		return "the Fs does not support it", true
+	}
+	if opts.caps&NoWrite != 0 && (c.only|c.unless)&ReadOnly != 0 {
+		return "the Fs does not support writes", true
+	}
+
+	return "", false
+}
+
+// modTime is the modification time of every fixture.
+var modTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+var fixture = map[string]string{
+	"hello.txt":     "hello, world\n",
+	"dir/a.txt":     "a",
+	"dir/b.txt":     "bb",
+	"dir/sub/c.txt": "ccc",
+}
+
+// Seed returns an in-memory Fs holding the fixture tree.
+func Seed() afero.Fs {
+	fsys := afero.NewMemMapFs()
+	for name, content := range fixture {
+		if err := fsys.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			panic(err)
+		}
+		if err := afero.WriteFile(fsys, name, []byte(content), 0644); err != nil {
+			panic(err)
+		}
+	}
+	if err := fsys.MkdirAll("empty", 0755); err != nil {
+		panic(err)
+	}
+
+	err := afero.Walk(fsys, "", func(path string, _ os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		return fsys.Chtimes(path, modTime, modTime)
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return fsys
+}
@@ -0,0 +1,40 @@
+package conformance_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox"
+	"github.com/unmango/aferox/testing/conformance"
+)
+
+func TestMemMapFs(t *testing.T) {
+	conformance.Test(t, func(seed afero.Fs) (afero.Fs, error) {
+		return seed, nil
+	}, conformance.Without(conformance.NoSymlink))
+}
+
+var _ = Describe("OsFs", func() {
+	root := filepath.Join(os.TempDir(), fmt.Sprintf("aferox-conformance-%d", os.Getpid()))
+
+	conformance.Describe("in a temp dir", func(seed afero.Fs) (afero.Fs, error) {
+		DeferCleanup(os.RemoveAll, root)
+		if err := os.Mkdir(root, 0755); err != nil {
+			return nil, err
+		}
+		if err := aferox.Copy(seed, afero.NewBasePathFs(afero.NewOsFs(), root)); err != nil {
+			return nil, err
+		}
+
+		return afero.NewOsFs(), nil
+	}, conformance.WithRoot(root))
+})
+
+var _ = conformance.Describe("ReadOnlyFs", func(seed afero.Fs) (afero.Fs, error) {
+	return afero.NewReadOnlyFs(seed), nil
+}, conformance.Without(conformance.ReadOnly))
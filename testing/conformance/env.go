@@ -0,0 +1,113 @@
+package conformance
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+)
+
+// env is the Fs under test of a single check.
+type env struct {
+	T
+	fs   afero.Fs
+	root string
+}
+
+func newEnv(t T, factory Factory, opts options) *env {
+	t.Helper()
+	fsys, err := factory(Seed())
+	if err != nil {
+		t.Fatalf("creating fs: %s", err)
+	}
+
+	return &env{T: t, fs: fsys, root: opts.root}
+}
+
+// path returns the path of the fixture name in the Fs.
+func (e *env) path(name string) string {
+	return filepath.Join(e.root, name)
+}
+
+func (e *env) ok(err error, op, name string) {
+	e.Helper()
+	if err != nil {
+		e.Fatalf("%s %s: unexpected error: %s", op, name, err)
+	}
+}
+
+func (e *env) fails(err error, op, name string) {
+	e.Helper()
+	if err == nil {
+		e.Fatalf("%s %s: expected an error", op, name)
+	}
+}
+
+// is checks that err wraps target inside an *fs.PathError or *os.LinkError.
+func (e *env) is(err, target error, op, name string) {
+	e.Helper()
+	if !errors.Is(err, target) {
+		e.Fatalf("%s %s: expected %v, got %v", op, name, target, err)
+	}
+
+	var pathErr *fs.PathError
+	var linkErr *os.LinkError
+	if !errors.As(err, &pathErr) && !errors.As(err, &linkErr) {
+		e.Errorf("%s %s: expected a *fs.PathError, got %T", op, name, err)
+	}
+}
+
+func (e *env) equal(actual, expected any, what, name string) {
+	e.Helper()
+	if actual != expected {
+		e.Errorf("%s of %s: expected %v, got %v", what, name, expected, actual)
+	}
+}
+
+func (e *env) open(name string) afero.File {
+	e.Helper()
+	f, err := e.fs.Open(e.path(name))
+	e.ok(err, "Open", name)
+
+	return f
+}
+
+func (e *env) stat(name string) fs.FileInfo {
+	e.Helper()
+	info, err := e.fs.Stat(e.path(name))
+	e.ok(err, "Stat", name)
+
+	return info
+}
+
+func (e *env) read(name string) string {
+	e.Helper()
+	f := e.open(name)
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+	e.ok(err, "Read", name)
+
+	return string(data)
+}
+
+func (e *env) write(name string, flag int, content string) {
+	e.Helper()
+	f, err := e.fs.OpenFile(e.path(name), flag, 0644)
+	e.ok(err, "OpenFile", name)
+
+	_, err = io.WriteString(f, content)
+	e.ok(err, "Write", name)
+	e.ok(f.Close(), "Close", name)
+}
+
+func (e *env) names(name string, f afero.File) []string {
+	e.Helper()
+	names, err := f.Readdirnames(-1)
+	e.ok(err, "Readdirnames", name)
+
+	return names
+}
@@ -0,0 +1,92 @@
+package fault
+
+import (
+	"math/rand/v2"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/unmango/aferox/op"
+)
+
+// Condition reports whether a fault applies to an operation. Conditions are
+// evaluated in order until one does not match, so stateful conditions such as
+// [Nth] only count the operations matching the conditions before them. They
+// are safe to share between faults, which then count operations together.
+type Condition func(op.Operation) bool
+
+// On matches operations of type T.
+func On[T op.Operation]() Condition {
+	return func(operation op.Operation) bool {
+		_, ok := operation.(T)
+		return ok
+	}
+}
+
+// Path matches operations on paths matching pattern with [path.Match]. Patterns
+// containing a slash match the slash-separated path of the operation, others
+// match its base name.
+func Path(pattern string) Condition {
+	return func(operation op.Operation) bool {
+		name := strings.TrimLeft(filepath.ToSlash(operation.Path()), "/")
+		if !strings.Contains(pattern, "/") {
+			name = path.Base(name)
+		}
+
+		ok, _ := path.Match(strings.TrimLeft(pattern, "/"), name)
+		return ok
+	}
+}
+
+// Any matches operations matching any of conds.
+func Any(conds ...Condition) Condition {
+	return func(operation op.Operation) bool {
+		for _, cond := range conds {
+			if cond(operation) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// Nth matches only the nth operation it sees, counting from 1.
+func Nth(n int) Condition {
+	var calls atomic.Int64
+	return func(op.Operation) bool {
+		return calls.Add(1) == int64(n)
+	}
+}
+
+// First matches the first n operations it sees, for example to fail
+// a call twice before letting a retry succeed.
+func First(n int) Condition {
+	var calls atomic.Int64
+	return func(op.Operation) bool {
+		return calls.Add(1) <= int64(n)
+	}
+}
+
+// After matches every operation after the first n it sees.
+func After(n int) Condition {
+	var calls atomic.Int64
+	return func(op.Operation) bool {
+		return calls.Add(1) > int64(n)
+	}
+}
+
+// Probability matches operations with probability p, drawn from a random
+// source seeded with seed so that runs are reproducible.
+func Probability(p float64, seed uint64) Condition {
+	var mu sync.Mutex
+	r := rand.New(rand.NewPCG(seed, seed))
+	return func(op.Operation) bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return r.Float64() < p
+	}
+}
@@ -0,0 +1,150 @@
+package fault
+
+import (
+	"io/fs"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/context"
+	"github.com/unmango/aferox/op"
+)
+
+// NewContextFs returns a [context.Fs] that injects faults into the calls made
+// to base, including calls made to the files it opens. Delays and hangs end
+// when the context of the call is done, and the operations on a file use the
+// context it was opened with.
+func NewContextFs(base context.Fs, faults ...*Fault) context.Fs {
+	return &ContextFs{src: base, faults: faults}
+}
+
+type ContextFs struct {
+	src    context.Fs
+	faults faults
+}
+
+// Chmod implements context.Fs.
+func (f *ContextFs) Chmod(ctx context.Context, name string, mode fs.FileMode) error {
+	if err := f.faults.inject(ctx, op.Chmod{Name: name, Mode: mode}); err != nil {
+		return err
+	}
+
+	return f.src.Chmod(ctx, name, mode)
+}
+
+// Chown implements context.Fs.
+func (f *ContextFs) Chown(ctx context.Context, name string, uid int, gid int) error {
+	if err := f.faults.inject(ctx, op.Chown{Name: name, UID: uid, GID: gid}); err != nil {
+		return err
+	}
+
+	return f.src.Chown(ctx, name, uid, gid)
+}
+
+// Chtimes implements context.Fs.
+func (f *ContextFs) Chtimes(ctx context.Context, name string, atime time.Time, mtime time.Time) error {
+	if err := f.faults.inject(ctx, op.Chtimes{Name: name, Atime: atime, Mtime: mtime}); err != nil {
+		return err
+	}
+
+	return f.src.Chtimes(ctx, name, atime, mtime)
+}
+
+// Create implements context.Fs.
+func (f *ContextFs) Create(ctx context.Context, name string) (afero.File, error) {
+	if err := f.faults.inject(ctx, op.Create{Name: name}); err != nil {
+		return nil, err
+	}
+
+	file, err := f.src.Create(ctx, name)
+	return f.file(ctx, file, err)
+}
+
+// Mkdir implements context.Fs.
+func (f *ContextFs) Mkdir(ctx context.Context, name string, perm fs.FileMode) error {
+	if err := f.faults.inject(ctx, op.Mkdir{Name: name, Perm: perm}); err != nil {
+		return err
+	}
+
+	return f.src.Mkdir(ctx, name, perm)
+}
+
+// MkdirAll implements context.Fs.
+func (f *ContextFs) MkdirAll(ctx context.Context, path string, perm fs.FileMode) error {
+	if err := f.faults.inject(ctx, op.MkdirAll{Name: path, Perm: perm}); err != nil {
+		return err
+	}
+
+	return f.src.MkdirAll(ctx, path, perm)
+}
+
+// Name implements context.Fs.
+func (f *ContextFs) Name() string {
+	return "fault: " + f.src.Name()
+}
+
+// Open implements context.Fs.
+func (f *ContextFs) Open(ctx context.Context, name string) (afero.File, error) {
+	if err := f.faults.inject(ctx, op.Open{Name: name}); err != nil {
+		return nil, err
+	}
+
+	file, err := f.src.Open(ctx, name)
+	return f.file(ctx, file, err)
+}
+
+// OpenFile implements context.Fs.
+func (f *ContextFs) OpenFile(ctx context.Context, name string, flag int, perm fs.FileMode) (afero.File, error) {
+	if err := f.faults.inject(ctx, op.OpenFile{Name: name, Flag: flag, Perm: perm}); err != nil {
+		return nil, err
+	}
+
+	file, err := f.src.OpenFile(ctx, name, flag, perm)
+	return f.file(ctx, file, err)
+}
+
+// Remove implements context.Fs.
+func (f *ContextFs) Remove(ctx context.Context, name string) error {
+	if err := f.faults.inject(ctx, op.Remove{Name: name}); err != nil {
+		return err
+	}
+
+	return f.src.Remove(ctx, name)
+}
+
+// RemoveAll implements context.Fs.
+func (f *ContextFs) RemoveAll(ctx context.Context, path string) error {
+	if err := f.faults.inject(ctx, op.RemoveAll{Name: path}); err != nil {
+		return err
+	}
+
+	return f.src.RemoveAll(ctx, path)
+}
+
+// Rename implements context.Fs.
+func (f *ContextFs) Rename(ctx context.Context, oldname string, newname string) error {
+	if err := f.faults.inject(ctx, op.Rename{Oldname: oldname, Newname: newname}); err != nil {
+		return err
+	}
+
+	return f.src.Rename(ctx, oldname, newname)
+}
+
+// Stat implements context.Fs.
+func (f *ContextFs) Stat(ctx context.Context, name string) (fs.FileInfo, error) {
+	if err := f.faults.inject(ctx, op.Stat{Name: name}); err != nil {
+		return nil, err
+	}
+
+	return f.src.Stat(ctx, name)
+}
+
+// file wraps a file opened with ctx.
+func (f *ContextFs) file(ctx context.Context, file afero.File, err error) (afero.File, error) {
+	if err != nil || file == nil {
+		return file, err
+	}
+
+	return &File{file: file, faults: f.faults, ctx: ctx}, nil
+}
+
+var _ context.Fs = (*ContextFs)(nil)
@@ -0,0 +1,105 @@
+package fault_test
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"syscall"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	aferoctx "github.com/unmango/aferox/context"
+	"github.com/unmango/aferox/op"
+	"github.com/unmango/aferox/testing/conformance"
+	"github.com/unmango/aferox/testing/fault"
+	"github.com/unmango/aferox/testing/mock"
+)
+
+var _ = Describe("ContextFs", func() {
+	var base afero.Fs
+
+	BeforeEach(func() {
+		base = afero.NewMemMapFs()
+		Expect(afero.WriteFile(base, "test.txt", []byte("testing"), os.ModePerm)).To(Succeed())
+	})
+
+	It("should fail matching operations", func(ctx context.Context) {
+		fsys := fault.NewContextFs(mock.NewFs(base), fault.Fail(syscall.EIO, fault.On[op.Stat]()))
+
+		_, err := fsys.Stat(ctx, "test.txt")
+		Expect(err).To(Equal(&fs.PathError{Op: "stat", Path: "test.txt", Err: syscall.EIO}))
+		_, err = fsys.Open(ctx, "test.txt")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should stop delaying when the context of the call is done", func(ctx SpecContext) {
+		fsys := fault.NewContextFs(mock.NewFs(base), fault.Delay(time.Hour, fault.On[op.Stat]()))
+		timeout, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		DeferCleanup(cancel)
+
+		_, err := fsys.Stat(timeout, "test.txt")
+
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+		Expect(err).To(BeAssignableToTypeOf(&fs.PathError{}))
+	}, SpecTimeout(5*time.Second))
+
+	It("should delay operations", func(ctx context.Context) {
+		fsys := fault.NewContextFs(mock.NewFs(base), fault.Delay(20*time.Millisecond, fault.On[op.Stat]()))
+
+		start := time.Now()
+		_, err := fsys.Stat(ctx, "test.txt")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(time.Since(start)).To(BeNumerically(">=", 20*time.Millisecond))
+	})
+
+	It("should hang operations until the context of the call is cancelled", func(ctx SpecContext) {
+		fsys := fault.NewContextFs(mock.NewFs(base), fault.Hang(context.Background(), fault.On[op.Open]()))
+		call, cancel := context.WithCancel(ctx)
+
+		errs := make(chan error)
+		go func() {
+			_, err := fsys.Open(call, "test.txt")
+			errs <- err
+		}()
+
+		Consistently(errs).ShouldNot(Receive())
+		cancel()
+		Eventually(errs).Should(Receive(MatchError(context.Canceled)))
+	}, SpecTimeout(5*time.Second))
+
+	It("should hang operations until the context of the fault is cancelled", func(ctx SpecContext) {
+		hang, cancel := context.WithCancel(ctx)
+		fsys := fault.NewContextFs(mock.NewFs(base), fault.Hang(hang, fault.On[op.Open]()))
+
+		errs := make(chan error)
+		go func() {
+			_, err := fsys.Open(ctx, "test.txt")
+			errs <- err
+		}()
+
+		Consistently(errs).ShouldNot(Receive())
+		cancel()
+		Eventually(errs).Should(Receive(MatchError(context.Canceled)))
+	}, SpecTimeout(5*time.Second))
+
+	It("should use the context a file was opened with", func(ctx SpecContext) {
+		fsys := fault.NewContextFs(mock.NewFs(base), fault.Hang(context.Background(), fault.On[op.Read]()))
+		open, cancel := context.WithCancel(ctx)
+
+		f, err := fsys.Open(open, "test.txt")
+		Expect(err).NotTo(HaveOccurred())
+		cancel()
+
+		_, err = io.ReadAll(f)
+		Expect(err).To(MatchError(context.Canceled))
+	}, SpecTimeout(5*time.Second))
+})
+
+var _ = conformance.Describe("ContextFs conformance", func(seed afero.Fs) (afero.Fs, error) {
+	return aferoctx.BackgroundFs(fault.NewContextFs(mock.NewFs(seed))), nil
+}, conformance.Without(conformance.NoSymlink))
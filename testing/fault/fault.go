@@ -0,0 +1,199 @@
+// Package fault injects errors, short writes and latency into the
+// operations made on an [afero.Fs], to exercise retry and recovery logic.
+//
+//	fsys := fault.NewFs(afero.NewMemMapFs(),
+//		fault.Fail(syscall.EIO, fault.On[op.Open](), fault.First(2)),
+//		fault.FailAfterBytes(1<<20, syscall.ENOSPC),
+//		fault.Delay(10*time.Millisecond, fault.Probability(0.1, 42)),
+//	)
+//
+// [NewContextFs] wraps a [context.Fs] the same way, ending delays and hangs
+// when the context of each call is done.
+package fault
+
+import (
+	"io/fs"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/unmango/aferox/context"
+	"github.com/unmango/aferox/op"
+)
+
+// Fault describes a fault injected into the operations matching all of its
+// conditions. Faults are evaluated in the order they are passed to [NewFs]
+// and evaluation stops at the first fault that fails an operation.
+type Fault struct {
+	mu    sync.Mutex
+	conds []Condition
+
+	err   error
+	delay time.Duration
+	ctx   context.Context
+
+	// limit is the number of bytes written before writes fail, or -1
+	limit   int64
+	written int64
+}
+
+// Fail fails the matching operations with err.
+func Fail(err error, conds ...Condition) *Fault {
+	return &Fault{conds: conds, err: err, limit: -1}
+}
+
+// FailAfterBytes lets the matching writes write limit bytes in total, then
+// fails them with err, such as [syscall.ENOSPC]. The write crossing the limit
+// is cut short and returns the number of bytes written along with err.
+// Operations other than writes are not affected.
+func FailAfterBytes(limit int64, err error, conds ...Condition) *Fault {
+	return &Fault{conds: conds, err: err, limit: limit}
+}
+
+// Delay waits for d before performing the matching operations. Calls made
+// through a [ContextFs] stop waiting when their context is done and fail
+// with its error.
+func Delay(d time.Duration, conds ...Condition) *Fault {
+	return &Fault{conds: conds, delay: d, limit: -1}
+}
+
+// Hang blocks the matching operations until ctx is done, then fails them
+// with the error of ctx. Calls made through a [ContextFs] are also released
+// when their own context is done, so passing [context.Background] hangs each
+// call until its context is done.
+func Hang(ctx context.Context, conds ...Condition) *Fault {
+	return &Fault{conds: conds, ctx: ctx, limit: -1}
+}
+
+// matches reports whether operation satisfies every condition of the fault.
+func (f *Fault) matches(operation op.Operation) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, cond := range f.conds {
+		if !cond(operation) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// apply performs the fault for an operation called with ctx, returning the
+// error to fail it with.
+func (f *Fault) apply(ctx context.Context, operation op.Operation) error {
+	if f.delay > 0 {
+		timer := time.NewTimer(f.delay)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return wrap(operation, ctx.Err())
+		}
+	}
+	if f.ctx != nil {
+		select {
+		case <-f.ctx.Done():
+			return wrap(operation, f.ctx.Err())
+		case <-ctx.Done():
+			return wrap(operation, ctx.Err())
+		}
+	}
+	if f.err != nil {
+		return wrap(operation, f.err)
+	}
+
+	return nil
+}
+
+// reserve returns how many of n bytes can still be written before the
+// limit, holding them until they are released.
+func (f *Fault) reserve(n int) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	allowed := int(min(int64(n), max(f.limit-f.written, 0)))
+	f.written += int64(allowed)
+
+	return allowed
+}
+
+// release gives back n reserved bytes that were not written.
+func (f *Fault) release(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.written -= int64(n)
+}
+
+// faults are the faults injected into the operations of an Fs and its files.
+type faults []*Fault
+
+// inject applies the faults matching an operation called with ctx, returning
+// the error to fail it with. Byte limits are only applied by write.
+func (faults faults) inject(ctx context.Context, operation op.Operation) error {
+	for _, fault := range faults {
+		if fault.limit >= 0 || !fault.matches(operation) {
+			continue
+		}
+		if err := fault.apply(ctx, operation); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// write injects faults into a write of p, cutting it short when a byte
+// limit is reached.
+func (faults faults) write(ctx context.Context, operation op.Operation, p []byte, write func([]byte) (int, error)) (int, error) {
+	if err := faults.inject(ctx, operation); err != nil {
+		return 0, err
+	}
+
+	type reservation struct {
+		fault *Fault
+		n     int
+	}
+
+	// Reserve bytes so that concurrent writes cannot exceed a limit, then
+	// count only the bytes written against each fault
+	n, limited := len(p), error(nil)
+	var reserved []reservation
+	for _, fault := range faults {
+		if fault.limit < 0 || !fault.matches(operation) {
+			continue
+		}
+
+		allowed := fault.reserve(n)
+		if allowed < n {
+			n, limited = allowed, fault.err
+		}
+
+		reserved = append(reserved, reservation{fault, allowed})
+	}
+
+	written, err := write(p[:n])
+	for _, r := range reserved {
+		r.fault.release(r.n - written)
+	}
+	if err == nil && limited != nil {
+		err = wrap(operation, limited)
+	}
+
+	return written, err
+}
+
+// wrap returns err as the error of operation, like the OS would.
+func wrap(operation op.Operation, err error) error {
+	switch o := operation.(type) {
+	case op.Rename:
+		return &os.LinkError{Op: "rename", Old: o.Oldname, New: o.Newname, Err: err}
+	case op.Symlink:
+		return &os.LinkError{Op: "symlink", Old: o.Oldname, New: o.Newname, Err: err}
+	default:
+		return &fs.PathError{Op: strings.ToLower(op.Name(operation)), Path: operation.Path(), Err: err}
+	}
+}
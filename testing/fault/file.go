@@ -0,0 +1,124 @@
+package fault
+
+import (
+	"io/fs"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/context"
+	"github.com/unmango/aferox/op"
+)
+
+type File struct {
+	file   afero.File
+	faults faults
+
+	// ctx is the context the file was opened with
+	ctx context.Context
+}
+
+// Close implements afero.File.
+func (f *File) Close() error {
+	if err := f.faults.inject(f.ctx, op.Close{Name: f.file.Name()}); err != nil {
+		return err
+	}
+
+	return f.file.Close()
+}
+
+// Name implements afero.File.
+func (f *File) Name() string {
+	return f.file.Name()
+}
+
+// Read implements afero.File.
+func (f *File) Read(p []byte) (n int, err error) {
+	if err := f.faults.inject(f.ctx, op.Read{Name: f.file.Name()}); err != nil {
+		return 0, err
+	}
+
+	return f.file.Read(p)
+}
+
+// ReadAt implements afero.File.
+func (f *File) ReadAt(p []byte, off int64) (n int, err error) {
+	if err := f.faults.inject(f.ctx, op.ReadAt{Name: f.file.Name(), Offset: off}); err != nil {
+		return 0, err
+	}
+
+	return f.file.ReadAt(p, off)
+}
+
+// Readdir implements afero.File.
+func (f *File) Readdir(count int) ([]fs.FileInfo, error) {
+	if err := f.faults.inject(f.ctx, op.Readdir{Name: f.file.Name(), Count: count}); err != nil {
+		return nil, err
+	}
+
+	return f.file.Readdir(count)
+}
+
+// Readdirnames implements afero.File.
+func (f *File) Readdirnames(n int) ([]string, error) {
+	if err := f.faults.inject(f.ctx, op.Readdirnames{Name: f.file.Name(), Count: n}); err != nil {
+		return nil, err
+	}
+
+	return f.file.Readdirnames(n)
+}
+
+// Seek implements afero.File.
+func (f *File) Seek(offset int64, whence int) (int64, error) {
+	if err := f.faults.inject(f.ctx, op.Seek{Name: f.file.Name(), Offset: offset, Whence: whence}); err != nil {
+		return 0, err
+	}
+
+	return f.file.Seek(offset, whence)
+}
+
+// Stat implements afero.File.
+func (f *File) Stat() (fs.FileInfo, error) {
+	if err := f.faults.inject(f.ctx, op.Stat{Name: f.file.Name()}); err != nil {
+		return nil, err
+	}
+
+	return f.file.Stat()
+}
+
+// Sync implements afero.File.
+func (f *File) Sync() error {
+	if err := f.faults.inject(f.ctx, op.Sync{Name: f.file.Name()}); err != nil {
+		return err
+	}
+
+	return f.file.Sync()
+}
+
+// Truncate implements afero.File.
+func (f *File) Truncate(size int64) error {
+	if err := f.faults.inject(f.ctx, op.Truncate{Name: f.file.Name(), Size: size}); err != nil {
+		return err
+	}
+
+	return f.file.Truncate(size)
+}
+
+// Write implements afero.File.
+func (f *File) Write(p []byte) (n int, err error) {
+	return f.faults.write(f.ctx, op.Write{Name: f.file.Name(), Data: p}, p, f.file.Write)
+}
+
+// WriteAt implements afero.File.
+func (f *File) WriteAt(p []byte, off int64) (n int, err error) {
+	return f.faults.write(f.ctx, op.WriteAt{Name: f.file.Name(), Offset: off, Data: p}, p,
+		func(p []byte) (int, error) {
+			return f.file.WriteAt(p, off)
+		},
+	)
+}
+
+// WriteString implements afero.File.
+func (f *File) WriteString(s string) (ret int, err error) {
+	return f.Write([]byte(s))
+}
+
+var _ afero.File = (*File)(nil)
@@ -0,0 +1,186 @@
+package fault
+
+import (
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/context"
+	"github.com/unmango/aferox/op"
+)
+
+// NewFs returns an [afero.Fs] that injects faults into the calls made to
+// base, including calls made to the files it opens.
+func NewFs(base afero.Fs, faults ...*Fault) afero.Fs {
+	return &Fs{src: base, faults: faults}
+}
+
+type Fs struct {
+	src    afero.Fs
+	faults faults
+}
+
+// Chmod implements afero.Fs.
+func (f *Fs) Chmod(name string, mode fs.FileMode) error {
+	if err := f.faults.inject(context.Background(), op.Chmod{Name: name, Mode: mode}); err != nil {
+		return err
+	}
+
+	return f.src.Chmod(name, mode)
+}
+
+// Chown implements afero.Fs.
+func (f *Fs) Chown(name string, uid int, gid int) error {
+	if err := f.faults.inject(context.Background(), op.Chown{Name: name, UID: uid, GID: gid}); err != nil {
+		return err
+	}
+
+	return f.src.Chown(name, uid, gid)
+}
+
+// Chtimes implements afero.Fs.
+func (f *Fs) Chtimes(name string, atime time.Time, mtime time.Time) error {
+	if err := f.faults.inject(context.Background(), op.Chtimes{Name: name, Atime: atime, Mtime: mtime}); err != nil {
+		return err
+	}
+
+	return f.src.Chtimes(name, atime, mtime)
+}
+
+// Create implements afero.Fs.
+func (f *Fs) Create(name string) (afero.File, error) {
+	if err := f.faults.inject(context.Background(), op.Create{Name: name}); err != nil {
+		return nil, err
+	}
+
+	return f.file(f.src.Create(name))
+}
+
+// LstatIfPossible implements afero.Lstater.
+func (f *Fs) LstatIfPossible(name string) (fs.FileInfo, bool, error) {
+	if err := f.faults.inject(context.Background(), op.Lstat{Name: name}); err != nil {
+		return nil, false, err
+	}
+	if lstater, ok := f.src.(afero.Lstater); ok {
+		return lstater.LstatIfPossible(name)
+	}
+
+	info, err := f.src.Stat(name)
+	return info, false, err
+}
+
+// Mkdir implements afero.Fs.
+func (f *Fs) Mkdir(name string, perm fs.FileMode) error {
+	if err := f.faults.inject(context.Background(), op.Mkdir{Name: name, Perm: perm}); err != nil {
+		return err
+	}
+
+	return f.src.Mkdir(name, perm)
+}
+
+// MkdirAll implements afero.Fs.
+func (f *Fs) MkdirAll(path string, perm fs.FileMode) error {
+	if err := f.faults.inject(context.Background(), op.MkdirAll{Name: path, Perm: perm}); err != nil {
+		return err
+	}
+
+	return f.src.MkdirAll(path, perm)
+}
+
+// Name implements afero.Fs.
+func (f *Fs) Name() string {
+	return "fault: " + f.src.Name()
+}
+
+// Open implements afero.Fs.
+func (f *Fs) Open(name string) (afero.File, error) {
+	if err := f.faults.inject(context.Background(), op.Open{Name: name}); err != nil {
+		return nil, err
+	}
+
+	return f.file(f.src.Open(name))
+}
+
+// OpenFile implements afero.Fs.
+func (f *Fs) OpenFile(name string, flag int, perm fs.FileMode) (afero.File, error) {
+	if err := f.faults.inject(context.Background(), op.OpenFile{Name: name, Flag: flag, Perm: perm}); err != nil {
+		return nil, err
+	}
+
+	return f.file(f.src.OpenFile(name, flag, perm))
+}
+
+// ReadlinkIfPossible implements afero.LinkReader.
+func (f *Fs) ReadlinkIfPossible(name string) (string, error) {
+	if err := f.faults.inject(context.Background(), op.Readlink{Name: name}); err != nil {
+		return "", err
+	}
+	if reader, ok := f.src.(afero.LinkReader); ok {
+		return reader.ReadlinkIfPossible(name)
+	}
+
+	return "", &fs.PathError{Op: "readlink", Path: name, Err: afero.ErrNoReadlink}
+}
+
+// Remove implements afero.Fs.
+func (f *Fs) Remove(name string) error {
+	if err := f.faults.inject(context.Background(), op.Remove{Name: name}); err != nil {
+		return err
+	}
+
+	return f.src.Remove(name)
+}
+
+// RemoveAll implements afero.Fs.
+func (f *Fs) RemoveAll(path string) error {
+	if err := f.faults.inject(context.Background(), op.RemoveAll{Name: path}); err != nil {
+		return err
+	}
+
+	return f.src.RemoveAll(path)
+}
+
+// Rename implements afero.Fs.
+func (f *Fs) Rename(oldname string, newname string) error {
+	if err := f.faults.inject(context.Background(), op.Rename{Oldname: oldname, Newname: newname}); err != nil {
+		return err
+	}
+
+	return f.src.Rename(oldname, newname)
+}
+
+// Stat implements afero.Fs.
+func (f *Fs) Stat(name string) (fs.FileInfo, error) {
+	if err := f.faults.inject(context.Background(), op.Stat{Name: name}); err != nil {
+		return nil, err
+	}
+
+	return f.src.Stat(name)
+}
+
+// SymlinkIfPossible implements afero.Linker.
+func (f *Fs) SymlinkIfPossible(oldname string, newname string) error {
+	if err := f.faults.inject(context.Background(), op.Symlink{Oldname: oldname, Newname: newname}); err != nil {
+		return err
+	}
+	if linker, ok := f.src.(afero.Linker); ok {
+		return linker.SymlinkIfPossible(oldname, newname)
+	}
+
+	return &os.LinkError{Op: "symlink", Old: oldname, New: newname, Err: afero.ErrNoSymlink}
+}
+
+func (f *Fs) file(file afero.File, err error) (afero.File, error) {
+	if err != nil || file == nil {
+		return file, err
+	}
+
+	return &File{file: file, faults: f.faults, ctx: context.Background()}, nil
+}
+
+var (
+	_ afero.Lstater    = (*Fs)(nil)
+	_ afero.Linker     = (*Fs)(nil)
+	_ afero.LinkReader = (*Fs)(nil)
+)
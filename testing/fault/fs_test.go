@@ -0,0 +1,204 @@
+package fault_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"syscall"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/op"
+	"github.com/unmango/aferox/testing/conformance"
+	"github.com/unmango/aferox/testing/fault"
+)
+
+var _ = Describe("Fs", func() {
+	var base afero.Fs
+
+	BeforeEach(func() {
+		base = afero.NewMemMapFs()
+		Expect(afero.WriteFile(base, "test.txt", []byte("testing"), os.ModePerm)).To(Succeed())
+		Expect(afero.WriteFile(base, "dir/test.log", []byte("log"), os.ModePerm)).To(Succeed())
+	})
+
+	It("should fail matching operations", func() {
+		fsys := fault.NewFs(base, fault.Fail(syscall.EIO, fault.On[op.Open]()))
+
+		_, err := fsys.Open("test.txt")
+		Expect(err).To(MatchError(syscall.EIO))
+		_, err = fsys.Stat("test.txt")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should describe injected errors like the OS", func() {
+		fsys := fault.NewFs(base, fault.Fail(syscall.EIO))
+
+		_, err := fsys.Open("test.txt")
+		Expect(err).To(Equal(&fs.PathError{Op: "open", Path: "test.txt", Err: syscall.EIO}))
+		err = fsys.Rename("test.txt", "new.txt")
+		Expect(err).To(Equal(&os.LinkError{Op: "rename", Old: "test.txt", New: "new.txt", Err: syscall.EIO}))
+	})
+
+	It("should fail the nth call", func() {
+		fsys := fault.NewFs(base, fault.Fail(syscall.EIO, fault.On[op.Stat](), fault.Nth(2)))
+
+		_, err := fsys.Stat("test.txt")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = fsys.Open("test.txt")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = fsys.Stat("test.txt")
+		Expect(err).To(MatchError(syscall.EIO))
+		_, err = fsys.Stat("test.txt")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should fail the first calls until a retry succeeds", func() {
+		fsys := fault.NewFs(base, fault.Fail(syscall.EAGAIN, fault.First(2)))
+
+		attempts := 0
+		Eventually(func() error {
+			attempts++
+			_, err := afero.ReadFile(fsys, "test.txt")
+			return err
+		}).Should(Succeed())
+		Expect(attempts).To(Equal(3))
+	})
+
+	It("should fail every call after the first", func() {
+		fsys := fault.NewFs(base, fault.Fail(syscall.EIO, fault.After(1)))
+
+		Expect(fsys.Chmod("test.txt", 0600)).To(Succeed())
+		Expect(fsys.Chmod("test.txt", 0600)).To(MatchError(syscall.EIO))
+		Expect(fsys.Chmod("test.txt", 0600)).To(MatchError(syscall.EIO))
+	})
+
+	It("should fail operations on matching paths", func() {
+		fsys := fault.NewFs(base, fault.Fail(syscall.EACCES, fault.Path("*.log")))
+
+		_, err := afero.ReadFile(fsys, "dir/test.log")
+		Expect(err).To(MatchError(syscall.EACCES))
+		Expect(afero.ReadFile(fsys, "test.txt")).To(BeEquivalentTo("testing"))
+	})
+
+	It("should fail operations matching any condition", func() {
+		fsys := fault.NewFs(base, fault.Fail(syscall.EROFS, fault.Any(
+			fault.On[op.Remove](),
+			fault.On[op.RemoveAll](),
+		)))
+
+		Expect(fsys.Remove("test.txt")).To(MatchError(syscall.EROFS))
+		Expect(fsys.RemoveAll("dir")).To(MatchError(syscall.EROFS))
+		Expect(fsys.Chmod("test.txt", 0600)).To(Succeed())
+	})
+
+	It("should fail file operations", func() {
+		fsys := fault.NewFs(base, fault.Fail(syscall.EIO, fault.On[op.Read]()))
+
+		f, err := fsys.Open("test.txt")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = f.Read(make([]byte, 1))
+		Expect(err).To(MatchError(syscall.EIO))
+		Expect(f.Close()).To(Succeed())
+	})
+
+	It("should fail calls with a reproducible probability", func() {
+		failures := func() []bool {
+			fsys := fault.NewFs(base, fault.Fail(syscall.EIO, fault.Probability(0.5, 42)))
+
+			results := []bool{}
+			for range 100 {
+				_, err := fsys.Stat("test.txt")
+				results = append(results, err != nil)
+			}
+
+			return results
+		}
+
+		first := failures()
+		Expect(first).To(ContainElement(true))
+		Expect(first).To(ContainElement(false))
+		Expect(failures()).To(Equal(first))
+	})
+
+	It("should cut writes short after a number of bytes", func() {
+		fsys := fault.NewFs(base, fault.FailAfterBytes(5, syscall.ENOSPC))
+		f, err := fsys.Create("new.txt")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(f.WriteString("abc")).To(Equal(3))
+		n, err := f.WriteString("defg")
+		Expect(n).To(Equal(2))
+		Expect(err).To(MatchError(syscall.ENOSPC))
+		n, err = f.Write([]byte("h"))
+		Expect(n).To(Equal(0))
+		Expect(err).To(MatchError(syscall.ENOSPC))
+
+		Expect(f.Close()).To(Succeed())
+		Expect(afero.ReadFile(base, "new.txt")).To(BeEquivalentTo("abcde"))
+	})
+
+	It("should share the byte limit between files", func() {
+		fsys := fault.NewFs(base, fault.FailAfterBytes(5, syscall.ENOSPC, fault.Path("*.txt")))
+
+		Expect(afero.WriteFile(fsys, "a.txt", []byte("abc"), os.ModePerm)).To(Succeed())
+		Expect(afero.WriteFile(fsys, "b.txt", []byte("abc"), os.ModePerm)).To(MatchError(syscall.ENOSPC))
+		Expect(afero.WriteFile(fsys, "c.log", []byte("abc"), os.ModePerm)).To(Succeed())
+	})
+
+	It("should only count the bytes written against overlapping limits", func() {
+		fsys := fault.NewFs(base,
+			fault.FailAfterBytes(2, syscall.ENOSPC, fault.Path("a.txt")),
+			fault.FailAfterBytes(5, syscall.EIO),
+		)
+
+		Expect(afero.WriteFile(fsys, "a.txt", []byte("abcd"), os.ModePerm)).To(MatchError(syscall.ENOSPC))
+		Expect(afero.WriteFile(fsys, "b.txt", []byte("xyz"), os.ModePerm)).To(Succeed())
+		Expect(afero.WriteFile(fsys, "c.txt", []byte("z"), os.ModePerm)).To(MatchError(syscall.EIO))
+	})
+
+	It("should share conditions between faults", func() {
+		first := fault.First(1)
+		fsys := fault.NewFs(base,
+			fault.Fail(syscall.EIO, fault.On[op.Stat](), first),
+			fault.Fail(syscall.EIO, fault.On[op.Open](), first),
+		)
+
+		_, err := fsys.Stat("test.txt")
+		Expect(err).To(MatchError(syscall.EIO))
+		_, err = fsys.Open("test.txt")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should delay operations", func() {
+		fsys := fault.NewFs(base, fault.Delay(20*time.Millisecond, fault.On[op.Stat]()))
+
+		start := time.Now()
+		_, err := fsys.Stat("test.txt")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(time.Since(start)).To(BeNumerically(">=", 20*time.Millisecond))
+	})
+
+	It("should hang operations until the context is cancelled", func(ctx SpecContext) {
+		hang, cancel := context.WithCancel(ctx)
+		fsys := fault.NewFs(base, fault.Hang(hang, fault.On[op.Open]()))
+
+		errs := make(chan error)
+		go func() {
+			_, err := fsys.Open("test.txt")
+			errs <- err
+		}()
+
+		Consistently(errs).ShouldNot(Receive())
+		cancel()
+		Eventually(errs).Should(Receive(MatchError(context.Canceled)))
+	}, SpecTimeout(5*time.Second))
+})
+
+var _ = conformance.Describe("Conformance", func(seed afero.Fs) (afero.Fs, error) {
+	return fault.NewFs(seed), nil
+}, conformance.Without(conformance.NoSymlink))
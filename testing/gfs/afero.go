@@ -1,10 +1,12 @@
 package gfs
 
 import (
-	"errors"
+	"bytes"
 	"fmt"
 	"io/fs"
+	"path/filepath"
 	"reflect"
+	"strings"
 
 	"github.com/onsi/gomega/types"
 	"github.com/spf13/afero"
@@ -83,6 +85,8 @@ func ContainFile(path string) types.GomegaMatcher {
 
 type beEquivalentToFs struct {
 	expected afero.Fs
+	exact    bool
+	diffs    []string
 }
 
 // Match implements types.GomegaMatcher.
@@ -92,7 +96,8 @@ func (e *beEquivalentToFs) Match(actual interface{}) (success bool, err error) {
 		return false, fmt.Errorf("exected an [afero.Fs] but got %s", reflect.TypeOf(actual))
 	}
 
-	failures := []error{}
+	e.diffs = nil
+	missing := false
 	err = afero.Walk(e.expected, "",
 		func(path string, info fs.FileInfo, err error) error {
 			if err != nil {
@@ -104,9 +109,7 @@ func (e *beEquivalentToFs) Match(actual interface{}) (success bool, err error) {
 					return err
 				}
 				if !exists {
-					failures = append(failures,
-						fmt.Errorf("expected dir to exist at %s", path),
-					)
+					missing = true
 				}
 
 				return nil
@@ -117,10 +120,7 @@ func (e *beEquivalentToFs) Match(actual interface{}) (success bool, err error) {
 				return err
 			}
 			if !exists {
-				failures = append(failures,
-					fmt.Errorf("expected file to exist at %s", path),
-				)
-
+				missing = true
 				return nil
 			}
 
@@ -129,21 +129,17 @@ func (e *beEquivalentToFs) Match(actual interface{}) (success bool, err error) {
 				return err
 			}
 
-			matched, err := afero.FileContainsBytes(target, path, expectedBytes)
+			actualBytes, err := afero.ReadFile(target, path)
 			if err != nil {
 				return err
 			}
-			if !matched {
-				actualBytes, err := afero.ReadFile(target, path)
-				if err != nil {
-					return err
-				}
-
-				failures = append(failures,
-					fmt.Errorf("expected file at %s to contain content:\n\t%s\nbut found\n\t%s",
-						path, string(expectedBytes), string(actualBytes),
-					),
-				)
+			if e.exact && !bytes.Equal(expectedBytes, actualBytes) ||
+				!e.exact && !bytes.Contains(actualBytes, expectedBytes) {
+				e.diffs = append(e.diffs, diff(
+					"expected/"+filepath.ToSlash(path),
+					"actual/"+filepath.ToSlash(path),
+					string(expectedBytes), string(actualBytes),
+				))
 			}
 
 			return nil
@@ -152,16 +148,52 @@ func (e *beEquivalentToFs) Match(actual interface{}) (success bool, err error) {
 	if err != nil {
 		return false, fmt.Errorf("walking expected filesystem: %w", err)
 	}
+	if e.exact && !missing {
+		if missing, err = e.unexpected(target); err != nil {
+			return false, fmt.Errorf("walking actual filesystem: %w", err)
+		}
+	}
+
+	if missing {
+		expectedTree, err := tree(e.expected)
+		if err != nil {
+			return false, fmt.Errorf("walking expected filesystem: %w", err)
+		}
+
+		actualTree, err := tree(target)
+		if err != nil {
+			return false, fmt.Errorf("walking actual filesystem: %w", err)
+		}
+
+		e.diffs = append([]string{diff("expected", "actual", expectedTree, actualTree)}, e.diffs...)
+	}
+
+	return len(e.diffs) == 0, nil
+}
+
+// unexpected reports whether target holds a path that is not in the expected filesystem.
+func (e *beEquivalentToFs) unexpected(target afero.Fs) (bool, error) {
+	found := false
+	err := afero.Walk(target, "", func(path string, info fs.FileInfo, err error) error {
+		if err != nil || found {
+			return err
+		}
+
+		exists, err := afero.Exists(e.expected, path)
+		found = !exists
+		return err
+	})
 
-	return len(failures) == 0, errors.Join(failures...)
+	return found, err
 }
 
 // FailureMessage implements types.GomegaMatcher.
 func (e *beEquivalentToFs) FailureMessage(actual interface{}) (message string) {
 	return fmt.Sprintf(
-		"expected fs %s to match fs %s",
+		"expected fs %s to match fs %s\n%s",
 		actual.(afero.Fs).Name(),
 		e.expected.Name(),
+		strings.Join(e.diffs, ""),
 	)
 }
 
@@ -174,6 +206,40 @@ func (e *beEquivalentToFs) NegatedFailureMessage(actual interface{}) (message st
 	)
 }
 
+// BeEquivalentToFs succeeds when the actual [afero.Fs] contains every
+// directory and file of fs, and each file contains the contents of the
+// file in fs. Use [EqualFs] to require the same files and contents. On
+// failure it prints a unified diff of the trees and of each differing file.
 func BeEquivalentToFs(fs afero.Fs) types.GomegaMatcher {
-	return &beEquivalentToFs{fs}
+	return &beEquivalentToFs{expected: fs}
+}
+
+// EqualFs succeeds when the actual [afero.Fs] holds exactly the directories
+// and files of fs, with the same contents. On failure it prints a unified
+// diff of the trees and of each differing file.
+func EqualFs(fs afero.Fs) types.GomegaMatcher {
+	return &beEquivalentToFs{expected: fs, exact: true}
+}
+
+// tree lists the paths in fsys one per line, with directories ending in a slash.
+func tree(fsys afero.Fs) (string, error) {
+	b := &strings.Builder{}
+	err := afero.Walk(fsys, "", func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		path = strings.TrimPrefix(filepath.ToSlash(path), "/")
+		if path == "" || path == "." {
+			return nil
+		}
+		if info.IsDir() {
+			path += "/"
+		}
+
+		_, err = fmt.Fprintln(b, path)
+		return err
+	})
+
+	return b.String(), err
 }
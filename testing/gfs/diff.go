@@ -0,0 +1,210 @@
+package gfs
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// context is the number of unchanged lines surrounding each hunk of a diff
+const context = 3
+
+type edit struct {
+	op   byte // ' ', '-' or '+'
+	text string
+}
+
+// diff returns a unified diff turning a into b, or an empty string when
+// they are equal.
+func diff(from, to, a, b string) string {
+	edits := diffLines(lines(a), lines(b))
+
+	// pos[i] holds the line of a and b preceding edits[i]
+	pos := make([][2]int, len(edits)+1)
+	for i, e := range edits {
+		pos[i+1] = pos[i]
+		if e.op != '+' {
+			pos[i+1][0]++
+		}
+		if e.op != '-' {
+			pos[i+1][1]++
+		}
+	}
+
+	out := &strings.Builder{}
+	for i, end := 0, 0; i < len(edits); {
+		for i < len(edits) && edits[i].op == ' ' {
+			i++
+		}
+		if i == len(edits) {
+			break
+		}
+
+		last := i
+		for j := i; j < len(edits) && j-last <= 2*context; j++ {
+			if edits[j].op != ' ' {
+				last = j
+			}
+		}
+
+		start := max(i-context, end)
+		end = min(last+context+1, len(edits))
+		if out.Len() == 0 {
+			fmt.Fprintf(out, "--- %s\n+++ %s\n", from, to)
+		}
+
+		fmt.Fprintf(out, "@@ -%s +%s @@\n",
+			hunkRange(pos[start][0], pos[end][0]-pos[start][0]),
+			hunkRange(pos[start][1], pos[end][1]-pos[start][1]),
+		)
+		for _, e := range edits[start:end] {
+			out.WriteByte(e.op)
+			out.WriteString(e.text)
+			if !strings.HasSuffix(e.text, "\n") {
+				out.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+
+		i = end
+	}
+
+	return out.String()
+}
+
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprint(start + 1)
+	}
+
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+func lines(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	l := strings.SplitAfter(s, "\n")
+	if l[len(l)-1] == "" {
+		l = l[:len(l)-1]
+	}
+
+	return l
+}
+
+// diffLines returns the shortest edits turning a into b, found with Myers'
+// algorithm after trimming the lines they start and end with in common.
+func diffLines(a, b []string) []edit {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	edits := make([]edit, 0, len(a)+len(b)-prefix-suffix)
+	for _, line := range a[:prefix] {
+		edits = append(edits, edit{' ', line})
+	}
+
+	edits = append(edits, myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		edits = append(edits, edit{' ', line})
+	}
+
+	return edits
+}
+
+// maxEdits bounds the edits searched for by [myers], whose memory grows
+// with the square of the number of edits.
+const maxEdits = 1000
+
+// myers returns the shortest edits turning a into b, or removes every line of
+// a and adds every line of b when that takes more than maxEdits edits.
+func myers(a, b []string) []edit {
+	n, m := len(a), len(b)
+	limit := min(n+m, maxEdits)
+
+	// v[offset+k] is the furthest line of a reached on diagonal k = x - y,
+	// and trace[d] holds v for diagonals -d to d before taking d edits
+	offset := limit + 1
+	v := make([]int, 2*offset+1)
+	trace := [][]int{}
+
+	for d := 0; d <= limit; d++ {
+		trace = append(trace, slices.Clone(v[offset-d:offset+d+1]))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || k != d && v[offset+k-1] < v[offset+k+1] {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x, y = x+1, y+1
+			}
+
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(a, b, trace)
+			}
+		}
+	}
+
+	edits := make([]edit, 0, n+m)
+	for _, line := range a {
+		edits = append(edits, edit{'-', line})
+	}
+	for _, line := range b {
+		edits = append(edits, edit{'+', line})
+	}
+
+	return edits
+}
+
+// backtrack follows the trace of [myers] from the end of a and b back to
+// their start, returning the edits taken along the way.
+func backtrack(a, b []string, trace [][]int) []edit {
+	edits := []edit{}
+	x, y := len(a), len(b)
+	for d := len(trace) - 1; d > 0; d-- {
+		v, k := trace[d], x-y
+		at := func(k int) int { return v[k+d] }
+
+		prev := k - 1
+		if k == -d || k != d && at(k-1) < at(k+1) {
+			prev = k + 1
+		}
+
+		px := at(prev)
+		py := px - prev
+		for x > px && y > py {
+			x, y = x-1, y-1
+			edits = append(edits, edit{' ', a[x]})
+		}
+
+		if x == px {
+			y--
+			edits = append(edits, edit{'+', b[y]})
+		} else {
+			x--
+			edits = append(edits, edit{'-', a[x]})
+		}
+	}
+	for x > 0 && y > 0 {
+		x, y = x-1, y-1
+		edits = append(edits, edit{' ', a[x]})
+	}
+
+	slices.Reverse(edits)
+	return edits
+}
@@ -0,0 +1,311 @@
+package gfs
+
+import (
+	"fmt"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	"github.com/spf13/afero"
+)
+
+type containDir struct {
+	path string
+}
+
+// Match implements types.GomegaMatcher.
+func (c *containDir) Match(actual interface{}) (success bool, err error) {
+	fs, ok := actual.(afero.Fs)
+	if !ok {
+		return false, fmt.Errorf("expected an [afero.Fs] got %s", reflect.TypeOf(actual))
+	}
+
+	return afero.DirExists(fs, c.path)
+}
+
+// FailureMessage implements types.GomegaMatcher.
+func (c *containDir) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("expected dir to exist at %s", c.path)
+}
+
+// NegatedFailureMessage implements types.GomegaMatcher.
+func (c *containDir) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("expected dir %s not to exist", c.path)
+}
+
+func ContainDir(path string) types.GomegaMatcher {
+	return &containDir{path}
+}
+
+type containFileWith struct {
+	path    string
+	matcher types.GomegaMatcher
+	content string
+}
+
+// Match implements types.GomegaMatcher.
+func (c *containFileWith) Match(actual interface{}) (success bool, err error) {
+	fs, ok := actual.(afero.Fs)
+	if !ok {
+		return false, fmt.Errorf("expected an [afero.Fs] got %s", reflect.TypeOf(actual))
+	}
+
+	data, err := afero.ReadFile(fs, c.path)
+	if err != nil {
+		return false, err
+	}
+
+	c.content = string(data)
+	return c.matcher.Match(c.content)
+}
+
+// FailureMessage implements types.GomegaMatcher.
+func (c *containFileWith) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("expected content of file at %s\n%s",
+		c.path, c.matcher.FailureMessage(c.content),
+	)
+}
+
+// NegatedFailureMessage implements types.GomegaMatcher.
+func (c *containFileWith) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("expected content of file at %s\n%s",
+		c.path, c.matcher.NegatedFailureMessage(c.content),
+	)
+}
+
+// ContainFileWith succeeds when the file at path exists and its content, as a
+// string, satisfies matcher. Values other than matchers are compared with
+// [gomega.Equal].
+//
+//	Expect(fs).To(gfs.ContainFileWith("main.go", ContainSubstring("package main")))
+func ContainFileWith(path string, matcher interface{}) types.GomegaMatcher {
+	return &containFileWith{path: path, matcher: matcherOr(matcher, gomega.Equal)}
+}
+
+// ContainFileMatching succeeds when the content of the file at path matches
+// the regular expression regexp.
+func ContainFileMatching(path string, regexp string) types.GomegaMatcher {
+	return ContainFileWith(path, gomega.MatchRegexp(regexp))
+}
+
+type haveFileInfo struct {
+	path    string
+	desc    string
+	field   func(fs.FileInfo) interface{}
+	matcher types.GomegaMatcher
+	value   interface{}
+}
+
+// Match implements types.GomegaMatcher.
+func (h *haveFileInfo) Match(actual interface{}) (success bool, err error) {
+	fsys, ok := actual.(afero.Fs)
+	if !ok {
+		return false, fmt.Errorf("expected an [afero.Fs] got %s", reflect.TypeOf(actual))
+	}
+
+	var info fs.FileInfo
+	if lstater, ok := fsys.(afero.Lstater); ok {
+		info, _, err = lstater.LstatIfPossible(h.path)
+	} else {
+		info, err = fsys.Stat(h.path)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	h.value = h.field(info)
+	return h.matcher.Match(h.value)
+}
+
+// FailureMessage implements types.GomegaMatcher.
+func (h *haveFileInfo) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("expected %s of file at %s\n%s",
+		h.desc, h.path, h.matcher.FailureMessage(h.value),
+	)
+}
+
+// NegatedFailureMessage implements types.GomegaMatcher.
+func (h *haveFileInfo) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("expected %s of file at %s\n%s",
+		h.desc, h.path, h.matcher.NegatedFailureMessage(h.value),
+	)
+}
+
+// HaveFileMode succeeds when the mode of the file at path, including its
+// type bits, equals mode. Symlinks are not followed when the Fs supports it.
+func HaveFileMode(path string, mode fs.FileMode) types.GomegaMatcher {
+	return &haveFileInfo{
+		path:    path,
+		desc:    "mode",
+		field:   func(info fs.FileInfo) interface{} { return info.Mode() },
+		matcher: gomega.Equal(mode),
+	}
+}
+
+// HaveFilePerm succeeds when the permission bits of the file at path equal perm.
+func HaveFilePerm(path string, perm fs.FileMode) types.GomegaMatcher {
+	return &haveFileInfo{
+		path:    path,
+		desc:    "permissions",
+		field:   func(info fs.FileInfo) interface{} { return info.Mode().Perm() },
+		matcher: gomega.Equal(perm.Perm()),
+	}
+}
+
+// HaveFileSize succeeds when the size of the file at path satisfies size,
+// either a matcher or a number compared with [gomega.BeEquivalentTo].
+func HaveFileSize(path string, size interface{}) types.GomegaMatcher {
+	return &haveFileInfo{
+		path:  path,
+		desc:  "size",
+		field: func(info fs.FileInfo) interface{} { return info.Size() },
+		matcher: matcherOr(size, func(expected interface{}) types.GomegaMatcher {
+			return gomega.BeEquivalentTo(expected)
+		}),
+	}
+}
+
+// HaveModTime succeeds when the modification time of the file at path
+// satisfies modTime, either a matcher such as [gomega.BeTemporally] or
+// a [time.Time] it must equal.
+func HaveModTime(path string, modTime interface{}) types.GomegaMatcher {
+	return &haveFileInfo{
+		path:  path,
+		desc:  "modification time",
+		field: func(info fs.FileInfo) interface{} { return info.ModTime() },
+		matcher: matcherOr(modTime, func(expected interface{}) types.GomegaMatcher {
+			if t, ok := expected.(time.Time); ok {
+				return gomega.BeTemporally("==", t)
+			}
+
+			return gomega.Equal(expected)
+		}),
+	}
+}
+
+type haveSymlink struct {
+	path    string
+	matcher types.GomegaMatcher
+	target  string
+}
+
+// Match implements types.GomegaMatcher.
+func (h *haveSymlink) Match(actual interface{}) (success bool, err error) {
+	reader, ok := actual.(afero.LinkReader)
+	if !ok {
+		return false, fmt.Errorf("expected an [afero.LinkReader] got %s", reflect.TypeOf(actual))
+	}
+
+	if h.target, err = reader.ReadlinkIfPossible(h.path); err != nil {
+		return false, err
+	}
+
+	return h.matcher.Match(h.target)
+}
+
+// FailureMessage implements types.GomegaMatcher.
+func (h *haveSymlink) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("expected target of symlink at %s\n%s",
+		h.path, h.matcher.FailureMessage(h.target),
+	)
+}
+
+// NegatedFailureMessage implements types.GomegaMatcher.
+func (h *haveSymlink) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("expected target of symlink at %s\n%s",
+		h.path, h.matcher.NegatedFailureMessage(h.target),
+	)
+}
+
+// HaveSymlink succeeds when path is a symlink whose target satisfies target,
+// either a matcher or a string it must equal. The actual Fs must implement
+// [afero.LinkReader].
+func HaveSymlink(path string, target interface{}) types.GomegaMatcher {
+	return &haveSymlink{path: path, matcher: matcherOr(target, gomega.Equal)}
+}
+
+type haveExactlyFiles struct {
+	paths      []string
+	missing    []string
+	unexpected []string
+}
+
+// Match implements types.GomegaMatcher.
+func (h *haveExactlyFiles) Match(actual interface{}) (success bool, err error) {
+	fsys, ok := actual.(afero.Fs)
+	if !ok {
+		return false, fmt.Errorf("expected an [afero.Fs] got %s", reflect.TypeOf(actual))
+	}
+
+	found := map[string]bool{}
+	err = afero.Walk(fsys, "", func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			found[cleanPath(path)] = true
+		}
+
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("walking actual filesystem: %w", err)
+	}
+
+	h.missing = nil
+	for _, p := range h.paths {
+		if !found[cleanPath(p)] {
+			h.missing = append(h.missing, p)
+		}
+
+		delete(found, cleanPath(p))
+	}
+
+	h.unexpected = slices.Sorted(maps.Keys(found))
+
+	return len(h.missing) == 0 && len(h.unexpected) == 0, nil
+}
+
+// FailureMessage implements types.GomegaMatcher.
+func (h *haveExactlyFiles) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf(
+		"expected fs to have exactly the files\n%s\nmissing files\n%s\nunexpected files\n%s",
+		format.Object(h.paths, 1),
+		format.Object(h.missing, 1),
+		format.Object(h.unexpected, 1),
+	)
+}
+
+// NegatedFailureMessage implements types.GomegaMatcher.
+func (h *haveExactlyFiles) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("expected fs not to have exactly the files\n%s",
+		format.Object(h.paths, 1),
+	)
+}
+
+// HaveExactlyFiles succeeds when the files of the actual [afero.Fs], in any
+// order and excluding directories, are exactly paths.
+func HaveExactlyFiles(paths ...string) types.GomegaMatcher {
+	return &haveExactlyFiles{paths: paths}
+}
+
+// matcherOr returns value when it is a matcher, otherwise the matcher
+// returned by fallback for value.
+func matcherOr(value interface{}, fallback func(interface{}) types.GomegaMatcher) types.GomegaMatcher {
+	if m, ok := value.(types.GomegaMatcher); ok {
+		return m
+	}
+
+	return fallback(value)
+}
+
+func cleanPath(path string) string {
+	return strings.TrimPrefix(filepath.ToSlash(filepath.Clean(path)), "/")
+}
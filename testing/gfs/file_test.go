@@ -0,0 +1,200 @@
+package gfs_test
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/testing"
+	"github.com/unmango/aferox/testing/gfs"
+)
+
+var _ = Describe("File matchers", func() {
+	var fsys afero.Fs
+
+	BeforeEach(func() {
+		fsys = testing.Txtar(`
+-- cmd/main.go --
+package main
+
+func main() {}
+-- README.md --
+# Test
+-- empty/ --
+`)
+	})
+
+	It("should match directories", func() {
+		Expect(fsys).To(gfs.ContainDir("cmd"))
+		Expect(fsys).To(gfs.ContainDir("empty"))
+		Expect(fsys).NotTo(gfs.ContainDir("README.md"))
+		Expect(fsys).NotTo(gfs.ContainDir("missing"))
+	})
+
+	It("should match file contents", func() {
+		Expect(fsys).To(gfs.ContainFileWith("cmd/main.go", ContainSubstring("func main()")))
+		Expect(fsys).To(gfs.ContainFileWith("README.md", "# Test\n"))
+		Expect(fsys).NotTo(gfs.ContainFileWith("README.md", ContainSubstring("main")))
+		Expect(fsys).To(gfs.ContainFileMatching("cmd/main.go", `^package \w+`))
+	})
+
+	It("should describe differing contents", func() {
+		matcher := gfs.ContainFileWith("README.md", "# Other\n")
+
+		Expect(matcher.Match(fsys)).To(BeFalse())
+		Expect(matcher.FailureMessage(fsys)).To(And(
+			ContainSubstring("README.md"),
+			ContainSubstring("# Other"),
+		))
+	})
+
+	It("should match modes", func() {
+		Expect(fsys.Chmod("README.md", 0600)).To(Succeed())
+
+		Expect(fsys).To(gfs.HaveFileMode("README.md", 0600))
+		Expect(fsys).To(gfs.HaveFileMode("cmd", fs.ModeDir|0777))
+		Expect(fsys).To(gfs.HaveFilePerm("cmd", 0777))
+		Expect(fsys).NotTo(gfs.HaveFilePerm("README.md", 0644))
+	})
+
+	It("should match sizes", func() {
+		Expect(fsys).To(gfs.HaveFileSize("README.md", 7))
+		Expect(fsys).To(gfs.HaveFileSize("cmd/main.go", BeNumerically(">", 7)))
+		Expect(fsys).NotTo(gfs.HaveFileSize("README.md", 0))
+	})
+
+	It("should match modification times", func() {
+		mtime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		Expect(fsys.Chtimes("README.md", mtime, mtime)).To(Succeed())
+
+		Expect(fsys).To(gfs.HaveModTime("README.md", mtime))
+		Expect(fsys).To(gfs.HaveModTime("README.md", BeTemporally("<", time.Now())))
+		Expect(fsys).NotTo(gfs.HaveModTime("README.md", mtime.Add(time.Second)))
+	})
+
+	It("should match symlink targets", func() {
+		dir := GinkgoT().TempDir()
+		osfs := afero.NewOsFs()
+		link := filepath.Join(dir, "link")
+		Expect(osfs.(afero.Linker).SymlinkIfPossible("target.txt", link)).To(Succeed())
+
+		Expect(osfs).To(gfs.HaveSymlink(link, "target.txt"))
+		Expect(osfs).To(gfs.HaveSymlink(link, HaveSuffix(".txt")))
+		Expect(osfs).To(gfs.HaveFileMode(link, fs.ModeSymlink|0777))
+		Expect(osfs).NotTo(gfs.HaveSymlink(link, "other.txt"))
+	})
+
+	It("should match exactly the files", func() {
+		Expect(fsys).To(gfs.HaveExactlyFiles("README.md", "cmd/main.go"))
+		Expect(fsys).To(gfs.HaveExactlyFiles("/cmd/main.go", "README.md"))
+		Expect(fsys).NotTo(gfs.HaveExactlyFiles("README.md"))
+		Expect(fsys).NotTo(gfs.HaveExactlyFiles("README.md", "cmd/main.go", "go.mod"))
+	})
+
+	It("should describe missing and unexpected files", func() {
+		matcher := gfs.HaveExactlyFiles("README.md", "go.mod")
+
+		Expect(matcher.Match(fsys)).To(BeFalse())
+		Expect(matcher.FailureMessage(fsys)).To(MatchRegexp(`missing files\n.*go\.mod[^\n]*\nunexpected files\n.*cmd/main\.go`))
+	})
+})
+
+var _ = Describe("BeEquivalentToFs", func() {
+	It("should match filesystems with the same files", func() {
+		expected := testing.Txtar("-- a.txt --\na\n-- dir/b.txt --\nb\n")
+		actual := testing.Txtar("-- a.txt --\na\n-- dir/b.txt --\nb\n")
+
+		Expect(actual).To(gfs.BeEquivalentToFs(expected))
+	})
+
+	It("should not match differing contents", func() {
+		expected := testing.Txtar("-- a.txt --\na\n")
+
+		Expect(testing.Txtar("-- a.txt --\nb\n")).NotTo(gfs.BeEquivalentToFs(expected))
+	})
+
+	It("should match filesystems containing the files and their contents", func() {
+		expected := testing.Txtar("-- a.txt --\na\n")
+
+		Expect(testing.Txtar("-- a.txt --\na\nb\n-- b.txt --\n")).To(gfs.BeEquivalentToFs(expected))
+	})
+
+	It("should print a unified diff of differing files", func() {
+		expected := testing.Txtar("-- a.txt --\n1\n2\n3\n4\n5\n6\n7\n8\n9\n")
+		actual := testing.Txtar("-- a.txt --\n1\n2\n3\n4\nfive\n6\n7\n8\n9\n")
+		matcher := gfs.BeEquivalentToFs(expected)
+
+		Expect(matcher.Match(actual)).To(BeFalse())
+		Expect(matcher.FailureMessage(actual)).To(HaveSuffix(`--- expected/a.txt
++++ actual/a.txt
+@@ -2,7 +2,7 @@
+ 2
+ 3
+ 4
+-5
++five
+ 6
+ 7
+ 8
+`))
+	})
+
+	It("should diff large files", func() {
+		a := strings.Repeat("a\n", 50000)
+		b := strings.Repeat("b\n", 50000)
+		expected := testing.Txtar("-- a.txt --\n" + a + "-- b.txt --\n" + a + "x\n" + a)
+		actual := testing.Txtar("-- a.txt --\n" + b + "-- b.txt --\n" + a + "y\n" + a)
+		matcher := gfs.BeEquivalentToFs(expected)
+
+		Expect(matcher.Match(actual)).To(BeFalse())
+		Expect(matcher.FailureMessage(actual)).To(SatisfyAll(
+			ContainSubstring("@@ -1,50000 +1,50000 @@"),
+			ContainSubstring("@@ -49998,7 +49998,7 @@\n a\n a\n a\n-x\n+y\n"),
+		))
+	})
+
+	It("should print a unified diff of differing trees", func() {
+		expected := testing.Txtar("-- a.txt --\n-- dir/b.txt --\n")
+		actual := testing.Txtar("-- a.txt --\n-- other.txt --\n")
+		matcher := gfs.BeEquivalentToFs(expected)
+
+		Expect(matcher.Match(actual)).To(BeFalse())
+		Expect(matcher.FailureMessage(actual)).To(HaveSuffix(`--- expected
++++ actual
+@@ -1,3 +1,2 @@
+ a.txt
+-dir/
+-dir/b.txt
++other.txt
+`))
+	})
+})
+
+var _ = Describe("EqualFs", func() {
+	It("should match filesystems with the same files", func() {
+		expected := testing.Txtar("-- a.txt --\na\n-- dir/b.txt --\nb\n-- empty/ --\n")
+		actual := testing.Txtar("-- a.txt --\na\n-- dir/b.txt --\nb\n-- empty/ --\n")
+
+		Expect(actual).To(gfs.EqualFs(expected))
+	})
+
+	It("should not match differing contents", func() {
+		expected := testing.Txtar("-- a.txt --\na\n")
+
+		Expect(testing.Txtar("-- a.txt --\na\nb\n")).NotTo(gfs.EqualFs(expected))
+	})
+
+	It("should not match unexpected files", func() {
+		expected := testing.Txtar("-- a.txt --\na\n")
+		actual := testing.Txtar("-- a.txt --\na\n-- b.txt --\nb\n")
+		matcher := gfs.EqualFs(expected)
+
+		Expect(matcher.Match(actual)).To(BeFalse())
+		Expect(matcher.FailureMessage(actual)).To(HaveSuffix("+b.txt\n"))
+	})
+})
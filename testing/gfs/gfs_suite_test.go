@@ -0,0 +1,13 @@
+package gfs_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestGfs(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Gfs Suite")
+}
@@ -0,0 +1,112 @@
+package gfs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+
+	"github.com/onsi/gomega/types"
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/testing"
+	"github.com/unmango/go/fopt"
+)
+
+// UpdateGoldenEnv is the environment variable that, when set to a true value
+// such as 1, makes [MatchGolden] rewrite golden files by default.
+const UpdateGoldenEnv = "UPDATE_GOLDEN"
+
+type goldenOptions struct {
+	update bool
+}
+
+type GoldenOption func(*goldenOptions)
+
+// Update rewrites golden files with the actual filesystems when update is
+// true, for example from a flag defined by the test binary:
+//
+//	var update = flag.Bool("update", false, "rewrite golden files")
+//
+//	Expect(fs).To(gfs.MatchGolden("testdata/fs.txtar", gfs.Update(*update)))
+func Update(update bool) GoldenOption {
+	return func(options *goldenOptions) {
+		options.update = update
+	}
+}
+
+func defaultGoldenOptions() goldenOptions {
+	update, _ := strconv.ParseBool(os.Getenv(UpdateGoldenEnv))
+	return goldenOptions{update: update}
+}
+
+type matchGolden struct {
+	path     string
+	opts     goldenOptions
+	expected []byte
+	actual   []byte
+}
+
+// Match implements types.GomegaMatcher.
+func (m *matchGolden) Match(actual interface{}) (success bool, err error) {
+	fs, ok := actual.(afero.Fs)
+	if !ok {
+		return false, fmt.Errorf("expected an [afero.Fs] got %s", reflect.TypeOf(actual))
+	}
+
+	if m.actual, err = testing.FormatTxtar(fs, ""); err != nil {
+		return false, fmt.Errorf("formatting actual filesystem: %w", err)
+	}
+	if m.opts.update {
+		if err := os.MkdirAll(filepath.Dir(m.path), os.ModePerm); err != nil {
+			return false, err
+		}
+
+		return true, os.WriteFile(m.path, m.actual, 0644)
+	}
+
+	m.expected, err = os.ReadFile(m.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, fmt.Errorf("golden file %s does not exist, run the tests with %s=1 to create it", m.path, UpdateGoldenEnv)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(m.expected, m.actual), nil
+}
+
+// FailureMessage implements types.GomegaMatcher.
+func (m *matchGolden) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf(
+		"expected fs to match golden file %s\n%srun the tests with %s=1 to rewrite it",
+		m.path, diff(m.path, "actual", string(m.expected), string(m.actual)), UpdateGoldenEnv,
+	)
+}
+
+// NegatedFailureMessage implements types.GomegaMatcher.
+func (m *matchGolden) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("expected fs not to match golden file %s", m.path)
+}
+
+// MatchGolden succeeds when the files of the actual [afero.Fs], formatted as
+// a txtar archive, equal the golden file at path on the OS filesystem. With
+// [Update] or [UpdateGoldenEnv] the golden file is rewritten instead.
+//
+// Txtar ends every file with a newline, so a file missing its final newline
+// matches a golden file holding the same contents with one. Use
+// [BeEquivalentToFs] or [ContainFileWithBytes] to compare the bytes exactly.
+func MatchGolden(path string, options ...GoldenOption) types.GomegaMatcher {
+	m := &matchGolden{path: path, opts: defaultGoldenOptions()}
+	fopt.ApplyAll(&m.opts, options)
+
+	return m
+}
+
+// MatchTxtar succeeds when the actual [afero.Fs] contains the files of the
+// txtar archive s, see [BeEquivalentToFs].
+func MatchTxtar(s string) types.GomegaMatcher {
+	return BeEquivalentToFs(testing.Txtar(s))
+}
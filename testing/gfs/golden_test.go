@@ -0,0 +1,79 @@
+package gfs_test
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/testing"
+	"github.com/unmango/aferox/testing/gfs"
+)
+
+var _ = Describe("Golden", func() {
+	It("should match the golden file", func() {
+		fs := afero.NewMemMapFs()
+		Expect(afero.WriteFile(fs, "gen/a.go", []byte("package gen\n"), 0644)).To(Succeed())
+		Expect(afero.WriteFile(fs, "README.md", []byte("# Gen\n"), 0644)).To(Succeed())
+
+		Expect(fs).To(gfs.MatchGolden(filepath.Join("testdata", "golden.txtar")))
+	})
+
+	Context("when not updating golden files", func() {
+		BeforeEach(func() {
+			if update, _ := strconv.ParseBool(os.Getenv(gfs.UpdateGoldenEnv)); update {
+				Skip("golden files are being updated")
+			}
+		})
+
+		It("should not match other filesystems", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "golden.txtar")
+			Expect(os.WriteFile(path, []byte("-- gen/a.go --\npackage gen\n"), 0644)).To(Succeed())
+			fs := testing.Txtar("-- gen/a.go --\npackage other\n")
+
+			Expect(fs).NotTo(gfs.MatchGolden(path))
+			Expect(fs).NotTo(gfs.MatchGolden(path, gfs.Update(false)))
+		})
+
+		It("should fail when the golden file does not exist", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "missing.txtar")
+
+			_, err := gfs.MatchGolden(path).Match(afero.NewMemMapFs())
+
+			Expect(err).To(MatchError(ContainSubstring(gfs.UpdateGoldenEnv)))
+			_, err = os.Stat(path)
+			Expect(err).To(MatchError(os.ErrNotExist))
+		})
+	})
+
+	It("should rewrite golden files when updating", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "testdata", "golden.txtar")
+		fs := testing.Txtar("-- a.txt --\na\n")
+
+		Expect(fs).To(gfs.MatchGolden(path, gfs.Update(true)))
+
+		Expect(os.ReadFile(path)).To(BeEquivalentTo("-- a.txt --\na\n"))
+		Expect(fs).To(gfs.MatchGolden(path, gfs.Update(false)))
+	})
+
+	It("should not tell files apart by their final newline", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "golden.txtar")
+		Expect(os.WriteFile(path, []byte("-- a.txt --\na\n"), 0644)).To(Succeed())
+		fs := afero.NewMemMapFs()
+		Expect(afero.WriteFile(fs, "a.txt", []byte("a"), 0644)).To(Succeed())
+
+		Expect(fs).To(gfs.MatchGolden(path, gfs.Update(false)))
+		Expect(fs).NotTo(gfs.MatchTxtar("-- a.txt --\na\n"))
+	})
+
+	It("should match inline archives", func() {
+		fs := afero.NewMemMapFs()
+		Expect(afero.WriteFile(fs, "a.txt", []byte("a\n"), 0644)).To(Succeed())
+
+		Expect(fs).To(gfs.MatchTxtar("-- a.txt --\na\n"))
+		Expect(fs).NotTo(gfs.MatchTxtar("-- a.txt --\nb\n"))
+	})
+})
@@ -0,0 +1,261 @@
+package mock
+
+import (
+	"io/fs"
+
+	"github.com/spf13/afero"
+)
+
+// File is a recording mock of [afero.File].
+type File struct {
+	*recorder
+	fallback afero.File
+	name     string
+}
+
+// NewFile returns a mock of the file name passing unexpected calls to
+// fallback. When fallback is nil, unexpected calls fail with [ErrUnexpectedCall].
+// Calls are recorded with name, which defaults to "file" when empty.
+func NewFile(name string, fallback afero.File, options ...Option) *File {
+	if name == "" {
+		name = "file"
+	}
+
+	return &File{newRecorder(fallback != nil, options), fallback, name}
+}
+
+// Expect returns a FileExpecter to set up the calls the mock expects.
+func (f *File) Expect() *FileExpecter {
+	return &FileExpecter{f.recorder, Any}
+}
+
+// Close implements afero.File.
+func (f *File) Close() error {
+	results, err := f.call(Call{Method: "Close", File: f.name}, func() []any {
+		return []any{f.fallback.Close()}
+	})
+	if err != nil {
+		return err
+	}
+
+	return result[error](results, 0)
+}
+
+// Name implements afero.File.
+func (f *File) Name() string {
+	if f.fallback != nil {
+		return f.fallback.Name()
+	}
+
+	return f.name
+}
+
+// Read implements afero.File.
+func (f *File) Read(p []byte) (int, error) {
+	results, err := f.call(Call{Method: "Read", File: f.name, Args: []any{p}}, func() []any {
+		n, err := f.fallback.Read(p)
+		return []any{n, err}
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return result[int](results, 0), result[error](results, 1)
+}
+
+// ReadAt implements afero.File.
+func (f *File) ReadAt(p []byte, off int64) (int, error) {
+	results, err := f.call(Call{Method: "ReadAt", File: f.name, Args: []any{p, off}}, func() []any {
+		n, err := f.fallback.ReadAt(p, off)
+		return []any{n, err}
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return result[int](results, 0), result[error](results, 1)
+}
+
+// Readdir implements afero.File.
+func (f *File) Readdir(count int) ([]fs.FileInfo, error) {
+	results, err := f.call(Call{Method: "Readdir", File: f.name, Args: []any{count}}, func() []any {
+		infos, err := f.fallback.Readdir(count)
+		return []any{infos, err}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result[[]fs.FileInfo](results, 0), result[error](results, 1)
+}
+
+// Readdirnames implements afero.File.
+func (f *File) Readdirnames(n int) ([]string, error) {
+	results, err := f.call(Call{Method: "Readdirnames", File: f.name, Args: []any{n}}, func() []any {
+		names, err := f.fallback.Readdirnames(n)
+		return []any{names, err}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result[[]string](results, 0), result[error](results, 1)
+}
+
+// Seek implements afero.File.
+func (f *File) Seek(offset int64, whence int) (int64, error) {
+	results, err := f.call(Call{Method: "Seek", File: f.name, Args: []any{offset, whence}}, func() []any {
+		off, err := f.fallback.Seek(offset, whence)
+		return []any{off, err}
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return result[int64](results, 0), result[error](results, 1)
+}
+
+// Stat implements afero.File.
+func (f *File) Stat() (fs.FileInfo, error) {
+	results, err := f.call(Call{Method: "Stat", File: f.name}, func() []any {
+		info, err := f.fallback.Stat()
+		return []any{info, err}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result[fs.FileInfo](results, 0), result[error](results, 1)
+}
+
+// Sync implements afero.File.
+func (f *File) Sync() error {
+	results, err := f.call(Call{Method: "Sync", File: f.name}, func() []any {
+		return []any{f.fallback.Sync()}
+	})
+	if err != nil {
+		return err
+	}
+
+	return result[error](results, 0)
+}
+
+// Truncate implements afero.File.
+func (f *File) Truncate(size int64) error {
+	results, err := f.call(Call{Method: "Truncate", File: f.name, Args: []any{size}}, func() []any {
+		return []any{f.fallback.Truncate(size)}
+	})
+	if err != nil {
+		return err
+	}
+
+	return result[error](results, 0)
+}
+
+// Write implements afero.File.
+func (f *File) Write(p []byte) (int, error) {
+	results, err := f.call(Call{Method: "Write", File: f.name, Args: []any{p}}, func() []any {
+		n, err := f.fallback.Write(p)
+		return []any{n, err}
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return result[int](results, 0), result[error](results, 1)
+}
+
+// WriteAt implements afero.File.
+func (f *File) WriteAt(p []byte, off int64) (int, error) {
+	results, err := f.call(Call{Method: "WriteAt", File: f.name, Args: []any{p, off}}, func() []any {
+		n, err := f.fallback.WriteAt(p, off)
+		return []any{n, err}
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return result[int](results, 0), result[error](results, 1)
+}
+
+// WriteString implements afero.File.
+func (f *File) WriteString(s string) (int, error) {
+	results, err := f.call(Call{Method: "WriteString", File: f.name, Args: []any{s}}, func() []any {
+		n, err := f.fallback.WriteString(s)
+		return []any{n, err}
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return result[int](results, 0), result[error](results, 1)
+}
+
+// FileExpecter sets up the calls a [File] expects. Arguments match when
+// they are equal, or when they are a [Matcher] matching the argument.
+type FileExpecter struct {
+	r    *recorder
+	name any
+}
+
+// Close expects a call to Close.
+func (e *FileExpecter) Close() *Expectation {
+	return e.r.expect("Close", e.name)
+}
+
+// Read expects a call to Read.
+func (e *FileExpecter) Read(p any) *Expectation {
+	return e.r.expect("Read", e.name, p)
+}
+
+// ReadAt expects a call to ReadAt.
+func (e *FileExpecter) ReadAt(p, off any) *Expectation {
+	return e.r.expect("ReadAt", e.name, p, off)
+}
+
+// Readdir expects a call to Readdir.
+func (e *FileExpecter) Readdir(count any) *Expectation {
+	return e.r.expect("Readdir", e.name, count)
+}
+
+// Readdirnames expects a call to Readdirnames.
+func (e *FileExpecter) Readdirnames(n any) *Expectation {
+	return e.r.expect("Readdirnames", e.name, n)
+}
+
+// Seek expects a call to Seek.
+func (e *FileExpecter) Seek(offset, whence any) *Expectation {
+	return e.r.expect("Seek", e.name, offset, whence)
+}
+
+// Stat expects a call to Stat.
+func (e *FileExpecter) Stat() *Expectation {
+	return e.r.expect("Stat", e.name)
+}
+
+// Sync expects a call to Sync.
+func (e *FileExpecter) Sync() *Expectation {
+	return e.r.expect("Sync", e.name)
+}
+
+// Truncate expects a call to Truncate.
+func (e *FileExpecter) Truncate(size any) *Expectation {
+	return e.r.expect("Truncate", e.name, size)
+}
+
+// Write expects a call to Write.
+func (e *FileExpecter) Write(p any) *Expectation {
+	return e.r.expect("Write", e.name, p)
+}
+
+// WriteAt expects a call to WriteAt.
+func (e *FileExpecter) WriteAt(p, off any) *Expectation {
+	return e.r.expect("WriteAt", e.name, p, off)
+}
+
+// WriteString expects a call to WriteString.
+func (e *FileExpecter) WriteString(s any) *Expectation {
+	return e.r.expect("WriteString", e.name, s)
+}
+
+var _ afero.File = (*File)(nil)
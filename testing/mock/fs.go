@@ -0,0 +1,267 @@
+package mock
+
+import (
+	"context"
+	"io/fs"
+	"time"
+
+	"github.com/spf13/afero"
+	aferoctx "github.com/unmango/aferox/context"
+)
+
+// Fs is a recording mock of [aferoctx.Fs]. Files opened through the fallback
+// are wrapped in a [File] recording to the same mock.
+type Fs struct {
+	*recorder
+	fallback afero.Fs
+}
+
+// NewFs returns a mock passing unexpected calls to fallback. When fallback
+// is nil, unexpected calls fail with [ErrUnexpectedCall].
+func NewFs(fallback afero.Fs, options ...Option) *Fs {
+	return &Fs{newRecorder(fallback != nil, options), fallback}
+}
+
+// Expect returns an FsExpecter to set up the calls the mock expects.
+func (m *Fs) Expect() *FsExpecter {
+	return &FsExpecter{m.recorder}
+}
+
+// Chmod implements context.Fs.
+func (m *Fs) Chmod(ctx context.Context, name string, mode fs.FileMode) error {
+	results, err := m.call(Call{Method: "Chmod", Ctx: ctx, Args: []any{name, mode}}, func() []any {
+		return []any{m.fallback.Chmod(name, mode)}
+	})
+	if err != nil {
+		return err
+	}
+
+	return result[error](results, 0)
+}
+
+// Chown implements context.Fs.
+func (m *Fs) Chown(ctx context.Context, name string, uid int, gid int) error {
+	results, err := m.call(Call{Method: "Chown", Ctx: ctx, Args: []any{name, uid, gid}}, func() []any {
+		return []any{m.fallback.Chown(name, uid, gid)}
+	})
+	if err != nil {
+		return err
+	}
+
+	return result[error](results, 0)
+}
+
+// Chtimes implements context.Fs.
+func (m *Fs) Chtimes(ctx context.Context, name string, atime time.Time, mtime time.Time) error {
+	results, err := m.call(Call{Method: "Chtimes", Ctx: ctx, Args: []any{name, atime, mtime}}, func() []any {
+		return []any{m.fallback.Chtimes(name, atime, mtime)}
+	})
+	if err != nil {
+		return err
+	}
+
+	return result[error](results, 0)
+}
+
+// Create implements context.Fs.
+func (m *Fs) Create(ctx context.Context, name string) (afero.File, error) {
+	results, err := m.call(Call{Method: "Create", Ctx: ctx, Args: []any{name}}, func() []any {
+		file, err := m.fallback.Create(name)
+		return []any{m.file(name, file), err}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result[afero.File](results, 0), result[error](results, 1)
+}
+
+// Mkdir implements context.Fs.
+func (m *Fs) Mkdir(ctx context.Context, name string, perm fs.FileMode) error {
+	results, err := m.call(Call{Method: "Mkdir", Ctx: ctx, Args: []any{name, perm}}, func() []any {
+		return []any{m.fallback.Mkdir(name, perm)}
+	})
+	if err != nil {
+		return err
+	}
+
+	return result[error](results, 0)
+}
+
+// MkdirAll implements context.Fs.
+func (m *Fs) MkdirAll(ctx context.Context, path string, perm fs.FileMode) error {
+	results, err := m.call(Call{Method: "MkdirAll", Ctx: ctx, Args: []any{path, perm}}, func() []any {
+		return []any{m.fallback.MkdirAll(path, perm)}
+	})
+	if err != nil {
+		return err
+	}
+
+	return result[error](results, 0)
+}
+
+// Name implements context.Fs.
+func (m *Fs) Name() string {
+	if m.fallback == nil {
+		return "mock"
+	}
+
+	return "mock: " + m.fallback.Name()
+}
+
+// Open implements context.Fs.
+func (m *Fs) Open(ctx context.Context, name string) (afero.File, error) {
+	results, err := m.call(Call{Method: "Open", Ctx: ctx, Args: []any{name}}, func() []any {
+		file, err := m.fallback.Open(name)
+		return []any{m.file(name, file), err}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result[afero.File](results, 0), result[error](results, 1)
+}
+
+// OpenFile implements context.Fs.
+func (m *Fs) OpenFile(ctx context.Context, name string, flag int, perm fs.FileMode) (afero.File, error) {
+	results, err := m.call(Call{Method: "OpenFile", Ctx: ctx, Args: []any{name, flag, perm}}, func() []any {
+		file, err := m.fallback.OpenFile(name, flag, perm)
+		return []any{m.file(name, file), err}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result[afero.File](results, 0), result[error](results, 1)
+}
+
+// Remove implements context.Fs.
+func (m *Fs) Remove(ctx context.Context, name string) error {
+	results, err := m.call(Call{Method: "Remove", Ctx: ctx, Args: []any{name}}, func() []any {
+		return []any{m.fallback.Remove(name)}
+	})
+	if err != nil {
+		return err
+	}
+
+	return result[error](results, 0)
+}
+
+// RemoveAll implements context.Fs.
+func (m *Fs) RemoveAll(ctx context.Context, path string) error {
+	results, err := m.call(Call{Method: "RemoveAll", Ctx: ctx, Args: []any{path}}, func() []any {
+		return []any{m.fallback.RemoveAll(path)}
+	})
+	if err != nil {
+		return err
+	}
+
+	return result[error](results, 0)
+}
+
+// Rename implements context.Fs.
+func (m *Fs) Rename(ctx context.Context, oldname string, newname string) error {
+	results, err := m.call(Call{Method: "Rename", Ctx: ctx, Args: []any{oldname, newname}}, func() []any {
+		return []any{m.fallback.Rename(oldname, newname)}
+	})
+	if err != nil {
+		return err
+	}
+
+	return result[error](results, 0)
+}
+
+// Stat implements context.Fs.
+func (m *Fs) Stat(ctx context.Context, name string) (fs.FileInfo, error) {
+	results, err := m.call(Call{Method: "Stat", Ctx: ctx, Args: []any{name}}, func() []any {
+		info, err := m.fallback.Stat(name)
+		return []any{info, err}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result[fs.FileInfo](results, 0), result[error](results, 1)
+}
+
+// file wraps a file opened through the fallback, recording its calls under
+// the name it was opened with.
+func (m *Fs) file(name string, file afero.File) afero.File {
+	if file == nil {
+		return nil
+	}
+
+	return &File{m.recorder, file, name}
+}
+
+// FsExpecter sets up the calls an [Fs] expects. Arguments match when they
+// are equal, or when they are a [Matcher] matching the argument.
+type FsExpecter struct{ r *recorder }
+
+// Chmod expects a call to Chmod.
+func (e *FsExpecter) Chmod(name, mode any) *Expectation {
+	return e.r.expect("Chmod", nil, name, mode)
+}
+
+// Chown expects a call to Chown.
+func (e *FsExpecter) Chown(name, uid, gid any) *Expectation {
+	return e.r.expect("Chown", nil, name, uid, gid)
+}
+
+// Chtimes expects a call to Chtimes.
+func (e *FsExpecter) Chtimes(name, atime, mtime any) *Expectation {
+	return e.r.expect("Chtimes", nil, name, atime, mtime)
+}
+
+// Create expects a call to Create.
+func (e *FsExpecter) Create(name any) *Expectation {
+	return e.r.expect("Create", nil, name)
+}
+
+// Mkdir expects a call to Mkdir.
+func (e *FsExpecter) Mkdir(name, perm any) *Expectation {
+	return e.r.expect("Mkdir", nil, name, perm)
+}
+
+// MkdirAll expects a call to MkdirAll.
+func (e *FsExpecter) MkdirAll(path, perm any) *Expectation {
+	return e.r.expect("MkdirAll", nil, path, perm)
+}
+
+// Open expects a call to Open.
+func (e *FsExpecter) Open(name any) *Expectation {
+	return e.r.expect("Open", nil, name)
+}
+
+// OpenFile expects a call to OpenFile.
+func (e *FsExpecter) OpenFile(name, flag, perm any) *Expectation {
+	return e.r.expect("OpenFile", nil, name, flag, perm)
+}
+
+// Remove expects a call to Remove.
+func (e *FsExpecter) Remove(name any) *Expectation {
+	return e.r.expect("Remove", nil, name)
+}
+
+// RemoveAll expects a call to RemoveAll.
+func (e *FsExpecter) RemoveAll(path any) *Expectation {
+	return e.r.expect("RemoveAll", nil, path)
+}
+
+// Rename expects a call to Rename.
+func (e *FsExpecter) Rename(oldname, newname any) *Expectation {
+	return e.r.expect("Rename", nil, oldname, newname)
+}
+
+// Stat expects a call to Stat.
+func (e *FsExpecter) Stat(name any) *Expectation {
+	return e.r.expect("Stat", nil, name)
+}
+
+// File returns a FileExpecter for the calls made to the files named name
+// opened through the fallback.
+func (e *FsExpecter) File(name any) *FileExpecter {
+	return &FileExpecter{e.r, name}
+}
+
+var _ aferoctx.Fs = (*Fs)(nil)
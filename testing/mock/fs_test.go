@@ -0,0 +1,227 @@
+package mock_test
+
+import (
+	"io"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/context"
+	"github.com/unmango/aferox/testing/mock"
+)
+
+type key struct{}
+
+var _ = Describe("Fs", func() {
+	var base afero.Fs
+
+	BeforeEach(func() {
+		base = afero.NewMemMapFs()
+		Expect(afero.WriteFile(base, "test.txt", []byte("testing"), os.ModePerm)).To(Succeed())
+	})
+
+	It("should return expected results", func() {
+		m := mock.NewFs(base)
+		m.Expect().Open("test.txt").Return(nil, os.ErrPermission)
+
+		_, err := m.Open(context.Background(), "test.txt")
+
+		Expect(err).To(MatchError(os.ErrPermission))
+		Expect(m.Verify()).To(Succeed())
+	})
+
+	It("should pass unexpected calls to the fallback", func() {
+		m := mock.NewFs(base)
+
+		Expect(afero.ReadFile(context.BackgroundFs(m), "test.txt")).To(BeEquivalentTo("testing"))
+		Expect(m.Verify()).To(Succeed())
+	})
+
+	It("should pass expected calls without results to the fallback", func() {
+		m := mock.NewFs(base)
+		m.Expect().Stat("test.txt")
+
+		info, err := m.Stat(context.Background(), "test.txt")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Size()).To(BeEquivalentTo(7))
+		Expect(m.Verify()).To(Succeed())
+	})
+
+	It("should record calls", func() {
+		m := mock.NewFs(base)
+		ctx := context.WithValue(context.Background(), key{}, "value")
+
+		Expect(m.MkdirAll(ctx, "dir", 0755)).To(Succeed())
+		Expect(m.Rename(ctx, "test.txt", "dir/test.txt")).To(Succeed())
+
+		Expect(m.Calls()).To(HaveExactElements(
+			mock.Call{Method: "MkdirAll", Ctx: ctx, Args: []any{"dir", os.FileMode(0755)}},
+			mock.Call{Method: "Rename", Ctx: ctx, Args: []any{"test.txt", "dir/test.txt"}},
+		))
+		Expect(m.Count("Rename")).To(Equal(1))
+	})
+
+	It("should record calls to files opened through the fallback", func() {
+		m := mock.NewFs(base)
+
+		f, err := m.Create(context.Background(), "new.txt")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = f.WriteString("new")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(f.Close()).To(Succeed())
+
+		Expect(m.Calls()).To(HaveExactElements(
+			HaveField("Method", "Create"),
+			mock.Call{Method: "WriteString", File: "new.txt", Args: []any{"new"}},
+			mock.Call{Method: "Close", File: "new.txt"},
+		))
+		Expect(afero.ReadFile(base, "new.txt")).To(BeEquivalentTo("new"))
+	})
+
+	It("should return expected results from files", func() {
+		m := mock.NewFs(base)
+		m.Expect().File("test.txt").Read(mock.Any).Return(0, io.ErrUnexpectedEOF)
+
+		_, err := afero.ReadFile(context.BackgroundFs(m), "test.txt")
+
+		Expect(err).To(MatchError(io.ErrUnexpectedEOF))
+		Expect(m.Verify()).To(Succeed())
+	})
+
+	It("should match arguments with matchers", func() {
+		m := mock.NewFs(base)
+		m.Expect().Chmod(HaveSuffix(".txt"), 0600).Return(os.ErrPermission)
+
+		Expect(m.Chmod(context.Background(), "test.txt", 0600)).To(MatchError(os.ErrPermission))
+		Expect(m.Chmod(context.Background(), "test.txt", 0644)).To(Succeed())
+	})
+
+	It("should fail expected calls without results when there is no fallback", func() {
+		m := mock.NewFs(nil)
+		m.Expect().Open("test.txt")
+
+		f, err := m.Open(context.Background(), "test.txt")
+
+		Expect(err).To(MatchError(mock.ErrUnexpectedCall))
+		Expect(err).To(MatchError(ContainSubstring("without Return")))
+		Expect(f).To(BeNil())
+		Expect(m.Verify()).To(MatchError(mock.ErrUnexpectedCall))
+	})
+
+	It("should expect calls a number of times", func() {
+		m := mock.NewFs(base)
+		m.Expect().Remove("test.txt").Return(os.ErrPermission).Times(2)
+
+		Expect(m.Remove(context.Background(), "test.txt")).To(MatchError(os.ErrPermission))
+		Expect(m.Verify()).To(MatchError(mock.ErrMissingCall))
+		Expect(m.Remove(context.Background(), "test.txt")).To(MatchError(os.ErrPermission))
+		Expect(m.Verify()).To(Succeed())
+
+		Expect(m.Remove(context.Background(), "test.txt")).To(MatchError(mock.ErrUnexpectedCall))
+		Expect(m.Verify()).To(MatchError(mock.ErrUnexpectedCall))
+	})
+
+	It("should allow calls any number of times", func() {
+		m := mock.NewFs(base)
+		m.Expect().Stat(mock.Any).Return(nil, os.ErrNotExist).AnyTimes()
+
+		Expect(m.Verify()).To(Succeed())
+		for range 3 {
+			_, err := m.Stat(context.Background(), "test.txt")
+			Expect(err).To(MatchError(os.ErrNotExist))
+		}
+		Expect(m.Verify()).To(Succeed())
+	})
+
+	It("should report missing calls", func() {
+		m := mock.NewFs(base)
+		m.Expect().Mkdir("dir", mock.Any)
+
+		err := m.Verify()
+
+		Expect(err).To(MatchError(mock.ErrMissingCall))
+		Expect(err).To(MatchError(ContainSubstring(`Mkdir("dir", Any) called 0 of 1 times`)))
+	})
+
+	It("should fail unexpected calls without a fallback", func() {
+		m := mock.NewFs(nil)
+
+		_, err := m.Open(context.Background(), "test.txt")
+
+		Expect(err).To(MatchError(mock.ErrUnexpectedCall))
+		Expect(err).To(MatchError(ContainSubstring(`Open("test.txt")`)))
+		Expect(m.Verify()).To(MatchError(mock.ErrUnexpectedCall))
+	})
+
+	Describe("Ordered", func() {
+		It("should expect calls in order", func() {
+			m := mock.NewFs(base, mock.Ordered)
+			m.Expect().Mkdir("dir", mock.Any).Return(nil)
+			m.Expect().Rename("test.txt", "dir/test.txt").Return(nil)
+
+			Expect(m.Mkdir(context.Background(), "dir", 0755)).To(Succeed())
+			Expect(m.Rename(context.Background(), "test.txt", "dir/test.txt")).To(Succeed())
+			Expect(m.Verify()).To(Succeed())
+		})
+
+		It("should fail calls out of order", func() {
+			m := mock.NewFs(base, mock.Ordered)
+			m.Expect().Mkdir("dir", mock.Any).Return(nil)
+			m.Expect().Rename("test.txt", "dir/test.txt").Return(nil)
+
+			err := m.Rename(context.Background(), "test.txt", "dir/test.txt")
+
+			Expect(err).To(MatchError(mock.ErrUnexpectedCall))
+			Expect(m.Mkdir(context.Background(), "dir", 0755)).To(Succeed())
+			Expect(m.Verify()).To(MatchError(mock.ErrMissingCall))
+		})
+
+		It("should pass other calls to the fallback", func() {
+			m := mock.NewFs(base, mock.Ordered)
+			m.Expect().Mkdir("dir", mock.Any).Return(nil)
+			m.Expect().Remove("test.txt").Return(nil)
+
+			Expect(m.Mkdir(context.Background(), "dir", 0755)).To(Succeed())
+			Expect(afero.ReadFile(context.BackgroundFs(m), "test.txt")).To(BeEquivalentTo("testing"))
+			Expect(m.Remove(context.Background(), "test.txt")).To(Succeed())
+			Expect(m.Verify()).To(Succeed())
+		})
+	})
+})
+
+var _ = Describe("File", func() {
+	It("should return expected results", func() {
+		f := mock.NewFile("test.txt", nil)
+		f.Expect().Write([]byte("testing")).Return(4, io.ErrShortWrite)
+
+		n, err := f.Write([]byte("testing"))
+
+		Expect(n).To(Equal(4))
+		Expect(err).To(MatchError(io.ErrShortWrite))
+		Expect(f.Verify()).To(Succeed())
+	})
+
+	It("should pass unexpected calls to the fallback", func() {
+		base := afero.NewMemMapFs()
+		Expect(afero.WriteFile(base, "test.txt", []byte("testing"), os.ModePerm)).To(Succeed())
+		file, err := base.Open("test.txt")
+		Expect(err).NotTo(HaveOccurred())
+		f := mock.NewFile("test.txt", file)
+		f.Expect().Seek(mock.Any, io.SeekEnd).Return(int64(0), os.ErrInvalid)
+
+		Expect(io.ReadAll(f)).To(BeEquivalentTo("testing"))
+		_, err = f.Seek(0, io.SeekEnd)
+		Expect(err).To(MatchError(os.ErrInvalid))
+		Expect(f.Count("Read")).To(BeNumerically(">", 0))
+	})
+
+	It("should fail unexpected calls without a fallback", func() {
+		f := mock.NewFile("test.txt", nil)
+
+		Expect(f.Close()).To(MatchError(mock.ErrUnexpectedCall))
+		Expect(f.Name()).To(Equal("test.txt"))
+	})
+})
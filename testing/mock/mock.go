@@ -0,0 +1,340 @@
+// Package mock provides recording mocks of [context.Fs] and [afero.File].
+//
+// Mocks record every call made to them and answer calls with the results of
+// the first matching expectation. Calls no expectation matches are passed to
+// a fallback, so tests only need to set up the calls they care about.
+//
+//	fsys := mock.NewFs(afero.NewMemMapFs())
+//	fsys.Expect().Open("config.json").Return(nil, os.ErrPermission)
+//
+//	_, err := load(context.BackgroundFs(fsys))
+//
+//	Expect(err).To(MatchError(os.ErrPermission))
+//	Expect(fsys.Verify()).To(Succeed())
+package mock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrUnexpectedCall is returned by calls no expectation allows and
+	// that cannot be passed to a fallback.
+	ErrUnexpectedCall = errors.New("unexpected call")
+
+	// ErrMissingCall is reported by Verify for expectations that were not
+	// called as many times as expected.
+	ErrMissingCall = errors.New("missing call")
+)
+
+// Matcher matches the argument of a call, for example a Gomega matcher.
+type Matcher interface {
+	Match(actual any) (bool, error)
+}
+
+type anything struct{}
+
+func (anything) Match(any) (bool, error) { return true, nil }
+
+func (anything) String() string { return "Any" }
+
+// Any matches any argument.
+var Any Matcher = anything{}
+
+// Call is a call made to a mock.
+type Call struct {
+	Method string
+	// Ctx is the context of calls to Fs methods, and nil for File methods
+	Ctx context.Context
+	// File is the name of the file for File methods, and empty for Fs methods
+	File string
+	Args []any
+}
+
+func (c Call) String() string {
+	return describe(c.Method, c.File != "", c.File, c.Args)
+}
+
+// Expectation describes calls a mock expects and the results to return.
+type Expectation struct {
+	method string
+	// file matches the name of the file for File methods, and is nil for Fs methods
+	file any
+	args []any
+
+	results  []any
+	returns  bool
+	times    int
+	anyTimes bool
+	calls    int
+}
+
+// Return sets the results of the expected call, in the order the method
+// returns them. Expected calls without results are passed to the fallback,
+// or fail with [ErrUnexpectedCall] when there is none.
+func (e *Expectation) Return(results ...any) *Expectation {
+	e.results, e.returns = results, true
+	return e
+}
+
+// Times expects the call n times instead of once.
+func (e *Expectation) Times(n int) *Expectation {
+	e.times = n
+	return e
+}
+
+// AnyTimes allows the call any number of times, including none.
+func (e *Expectation) AnyTimes() *Expectation {
+	e.anyTimes = true
+	return e
+}
+
+func (e *Expectation) String() string {
+	return describe(e.method, e.file != nil, e.file, e.args)
+}
+
+func (e *Expectation) matches(c Call) bool {
+	if e.method != c.Method || (e.file != nil) != (c.File != "") {
+		return false
+	}
+	if e.file != nil && !match(e.file, c.File) {
+		return false
+	}
+
+	for i, arg := range e.args {
+		if !match(arg, c.Args[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (e *Expectation) satisfied() bool {
+	return e.anyTimes || e.calls >= e.times
+}
+
+func (e *Expectation) exhausted() bool {
+	return !e.anyTimes && e.calls >= e.times
+}
+
+type options struct {
+	ordered bool
+}
+
+type Option func(*options)
+
+// Ordered expects calls in the order their expectations were set. Calls
+// matching no expectation are still passed to the fallback.
+func Ordered(options *options) {
+	options.ordered = true
+}
+
+// recorder records the calls made to a mock and its files.
+type recorder struct {
+	mu           sync.Mutex
+	opts         options
+	fallback     bool
+	calls        []Call
+	expectations []*Expectation
+	violations   []error
+
+	// next is the index of the current expectation when ordered
+	next int
+}
+
+func newRecorder(fallback bool, options []Option) *recorder {
+	r := &recorder{fallback: fallback}
+	for _, option := range options {
+		option(&r.opts)
+	}
+
+	return r
+}
+
+// Calls returns the calls made to the mock and the files it opened.
+func (r *recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Call(nil), r.calls...)
+}
+
+// Count returns the number of calls made to method.
+func (r *recorder) Count(method string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := 0
+	for _, c := range r.calls {
+		if c.Method == method {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Verify returns an error describing the unexpected calls made to the mock
+// and the expected calls it did not receive.
+func (r *recorder) Verify() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	errs := append([]error(nil), r.violations...)
+	for _, e := range r.expectations {
+		if !e.satisfied() {
+			errs = append(errs, fmt.Errorf("%w: %s called %d of %d times",
+				ErrMissingCall, e, e.calls, e.times,
+			))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (r *recorder) expect(method string, file any, args ...any) *Expectation {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e := &Expectation{method: method, file: file, args: args, times: 1}
+	r.expectations = append(r.expectations, e)
+
+	return e
+}
+
+// call records c and returns the results of the expectation it matches,
+// or the results of fallback.
+func (r *recorder) call(c Call, fallback func() []any) ([]any, error) {
+	r.mu.Lock()
+	r.calls = append(r.calls, c)
+	e, err := r.match(c)
+	r.mu.Unlock()
+
+	switch {
+	case err != nil:
+		return nil, err
+	case e != nil && e.returns:
+		return e.results, nil
+	case r.fallback:
+		return fallback(), nil
+	default:
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		return nil, r.violate(fmt.Errorf("%w: %s is expected without Return and there is no fallback",
+			ErrUnexpectedCall, c,
+		))
+	}
+}
+
+func (r *recorder) match(c Call) (*Expectation, error) {
+	start := 0
+	if r.opts.ordered {
+		start = r.next
+	}
+
+	for i := start; i < len(r.expectations); i++ {
+		e := r.expectations[i]
+		if e.matches(c) && !e.exhausted() {
+			e.calls++
+			if r.opts.ordered {
+				r.next = i
+			}
+
+			return e, nil
+		}
+		if r.opts.ordered && !e.satisfied() {
+			break
+		}
+	}
+
+	for _, e := range r.expectations {
+		if e.matches(c) {
+			return nil, r.violate(fmt.Errorf("%w: %s called out of order or more than %d times",
+				ErrUnexpectedCall, c, e.times,
+			))
+		}
+	}
+	if r.fallback {
+		return nil, nil
+	}
+
+	return nil, r.violate(fmt.Errorf("%w: %s", ErrUnexpectedCall, c))
+}
+
+func (r *recorder) violate(err error) error {
+	r.violations = append(r.violations, err)
+	return err
+}
+
+// match reports whether actual matches the expected argument.
+func match(expected, actual any) bool {
+	if m, ok := expected.(Matcher); ok {
+		ok, err := m.Match(actual)
+		return ok && err == nil
+	}
+	if expected == nil || actual == nil {
+		return expected == actual
+	}
+
+	ev, av := reflect.ValueOf(expected), reflect.ValueOf(actual)
+	if ev.Type() != av.Type() && numeric(ev.Kind()) && numeric(av.Kind()) {
+		ev = ev.Convert(av.Type())
+	}
+
+	return reflect.DeepEqual(ev.Interface(), av.Interface())
+}
+
+func numeric(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Float64
+}
+
+// result returns the ith result of a call as a T.
+func result[T any](results []any, i int) T {
+	var zero T
+	if i >= len(results) || results[i] == nil {
+		return zero
+	}
+
+	v, ok := results[i].(T)
+	if !ok {
+		panic(fmt.Sprintf("mock: result %d must be a %T, got %T", i, zero, results[i]))
+	}
+
+	return v
+}
+
+func describe(method string, isFile bool, file any, args []any) string {
+	b := &strings.Builder{}
+	if isFile {
+		fmt.Fprintf(b, "File(%s).", format(file))
+	}
+
+	b.WriteString(method)
+	b.WriteByte('(')
+	for i, arg := range args {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+
+		b.WriteString(format(arg))
+	}
+	b.WriteByte(')')
+
+	return b.String()
+}
+
+func format(arg any) string {
+	switch v := arg.(type) {
+	case string, []byte:
+		return fmt.Sprintf("%q", v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,110 @@
+package testing
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+	"golang.org/x/tools/txtar"
+)
+
+// FromTxtar returns an in-memory Fs holding the files of archive. File names
+// ending with a slash create empty directories.
+func FromTxtar(archive *txtar.Archive) (afero.Fs, error) {
+	fsys := afero.NewMemMapFs()
+	for _, f := range archive.Files {
+		if strings.HasSuffix(f.Name, "/") {
+			if err := fsys.MkdirAll(f.Name, os.ModePerm); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+		if err := fsys.MkdirAll(filepath.Dir(f.Name), os.ModePerm); err != nil {
+			return nil, err
+		}
+		if err := afero.WriteFile(fsys, f.Name, f.Data, 0644); err != nil {
+			return nil, err
+		}
+	}
+
+	return fsys, nil
+}
+
+// ParseTxtar parses data as a txtar archive, see [FromTxtar].
+func ParseTxtar(data []byte) (afero.Fs, error) {
+	return FromTxtar(txtar.Parse(data))
+}
+
+// Txtar returns an in-memory Fs holding the files of the txtar archive s,
+// so fixtures can live inline in tests. It panics when s cannot be loaded.
+//
+//	fs := testing.Txtar(`
+//	-- go.mod --
+//	module example.com/test
+//	-- main.go --
+//	package main
+//	`)
+func Txtar(s string) afero.Fs {
+	fsys, err := ParseTxtar([]byte(s))
+	if err != nil {
+		panic(err)
+	}
+
+	return fsys
+}
+
+// ToTxtar returns a txtar archive holding the files beneath root in fsys in
+// lexical order. Empty directories are added with a trailing slash.
+func ToTxtar(fsys afero.Fs, root string) (*txtar.Archive, error) {
+	archive := &txtar.Archive{}
+	err := afero.Walk(fsys, root, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+
+		name := filepath.ToSlash(rel)
+		if info.IsDir() {
+			if empty, err := afero.IsEmpty(fsys, path); err != nil {
+				return err
+			} else if empty {
+				archive.Files = append(archive.Files, txtar.File{Name: name + "/"})
+			}
+
+			return nil
+		}
+
+		data, err := afero.ReadFile(fsys, path)
+		if err != nil {
+			return err
+		}
+
+		archive.Files = append(archive.Files, txtar.File{Name: name, Data: data})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return archive, nil
+}
+
+// FormatTxtar returns the files beneath root in fsys as a txtar archive.
+func FormatTxtar(fsys afero.Fs, root string) ([]byte, error) {
+	archive, err := ToTxtar(fsys, root)
+	if err != nil {
+		return nil, err
+	}
+
+	return txtar.Format(archive), nil
+}
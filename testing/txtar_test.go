@@ -0,0 +1,58 @@
+package testing_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/testing"
+	"golang.org/x/tools/txtar"
+)
+
+var _ = Describe("Txtar", func() {
+	It("should load files", func() {
+		fs := testing.Txtar(`
+-- go.mod --
+module example.com/test
+-- cmd/main.go --
+package main
+-- empty/ --
+`)
+
+		Expect(afero.ReadFile(fs, "go.mod")).To(BeEquivalentTo("module example.com/test\n"))
+		Expect(afero.ReadFile(fs, "cmd/main.go")).To(BeEquivalentTo("package main\n"))
+		Expect(afero.DirExists(fs, "empty")).To(BeTrue())
+	})
+
+	It("should format files in lexical order", func() {
+		fs := afero.NewMemMapFs()
+		Expect(fs.MkdirAll("b/empty", 0755)).To(Succeed())
+		Expect(afero.WriteFile(fs, "b/c.txt", []byte("c\n"), 0644)).To(Succeed())
+		Expect(afero.WriteFile(fs, "a.txt", []byte("a\n"), 0644)).To(Succeed())
+
+		data, err := testing.FormatTxtar(fs, "")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("-- a.txt --\na\n-- b/c.txt --\nc\n-- b/empty/ --\n"))
+	})
+
+	It("should format files relative to root", func() {
+		fs := testing.Txtar("-- dist/a.txt --\na\n-- other.txt --\n")
+
+		archive, err := testing.ToTxtar(fs, "dist")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(archive.Files).To(Equal([]txtar.File{{Name: "a.txt", Data: []byte("a\n")}}))
+	})
+
+	It("should round trip archives", func() {
+		archive := txtar.Parse([]byte("-- a/b.txt --\nb\n-- c/ --\n-- d.txt --\n"))
+
+		fs, err := testing.FromTxtar(archive)
+		Expect(err).NotTo(HaveOccurred())
+		actual, err := testing.ToTxtar(fs, "")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(txtar.Format(actual)).To(Equal(txtar.Format(archive)))
+	})
+})
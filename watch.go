@@ -0,0 +1,294 @@
+package aferox
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/op"
+)
+
+// Watcher is implemented by filesystems that report changes.
+type Watcher interface {
+	// Watch returns a channel that receives an [op.Create], [op.Remove],
+	// [op.Rename] or [op.Chmod] for each change to name, or to anything beneath
+	// name when it is a directory. The channel is closed once ctx is done.
+	// Receivers should drain the channel, as implementations may close it or
+	// block when the receiver falls behind.
+	Watch(ctx context.Context, name string) (<-chan op.Operation, error)
+}
+
+// WatchBufferSize is the capacity of the channels returned by [WatchFs.Watch].
+const WatchBufferSize = 64
+
+// WatchFs is an [afero.Fs] that reports the changes made through it.
+// It does not observe changes made to the base Fs by other means.
+//
+// Changes never wait for watchers to receive them. When a watcher falls
+// [WatchBufferSize] changes behind, its channel is closed so that it can
+// watch again and rescan, instead of silently missing changes.
+type WatchFs struct {
+	base afero.Fs
+
+	mu       sync.Mutex
+	watchers map[*watch]struct{}
+}
+
+type watch struct {
+	name string
+	ch   chan op.Operation
+
+	// done is closed once the watch is removed
+	done chan struct{}
+}
+
+func NewWatchFs(base afero.Fs) *WatchFs {
+	return &WatchFs{base: base}
+}
+
+// Watch implements Watcher.
+func (w *WatchFs) Watch(ctx context.Context, name string) (<-chan op.Operation, error) {
+	if _, err := w.base.Stat(name); err != nil {
+		return nil, err
+	}
+
+	sub := &watch{
+		name: filepath.Clean(name),
+		ch:   make(chan op.Operation, WatchBufferSize),
+		done: make(chan struct{}),
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.watchers == nil {
+		w.watchers = map[*watch]struct{}{}
+	}
+	w.watchers[sub] = struct{}{}
+
+	if ctx.Done() == nil {
+		return sub.ch, nil
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-sub.done:
+			return
+		}
+
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		w.unwatch(sub)
+	}()
+
+	return sub.ch, nil
+}
+
+// Chmod implements afero.Fs.
+func (w *WatchFs) Chmod(name string, mode os.FileMode) error {
+	if err := w.base.Chmod(name, mode); err != nil {
+		return err
+	}
+
+	w.chmod(name)
+	return nil
+}
+
+// Chown implements afero.Fs.
+func (w *WatchFs) Chown(name string, uid int, gid int) error {
+	if err := w.base.Chown(name, uid, gid); err != nil {
+		return err
+	}
+
+	w.chmod(name)
+	return nil
+}
+
+// Chtimes implements afero.Fs.
+func (w *WatchFs) Chtimes(name string, atime time.Time, mtime time.Time) error {
+	if err := w.base.Chtimes(name, atime, mtime); err != nil {
+		return err
+	}
+
+	w.chmod(name)
+	return nil
+}
+
+// Create implements afero.Fs.
+func (w *WatchFs) Create(name string) (afero.File, error) {
+	exists, err := afero.Exists(w.base, name)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := w.base.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		w.emit(op.Create{Name: name})
+	}
+
+	return file, nil
+}
+
+// Mkdir implements afero.Fs.
+func (w *WatchFs) Mkdir(name string, perm os.FileMode) error {
+	if err := w.base.Mkdir(name, perm); err != nil {
+		return err
+	}
+
+	w.emit(op.Create{Name: name})
+	return nil
+}
+
+// MkdirAll implements afero.Fs.
+func (w *WatchFs) MkdirAll(path string, perm os.FileMode) error {
+	exists, err := afero.DirExists(w.base, path)
+	if err != nil {
+		return err
+	}
+	if err := w.base.MkdirAll(path, perm); err != nil {
+		return err
+	}
+	if !exists {
+		w.emit(op.Create{Name: path})
+	}
+
+	return nil
+}
+
+// Name implements afero.Fs.
+func (w *WatchFs) Name() string {
+	return "WatchFs"
+}
+
+// Open implements afero.Fs.
+func (w *WatchFs) Open(name string) (afero.File, error) {
+	return w.base.Open(name)
+}
+
+// OpenFile implements afero.Fs.
+func (w *WatchFs) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	if flag&os.O_CREATE == 0 {
+		return w.base.OpenFile(name, flag, perm)
+	}
+
+	exists, err := afero.Exists(w.base, name)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := w.base.OpenFile(name, flag, perm)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		w.emit(op.Create{Name: name})
+	}
+
+	return file, nil
+}
+
+// Remove implements afero.Fs.
+func (w *WatchFs) Remove(name string) error {
+	if err := w.base.Remove(name); err != nil {
+		return err
+	}
+
+	w.emit(op.Remove{Name: name})
+	return nil
+}
+
+// RemoveAll implements afero.Fs.
+func (w *WatchFs) RemoveAll(path string) error {
+	exists, err := afero.Exists(w.base, path)
+	if err != nil {
+		return err
+	}
+	if err := w.base.RemoveAll(path); err != nil {
+		return err
+	}
+	if exists {
+		w.emit(op.Remove{Name: path})
+	}
+
+	return nil
+}
+
+// Rename implements afero.Fs.
+func (w *WatchFs) Rename(oldname string, newname string) error {
+	if err := w.base.Rename(oldname, newname); err != nil {
+		return err
+	}
+
+	w.emit(op.Rename{Oldname: oldname, Newname: newname})
+	return nil
+}
+
+// Stat implements afero.Fs.
+func (w *WatchFs) Stat(name string) (os.FileInfo, error) {
+	return w.base.Stat(name)
+}
+
+func (w *WatchFs) chmod(name string) {
+	operation := op.Chmod{Name: name}
+	if info, err := w.base.Stat(name); err == nil {
+		operation.Mode = info.Mode()
+	}
+
+	w.emit(operation)
+}
+
+func (w *WatchFs) emit(operation op.Operation) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for sub := range w.watchers {
+		if !sub.matches(operation) {
+			continue
+		}
+
+		select {
+		case sub.ch <- operation:
+		default:
+			w.unwatch(sub)
+		}
+	}
+}
+
+// unwatch removes sub and closes its channels. The caller must hold w.mu.
+func (w *WatchFs) unwatch(sub *watch) {
+	if _, ok := w.watchers[sub]; ok {
+		delete(w.watchers, sub)
+		close(sub.ch)
+		close(sub.done)
+	}
+}
+
+func (w *watch) matches(operation op.Operation) bool {
+	if rename, ok := operation.(op.Rename); ok {
+		return within(w.name, rename.Oldname) || within(w.name, rename.Newname)
+	} else {
+		return within(w.name, operation.Path())
+	}
+}
+
+// within reports whether path is root or is beneath root.
+func within(root, path string) bool {
+	root, path = filepath.Clean(root), filepath.Clean(path)
+	if root == "." || root == string(filepath.Separator) {
+		return true
+	}
+
+	return path == root || strings.HasPrefix(path, root+string(filepath.Separator))
+}
+
+var (
+	_ afero.Fs = (*WatchFs)(nil)
+	_ Watcher  = (*WatchFs)(nil)
+)
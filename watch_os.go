@@ -0,0 +1,124 @@
+package aferox
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/op"
+)
+
+// OsFs is an [afero.OsFs] that reports changes to the
+// underlying filesystem using [fsnotify].
+type OsFs struct {
+	afero.OsFs
+}
+
+func NewOsFs() *OsFs {
+	return &OsFs{}
+}
+
+// Watch implements Watcher.
+// Directories created beneath name are watched as they appear.
+// Changes to file contents are not reported.
+func (o *OsFs) Watch(ctx context.Context, name string) (<-chan op.Operation, error) {
+	info, err := o.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		err = addRecursive(watcher, name)
+	} else {
+		err = watcher.Add(name)
+	}
+	if err != nil {
+		_ = watcher.Close()
+		return nil, err
+	}
+
+	ch := make(chan op.Operation, WatchBufferSize)
+	go func() {
+		defer close(ch)
+		defer watcher.Close()
+
+		for {
+			var event fsnotify.Event
+			select {
+			case <-ctx.Done():
+				return
+			case e, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				event = e
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				continue
+			}
+
+			if event.Has(fsnotify.Create) {
+				_ = addRecursive(watcher, event.Name)
+			}
+			for _, operation := range operations(event) {
+				select {
+				case ch <- operation:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+func operations(event fsnotify.Event) (ops []op.Operation) {
+	if event.Has(fsnotify.Create) {
+		ops = append(ops, op.Create{Name: event.Name})
+	}
+	if event.Has(fsnotify.Remove) {
+		ops = append(ops, op.Remove{Name: event.Name})
+	}
+	if event.Has(fsnotify.Rename) {
+		// The new name is reported by a subsequent Create
+		ops = append(ops, op.Rename{Oldname: event.Name})
+	}
+	if event.Has(fsnotify.Chmod) {
+		operation := op.Chmod{Name: event.Name}
+		if info, err := afero.NewOsFs().Stat(event.Name); err == nil {
+			operation.Mode = info.Mode()
+		}
+
+		ops = append(ops, operation)
+	}
+
+	return ops
+}
+
+func addRecursive(watcher *fsnotify.Watcher, name string) error {
+	return filepath.WalkDir(name, func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) && path != name {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return watcher.Add(path)
+		}
+
+		return nil
+	})
+}
+
+var _ Watcher = (*OsFs)(nil)
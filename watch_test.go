@@ -0,0 +1,227 @@
+package aferox_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox"
+	"github.com/unmango/aferox/op"
+)
+
+var _ = Describe("Watch", func() {
+	Describe("WatchFs", func() {
+		var fsys *aferox.WatchFs
+
+		BeforeEach(func() {
+			base := afero.NewMemMapFs()
+			Expect(base.MkdirAll("dir", os.ModePerm)).To(Succeed())
+			Expect(base.MkdirAll("other", os.ModePerm)).To(Succeed())
+			fsys = aferox.NewWatchFs(base)
+		})
+
+		It("should report created files", func(ctx context.Context) {
+			events, err := fsys.Watch(ctx, "dir")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = fsys.Create("dir/test.txt")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(events).To(Receive(Equal(op.Create{Name: "dir/test.txt"})))
+		})
+
+		It("should report created directories", func(ctx context.Context) {
+			events, err := fsys.Watch(ctx, "dir")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fsys.Mkdir("dir/sub", os.ModePerm)).To(Succeed())
+
+			Expect(events).To(Receive(Equal(op.Create{Name: "dir/sub"})))
+		})
+
+		It("should not report opening existing files", func(ctx context.Context) {
+			Expect(afero.WriteFile(fsys, "dir/test.txt", []byte("testing"), os.ModePerm)).To(Succeed())
+			events, err := fsys.Watch(ctx, "dir")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = fsys.OpenFile("dir/test.txt", os.O_RDWR|os.O_CREATE, os.ModePerm)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(events).NotTo(Receive())
+		})
+
+		It("should report removed files", func(ctx context.Context) {
+			_, err := fsys.Create("dir/test.txt")
+			Expect(err).NotTo(HaveOccurred())
+			events, err := fsys.Watch(ctx, "dir")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fsys.Remove("dir/test.txt")).To(Succeed())
+
+			Expect(events).To(Receive(Equal(op.Remove{Name: "dir/test.txt"})))
+		})
+
+		It("should report renamed files", func(ctx context.Context) {
+			_, err := fsys.Create("dir/test.txt")
+			Expect(err).NotTo(HaveOccurred())
+			events, err := fsys.Watch(ctx, "dir")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fsys.Rename("dir/test.txt", "other/test.txt")).To(Succeed())
+
+			Expect(events).To(Receive(Equal(op.Rename{
+				Oldname: "dir/test.txt",
+				Newname: "other/test.txt",
+			})))
+		})
+
+		It("should report mode changes", func(ctx context.Context) {
+			_, err := fsys.Create("dir/test.txt")
+			Expect(err).NotTo(HaveOccurred())
+			events, err := fsys.Watch(ctx, "dir")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fsys.Chmod("dir/test.txt", 0o600)).To(Succeed())
+
+			var event op.Operation
+			Expect(events).To(Receive(&event))
+			Expect(event).To(BeAssignableToTypeOf(op.Chmod{}))
+			Expect(event.Path()).To(Equal("dir/test.txt"))
+			Expect(event.(op.Chmod).Mode.Perm()).To(Equal(os.FileMode(0o600)))
+		})
+
+		It("should ignore changes outside the watched directory", func(ctx context.Context) {
+			events, err := fsys.Watch(ctx, "dir")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = fsys.Create("other/test.txt")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(events).NotTo(Receive())
+		})
+
+		It("should close the channel when the context is done", func(ctx context.Context) {
+			ctx, cancel := context.WithCancel(ctx)
+			events, err := fsys.Watch(ctx, "dir")
+			Expect(err).NotTo(HaveOccurred())
+
+			cancel()
+
+			Eventually(events).Should(BeClosed())
+		})
+
+		It("should close the channels of watchers that fall behind", func(ctx context.Context) {
+			slow, err := fsys.Watch(ctx, "dir")
+			Expect(err).NotTo(HaveOccurred())
+			events, err := fsys.Watch(ctx, "dir")
+			Expect(err).NotTo(HaveOccurred())
+
+			for i := range aferox.WatchBufferSize + 1 {
+				Expect(fsys.Mkdir(fmt.Sprint("dir/", i), os.ModePerm)).To(Succeed())
+				Expect(events).To(Receive())
+			}
+
+			Expect(slow).To(HaveLen(aferox.WatchBufferSize))
+			for range aferox.WatchBufferSize {
+				Expect(slow).To(Receive())
+			}
+			Expect(slow).To(BeClosed())
+
+			Expect(fsys.Mkdir("dir/other", os.ModePerm)).To(Succeed())
+			Expect(events).To(Receive(Equal(op.Create{Name: "dir/other"})))
+		})
+
+		It("should stop waiting on the context of watchers that fall behind", func(ctx context.Context) {
+			goroutines := runtime.NumGoroutine()
+			for range 100 {
+				_, err := fsys.Watch(ctx, "dir")
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			for i := range aferox.WatchBufferSize + 1 {
+				Expect(fsys.Mkdir(fmt.Sprint("dir/", i), os.ModePerm)).To(Succeed())
+			}
+
+			Eventually(runtime.NumGoroutine).Should(BeNumerically("<", goroutines+50))
+		})
+
+		It("should fail for missing paths", func(ctx context.Context) {
+			_, err := fsys.Watch(ctx, "missing")
+
+			Expect(err).To(MatchError(os.ErrNotExist))
+		})
+	})
+
+	Describe("OsFs", func() {
+		var (
+			fsys *aferox.OsFs
+			dir  string
+		)
+
+		BeforeEach(func() {
+			fsys = aferox.NewOsFs()
+			dir = GinkgoT().TempDir()
+		})
+
+		It("should report created files", func(ctx context.Context) {
+			events, err := fsys.Watch(ctx, dir)
+			Expect(err).NotTo(HaveOccurred())
+
+			name := filepath.Join(dir, "test.txt")
+			Expect(afero.WriteFile(fsys, name, []byte("testing"), os.ModePerm)).To(Succeed())
+
+			Eventually(events).Should(Receive(Equal(op.Create{Name: name})))
+		})
+
+		It("should report changes in created directories", func(ctx context.Context) {
+			events, err := fsys.Watch(ctx, dir)
+			Expect(err).NotTo(HaveOccurred())
+
+			sub := filepath.Join(dir, "sub")
+			Expect(fsys.Mkdir(sub, os.ModePerm)).To(Succeed())
+			Eventually(events).Should(Receive(Equal(op.Create{Name: sub})))
+
+			name := filepath.Join(sub, "test.txt")
+			Expect(afero.WriteFile(fsys, name, []byte("testing"), os.ModePerm)).To(Succeed())
+			Eventually(events).Should(Receive(Equal(op.Create{Name: name})))
+		})
+
+		It("should report removed files", func(ctx context.Context) {
+			name := filepath.Join(dir, "test.txt")
+			Expect(afero.WriteFile(fsys, name, []byte("testing"), os.ModePerm)).To(Succeed())
+			events, err := fsys.Watch(ctx, dir)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fsys.Remove(name)).To(Succeed())
+
+			Eventually(events).Should(Receive(Equal(op.Remove{Name: name})))
+		})
+
+		It("should report renamed files", func(ctx context.Context) {
+			name := filepath.Join(dir, "test.txt")
+			Expect(afero.WriteFile(fsys, name, []byte("testing"), os.ModePerm)).To(Succeed())
+			events, err := fsys.Watch(ctx, dir)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fsys.Rename(name, filepath.Join(dir, "new.txt"))).To(Succeed())
+
+			Eventually(events).Should(Receive(Equal(op.Rename{Oldname: name})))
+		})
+
+		It("should close the channel when the context is done", func(ctx context.Context) {
+			ctx, cancel := context.WithCancel(ctx)
+			events, err := fsys.Watch(ctx, dir)
+			Expect(err).NotTo(HaveOccurred())
+
+			cancel()
+
+			Eventually(events).Should(BeClosed())
+		})
+	})
+})
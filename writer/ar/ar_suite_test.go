@@ -0,0 +1,13 @@
+package ar_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestAr(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Ar Suite")
+}
@@ -0,0 +1,50 @@
+package ar
+
+import (
+	"errors"
+	"io"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/writer/internal/archive"
+)
+
+// NewFs returns an [afero.Fs] implementation that writes its contents to the
+// provided [Writer]. The returned filesystem is append-only: it adds files
+// to the archive and describes the members added so far. Files are buffered
+// until they are closed, and members are owned by root as in Debian packages.
+//
+// The ar format is flat, so Mkdir and symlinks are not supported, and names
+// must fit in the 16 bytes of a member header.
+//
+// The caller retains ownership of the Writer and is responsible for calling
+// Close on it when all filesystem operations are complete.
+func NewFs(w *Writer) afero.Fs {
+	return archive.NewFs("ar.Writer", &writer{w})
+}
+
+type writer struct {
+	w *Writer
+}
+
+// WriteEntry implements archive.Writer.
+func (w *writer) WriteEntry(h *archive.Header, r io.Reader) error {
+	if !h.Mode.IsRegular() {
+		return errors.ErrUnsupported
+	}
+
+	header := &Header{
+		Name:    h.Name,
+		ModTime: h.ModTime,
+		Mode:    0o100000 | int64(h.Mode.Perm()),
+		Size:    h.Size,
+	}
+	if err := w.w.WriteHeader(header); err != nil {
+		return err
+	}
+	if _, err := io.Copy(w.w, r); err != nil {
+		return err
+	}
+
+	h.Sys = header
+	return nil
+}
@@ -0,0 +1,113 @@
+package ar_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/writer/ar"
+)
+
+type member struct {
+	Header   *ar.Header
+	Contents string
+}
+
+var _ = Describe("Fs", func() {
+	var (
+		buf  *bytes.Buffer
+		w    *ar.Writer
+		fsys afero.Fs
+	)
+
+	read := func() []member {
+		GinkgoHelper()
+		Expect(w.Close()).To(Succeed())
+
+		var members []member
+		r := ar.NewReader(bytes.NewReader(buf.Bytes()))
+		for {
+			h, err := r.Next()
+			if err == io.EOF {
+				return members
+			}
+			Expect(err).NotTo(HaveOccurred())
+
+			data, err := io.ReadAll(r)
+			Expect(err).NotTo(HaveOccurred())
+			members = append(members, member{h, string(data)})
+		}
+	}
+
+	BeforeEach(func() {
+		buf = &bytes.Buffer{}
+		w = ar.NewWriter(buf)
+		fsys = ar.NewFs(w)
+	})
+
+	It("should return its name", func() {
+		Expect(fsys.Name()).To(Equal("ar.Writer"))
+	})
+
+	It("should write members in order", func() {
+		Expect(afero.WriteFile(fsys, "debian-binary", []byte("2.0\n"), 0644)).To(Succeed())
+		Expect(afero.WriteFile(fsys, "control.tar.gz", []byte("odd"), 0644)).To(Succeed())
+		Expect(afero.WriteFile(fsys, "data.tar.gz", []byte("data"), 0644)).To(Succeed())
+
+		Expect(buf.String()).To(HavePrefix("!<arch>\ndebian-binary   "))
+		Expect(read()).To(HaveExactElements(
+			SatisfyAll(
+				HaveField("Header.Name", "debian-binary"),
+				HaveField("Header.Mode", BeEquivalentTo(0o100644)),
+				HaveField("Contents", "2.0\n"),
+			),
+			HaveField("Contents", "odd"),
+			HaveField("Contents", "data"),
+		))
+	})
+
+	It("should not support directories", func() {
+		err := fsys.Mkdir("dir", 0755)
+
+		Expect(err).To(MatchError(errors.ErrUnsupported))
+		_, err = fsys.Stat("dir")
+		Expect(err).To(MatchError(os.ErrNotExist))
+	})
+
+	It("should not support symlinks", func() {
+		err := fsys.(afero.Linker).SymlinkIfPossible("target", "link")
+
+		Expect(err).To(MatchError(afero.ErrNoSymlink))
+	})
+
+	It("should reject long names", func() {
+		err := afero.WriteFile(fsys, "a-very-long-member-name", nil, 0644)
+
+		Expect(err).To(MatchError(ar.ErrHeader))
+	})
+
+	It("should describe written members with their ar headers", func() {
+		Expect(afero.WriteFile(fsys, "debian-binary", []byte("2.0\n"), 0644)).To(Succeed())
+
+		info, err := fsys.Stat("debian-binary")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Size()).To(BeEquivalentTo(4))
+		Expect(info.Sys()).To(BeAssignableToTypeOf(&ar.Header{}))
+	})
+})
+
+var _ = Describe("Writer", func() {
+	It("should write an empty archive", func() {
+		buf := &bytes.Buffer{}
+
+		Expect(ar.NewWriter(buf).Close()).To(Succeed())
+
+		Expect(buf.String()).To(Equal("!<arch>\n"))
+	})
+})
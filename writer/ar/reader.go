@@ -0,0 +1,104 @@
+package ar
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Reader reads the members of an ar archive. Next advances to the next
+// member, and Read reads its contents.
+type Reader struct {
+	r         io.Reader
+	started   bool
+	remaining int64
+	pad       int64
+}
+
+// NewReader returns a new Reader reading an ar archive from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: r}
+}
+
+// Next advances to the next member of the archive, returning io.EOF at its end.
+func (r *Reader) Next() (*Header, error) {
+	if !r.started {
+		buf := make([]byte, len(magic))
+		if _, err := io.ReadFull(r.r, buf); err != nil {
+			return nil, err
+		}
+		if string(buf) != magic {
+			return nil, ErrHeader
+		}
+
+		r.started = true
+	}
+	if _, err := io.CopyN(io.Discard, r.r, r.remaining+r.pad); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, headerSize)
+	if n, err := io.ReadFull(r.r, buf); n == 0 && err == io.EOF {
+		return nil, io.EOF
+	} else if err != nil {
+		return nil, err
+	}
+	if string(buf[58:]) != "`\n" {
+		return nil, ErrHeader
+	}
+
+	field := func(start, end, base int) (int64, error) {
+		s := strings.TrimSpace(string(buf[start:end]))
+		if s == "" {
+			return 0, nil
+		}
+
+		v, err := strconv.ParseInt(s, base, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %w", ErrHeader, err)
+		}
+
+		return v, nil
+	}
+
+	var values [5]int64
+	for i, f := range []struct{ start, end, base int }{
+		{16, 28, 10}, {28, 34, 10}, {34, 40, 10}, {40, 48, 8}, {48, 58, 10},
+	} {
+		v, err := field(f.start, f.end, f.base)
+		if err != nil {
+			return nil, err
+		}
+
+		values[i] = v
+	}
+
+	header := &Header{
+		Name:    strings.TrimSuffix(strings.TrimRight(string(buf[:nameSize]), " "), "/"),
+		ModTime: time.Unix(values[0], 0),
+		Uid:     int(values[1]),
+		Gid:     int(values[2]),
+		Mode:    values[3],
+		Size:    values[4],
+	}
+
+	r.remaining = header.Size
+	r.pad = header.Size % 2
+	return header, nil
+}
+
+// Read reads from the current member, returning io.EOF at its end.
+func (r *Reader) Read(p []byte) (int, error) {
+	if r.remaining == 0 {
+		return 0, io.EOF
+	}
+	if int64(len(p)) > r.remaining {
+		p = p[:r.remaining]
+	}
+
+	n, err := r.r.Read(p)
+	r.remaining -= int64(n)
+	return n, err
+}
@@ -0,0 +1,163 @@
+package ar
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	magic = "!<arch>\n"
+
+	// headerSize is the length of a member header, and nameSize the length of its name field.
+	headerSize = 60
+	nameSize   = 16
+)
+
+var (
+	ErrHeader          = errors.New("ar: invalid header")
+	ErrWriteTooLong    = errors.New("ar: write too long")
+	ErrWriteAfterClose = errors.New("ar: write after close")
+)
+
+// Header describes a member of an ar archive.
+type Header struct {
+	Name    string
+	ModTime time.Time
+	Uid     int
+	Gid     int
+	Mode    int64
+	Size    int64
+}
+
+// Writer writes an archive in the common ar format used by Debian packages.
+// Member names are limited to 16 bytes and cannot contain slashes. Call
+// WriteHeader to begin a new member, write its contents with Write, and
+// Close to finish the archive.
+type Writer struct {
+	w       io.Writer
+	started bool
+
+	// remaining is the size of the current member left to write, and pad
+	// the padding following it
+	remaining int64
+	pad       int64
+	closed    bool
+	err       error
+}
+
+// NewWriter returns a new Writer writing an ar archive to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// WriteHeader writes h and prepares to accept the contents of the member,
+// padding the previous member first.
+func (w *Writer) WriteHeader(h *Header) error {
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if h.Name == "" || len(h.Name) > nameSize || strings.ContainsAny(h.Name, "/ ") {
+		return fmt.Errorf("%w: unsupported name %q", ErrHeader, h.Name)
+	}
+
+	header := fmt.Sprintf("%-16s%-12d%-6d%-6d%-8o%-10d`\n",
+		h.Name, mtime(h.ModTime), h.Uid, h.Gid, h.Mode, h.Size,
+	)
+	if len(header) != headerSize {
+		return fmt.Errorf("%w: field too large for %s", ErrHeader, h.Name)
+	}
+	if err := w.write([]byte(header)); err != nil {
+		return err
+	}
+
+	w.remaining = h.Size
+	w.pad = h.Size % 2
+	return nil
+}
+
+// Write writes to the current member, failing with ErrWriteTooLong when
+// more than the size in its header is written.
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, ErrWriteAfterClose
+	}
+	if w.err != nil {
+		return 0, w.err
+	}
+
+	var err error
+	if int64(len(p)) > w.remaining {
+		p, err = p[:w.remaining], ErrWriteTooLong
+	}
+
+	n, werr := w.w.Write(p)
+	w.remaining -= int64(n)
+	if werr != nil {
+		w.err = werr
+		return n, werr
+	}
+
+	return n, err
+}
+
+// Flush pads the current member, failing when it has not been fully written.
+func (w *Writer) Flush() error {
+	if w.closed {
+		return ErrWriteAfterClose
+	}
+	if w.err != nil {
+		return w.err
+	}
+	if w.remaining > 0 {
+		return fmt.Errorf("ar: missed writing %d bytes", w.remaining)
+	}
+	if w.pad == 0 {
+		return nil
+	}
+
+	w.pad = 0
+	return w.write([]byte{'\n'})
+}
+
+// Close finishes the archive, writing the global header of empty archives.
+// It does not close the underlying writer.
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	err := w.write(nil)
+	w.closed = true
+	return err
+}
+
+// write writes p, preceded by the global header at the start of the archive.
+func (w *Writer) write(p []byte) error {
+	if !w.started {
+		p = append([]byte(magic), p...)
+		w.started = true
+	}
+	if len(p) == 0 {
+		return nil
+	}
+	if _, err := w.w.Write(p); err != nil {
+		w.err = err
+		return err
+	}
+
+	return nil
+}
+
+func mtime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.Unix()
+}
@@ -0,0 +1,62 @@
+package cpio
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/writer/internal/archive"
+)
+
+// NewFs returns an [afero.Fs] implementation that writes its contents to the
+// provided [Writer]. The returned filesystem is append-only: it adds files,
+// directories and symlinks to the archive, and describes the entries added
+// so far. Files are buffered until they are closed, and entries are owned by
+// root as expected of initramfs images.
+//
+// The caller retains ownership of the Writer and is responsible for calling
+// Close on it to write the trailer when all filesystem operations are complete.
+func NewFs(w *Writer) afero.Fs {
+	return archive.NewFs("cpio.Writer", &writer{w})
+}
+
+type writer struct {
+	w *Writer
+}
+
+// WriteEntry implements archive.Writer.
+func (w *writer) WriteEntry(h *archive.Header, r io.Reader) error {
+	header := &Header{
+		Name:    h.Name,
+		Mode:    int64(h.Mode.Perm()),
+		ModTime: h.ModTime,
+		Size:    h.Size,
+	}
+
+	switch {
+	case h.Mode.IsDir():
+		header.Mode |= TypeDir
+		header.Nlink = 2
+	case h.Mode&fs.ModeSymlink != 0:
+		header.Mode |= TypeSymlink
+		r = strings.NewReader(h.Linkname)
+	case h.Mode.IsRegular():
+		header.Mode |= TypeReg
+	default:
+		return errors.ErrUnsupported
+	}
+
+	if err := w.w.WriteHeader(header); err != nil {
+		return err
+	}
+	if r != nil {
+		if _, err := io.Copy(w.w, r); err != nil {
+			return err
+		}
+	}
+
+	h.Sys = header
+	return nil
+}
@@ -0,0 +1,127 @@
+package cpio_test
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/writer/cpio"
+)
+
+type entry struct {
+	Header   *cpio.Header
+	Contents string
+}
+
+var _ = Describe("Fs", func() {
+	var (
+		buf  *bytes.Buffer
+		w    *cpio.Writer
+		fsys afero.Fs
+	)
+
+	read := func() map[string]entry {
+		GinkgoHelper()
+		Expect(w.Close()).To(Succeed())
+		Expect(buf.Len() % 4).To(BeZero())
+
+		entries := map[string]entry{}
+		r := cpio.NewReader(bytes.NewReader(buf.Bytes()))
+		for {
+			h, err := r.Next()
+			if err == io.EOF {
+				return entries
+			}
+			Expect(err).NotTo(HaveOccurred())
+
+			data, err := io.ReadAll(r)
+			Expect(err).NotTo(HaveOccurred())
+			entries[h.Name] = entry{h, string(data)}
+		}
+	}
+
+	BeforeEach(func() {
+		buf = &bytes.Buffer{}
+		w = cpio.NewWriter(buf)
+		fsys = cpio.NewFs(w)
+	})
+
+	It("should return its name", func() {
+		Expect(fsys.Name()).To(Equal("cpio.Writer"))
+	})
+
+	It("should write files", func() {
+		Expect(afero.WriteFile(fsys, "/init", []byte("#!/bin/sh"), 0755)).To(Succeed())
+		Expect(afero.WriteFile(fsys, "etc/hostname", []byte("test"), 0644)).To(Succeed())
+
+		entries := read()
+		Expect(entries).To(HaveKeyWithValue("init", SatisfyAll(
+			HaveField("Contents", "#!/bin/sh"),
+			HaveField("Header.Mode", BeEquivalentTo(cpio.TypeReg|0755)),
+			HaveField("Header.Uid", 0),
+		)))
+		Expect(entries).To(HaveKeyWithValue("etc/hostname", HaveField("Contents", "test")))
+	})
+
+	It("should write directories", func() {
+		Expect(fsys.MkdirAll("usr/bin", 0755)).To(Succeed())
+
+		entries := read()
+		Expect(entries).To(HaveKey("usr"))
+		Expect(entries["usr/bin"].Header.FileMode()).To(Equal(fs.ModeDir | 0755))
+		Expect(entries["usr/bin"].Header.Nlink).To(Equal(2))
+	})
+
+	It("should write symlinks", func() {
+		Expect(fsys.(afero.Linker).SymlinkIfPossible("busybox", "bin/sh")).To(Succeed())
+
+		entries := read()
+		Expect(entries["bin/sh"].Header.FileMode()).To(Equal(fs.ModeSymlink | 0777))
+		Expect(entries["bin/sh"].Contents).To(Equal("busybox"))
+	})
+
+	It("should describe written entries with their cpio headers", func() {
+		Expect(afero.WriteFile(fsys, "init", []byte("#!/bin/sh"), 0755)).To(Succeed())
+
+		info, err := fsys.Stat("init")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Size()).To(BeEquivalentTo(9))
+		Expect(info.Sys()).To(BeAssignableToTypeOf(&cpio.Header{}))
+	})
+})
+
+var _ = Describe("Writer", func() {
+	It("should write an empty archive", func() {
+		buf := &bytes.Buffer{}
+		w := cpio.NewWriter(buf)
+
+		Expect(w.Close()).To(Succeed())
+
+		_, err := cpio.NewReader(buf).Next()
+		Expect(err).To(Equal(io.EOF))
+	})
+
+	It("should fail writes beyond the size of the entry", func() {
+		w := cpio.NewWriter(&bytes.Buffer{})
+		Expect(w.WriteHeader(&cpio.Header{Name: "test", Mode: cpio.TypeReg, Size: 2})).To(Succeed())
+
+		n, err := w.Write([]byte("test"))
+
+		Expect(err).To(MatchError(cpio.ErrWriteTooLong))
+		Expect(n).To(Equal(2))
+	})
+
+	It("should fail to begin an entry before finishing the last", func() {
+		w := cpio.NewWriter(&bytes.Buffer{})
+		Expect(w.WriteHeader(&cpio.Header{Name: "test", Mode: cpio.TypeReg, Size: 2})).To(Succeed())
+
+		err := w.WriteHeader(&cpio.Header{Name: "other"})
+
+		Expect(err).To(MatchError(ContainSubstring("missed writing 2 bytes")))
+	})
+})
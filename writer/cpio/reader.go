@@ -0,0 +1,85 @@
+package cpio
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Reader reads the entries of a "newc" cpio archive. Next advances to the
+// next entry, and Read reads its contents.
+type Reader struct {
+	r         io.Reader
+	remaining int64
+	pad       int64
+}
+
+// NewReader returns a new Reader reading a cpio archive from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: r}
+}
+
+// Next advances to the next entry of the archive, returning io.EOF at the trailer.
+func (r *Reader) Next() (*Header, error) {
+	if _, err := io.CopyN(io.Discard, r.r, r.remaining+r.pad); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, headerSize)
+	if _, err := io.ReadFull(r.r, buf); err != nil {
+		return nil, err
+	}
+	if string(buf[:len(magic)]) != magic {
+		return nil, ErrHeader
+	}
+
+	fields := make([]int64, 13)
+	for i := range fields {
+		start := len(magic) + i*8
+		v, err := strconv.ParseInt(string(buf[start:start+8]), 16, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrHeader, err)
+		}
+
+		fields[i] = v
+	}
+
+	namesize := fields[11]
+	name := make([]byte, namesize+align(headerSize+namesize))
+	if _, err := io.ReadFull(r.r, name); err != nil {
+		return nil, err
+	}
+
+	header := &Header{
+		Name:    string(bytes.TrimRight(name[:namesize], "\x00")),
+		Mode:    fields[1],
+		Uid:     int(fields[2]),
+		Gid:     int(fields[3]),
+		Nlink:   int(fields[4]),
+		ModTime: time.Unix(fields[5], 0),
+		Size:    fields[6],
+	}
+	if header.Name == trailer {
+		return nil, io.EOF
+	}
+
+	r.remaining = header.Size
+	r.pad = align(header.Size)
+	return header, nil
+}
+
+// Read reads from the current entry, returning io.EOF at its end.
+func (r *Reader) Read(p []byte) (int, error) {
+	if r.remaining == 0 {
+		return 0, io.EOF
+	}
+	if int64(len(p)) > r.remaining {
+		p = p[:r.remaining]
+	}
+
+	n, err := r.r.Read(p)
+	r.remaining -= int64(n)
+	return n, err
+}
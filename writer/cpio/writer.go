@@ -0,0 +1,213 @@
+package cpio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"time"
+)
+
+const (
+	magic   = "070701"
+	trailer = "TRAILER!!!"
+
+	// headerSize is the length of a newc header before the name.
+	headerSize = 110
+)
+
+// Mode bits of the file types in a cpio header.
+const (
+	TypeDir     = 0o040000
+	TypeReg     = 0o100000
+	TypeSymlink = 0o120000
+)
+
+var (
+	ErrHeader          = errors.New("cpio: invalid header")
+	ErrWriteTooLong    = errors.New("cpio: write too long")
+	ErrWriteAfterClose = errors.New("cpio: write after close")
+)
+
+// Header describes an entry of a cpio archive. The contents of a symlink
+// are the path it points to.
+type Header struct {
+	Name    string
+	Mode    int64 // Permission and type bits, see TypeDir, TypeReg and TypeSymlink
+	Uid     int
+	Gid     int
+	Nlink   int
+	ModTime time.Time
+	Size    int64
+}
+
+// FileMode returns an fs.FileMode describing the permission and type bits of h.
+func (h *Header) FileMode() fs.FileMode {
+	mode := fs.FileMode(h.Mode & 0o777)
+	switch h.Mode &^ 0o7777 {
+	case TypeDir:
+		mode |= fs.ModeDir
+	case TypeSymlink:
+		mode |= fs.ModeSymlink
+	}
+
+	return mode
+}
+
+// Writer writes an archive in the SVR4 "newc" cpio format read by the Linux
+// kernel for initramfs images. Call WriteHeader to begin a new entry, write
+// its contents with Write, and Close to write the trailer.
+type Writer struct {
+	w   io.Writer
+	ino int64
+
+	// remaining is the size of the current entry left to write, and pad
+	// the padding following it
+	remaining int64
+	pad       int64
+	closed    bool
+	err       error
+}
+
+// NewWriter returns a new Writer writing a cpio archive to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// WriteHeader writes h and prepares to accept the contents of the entry,
+// padding the previous entry first.
+func (w *Writer) WriteHeader(h *Header) error {
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	nlink := h.Nlink
+	if nlink == 0 {
+		nlink = 1
+	}
+
+	w.ino++
+	return w.writeHeader(w.ino, h, nlink)
+}
+
+// Write writes to the current entry, failing with ErrWriteTooLong when
+// more than the size in its header is written.
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, ErrWriteAfterClose
+	}
+	if w.err != nil {
+		return 0, w.err
+	}
+
+	var err error
+	if int64(len(p)) > w.remaining {
+		p, err = p[:w.remaining], ErrWriteTooLong
+	}
+
+	n, werr := w.w.Write(p)
+	w.remaining -= int64(n)
+	if werr != nil {
+		w.err = werr
+		return n, werr
+	}
+
+	return n, err
+}
+
+// Flush pads the current entry, failing when it has not been fully written.
+func (w *Writer) Flush() error {
+	if w.closed {
+		return ErrWriteAfterClose
+	}
+	if w.err != nil {
+		return w.err
+	}
+	if w.remaining > 0 {
+		return fmt.Errorf("cpio: missed writing %d bytes", w.remaining)
+	}
+
+	return w.padding()
+}
+
+// Close writes the trailer of the archive. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	err := w.writeHeader(0, &Header{Name: trailer}, 1)
+	if err == nil {
+		err = w.padding()
+	}
+
+	w.closed = true
+	return err
+}
+
+func (w *Writer) writeHeader(ino int64, h *Header, nlink int) error {
+	namesize := len(h.Name) + 1
+	fields := []int64{
+		ino,
+		h.Mode,
+		int64(h.Uid),
+		int64(h.Gid),
+		int64(nlink),
+		mtime(h.ModTime),
+		h.Size,
+		0, 0, 0, 0, // devmajor, devminor, rdevmajor, rdevminor
+		int64(namesize),
+		0, // check
+	}
+
+	buf := make([]byte, 0, headerSize+namesize+3)
+	buf = append(buf, magic...)
+	for _, field := range fields {
+		if field < 0 || field > 0xffffffff {
+			return fmt.Errorf("cpio: header field too large for %s", h.Name)
+		}
+
+		buf = fmt.Appendf(buf, "%08x", field)
+	}
+
+	buf = append(buf, h.Name...)
+	buf = append(buf, 0)
+	buf = append(buf, make([]byte, align(int64(len(buf))))...)
+	if _, err := w.w.Write(buf); err != nil {
+		w.err = err
+		return err
+	}
+
+	w.remaining = h.Size
+	w.pad = align(h.Size)
+	return nil
+}
+
+func (w *Writer) padding() error {
+	if w.pad == 0 {
+		return nil
+	}
+	if _, err := w.w.Write(make([]byte, w.pad)); err != nil {
+		w.err = err
+		return err
+	}
+
+	w.pad = 0
+	return nil
+}
+
+// align returns the padding following n bytes to reach a multiple of four.
+func align(n int64) int64 {
+	return (4 - n%4) % 4
+}
+
+func mtime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.Unix()
+}
@@ -1,19 +1,40 @@
 package writer
 
 import (
+	"bytes"
 	"io"
 	"io/fs"
+	"sync"
 	"syscall"
 )
 
+// File is a file of an [Fs]. It writes straight to the underlying writer,
+// or buffers its contents until it is closed when the Fs has a [Format].
 type File struct {
 	writer io.Writer
 	name   string
+
+	// Set when the Fs has a format
+	sink    *sink
+	format  Format
+	buf     *bytes.Buffer
+	written bool
+	once    sync.Once
+	err     error
 }
 
-// Close implements afero.File.
+// Close implements afero.File. It does not close the underlying writer,
+// and only formats files that were written to.
 func (f *File) Close() error {
-	return nil
+	if f.buf == nil || !f.written {
+		return nil
+	}
+
+	f.once.Do(func() {
+		f.err = f.sink.write(f.format, f.name, f.buf.Bytes())
+	})
+
+	return f.err
 }
 
 // Name implements afero.File.
@@ -56,19 +77,29 @@ func (f *File) Sync() error {
 	return syscall.EROFS
 }
 
-// Truncate implements afero.File.
+// Truncate implements afero.File. Only buffered files can be truncated.
 func (f *File) Truncate(size int64) error {
-	return syscall.EROFS
+	if f.buf == nil || size < 0 || size > int64(f.buf.Len()) {
+		return syscall.EROFS
+	}
+
+	f.buf.Truncate(int(size))
+	return nil
 }
 
 // Write implements afero.File.
 func (f *File) Write(p []byte) (n int, err error) {
+	if f.buf != nil {
+		f.written = true
+		return f.buf.Write(p)
+	}
+
 	return f.writer.Write(p)
 }
 
 // WriteAt implements afero.File.
 func (f *File) WriteAt(p []byte, off int64) (n int, err error) {
-	if wa, ok := f.writer.(io.WriterAt); ok {
+	if wa, ok := f.writer.(io.WriterAt); ok && f.buf == nil {
 		return wa.WriteAt(p, off)
 	}
 
@@ -77,5 +108,10 @@ func (f *File) WriteAt(p []byte, off int64) (n int, err error) {
 
 // WriteString implements afero.File.
 func (f *File) WriteString(s string) (ret int, err error) {
+	if f.buf != nil {
+		f.written = true
+		return f.buf.WriteString(s)
+	}
+
 	return io.WriteString(f.writer, s)
 }
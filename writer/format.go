@@ -0,0 +1,44 @@
+package writer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// Format writes the complete contents of the file name to w. first reports
+// whether it is the first file written to w, so formats can separate files.
+type Format func(w io.Writer, name string, data []byte, first bool) error
+
+// Tail precedes each file with a "==> name <==" header line and separates
+// files with a blank line, as tail(1) does when given several files.
+func Tail(w io.Writer, name string, data []byte, first bool) error {
+	sep := "\n"
+	if first {
+		sep = ""
+	}
+
+	if _, err := fmt.Fprintf(w, "%s==> %s <==\n", sep, name); err != nil {
+		return err
+	}
+
+	_, err := w.Write(data)
+	return err
+}
+
+// Txtar writes each file as a member of a txtar archive: a "-- name --"
+// line followed by the contents, which always end with a newline.
+func Txtar(w io.Writer, name string, data []byte, first bool) error {
+	if _, err := fmt.Fprintf(w, "-- %s --\n", name); err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if len(data) > 0 && !bytes.HasSuffix(data, []byte("\n")) {
+		_, err := io.WriteString(w, "\n")
+		return err
+	}
+
+	return nil
+}
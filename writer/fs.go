@@ -1,15 +1,57 @@
 package writer
 
 import (
+	"bytes"
 	"io"
 	"io/fs"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
 
 	"github.com/spf13/afero"
+	"github.com/unmango/go/fopt"
 )
 
+// Fs is an [afero.Fs] sink that sends the contents of every file written
+// through it to an io.Writer, for example to print generated files to
+// stdout in a dry run. By default writes pass straight through, see
+// [WithFormat] to delimit files and [WithRoute] to split them across writers.
+//
+// Directories are not tracked, so Mkdir and MkdirAll succeed without doing anything.
+// Only files that were written to are formatted when they are closed.
 type Fs struct {
 	afero.ReadOnlyFs
 	writer io.Writer
+	opts   options
+
+	// sinks serialize the files written to each writer, so routes sharing a
+	// writer share its sink
+	sink   *sink
+	routes []*sink
+}
+
+// sink is a writer shared by the files routed to it.
+type sink struct {
+	mu      sync.Mutex
+	w       io.Writer
+	written bool
+}
+
+// Create implements afero.Fs.
+func (w *Fs) Create(name string) (afero.File, error) {
+	return w.open(name), nil
+}
+
+// Mkdir implements afero.Fs.
+func (w *Fs) Mkdir(name string, perm fs.FileMode) error {
+	return nil
+}
+
+// MkdirAll implements afero.Fs.
+func (w *Fs) MkdirAll(path string, perm fs.FileMode) error {
+	return nil
 }
 
 // Name implements afero.Fs.
@@ -19,19 +61,85 @@ func (w *Fs) Name() string {
 
 // Open implements afero.Fs.
 func (w *Fs) Open(name string) (afero.File, error) {
-	return &File{w.writer, name}, nil
+	return w.open(name), nil
 }
 
 // OpenFile implements afero.Fs.
 func (w *Fs) OpenFile(name string, _ int, _ fs.FileMode) (afero.File, error) {
-	return &File{w.writer, name}, nil
+	return w.open(name), nil
 }
 
 // Stat implements afero.Fs.
 func (w *Fs) Stat(name string) (fs.FileInfo, error) {
-	return &FileInfo{w.writer, name}, nil
+	return &FileInfo{w.route(name).w, name}, nil
+}
+
+func (w *Fs) open(name string) *File {
+	s := w.route(name)
+	if w.opts.format == nil {
+		return &File{writer: s.w, name: name}
+	}
+
+	return &File{
+		writer: s.w,
+		name:   name,
+		sink:   s,
+		format: w.opts.format,
+		buf:    &bytes.Buffer{},
+	}
+}
+
+// route returns the sink of the first route matching name.
+func (w *Fs) route(name string) *sink {
+	rel := strings.TrimLeft(path.Clean(filepath.ToSlash(name)), "/")
+	for i, r := range w.opts.routes {
+		target := rel
+		if !strings.Contains(r.pattern, "/") {
+			target = path.Base(rel)
+		}
+		if ok, _ := path.Match(r.pattern, target); ok {
+			return w.routes[i]
+		}
+	}
+
+	return w.sink
+}
+
+// write writes the complete contents of a file with format.
+func (s *sink) write(format Format, name string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := format(s.w, name, data, !s.written)
+	s.written = true
+	return err
+}
+
+// NewFs returns an Fs sending the files written through it to writer.
+func NewFs(writer io.Writer, options ...Option) afero.Fs {
+	fs := &Fs{writer: writer, sink: &sink{w: writer}}
+	fopt.ApplyAll(&fs.opts, options)
+
+	sinks := map[io.Writer]*sink{}
+	if hashable(writer) {
+		sinks[writer] = fs.sink
+	}
+	for _, r := range fs.opts.routes {
+		if !hashable(r.w) {
+			fs.routes = append(fs.routes, &sink{w: r.w})
+			continue
+		}
+		if _, ok := sinks[r.w]; !ok {
+			sinks[r.w] = &sink{w: r.w}
+		}
+
+		fs.routes = append(fs.routes, sinks[r.w])
+	}
+
+	return fs
 }
 
-func NewFs(writer io.Writer) afero.Fs {
-	return &Fs{writer: writer}
+// hashable reports whether w can be used as a map key.
+func hashable(w io.Writer) bool {
+	return w != nil && reflect.TypeOf(w).Comparable()
 }
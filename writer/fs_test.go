@@ -2,10 +2,13 @@ package writer_test
 
 import (
 	"bytes"
+	"os"
+	"sync"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/spf13/afero"
 	"github.com/unmango/aferox/writer"
 )
 
@@ -21,4 +24,143 @@ var _ = Describe("Fs", func() {
 		Expect(err).NotTo(HaveOccurred())
 		Expect(buf.String()).To(Equal("blahblahblah"))
 	})
+
+	It("should write files opened for writing", func() {
+		buf := &bytes.Buffer{}
+		fs := writer.NewFs(buf)
+
+		file, err := fs.OpenFile("a.txt", os.O_WRONLY|os.O_CREATE, os.ModePerm)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = file.WriteString("a")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(buf.String()).To(Equal("a"))
+	})
+
+	It("should create files", func() {
+		buf := &bytes.Buffer{}
+		fs := writer.NewFs(buf)
+
+		Expect(fs.MkdirAll("gen", os.ModePerm)).To(Succeed())
+		Expect(afero.WriteFile(fs, "gen/test.go", []byte("package gen"), os.ModePerm)).To(Succeed())
+
+		Expect(buf.String()).To(Equal("package gen"))
+	})
+
+	Describe("Tail", func() {
+		It("should precede files with headers", func() {
+			buf := &bytes.Buffer{}
+			fs := writer.NewFs(buf, writer.WithFormat(writer.Tail))
+
+			Expect(afero.WriteFile(fs, "a.txt", []byte("a\n"), os.ModePerm)).To(Succeed())
+			Expect(afero.WriteFile(fs, "b.txt", []byte("b\n"), os.ModePerm)).To(Succeed())
+
+			Expect(buf.String()).To(Equal("==> a.txt <==\na\n\n==> b.txt <==\nb\n"))
+		})
+
+		It("should write files atomically when they are closed", func() {
+			buf := &bytes.Buffer{}
+			fs := writer.NewFs(buf, writer.WithFormat(writer.Tail))
+			a, err := fs.Create("a.txt")
+			Expect(err).NotTo(HaveOccurred())
+			b, err := fs.Create("b.txt")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = a.WriteString("a1\n")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = b.WriteString("b\n")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = a.WriteString("a2\n")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buf.String()).To(BeEmpty())
+
+			Expect(b.Close()).To(Succeed())
+			Expect(a.Close()).To(Succeed())
+			Expect(buf.String()).To(Equal("==> b.txt <==\nb\n\n==> a.txt <==\na1\na2\n"))
+		})
+
+		It("should not interleave concurrent files", func() {
+			buf := &bytes.Buffer{}
+			fs := writer.NewFs(buf, writer.WithFormat(writer.Tail))
+
+			wg := sync.WaitGroup{}
+			for _, name := range []string{"a", "b", "c", "d"} {
+				wg.Go(func() {
+					defer GinkgoRecover()
+					Expect(afero.WriteFile(fs, name, bytes.Repeat([]byte(name), 1000), os.ModePerm)).To(Succeed())
+				})
+			}
+			wg.Wait()
+
+			for _, name := range []string{"a", "b", "c", "d"} {
+				Expect(buf.String()).To(ContainSubstring("==> %s <==\n%s", name, bytes.Repeat([]byte(name), 1000)))
+			}
+		})
+	})
+
+	It("should only format files that were written to", func() {
+		buf := &bytes.Buffer{}
+		fs := writer.NewFs(buf, writer.WithFormat(writer.Tail))
+
+		file, err := fs.Create("empty.txt")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(file.Close()).To(Succeed())
+		Expect(afero.WriteFile(fs, "a.txt", []byte("a\n"), os.ModePerm)).To(Succeed())
+
+		Expect(buf.String()).To(Equal("==> a.txt <==\na\n"))
+	})
+
+	It("should write txtar archives", func() {
+		buf := &bytes.Buffer{}
+		fs := writer.NewFs(buf, writer.WithFormat(writer.Txtar))
+
+		Expect(afero.WriteFile(fs, "a.txt", []byte("a"), os.ModePerm)).To(Succeed())
+		Expect(afero.WriteFile(fs, "dir/b.txt", []byte("b\n"), os.ModePerm)).To(Succeed())
+
+		Expect(buf.String()).To(Equal("-- a.txt --\na\n-- dir/b.txt --\nb\n"))
+	})
+
+	It("should route files to writers by pattern", func() {
+		buf, gen, docs := &bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{}
+		fs := writer.NewFs(buf,
+			writer.WithRoute("*.go", gen),
+			writer.WithRoute("docs/*", docs),
+		)
+
+		Expect(afero.WriteFile(fs, "/pkg/gen.go", []byte("go"), os.ModePerm)).To(Succeed())
+		Expect(afero.WriteFile(fs, "docs/index.md", []byte("md"), os.ModePerm)).To(Succeed())
+		Expect(afero.WriteFile(fs, "other.txt", []byte("txt"), os.ModePerm)).To(Succeed())
+
+		Expect(gen.String()).To(Equal("go"))
+		Expect(docs.String()).To(Equal("md"))
+		Expect(buf.String()).To(Equal("txt"))
+	})
+
+	It("should format routed files per writer", func() {
+		buf, gen := &bytes.Buffer{}, &bytes.Buffer{}
+		fs := writer.NewFs(buf, writer.WithFormat(writer.Tail), writer.WithRoute("*.go", gen))
+
+		Expect(afero.WriteFile(fs, "other.txt", []byte("txt\n"), os.ModePerm)).To(Succeed())
+		Expect(afero.WriteFile(fs, "gen.go", []byte("go\n"), os.ModePerm)).To(Succeed())
+
+		Expect(gen.String()).To(Equal("==> gen.go <==\ngo\n"))
+		Expect(buf.String()).To(Equal("==> other.txt <==\ntxt\n"))
+	})
+
+	It("should share the sink of routes to the same writer", func() {
+		buf, gen := &bytes.Buffer{}, &bytes.Buffer{}
+		fs := writer.NewFs(buf, writer.WithFormat(writer.Tail),
+			writer.WithRoute("*.go", gen),
+			writer.WithRoute("*.md", buf),
+			writer.WithRoute("*.mod", gen),
+		)
+
+		Expect(afero.WriteFile(fs, "a.txt", []byte("a\n"), os.ModePerm)).To(Succeed())
+		Expect(afero.WriteFile(fs, "b.md", []byte("b\n"), os.ModePerm)).To(Succeed())
+		Expect(afero.WriteFile(fs, "c.go", []byte("c\n"), os.ModePerm)).To(Succeed())
+		Expect(afero.WriteFile(fs, "go.mod", []byte("d\n"), os.ModePerm)).To(Succeed())
+
+		Expect(buf.String()).To(Equal("==> a.txt <==\na\n\n==> b.md <==\nb\n"))
+		Expect(gen.String()).To(Equal("==> c.go <==\nc\n\n==> go.mod <==\nd\n"))
+	})
 })
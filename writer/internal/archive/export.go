@@ -0,0 +1,208 @@
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/filter"
+	"github.com/unmango/aferox/ignore"
+	"github.com/unmango/aferox/internal/archivepath"
+)
+
+// SourceDateEpochEnv is the environment variable holding the time, in
+// seconds since the Unix epoch, that reproducible builds clamp timestamps to.
+const SourceDateEpochEnv = "SOURCE_DATE_EPOCH"
+
+// ExportOptions configure how a tree is walked and written to an archive.
+type ExportOptions struct {
+	Sorted          bool
+	NormalizeOwner  bool
+	ClampModTime    *time.Time
+	SourceDateEpoch bool
+	Filter          filter.Filter
+	IgnoreFile      string
+
+	// Compression is the format specific compression of the archive.
+	Compression int
+}
+
+type ExportOption func(*ExportOptions)
+
+// Entry is a file, directory or symlink visited by [Walk].
+type Entry struct {
+	// Name is the slash-separated path of the entry relative to the root.
+	Name     string
+	Path     string
+	Info     fs.FileInfo
+	Linkname string
+}
+
+// ModTime returns t clamped to the configured time, truncated to whole seconds.
+func (o *ExportOptions) ModTime(t time.Time) time.Time {
+	if o.ClampModTime != nil && t.After(*o.ClampModTime) {
+		t = *o.ClampModTime
+	}
+
+	return t.Truncate(time.Second).UTC()
+}
+
+// Walk calls fn for the files, directories and symlinks beneath root in
+// fsys, skipping those denied by the configured filter or ignore file.
+// Directories are visited before their contents.
+func Walk(fsys afero.Fs, root string, opts *ExportOptions, fn func(Entry) error) error {
+	if opts.SourceDateEpoch {
+		if err := opts.sourceDateEpoch(); err != nil {
+			return err
+		}
+	}
+
+	// Filters see names relative to the root, like the patterns of the ignore file
+	src := fsys
+	if root := filepath.Clean(root); root != "." {
+		src = afero.NewBasePathFs(fsys, root)
+	}
+	if opts.Filter != nil {
+		src = filter.NewFs(src, opts.Filter, filter.FilterDirs)
+	}
+
+	var patterns ignore.Patterns
+	if opts.IgnoreFile != "" {
+		file, err := fsys.Open(filepath.Join(root, opts.IgnoreFile))
+		if err == nil {
+			patterns, err = ignore.ReadPatterns(file)
+			err = errors.Join(err, file.Close())
+		}
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("reading %s: %w", opts.IgnoreFile, err)
+		}
+	}
+
+	w := &walker{src, root, opts, patterns, fn}
+	return w.walk("")
+}
+
+type walker struct {
+	// fsys is rooted at root
+	fsys     afero.Fs
+	root     string
+	opts     *ExportOptions
+	patterns ignore.Patterns
+	fn       func(Entry) error
+}
+
+func (w *walker) walk(dir string) error {
+	file, err := w.fsys.Open(filepath.FromSlash(dir))
+	if err != nil {
+		return err
+	}
+
+	infos, err := file.Readdir(-1)
+	if err = errors.Join(err, file.Close()); err != nil {
+		return err
+	}
+	if w.opts.Sorted {
+		sort.Slice(infos, func(i, j int) bool {
+			return infos[i].Name() < infos[j].Name()
+		})
+	}
+
+	for _, info := range infos {
+		entry := Entry{Name: archivepath.Join(dir, info.Name()), Info: info}
+		entry.Path = w.path(entry.Name)
+		if w.patterns.Ignores(entry.Name, info.IsDir()) {
+			continue
+		}
+
+		if info.Mode()&fs.ModeSymlink != 0 {
+			reader, ok := w.fsys.(afero.LinkReader)
+			if !ok {
+				return &fs.PathError{Op: "readlink", Path: entry.Path, Err: afero.ErrNoReadlink}
+			}
+			if entry.Linkname, err = reader.ReadlinkIfPossible(filepath.FromSlash(entry.Name)); err != nil {
+				return err
+			}
+		}
+
+		if err := w.fn(entry); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if err := w.walk(entry.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// path returns the path of name beneath the root in the source Fs.
+func (w *walker) path(name string) string {
+	return filepath.Join(w.root, filepath.FromSlash(name))
+}
+
+func (o *ExportOptions) sourceDateEpoch() error {
+	value := strings.TrimSpace(os.Getenv(SourceDateEpochEnv))
+	if value == "" {
+		return nil
+	}
+
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", SourceDateEpochEnv, err)
+	}
+
+	epoch := time.Unix(seconds, 0)
+	if o.ClampModTime == nil || epoch.Before(*o.ClampModTime) {
+		o.ClampModTime = &epoch
+	}
+
+	return nil
+}
+
+// Sorted visits the entries of each directory sorted by name.
+func Sorted(options *ExportOptions) {
+	options.Sorted = true
+}
+
+// NormalizeOwner writes entries owned by root, without user or group names.
+func NormalizeOwner(options *ExportOptions) {
+	options.NormalizeOwner = true
+}
+
+// SourceDateEpoch clamps modification times to the SOURCE_DATE_EPOCH
+// environment variable when it is set.
+func SourceDateEpoch(options *ExportOptions) {
+	options.SourceDateEpoch = true
+}
+
+// ClampModTime writes modification times later than t as t.
+func ClampModTime(t time.Time) ExportOption {
+	return func(options *ExportOptions) {
+		options.ClampModTime = &t
+	}
+}
+
+// WithFilter skips the files and directories denied by f, which sees their
+// paths relative to the root like the patterns of [WithIgnoreFile].
+func WithFilter(f filter.Filter) ExportOption {
+	return func(options *ExportOptions) {
+		options.Filter = f
+	}
+}
+
+// WithIgnoreFile skips the files and directories matching the gitignore
+// style patterns in the file name, relative to the root, when it exists.
+func WithIgnoreFile(name string) ExportOption {
+	return func(options *ExportOptions) {
+		options.IgnoreFile = name
+	}
+}
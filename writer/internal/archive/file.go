@@ -0,0 +1,303 @@
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// File is an entry of an archive [Fs]. Files returned by Create and OpenFile
+// are written to the archive as they are written or when closed, while files
+// returned by Open describe entries that have already been written.
+type File struct {
+	name   string
+	fs     *Fs
+	header *Header
+
+	// Set when writing a new entry. Contents are buffered in buf until the
+	// size is known and the file holds the archive, then streamed.
+	buf       *spool
+	size      int64
+	streaming bool
+	written   int64
+
+	// Set when reading an entry, for directories and readable files respectively
+	names []string
+	r     *io.SectionReader
+
+	err  error
+	once sync.Once
+}
+
+// Close implements [afero.File]. Closing a streaming file writes the entries
+// queued while it held the archive, and returns any error writing them.
+func (f *File) Close() error {
+	if f.buf == nil {
+		return nil
+	}
+
+	f.once.Do(func() {
+		if !f.streaming {
+			f.err = f.fs.enqueue(f.flush)
+			return
+		}
+
+		f.fs.stream.Lock()
+		defer f.fs.stream.Unlock()
+
+		f.err = errors.Join(f.pad(), f.fs.drain())
+	})
+
+	return f.err
+}
+
+// Name implements [afero.File].
+func (f *File) Name() string {
+	return f.name
+}
+
+// Read implements [afero.File].
+func (f *File) Read(p []byte) (n int, err error) {
+	if r, err := f.reader(); err != nil {
+		return 0, err
+	} else {
+		return r.Read(p)
+	}
+}
+
+// ReadAt implements [afero.File].
+func (f *File) ReadAt(p []byte, off int64) (n int, err error) {
+	if r, err := f.reader(); err != nil {
+		return 0, err
+	} else {
+		return r.ReadAt(p, off)
+	}
+}
+
+// Readdir implements [afero.File].
+func (f *File) Readdir(count int) ([]os.FileInfo, error) {
+	names, err := f.Readdirnames(count)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]os.FileInfo, 0, len(names))
+	for _, name := range names {
+		info, err := f.fs.Stat(path.Join(f.header.Name, name))
+		if err != nil {
+			return nil, err
+		}
+
+		infos = append(infos, info)
+	}
+
+	return infos, nil
+}
+
+// Readdirnames implements [afero.File].
+func (f *File) Readdirnames(n int) ([]string, error) {
+	if f.buf != nil {
+		return nil, syscall.EROFS
+	}
+	if !f.header.Mode.IsDir() {
+		return nil, syscall.ENOTDIR
+	}
+	if n > 0 && len(f.names) == 0 {
+		return nil, io.EOF
+	}
+	if n <= 0 || n > len(f.names) {
+		n = len(f.names)
+	}
+
+	names := f.names[:n]
+	f.names = f.names[n:]
+	return names, nil
+}
+
+// Seek implements [afero.File].
+func (f *File) Seek(offset int64, whence int) (int64, error) {
+	if r, err := f.reader(); err != nil {
+		return 0, err
+	} else {
+		return r.Seek(offset, whence)
+	}
+}
+
+// Stat implements [afero.File].
+func (f *File) Stat() (os.FileInfo, error) {
+	if f.buf != nil && !f.streaming {
+		header := *f.header
+		header.Size = f.buf.Len()
+		return &FileInfo{header: &header}, nil
+	}
+
+	return &FileInfo{header: f.header}, nil
+}
+
+// Sync implements [afero.File].
+func (f *File) Sync() error {
+	return syscall.EROFS
+}
+
+// Truncate implements [afero.File]. When the Fs writes to a [Streamer],
+// truncating a file that is being written declares its final size, so the
+// rest of its contents are streamed to the archive. Contents already written
+// beyond size are discarded, and closing the file before writing size bytes
+// pads it with zeros and returns [io.ErrShortWrite]. Otherwise the buffered
+// contents are truncated or extended with zeros.
+func (f *File) Truncate(size int64) error {
+	if f.buf == nil || f.streaming || size < 0 {
+		return syscall.EROFS
+	}
+	if _, ok := f.fs.w.(Streamer); !ok && size > f.buf.Len() {
+		_, err := io.CopyN(f.buf, zeros{}, size-f.buf.Len())
+		return err
+	}
+	if err := f.buf.Truncate(size); err != nil {
+		return err
+	}
+	if _, ok := f.fs.w.(Streamer); ok {
+		f.size = size
+	}
+
+	return nil
+}
+
+// Write implements [afero.File].
+func (f *File) Write(p []byte) (n int, err error) {
+	if f.buf == nil {
+		return 0, syscall.EBADF
+	}
+	if f.size >= 0 && !f.streaming && f.fs.acquire(f) {
+		if err := f.stream(); err != nil {
+			return 0, err
+		}
+	}
+	if !f.streaming {
+		if f.size >= 0 && f.buf.Len()+int64(len(p)) > f.size {
+			return 0, ErrWriteTooLong
+		}
+
+		return f.buf.Write(p)
+	}
+
+	f.fs.stream.Lock()
+	defer f.fs.stream.Unlock()
+
+	n, err = f.fs.w.(Streamer).Write(p)
+	f.written += int64(n)
+	return n, err
+}
+
+// WriteAt implements [afero.File].
+func (f *File) WriteAt(p []byte, off int64) (n int, err error) {
+	return 0, syscall.EROFS
+}
+
+// WriteString implements [afero.File].
+func (f *File) WriteString(s string) (ret int, err error) {
+	return f.Write([]byte(s))
+}
+
+// stream writes the header and any buffered contents, so later writes go
+// directly to the archive until the file is closed. The file must hold the
+// archive, see [Fs.acquire].
+func (f *File) stream() error {
+	f.fs.stream.Lock()
+	defer f.fs.stream.Unlock()
+
+	header := f.entry()
+	err := f.fs.w.(Streamer).WriteHeader(header)
+	if err := f.fs.publish("write", header, err); err != nil {
+		return errors.Join(err, f.fs.drain())
+	}
+
+	f.header = header
+	f.streaming = true
+	n, err := f.buf.WriteTo(f.fs.w.(Streamer))
+	f.written += n
+	if err != nil {
+		return fmt.Errorf("copying file buffer: %w", err)
+	}
+
+	return f.buf.Close()
+}
+
+// flush writes the entry and its buffered contents, padded to the declared
+// size, to the archive. The caller must hold f.fs.stream.
+func (f *File) flush() error {
+	defer f.buf.Close()
+
+	if f.size < 0 {
+		f.size = f.buf.Len()
+	}
+
+	r, err := f.buf.Reader()
+	if err != nil {
+		f.fs.release(f.name)
+		return fmt.Errorf("reading file buffer: %w", err)
+	}
+
+	header := f.entry()
+	short := f.size - f.buf.Len()
+	r = io.MultiReader(r, io.LimitReader(zeros{}, short))
+	if err := f.fs.write("close", header, r); err != nil {
+		return err
+	}
+
+	f.header = header
+	if short > 0 {
+		return f.shortWrite(f.buf.Len())
+	}
+
+	return nil
+}
+
+// pad fills the rest of the declared size of a streaming file with zeros,
+// keeping the archive readable after a short write. The caller must hold
+// f.fs.stream.
+func (f *File) pad() error {
+	if f.written >= f.size {
+		return nil
+	}
+
+	_, err := io.CopyN(f.fs.w.(Streamer), zeros{}, f.size-f.written)
+	return errors.Join(f.shortWrite(f.written), err)
+}
+
+// entry returns the header of the entry as it is written to the archive.
+func (f *File) entry() *Header {
+	header := *f.header
+	header.Size = f.size
+	header.ModTime = time.Now().Truncate(time.Second)
+	return &header
+}
+
+func (f *File) shortWrite(n int64) error {
+	return fmt.Errorf("%s: wrote %d of %d bytes: %w", f.name, n, f.size, io.ErrShortWrite)
+}
+
+// reader returns the contents of a file opened for reading, when they can be read back.
+func (f *File) reader() (*io.SectionReader, error) {
+	switch {
+	case f.r != nil:
+		return f.r, nil
+	case f.buf == nil && f.header.Mode.IsDir():
+		return nil, syscall.EISDIR
+	default:
+		return nil, syscall.EROFS
+	}
+}
+
+// zeros reads an endless stream of zero bytes.
+type zeros struct{}
+
+func (zeros) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
@@ -1,43 +1,43 @@
-package tar
+package archive
 
 import (
-	"archive/tar"
 	"io/fs"
-	"os"
+	"path"
 	"time"
 )
 
+// FileInfo describes an entry of an archive [Fs] from its header.
 type FileInfo struct {
-	name string
-	w    *tar.Writer
+	header *Header
 }
 
 // IsDir implements fs.FileInfo.
 func (f *FileInfo) IsDir() bool {
-	return false
+	return f.header.Mode.IsDir()
 }
 
 // ModTime implements fs.FileInfo.
 func (f *FileInfo) ModTime() time.Time {
-	return time.Time{}
+	return f.header.ModTime
 }
 
 // Mode implements fs.FileInfo.
 func (f *FileInfo) Mode() fs.FileMode {
-	return os.ModePerm
+	return f.header.Mode
 }
 
 // Name implements fs.FileInfo.
 func (f *FileInfo) Name() string {
-	return f.name
+	return path.Base(f.header.Name)
 }
 
 // Size implements fs.FileInfo.
 func (f *FileInfo) Size() int64 {
-	return 0
+	return f.header.Size
 }
 
-// Sys implements fs.FileInfo.
+// Sys implements fs.FileInfo. It returns the header of the entry in the
+// archive format, or nil for implied directories.
 func (f *FileInfo) Sys() any {
-	return nil
+	return f.header.Sys
 }
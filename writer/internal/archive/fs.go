@@ -0,0 +1,474 @@
+// Package archive implements the afero.Fs semantics shared by the archive
+// writer filesystems, leaving the encoding of entries to a [Writer].
+package archive
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/internal/archivepath"
+	"github.com/unmango/go/fopt"
+)
+
+// Header describes an entry written to an archive.
+type Header struct {
+	// Name is the slash-separated path of the entry relative to the archive root.
+	Name     string
+	Mode     fs.FileMode
+	ModTime  time.Time
+	Size     int64
+	Linkname string
+
+	// Sys is the header of the entry in the archive format, set by the [Writer].
+	Sys any
+
+	// Contents reads back the contents of a file, when set by the [Writer].
+	Contents io.ReaderAt
+}
+
+// Writer encodes entries in an archive format.
+type Writer interface {
+	// WriteEntry writes the entry described by h, with contents read from r.
+	// r is nil for directories and symlinks. Formats that cannot hold the
+	// type of entry return an error wrapping [errors.ErrUnsupported].
+	WriteEntry(h *Header, r io.Reader) error
+}
+
+// Streamer is a [Writer] that can write the contents of a file after its
+// header, so files of a known size are streamed instead of buffered.
+type Streamer interface {
+	Writer
+
+	// WriteHeader writes the header of the file described by h, whose
+	// h.Size bytes of contents follow in calls to Write.
+	WriteHeader(h *Header) error
+	io.Writer
+}
+
+// ErrWriteTooLong is returned when writing more than the declared size of a file.
+var ErrWriteTooLong = errors.New("write too long")
+
+// Options configure an [Fs].
+type Options struct {
+	// Size reports the size of the file name ahead of time, or false when it is
+	// unknown, so a [Streamer] can stream its contents as they are written.
+	Size func(name string) (int64, bool)
+
+	// Spill holds temporary files for buffers larger than Threshold bytes.
+	Spill     afero.Fs
+	Threshold int64
+}
+
+type Option func(*Options)
+
+// Fs implements [afero.Fs] and [afero.Symlinker] as an append-only
+// filesystem writing entries to a [Writer].
+//
+// The Fs keeps track of the entries it writes, so Stat, Open and Readdir
+// describe what has been written so far, including the directories implied
+// by the names of files. Writing an entry that already exists, or beneath
+// a file, fails rather than adding a duplicate to the archive. Contents can
+// only be read back when the Writer sets [Header.Contents].
+//
+// Files are buffered until they are closed, unless the Writer is a [Streamer]
+// and their size is declared with [Options.Size] or Truncate. Only one file
+// streams at a time, holding the archive from its first Write until Close.
+// Meanwhile other entries are queued, and written when the streaming file
+// is closed, whose Close returns any error writing them.
+type Fs struct {
+	// m guards entries, active and queue, while stream serializes writes to w
+	m      *sync.Mutex
+	stream *sync.Mutex
+	name   string
+	w      Writer
+	opts   Options
+
+	entries map[string]*Header
+
+	// active is the file streaming to the archive, and queue holds the
+	// writes of other entries until it is closed
+	active *File
+	queue  []func() error
+}
+
+var _ afero.Symlinker = (*Fs)(nil)
+
+// NewFs returns an Fs named name writing entries to w.
+func NewFs(name string, w Writer, options ...Option) *Fs {
+	fs := &Fs{
+		m:       &sync.Mutex{},
+		stream:  &sync.Mutex{},
+		name:    name,
+		w:       w,
+		entries: map[string]*Header{},
+	}
+
+	fopt.ApplyAll(&fs.opts, options)
+	return fs
+}
+
+// Add records h as an entry already in the archive, replacing any entry
+// with the same name, as later entries do when an archive is extracted.
+func (f *Fs) Add(h *Header) {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	f.entries[h.Name] = h
+}
+
+// Finish calls fn while holding the archive, for example to write its footer.
+// It fails with [syscall.EBUSY] while a file is streaming.
+func (f *Fs) Finish(fn func() error) error {
+	f.stream.Lock()
+	defer f.stream.Unlock()
+
+	f.m.Lock()
+	active := f.active
+	f.m.Unlock()
+	if active != nil {
+		return &fs.PathError{Op: "close", Path: active.name, Err: syscall.EBUSY}
+	}
+
+	return fn()
+}
+
+// Chmod implements [afero.Fs].
+func (f *Fs) Chmod(name string, mode os.FileMode) error {
+	return syscall.EPERM
+}
+
+// Chown implements [afero.Fs].
+func (f *Fs) Chown(name string, uid int, gid int) error {
+	return syscall.EPERM
+}
+
+// Chtimes implements [afero.Fs].
+func (f *Fs) Chtimes(name string, atime time.Time, mtime time.Time) error {
+	return syscall.EPERM
+}
+
+// Create implements [afero.Fs].
+func (f *Fs) Create(name string) (afero.File, error) {
+	return f.create("create", name, 0644)
+}
+
+// Mkdir implements [afero.Fs].
+func (f *Fs) Mkdir(name string, perm os.FileMode) error {
+	header := f.header(name, fs.ModeDir|perm.Perm())
+	if err := f.reserve("mkdir", header); err != nil {
+		return err
+	}
+
+	return f.enqueue(func() error {
+		return f.write("mkdir", header, nil)
+	})
+}
+
+// MkdirAll implements [afero.Fs]. It writes an entry for each directory
+// in path that has not been written yet.
+func (f *Fs) MkdirAll(path string, perm os.FileMode) error {
+	dir := ""
+	for _, segment := range strings.Split(archivepath.Clean(path), "/") {
+		if segment == "" {
+			continue
+		}
+
+		// Directories implied by the names of other entries are written too
+		dir = archivepath.Join(dir, segment)
+		f.m.Lock()
+		header, ok := f.entries[dir]
+		if !ok {
+			header = f.header(dir, fs.ModeDir|perm.Perm())
+			f.entries[dir] = header
+		}
+		f.m.Unlock()
+
+		if ok && !header.Mode.IsDir() {
+			return &fs.PathError{Op: "mkdir", Path: path, Err: syscall.ENOTDIR}
+		} else if ok {
+			continue
+		}
+
+		if err := f.enqueue(func() error {
+			return f.write("mkdir", header, nil)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Name implements [afero.Fs].
+func (f *Fs) Name() string {
+	return f.name
+}
+
+// Open implements [afero.Fs].
+func (f *Fs) Open(name string) (afero.File, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	header, err := f.lookup("open", name)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &File{name: name, fs: f, header: header}
+	if header.Mode.IsDir() {
+		file.names = archivepath.Children(f.entries, archivepath.Clean(name))
+	} else if header.Contents != nil {
+		file.r = io.NewSectionReader(header.Contents, 0, header.Size)
+	}
+
+	return file, nil
+}
+
+// OpenFile implements [afero.Fs]. Flags requesting write access create a
+// new entry, while read-only flags open an existing one.
+func (f *Fs) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	if flag&(os.O_WRONLY|os.O_RDWR|os.O_CREATE|os.O_TRUNC|os.O_APPEND) == 0 {
+		return f.Open(name)
+	}
+
+	return f.create("open", name, perm)
+}
+
+// Remove implements [afero.Fs].
+func (f *Fs) Remove(name string) error {
+	return syscall.EROFS
+}
+
+// RemoveAll implements [afero.Fs].
+func (f *Fs) RemoveAll(path string) error {
+	return syscall.EROFS
+}
+
+// Rename implements [afero.Fs].
+func (f *Fs) Rename(oldname string, newname string) error {
+	return syscall.EROFS
+}
+
+// Stat implements [afero.Fs]. Symlinks are not followed, as their targets
+// may not be part of the archive.
+func (f *Fs) Stat(name string) (os.FileInfo, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	header, err := f.lookup("stat", name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileInfo{header: header}, nil
+}
+
+// LstatIfPossible implements [afero.Lstater].
+func (f *Fs) LstatIfPossible(name string) (os.FileInfo, bool, error) {
+	info, err := f.Stat(name)
+	return info, true, err
+}
+
+// ReadlinkIfPossible implements [afero.LinkReader].
+func (f *Fs) ReadlinkIfPossible(name string) (string, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	header, err := f.lookup("readlink", name)
+	if err != nil {
+		return "", err
+	}
+	if header.Mode&fs.ModeSymlink == 0 {
+		return "", &fs.PathError{Op: "readlink", Path: name, Err: syscall.EINVAL}
+	}
+
+	return header.Linkname, nil
+}
+
+// SymlinkIfPossible implements [afero.Linker]. It writes a symlink named
+// newname pointing to oldname, which does not need to exist.
+func (f *Fs) SymlinkIfPossible(oldname, newname string) error {
+	header := f.header(newname, fs.ModeSymlink|0777)
+	header.Linkname = oldname
+	header.Size = int64(len(oldname))
+	if err := f.reserve("symlink", header); err != nil {
+		return &os.LinkError{Op: "symlink", Old: oldname, New: newname, Err: unwrap(err)}
+	}
+
+	if err := f.enqueue(func() error {
+		return f.write("symlink", header, nil)
+	}); err != nil {
+		if errors.Is(err, errors.ErrUnsupported) {
+			err = afero.ErrNoSymlink
+		}
+
+		return &os.LinkError{Op: "symlink", Old: oldname, New: newname, Err: unwrap(err)}
+	}
+
+	return nil
+}
+
+func (f *Fs) create(op, name string, perm os.FileMode) (*File, error) {
+	header := f.header(name, perm.Perm())
+	if err := f.reserve(op, header); err != nil {
+		return nil, err
+	}
+
+	size := int64(-1)
+	if _, ok := f.w.(Streamer); ok && f.opts.Size != nil {
+		if s, ok := f.opts.Size(name); ok {
+			size = s
+		}
+	}
+
+	return &File{
+		name:   name,
+		fs:     f,
+		header: header,
+		buf:    &spool{fs: f.opts.Spill, limit: f.opts.Threshold},
+		size:   size,
+	}, nil
+}
+
+// header returns a header for a new entry named name.
+func (f *Fs) header(name string, mode fs.FileMode) *Header {
+	return &Header{
+		Name:    archivepath.Clean(name),
+		Mode:    mode,
+		ModTime: time.Now().Truncate(time.Second),
+	}
+}
+
+// reserve adds an entry for header, or fails when its name already exists
+// or a parent of it is a file.
+func (f *Fs) reserve(op string, header *Header) error {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	name := header.Name
+	if name == "" {
+		return &fs.PathError{Op: op, Path: "/", Err: fs.ErrExist}
+	}
+	if _, ok := f.entries[name]; ok || archivepath.Implied(f.entries, name) {
+		return &fs.PathError{Op: op, Path: name, Err: fs.ErrExist}
+	}
+
+	for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+		if h, ok := f.entries[dir]; ok && !h.Mode.IsDir() {
+			return &fs.PathError{Op: op, Path: name, Err: syscall.ENOTDIR}
+		}
+	}
+
+	f.entries[name] = header
+	return nil
+}
+
+// write writes a copy of the reserved entry header to the archive and records
+// it as written, or releases the entry on failure. The caller must hold f.stream.
+func (f *Fs) write(op string, header *Header, r io.Reader) error {
+	written := *header
+	return f.publish(op, &written, f.w.WriteEntry(&written, r))
+}
+
+// publish records header as written, or releases its entry when err is not nil.
+func (f *Fs) publish(op string, header *Header, err error) error {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	if err != nil {
+		delete(f.entries, header.Name)
+		return &fs.PathError{Op: op, Path: header.Name, Err: err}
+	}
+
+	f.entries[header.Name] = header
+	return nil
+}
+
+// release removes the entry reserved for name after failing to write it.
+func (f *Fs) release(name string) {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	delete(f.entries, archivepath.Clean(name))
+}
+
+// acquire lets file stream to the archive, unless another file already is.
+func (f *Fs) acquire(file *File) bool {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	if f.active == nil {
+		f.active = file
+	}
+
+	return f.active == file
+}
+
+// enqueue calls write while holding f.stream, or queues it until the
+// streaming file is closed.
+func (f *Fs) enqueue(write func() error) error {
+	f.stream.Lock()
+	defer f.stream.Unlock()
+
+	f.m.Lock()
+	if f.active != nil {
+		f.queue = append(f.queue, write)
+		f.m.Unlock()
+		return nil
+	}
+	f.m.Unlock()
+
+	return write()
+}
+
+// drain calls the queued writes and releases the archive held by the
+// streaming file. The caller must hold f.stream.
+func (f *Fs) drain() error {
+	var errs []error
+	for {
+		f.m.Lock()
+		if len(f.queue) == 0 {
+			f.active = nil
+			f.m.Unlock()
+			return errors.Join(errs...)
+		}
+
+		write := f.queue[0]
+		f.queue = f.queue[1:]
+		f.m.Unlock()
+
+		errs = append(errs, write())
+	}
+}
+
+// lookup returns the header written for name, or a header describing a
+// directory implied by the names of other entries. The caller must hold f.m.
+func (f *Fs) lookup(op, name string) (*Header, error) {
+	rel := archivepath.Clean(name)
+	if h, ok := f.entries[rel]; ok {
+		return h, nil
+	}
+	if rel == "" || archivepath.Implied(f.entries, rel) {
+		return &Header{Name: rel, Mode: fs.ModeDir | 0755}, nil
+	}
+
+	return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+}
+
+// unwrap returns the error wrapped by a *fs.PathError, so it can be
+// reported as part of an *os.LinkError instead.
+func unwrap(err error) error {
+	if perr, ok := err.(*fs.PathError); ok {
+		return perr.Err
+	}
+
+	return err
+}
@@ -0,0 +1,157 @@
+package archive_test
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"syscall"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/writer/internal/archive"
+)
+
+type entry struct {
+	Header   archive.Header
+	Contents string
+}
+
+// recorder records the entries written to it, failing with err when set
+type recorder struct {
+	entries []entry
+	err     error
+}
+
+func (r *recorder) WriteEntry(h *archive.Header, in io.Reader) error {
+	if r.err != nil {
+		return r.err
+	}
+
+	e := entry{Header: *h}
+	if in != nil {
+		data, err := io.ReadAll(in)
+		if err != nil {
+			return err
+		}
+		e.Contents = string(data)
+	}
+
+	h.Sys = len(r.entries)
+	r.entries = append(r.entries, e)
+	return nil
+}
+
+var _ = Describe("Fs", func() {
+	var (
+		w    *recorder
+		fsys *archive.Fs
+	)
+
+	BeforeEach(func() {
+		w = &recorder{}
+		fsys = archive.NewFs("test", w)
+	})
+
+	It("should return its name", func() {
+		Expect(fsys.Name()).To(Equal("test"))
+	})
+
+	It("should write files when they are closed", func() {
+		file, err := fsys.Create("/dir/test.txt")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = file.WriteString("testing")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(w.entries).To(BeEmpty())
+
+		Expect(file.Close()).To(Succeed())
+
+		Expect(w.entries).To(ConsistOf(SatisfyAll(
+			HaveField("Header.Name", "dir/test.txt"),
+			HaveField("Header.Size", BeEquivalentTo(7)),
+			HaveField("Header.Mode", fs.FileMode(0644)),
+			HaveField("Contents", "testing"),
+		)))
+	})
+
+	It("should write directories", func() {
+		Expect(fsys.MkdirAll("a/b", 0755)).To(Succeed())
+		Expect(fsys.MkdirAll("a/b/c", 0755)).To(Succeed())
+
+		Expect(w.entries).To(HaveLen(3))
+		Expect(w.entries[2].Header.Name).To(Equal("a/b/c"))
+		Expect(w.entries[2].Header.Mode).To(Equal(fs.ModeDir | 0755))
+	})
+
+	It("should write symlinks", func() {
+		Expect(fsys.SymlinkIfPossible("../target", "link")).To(Succeed())
+
+		target, err := fsys.ReadlinkIfPossible("link")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(target).To(Equal("../target"))
+
+		info, lstat, err := fsys.LstatIfPossible("link")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lstat).To(BeTrue())
+		Expect(info.Mode() & fs.ModeSymlink).NotTo(BeZero())
+	})
+
+	It("should report unsupported symlinks", func() {
+		w.err = errors.ErrUnsupported
+
+		err := fsys.SymlinkIfPossible("target", "link")
+
+		Expect(err).To(MatchError(afero.ErrNoSymlink))
+		_, err = fsys.Stat("link")
+		Expect(err).To(MatchError(os.ErrNotExist))
+	})
+
+	It("should describe written entries", func() {
+		Expect(afero.WriteFile(fsys, "dir/test.txt", []byte("testing"), 0600)).To(Succeed())
+
+		info, err := fsys.Stat("dir/test.txt")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Name()).To(Equal("test.txt"))
+		Expect(info.Size()).To(BeEquivalentTo(7))
+		Expect(info.Sys()).To(Equal(0))
+
+		names, err := afero.ReadDir(fsys, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(ConsistOf(HaveField("Name()", "dir")))
+		Expect(names[0].IsDir()).To(BeTrue())
+	})
+
+	It("should reject duplicate entries", func() {
+		Expect(afero.WriteFile(fsys, "test.txt", nil, 0600)).To(Succeed())
+
+		_, err := fsys.Create("test.txt")
+		Expect(err).To(MatchError(fs.ErrExist))
+
+		err = fsys.Mkdir("test.txt/dir", 0755)
+		Expect(err).To(MatchError(syscall.ENOTDIR))
+	})
+
+	It("should release entries that fail to write", func() {
+		w.err = errors.New("write failed")
+
+		Expect(fsys.Mkdir("dir", 0755)).To(MatchError("mkdir dir: write failed"))
+		_, err := fsys.Stat("dir")
+		Expect(err).To(MatchError(os.ErrNotExist))
+	})
+
+	It("should not read back contents", func() {
+		Expect(afero.WriteFile(fsys, "test.txt", []byte("testing"), 0600)).To(Succeed())
+
+		_, err := afero.ReadFile(fsys, "test.txt")
+
+		Expect(err).To(MatchError(syscall.EROFS))
+	})
+
+	It("should refuse to modify entries", func() {
+		Expect(fsys.Remove("test.txt")).To(MatchError(syscall.EROFS))
+		Expect(fsys.Rename("a", "b")).To(MatchError(syscall.EROFS))
+		Expect(fsys.Chmod("a", 0600)).To(MatchError(syscall.EPERM))
+	})
+})
@@ -0,0 +1,113 @@
+package archive
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/afero"
+)
+
+// spool buffers the contents of a file in memory until they grow past
+// limit, then moves them to a temporary file in fs.
+type spool struct {
+	fs    afero.Fs
+	limit int64
+
+	buf  bytes.Buffer
+	file afero.File
+	size int64
+}
+
+// Write implements io.Writer.
+func (s *spool) Write(p []byte) (int, error) {
+	if s.file == nil && s.fs != nil && s.size+int64(len(p)) > s.limit {
+		if err := s.spill(); err != nil {
+			return 0, err
+		}
+	}
+
+	var n int
+	var err error
+	if s.file != nil {
+		n, err = s.file.Write(p)
+	} else {
+		n, err = s.buf.Write(p)
+	}
+
+	s.size += int64(n)
+	return n, err
+}
+
+// Len returns the number of bytes written.
+func (s *spool) Len() int64 {
+	return s.size
+}
+
+// Truncate discards all but the first size bytes.
+func (s *spool) Truncate(size int64) error {
+	if size >= s.size {
+		return nil
+	}
+	if s.file == nil {
+		s.buf.Truncate(int(size))
+	} else if err := s.file.Truncate(size); err != nil {
+		return err
+	} else if _, err := s.file.Seek(size, io.SeekStart); err != nil {
+		return err
+	}
+
+	s.size = size
+	return nil
+}
+
+// Reader returns a reader of the contents.
+func (s *spool) Reader() (io.Reader, error) {
+	if s.file == nil {
+		return &s.buf, nil
+	}
+	if _, err := s.file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	return s.file, nil
+}
+
+// WriteTo copies the contents to w.
+func (s *spool) WriteTo(w io.Writer) (int64, error) {
+	if s.file == nil {
+		return s.buf.WriteTo(w)
+	}
+	if _, err := s.file.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	return io.Copy(w, s.file)
+}
+
+// Close removes the temporary file, if any.
+func (s *spool) Close() error {
+	s.buf = bytes.Buffer{}
+	if s.file == nil {
+		return nil
+	}
+
+	file := s.file
+	s.file = nil
+	return errors.Join(file.Close(), s.fs.Remove(file.Name()))
+}
+
+func (s *spool) spill() error {
+	file, err := afero.TempFile(s.fs, "", "aferox-archive-")
+	if err != nil {
+		return fmt.Errorf("creating spill file: %w", err)
+	}
+	if _, err := s.buf.WriteTo(file); err != nil {
+		return errors.Join(fmt.Errorf("spilling file buffer: %w", err), file.Close(), s.fs.Remove(file.Name()))
+	}
+
+	s.file = file
+	s.buf = bytes.Buffer{}
+	return nil
+}
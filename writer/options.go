@@ -0,0 +1,35 @@
+package writer
+
+import "io"
+
+type options struct {
+	format Format
+	routes []route
+}
+
+type Option func(*options)
+
+// route sends the files whose path matches pattern to w.
+type route struct {
+	pattern string
+	w       io.Writer
+}
+
+// WithFormat buffers each file until it is closed, then writes it to the
+// underlying writer at once using format, so files written concurrently
+// are never interleaved.
+func WithFormat(format Format) Option {
+	return func(options *options) {
+		options.format = format
+	}
+}
+
+// WithRoute sends the files whose path matches pattern to w instead of the
+// writer passed to [NewFs]. Patterns use [path.Match] syntax and match the
+// slash-separated path of a file relative to the root, or its base name when
+// the pattern contains no slash. The first matching route wins.
+func WithRoute(pattern string, w io.Writer) Option {
+	return func(options *options) {
+		options.routes = append(options.routes, route{pattern, w})
+	}
+}
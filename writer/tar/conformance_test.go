@@ -0,0 +1,31 @@
+package tar_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/testing/conformance"
+	aferoxtar "github.com/unmango/aferox/writer/tar"
+)
+
+var _ = conformance.Describe("Conformance", func(seed afero.Fs) (afero.Fs, error) {
+	base := afero.NewMemMapFs()
+	file, err := base.Create("archive.tar")
+	if err != nil {
+		return nil, err
+	}
+	if err := aferoxtar.Export(seed, "", file); err != nil {
+		return nil, err
+	}
+	if err := file.Close(); err != nil {
+		return nil, err
+	}
+
+	fsys, err := aferoxtar.Append(base, "archive.tar")
+	if err != nil {
+		return nil, err
+	}
+
+	DeferCleanup(fsys.Close)
+	return fsys, nil
+}, conformance.Without(conformance.NoWrite))
@@ -2,7 +2,16 @@ package tar
 
 import (
 	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
 	"io"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/writer/internal/archive"
+	"github.com/unmango/go/fopt"
 )
 
 const (
@@ -21,6 +30,9 @@ const (
 	TypeGNULongLink   = tar.TypeGNULongLink
 )
 
+// ErrWriteTooLong is returned when writing more than the declared size of a file.
+var ErrWriteTooLong = archive.ErrWriteTooLong
+
 // NewWriter returns a new tar.Writer that writes a tar archive to w.
 //
 // This function is a thin wrapper around archive/tar.NewWriter and exists to
@@ -41,3 +53,139 @@ func NewWriter(w io.Writer) *tar.Writer {
 func NewReader(r io.Reader) *tar.Reader {
 	return tar.NewReader(r)
 }
+
+// Compression is the compression of an exported archive.
+type Compression int
+
+const (
+	Uncompressed Compression = iota
+	Gzip
+	Zstd
+)
+
+// ExportOption configures [Export].
+type ExportOption = archive.ExportOption
+
+var (
+	// Sorted writes the entries of each directory sorted by name, rather
+	// than in the order the Fs lists them.
+	Sorted ExportOption = archive.Sorted
+
+	// NormalizeOwner writes entries owned by root, without user or group names.
+	NormalizeOwner ExportOption = archive.NormalizeOwner
+
+	// SourceDateEpoch clamps modification times to the SOURCE_DATE_EPOCH
+	// environment variable when it is set.
+	SourceDateEpoch ExportOption = archive.SourceDateEpoch
+
+	// ClampModTime writes modification times later than t as t.
+	ClampModTime = archive.ClampModTime
+
+	// WithFilter skips the files and directories denied by a filter.Filter,
+	// which sees their paths relative to the root.
+	WithFilter = archive.WithFilter
+
+	// WithIgnoreFile skips the files and directories matching the gitignore
+	// style patterns of an ignore file in the root, when it exists.
+	WithIgnoreFile = archive.WithIgnoreFile
+)
+
+// WithCompression compresses the exported archive with c.
+func WithCompression(c Compression) ExportOption {
+	return func(options *archive.ExportOptions) {
+		options.Compression = int(c)
+	}
+}
+
+// Export writes the files, directories and symlinks beneath root in fsys to
+// w as a tar archive, including the footer. It does not close w.
+//
+// Access and change times are never written, so with [Sorted],
+// [NormalizeOwner] and either [ClampModTime] or [SourceDateEpoch] the same
+// tree always produces the same bytes.
+//
+// Example:
+//
+//	err := tar.Export(fsys, "dist", w,
+//		tar.Sorted,
+//		tar.NormalizeOwner,
+//		tar.SourceDateEpoch,
+//		tar.WithCompression(tar.Gzip),
+//	)
+func Export(fsys afero.Fs, root string, w io.Writer, options ...ExportOption) error {
+	opts := &archive.ExportOptions{}
+	fopt.ApplyAll(opts, options)
+
+	out, err := compressor(Compression(opts.Compression), w)
+	if err != nil {
+		return err
+	}
+
+	tw := tar.NewWriter(out)
+	err = archive.Walk(fsys, root, opts, func(e archive.Entry) error {
+		header, err := tar.FileInfoHeader(e.Info, e.Linkname)
+		if err != nil {
+			return fmt.Errorf("%s: %w", e.Path, err)
+		}
+
+		header.Name = e.Name
+		if e.Info.IsDir() {
+			header.Name += "/"
+		}
+		header.ModTime = opts.ModTime(header.ModTime)
+		header.AccessTime = time.Time{}
+		header.ChangeTime = time.Time{}
+		if opts.NormalizeOwner {
+			header.Uid, header.Gid = 0, 0
+			header.Uname, header.Gname = "", ""
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("%s: %w", e.Path, err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			return nil
+		}
+
+		return copyFile(tw, fsys, e.Path)
+	})
+	if err != nil {
+		return errors.Join(err, tw.Close(), out.Close())
+	}
+
+	return errors.Join(tw.Close(), out.Close())
+}
+
+func copyFile(w io.Writer, fsys afero.Fs, name string) error {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w, file)
+	return errors.Join(err, file.Close())
+}
+
+// compressor wraps w with c. Closing the returned writer flushes the
+// compressed stream but does not close w.
+func compressor(c Compression, w io.Writer) (io.WriteCloser, error) {
+	switch c {
+	case Uncompressed:
+		return nopCloser{w}, nil
+	case Gzip:
+		return gzip.NewWriter(w), nil
+	case Zstd:
+		// A single goroutine keeps the output reproducible
+		return zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
+	default:
+		return nil, fmt.Errorf("unsupported compression: %d", c)
+	}
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
@@ -136,12 +136,15 @@ var _ = Describe("File", func() {
 		Expect(file.Close()).To(Succeed())
 	})
 
-	It("should return EROFS for Truncate operations", func() {
+	It("should return EROFS for Truncate of streaming files", func() {
 		buf := &bytes.Buffer{}
 		fs := aferoxtar.NewFs(aferoxtar.NewWriter(buf))
 
 		file, err := fs.Create("test.txt")
 		Expect(err).ToNot(HaveOccurred())
+		Expect(file.Truncate(4)).To(Succeed())
+		_, err = file.WriteString("test")
+		Expect(err).ToNot(HaveOccurred())
 
 		err = file.Truncate(0)
 		Expect(err).To(Equal(syscall.EROFS))
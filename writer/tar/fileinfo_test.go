@@ -1,66 +1,66 @@
 package tar_test
 
 import (
+	"archive/tar"
 	"bytes"
 	"os"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
 	aferoxtar "github.com/unmango/aferox/writer/tar"
 )
 
 var _ = Describe("FileInfo", func() {
-	It("should return false for IsDir", func() {
-		buf := &bytes.Buffer{}
-		fs := aferoxtar.NewFs(aferoxtar.NewWriter(buf))
+	var fs afero.Fs
+
+	BeforeEach(func() {
+		fs = aferoxtar.NewFs(aferoxtar.NewWriter(&bytes.Buffer{}))
+		Expect(afero.WriteFile(fs, "dir/test.txt", []byte("testing"), 0o600)).To(Succeed())
+	})
 
-		info, err := fs.Stat("test.txt")
+	It("should return false for IsDir", func() {
+		info, err := fs.Stat("dir/test.txt")
 		Expect(err).ToNot(HaveOccurred())
 		Expect(info.IsDir()).To(BeFalse())
 	})
 
-	It("should return zero time for ModTime", func() {
-		buf := &bytes.Buffer{}
-		fs := aferoxtar.NewFs(aferoxtar.NewWriter(buf))
-
-		info, err := fs.Stat("test.txt")
+	It("should return true for IsDir of implied directories", func() {
+		info, err := fs.Stat("dir")
 		Expect(err).ToNot(HaveOccurred())
-		Expect(info.ModTime().IsZero()).To(BeTrue())
+		Expect(info.IsDir()).To(BeTrue())
 	})
 
-	It("should return ModePerm for Mode", func() {
-		buf := &bytes.Buffer{}
-		fs := aferoxtar.NewFs(aferoxtar.NewWriter(buf))
-
-		info, err := fs.Stat("test.txt")
+	It("should return the time the entry was written for ModTime", func() {
+		info, err := fs.Stat("dir/test.txt")
 		Expect(err).ToNot(HaveOccurred())
-		Expect(info.Mode()).To(Equal(os.ModePerm))
+		Expect(info.ModTime()).To(BeTemporally("~", time.Now(), 2*time.Second))
 	})
 
-	It("should return correct name", func() {
-		buf := &bytes.Buffer{}
-		fs := aferoxtar.NewFs(aferoxtar.NewWriter(buf))
-
-		info, err := fs.Stat("myfile.txt")
+	It("should return the written Mode", func() {
+		info, err := fs.Stat("dir/test.txt")
 		Expect(err).ToNot(HaveOccurred())
-		Expect(info.Name()).To(Equal("myfile.txt"))
+		Expect(info.Mode()).To(Equal(os.FileMode(0o600)))
 	})
 
-	It("should return 0 for Size", func() {
-		buf := &bytes.Buffer{}
-		fs := aferoxtar.NewFs(aferoxtar.NewWriter(buf))
-
-		info, err := fs.Stat("test.txt")
+	It("should return the base name", func() {
+		info, err := fs.Stat("dir/test.txt")
 		Expect(err).ToNot(HaveOccurred())
-		Expect(info.Size()).To(Equal(int64(0)))
+		Expect(info.Name()).To(Equal("test.txt"))
 	})
 
-	It("should return nil for Sys", func() {
-		buf := &bytes.Buffer{}
-		fs := aferoxtar.NewFs(aferoxtar.NewWriter(buf))
+	It("should return the written Size", func() {
+		info, err := fs.Stat("dir/test.txt")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Size()).To(Equal(int64(7)))
+	})
 
-		info, err := fs.Stat("test.txt")
+	It("should return the header for Sys", func() {
+		info, err := fs.Stat("dir/test.txt")
 		Expect(err).ToNot(HaveOccurred())
-		Expect(info.Sys()).To(BeNil())
+		Expect(info.Sys()).To(HaveField("Name", "dir/test.txt"))
+		Expect(info.Sys()).To(BeAssignableToTypeOf(&tar.Header{}))
 	})
 })
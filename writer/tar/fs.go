@@ -2,129 +2,255 @@ package tar
 
 import (
 	"archive/tar"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
-	"sync"
-	"syscall"
-	"time"
 
 	"github.com/spf13/afero"
+	"github.com/unmango/aferox/internal/archivepath"
+	"github.com/unmango/aferox/writer/internal/archive"
+	"github.com/unmango/go/fopt"
 )
 
-// Fs implements [afero.Fs] as a write-only filesystem backed by an archive/tar.Writer.
+const (
+	blockSize = 512
+
+	// nameSize is the longest name a ustar header holds without a prefix.
+	nameSize = 100
+)
+
+// Fs implements [afero.Fs] and [afero.Symlinker] as an append-only filesystem
+// backed by an archive/tar.Writer.
 //
 // It is intended for creating tar archive entries via standard filesystem-style calls
-// such as Create and OpenFile. Read, stat-based modification, and mutating operations
-// (for example Chmod, Chown, Chtimes, Remove, RemoveAll, and Rename) are not supported
-// on the underlying tar stream and will return appropriate permission or read-only
-// filesystem errors (for example syscall.EPERM or syscall.EROFS).
+// such as Create, OpenFile and MkdirAll. The Fs keeps track of the entries it writes,
+// so Stat, Open and Readdir describe what has been written so far, including the
+// directories implied by the names of files. Writing an entry that already exists, or
+// beneath a file, fails rather than adding a duplicate to the archive.
 //
-// Callers should treat Fs as an append-only view of a tar archive and should not expect
-// to be able to read back or modify previously written entries through this interface.
+// Entries cannot be modified once written, so Chmod, Chown, Chtimes, Remove, RemoveAll
+// and Rename return syscall.EPERM or syscall.EROFS. File contents can only be read back
+// from archives opened with [Append], which can read the underlying file.
 type Fs struct {
-	m *sync.Mutex
-	w *tar.Writer
-}
+	*archive.Fs
+	w *writer
 
-// Chmod implements [afero.Fs].
-func (f *Fs) Chmod(name string, mode os.FileMode) error {
-	return syscall.EPERM
+	// Set by Append, to write the footer of the archive
+	file afero.File
 }
 
-// Chown implements [afero.Fs].
-func (f *Fs) Chown(name string, uid int, gid int) error {
-	return syscall.EPERM
+// Close finishes an archive opened with [Append] by writing the tar footer
+// and closing the underlying file. It fails while a file is streaming, and
+// does nothing for an Fs returned by [NewFs], as the caller owns the tar.Writer.
+func (f *Fs) Close() error {
+	if f.file == nil {
+		return nil
+	}
+
+	return f.Finish(func() error {
+		return errors.Join(f.w.w.Close(), f.file.Close())
+	})
 }
 
-// Chtimes implements [afero.Fs].
-func (f *Fs) Chtimes(name string, atime time.Time, mtime time.Time) error {
-	return syscall.EPERM
+// NewFs returns an [afero.Fs] implementation that writes its contents to the
+// provided [tar.Writer]. The returned filesystem is append-only and exposes a
+// subset of operations that add files and directories to the underlying tar
+// stream, and describe the entries added so far.
+//
+// Files are buffered until closed, as tar headers hold the size of their
+// contents, unless the size is declared with [WithSizeFunc] or Truncate.
+// Entries record the time they were written and the owner of the process,
+// see [WithOwner].
+//
+// The caller retains ownership of the tar.Writer: NewFs does not close or
+// flush the writer. The caller is responsible for calling Close on the
+// tar.Writer when all filesystem operations are complete.
+//
+// Example:
+//
+//	tw := tar.NewWriter(dst)
+//	defer tw.Close()
+//
+//	fs := NewFs(tw)
+//	// Use any afero helpers with fs, for example:
+//	//   afero.WriteFile(fs, "path/to/file.txt", []byte("data"), 0o644)
+func NewFs(w *tar.Writer, options ...Option) afero.Fs {
+	return newFs(w, options)
 }
 
-// Create implements [afero.Fs].
-func (f *Fs) Create(name string) (afero.File, error) {
-	return newFile(name, f.w, f.m, 0644), nil
+func newFs(w *tar.Writer, options []Option) *Fs {
+	opts := defaultOptions()
+	fopt.ApplyAll(&opts, options)
+
+	tw := &writer{w: w, opts: opts}
+	return &Fs{
+		Fs: archive.NewFs("tar.Writer", tw, func(o *archive.Options) {
+			*o = opts.archive
+		}),
+		w: tw,
+	}
 }
 
-// Mkdir implements [afero.Fs].
-func (f *Fs) Mkdir(name string, perm os.FileMode) error {
-	f.m.Lock()
-	defer f.m.Unlock()
+// Append opens the uncompressed tar archive name in fsys, or creates it when
+// it does not exist, and returns an Fs adding entries after its last record.
+// The existing entries are visible through the Fs and, unlike with [NewFs],
+// the contents of files can be read back. When the archive holds several
+// entries with the same name, the last one is visible, as when extracting it.
+//
+// The caller must Close the Fs to write the tar footer.
+func Append(fsys afero.Fs, name string, options ...Option) (*Fs, error) {
+	file, err := fsys.OpenFile(name, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	f := newFs(nil, options)
+	f.file = file
 
-	if err := f.w.WriteHeader(&tar.Header{
-		Typeflag: tar.TypeDir,
-		Name:     name,
-		Mode:     int64(perm),
-	}); err != nil {
-		return fmt.Errorf("writing header: %w", err)
+	end, err := f.index()
+	if err != nil {
+		return nil, errors.Join(err, file.Close())
 	}
 
-	return nil
+	// Overwrite the footer of the existing archive
+	if _, err := file.Seek(end, io.SeekStart); err != nil {
+		return nil, errors.Join(err, file.Close())
+	}
+	if err := file.Truncate(end); err != nil {
+		return nil, errors.Join(err, file.Close())
+	}
+
+	f.w.out = &counter{w: file, n: end}
+	f.w.w = tar.NewWriter(f.w.out)
+	f.w.src = file
+	return f, nil
 }
 
-// MkdirAll implements [afero.Fs].
-func (f *Fs) MkdirAll(path string, perm os.FileMode) error {
-	return f.Mkdir(path, perm)
+// index reads the entries of f.file and returns the offset following the last one.
+// Like tar readers, later entries replace earlier ones with the same name.
+func (f *Fs) index() (int64, error) {
+	in := &counter{r: f.file}
+	r := tar.NewReader(in)
+
+	var end int64
+	for {
+		header, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return end, nil
+		} else if err != nil {
+			return 0, fmt.Errorf("reading archive: %w", err)
+		}
+
+		if name := archivepath.Clean(header.Name); name != "" {
+			f.Add(&archive.Header{
+				Name:     name,
+				Mode:     header.FileInfo().Mode(),
+				ModTime:  header.ModTime,
+				Size:     header.Size,
+				Linkname: header.Linkname,
+				Sys:      header,
+				Contents: io.NewSectionReader(f.file, in.n, header.Size),
+			})
+		}
+
+		end = in.n + padded(header.Size)
+	}
 }
 
-// Name implements [afero.Fs].
-func (f *Fs) Name() string {
-	return "tar.Writer"
+// counter counts the bytes written to w or read from r.
+type counter struct {
+	w io.Writer
+	r io.Reader
+	n int64
 }
 
-// Open implements [afero.Fs].
-func (f *Fs) Open(name string) (afero.File, error) {
-	// This filesystem is write-only (backed by tar.Writer), so opening files
-	// for reading is not supported.
-	return nil, syscall.EROFS
+func (c *counter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
 }
 
-// OpenFile implements [afero.Fs].
-func (f *Fs) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
-	return newFile(name, f.w, f.m, perm), nil
+func (c *counter) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
 }
 
-// Remove implements [afero.Fs].
-func (f *Fs) Remove(name string) error {
-	return syscall.EROFS
+// padded rounds size up to a whole number of tar blocks.
+func padded(size int64) int64 {
+	return (size + blockSize - 1) / blockSize * blockSize
 }
 
-// RemoveAll implements [afero.Fs].
-func (f *Fs) RemoveAll(path string) error {
-	return syscall.EROFS
+// writer writes entries to a tar.Writer.
+type writer struct {
+	w    *tar.Writer
+	opts options
+
+	// Set by Append, to locate and read back file contents
+	out *counter
+	src io.ReaderAt
 }
 
-// Rename implements [afero.Fs].
-func (f *Fs) Rename(oldname string, newname string) error {
-	return syscall.EROFS
+// WriteEntry implements archive.Writer.
+func (w *writer) WriteEntry(h *archive.Header, r io.Reader) error {
+	if err := w.WriteHeader(h); err != nil {
+		return err
+	}
+	if r != nil && h.Mode.IsRegular() {
+		if _, err := io.Copy(w.w, r); err != nil {
+			return fmt.Errorf("copying file buffer: %w", err)
+		}
+	}
+
+	return nil
 }
 
-// Stat implements [afero.Fs].
-func (f *Fs) Stat(name string) (os.FileInfo, error) {
-	return &FileInfo{name: name, w: f.w}, nil
+// WriteHeader implements archive.Streamer, using the PAX format for long names.
+func (w *writer) WriteHeader(h *archive.Header) error {
+	header := &tar.Header{
+		Name:    h.Name,
+		Mode:    int64(h.Mode.Perm()),
+		ModTime: h.ModTime,
+		Uid:     w.opts.uid,
+		Gid:     w.opts.gid,
+	}
+	if len(h.Name) > nameSize {
+		header.Format = tar.FormatPAX
+	}
+
+	switch {
+	case h.Mode.IsDir():
+		header.Typeflag = tar.TypeDir
+	case h.Mode&fs.ModeSymlink != 0:
+		header.Typeflag = tar.TypeSymlink
+		header.Linkname = h.Linkname
+	case h.Mode.IsRegular():
+		header.Typeflag = tar.TypeReg
+		header.Size = h.Size
+	default:
+		return errors.ErrUnsupported
+	}
+
+	if err := w.w.WriteHeader(header); err != nil {
+		return fmt.Errorf("writing header: %w", err)
+	}
+
+	h.Sys = header
+	if w.src != nil && h.Mode.IsRegular() {
+		h.Contents = io.NewSectionReader(w.src, w.out.n, h.Size)
+	}
+
+	return nil
 }
 
-// NewFs returns an [afero.Fs] implementation that writes its contents to the
-// provided [tar.Writer]. The returned filesystem is write-only and exposes a
-// subset of operations that add files and directories to the underlying tar
-// stream.
-//
-// The caller retains ownership of the tar.Writer: NewFs does not close or
-// flush the writer, and it does not perform any synchronization. The caller
-// is responsible for calling Close on the tar.Writer when all filesystem
-// operations are complete.
-//
-// Example:
-//
-//	tw := tar.NewWriter(dst)
-//	defer tw.Close()
-//
-//	fs := NewFs(tw)
-//	// Use any afero helpers with fs, for example:
-//	//   afero.WriteFile(fs, "path/to/file.txt", []byte("data"), 0o644)
-func NewFs(w *tar.Writer) afero.Fs {
-	return &Fs{
-		m: &sync.Mutex{},
-		w: w,
+// Write implements archive.Streamer.
+func (w *writer) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	if errors.Is(err, tar.ErrWriteTooLong) {
+		err = ErrWriteTooLong
 	}
+
+	return n, err
 }
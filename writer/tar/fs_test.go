@@ -3,13 +3,20 @@ package tar_test
 import (
 	"archive/tar"
 	"bytes"
+	"compress/gzip"
 	"errors"
+	"io"
+	"os"
+	"strings"
 	"syscall"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/klauspost/compress/zstd"
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/filter"
 	aferoxtar "github.com/unmango/aferox/writer/tar"
 )
 
@@ -165,10 +172,51 @@ var _ = Describe("Fs", func() {
 		r := aferoxtar.NewReader(buf)
 		header, err := r.Next()
 		Expect(err).ToNot(HaveOccurred())
+		Expect(header.Name).To(Equal("testdir"))
+		Expect(header.Typeflag).To(Equal(byte(tar.TypeDir)))
+
+		header, err = r.Next()
+		Expect(err).ToNot(HaveOccurred())
 		Expect(header.Name).To(Equal("testdir/subdir"))
 		Expect(header.Typeflag).To(Equal(byte(tar.TypeDir)))
 	})
 
+	It("should write missing parents with MkdirAll once", func() {
+		buf := &bytes.Buffer{}
+		tw := aferoxtar.NewWriter(buf)
+		fs := aferoxtar.NewFs(tw)
+
+		Expect(fs.MkdirAll("a/b", 0755)).To(Succeed())
+		Expect(fs.MkdirAll("a/b/c", 0755)).To(Succeed())
+		Expect(fs.MkdirAll("a/b", 0755)).To(Succeed())
+		tw.Close()
+
+		var names []string
+		r := aferoxtar.NewReader(buf)
+		for header, err := r.Next(); err == nil; header, err = r.Next() {
+			names = append(names, header.Name)
+		}
+		Expect(names).To(Equal([]string{"a", "a/b", "a/b/c"}))
+	})
+
+	It("should not write entries for the root with MkdirAll", func() {
+		buf := &bytes.Buffer{}
+		tw := aferoxtar.NewWriter(buf)
+		fs := aferoxtar.NewFs(tw)
+
+		Expect(fs.MkdirAll("/", 0755)).To(Succeed())
+		Expect(fs.MkdirAll(".", 0755)).To(Succeed())
+		Expect(fs.MkdirAll("/a", 0755)).To(Succeed())
+		tw.Close()
+
+		var names []string
+		r := aferoxtar.NewReader(buf)
+		for header, err := r.Next(); err == nil; header, err = r.Next() {
+			names = append(names, header.Name)
+		}
+		Expect(names).To(Equal([]string{"a"}))
+	})
+
 	It("should return filesystem name", func() {
 		buf := &bytes.Buffer{}
 		fs := aferoxtar.NewFs(aferoxtar.NewWriter(buf))
@@ -176,12 +224,12 @@ var _ = Describe("Fs", func() {
 		Expect(fs.Name()).To(Equal("tar.Writer"))
 	})
 
-	It("should return EROFS for Open", func() {
+	It("should return ENOENT for Open of missing files", func() {
 		buf := &bytes.Buffer{}
 		fs := aferoxtar.NewFs(aferoxtar.NewWriter(buf))
 
 		_, err := fs.Open("test.txt")
-		Expect(err).To(Equal(syscall.EROFS))
+		Expect(err).To(MatchError(os.ErrNotExist))
 	})
 
 	It("should support OpenFile", func() {
@@ -189,7 +237,7 @@ var _ = Describe("Fs", func() {
 		tw := aferoxtar.NewWriter(buf)
 		fs := aferoxtar.NewFs(tw)
 
-		file, err := fs.OpenFile("test.txt", 0, 0644)
+		file, err := fs.OpenFile("test.txt", os.O_WRONLY|os.O_CREATE, 0644)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(file).ToNot(BeNil())
 
@@ -233,11 +281,454 @@ var _ = Describe("Fs", func() {
 	It("should return FileInfo for Stat", func() {
 		buf := &bytes.Buffer{}
 		fs := aferoxtar.NewFs(aferoxtar.NewWriter(buf))
+		Expect(afero.WriteFile(fs, "test.txt", []byte("testing"), 0644)).To(Succeed())
 
 		info, err := fs.Stat("test.txt")
 		Expect(err).ToNot(HaveOccurred())
 		Expect(info).ToNot(BeNil())
 		Expect(info.Name()).To(Equal("test.txt"))
+		Expect(info.Size()).To(Equal(int64(7)))
+	})
+
+	It("should return ENOENT for Stat of missing files", func() {
+		buf := &bytes.Buffer{}
+		fs := aferoxtar.NewFs(aferoxtar.NewWriter(buf))
+
+		_, err := fs.Stat("test.txt")
+		Expect(err).To(MatchError(os.ErrNotExist))
+	})
+
+	It("should write symlinks", func() {
+		buf := &bytes.Buffer{}
+		tw := aferoxtar.NewWriter(buf)
+		fs := aferoxtar.NewFs(tw)
+
+		Expect(fs.(afero.Linker).SymlinkIfPossible("target.txt", "link")).To(Succeed())
+		Expect(tw.Close()).To(Succeed())
+
+		header, err := aferoxtar.NewReader(buf).Next()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(header.Typeflag).To(Equal(byte(tar.TypeSymlink)))
+		Expect(header.Linkname).To(Equal("target.txt"))
+	})
+
+	Describe("Entries", func() {
+		var fs afero.Fs
+
+		BeforeEach(func() {
+			fs = aferoxtar.NewFs(aferoxtar.NewWriter(&bytes.Buffer{}))
+			Expect(fs.Mkdir("dir", 0755)).To(Succeed())
+			Expect(afero.WriteFile(fs, "dir/a.txt", []byte("a"), 0644)).To(Succeed())
+			Expect(afero.WriteFile(fs, "implied/b.txt", []byte("b"), 0644)).To(Succeed())
+		})
+
+		It("should list written entries", func() {
+			Expect(afero.ReadDir(fs, "")).To(HaveExactElements(
+				HaveField("Name()", "dir"),
+				HaveField("Name()", "implied"),
+			))
+			Expect(afero.ReadDir(fs, "dir")).To(HaveExactElements(
+				HaveField("Name()", "a.txt"),
+			))
+		})
+
+		It("should walk written entries", func() {
+			var paths []string
+			Expect(afero.Walk(fs, "", func(path string, info os.FileInfo, err error) error {
+				paths = append(paths, path)
+				return err
+			})).To(Succeed())
+
+			Expect(paths).To(Equal([]string{"", "dir", "dir/a.txt", "implied", "implied/b.txt"}))
+		})
+
+		It("should page directory listings", func() {
+			dir, err := fs.Open("")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(dir.Readdirnames(1)).To(Equal([]string{"dir"}))
+			Expect(dir.Readdirnames(1)).To(Equal([]string{"implied"}))
+			_, err = dir.Readdirnames(1)
+			Expect(err).To(MatchError(io.EOF))
+		})
+
+		It("should not read back contents", func() {
+			file, err := fs.Open("dir/a.txt")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = file.Read(make([]byte, 1))
+			Expect(err).To(Equal(syscall.EROFS))
+		})
+
+		It("should reject duplicate files", func() {
+			_, err := fs.Create("dir/a.txt")
+
+			Expect(err).To(MatchError(os.ErrExist))
+		})
+
+		It("should reject files being written", func() {
+			_, err := fs.Create("new.txt")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = fs.Create("new.txt")
+			Expect(err).To(MatchError(os.ErrExist))
+		})
+
+		It("should reject duplicate directories", func() {
+			Expect(fs.Mkdir("dir", 0755)).To(MatchError(os.ErrExist))
+			Expect(fs.Mkdir("implied", 0755)).To(MatchError(os.ErrExist))
+		})
+
+		It("should reject entries beneath files", func() {
+			_, err := fs.Create("dir/a.txt/b.txt")
+			Expect(err).To(MatchError(syscall.ENOTDIR))
+
+			Expect(fs.MkdirAll("dir/a.txt/sub", 0755)).To(MatchError(syscall.ENOTDIR))
+		})
+
+		It("should write headers for implied directories with MkdirAll", func() {
+			Expect(fs.MkdirAll("implied", 0700)).To(Succeed())
+
+			info, err := fs.Stat("implied")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0700)))
+		})
+	})
+
+	Describe("Append", func() {
+		var base afero.Fs
+
+		BeforeEach(func() {
+			base = afero.NewMemMapFs()
+			buf := &bytes.Buffer{}
+			tw := aferoxtar.NewWriter(buf)
+			Expect(tw.WriteHeader(&tar.Header{Name: "existing.txt", Mode: 0644, Size: 600})).To(Succeed())
+			_, err := tw.Write(bytes.Repeat([]byte("x"), 600))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tw.Close()).To(Succeed())
+			Expect(afero.WriteFile(base, "archive.tar", buf.Bytes(), 0644)).To(Succeed())
+		})
+
+		It("should add entries after the existing records", func() {
+			fs, err := aferoxtar.Append(base, "archive.tar")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(afero.WriteFile(fs, "new.txt", []byte("testing"), 0644)).To(Succeed())
+			Expect(fs.Close()).To(Succeed())
+
+			data, err := afero.ReadFile(base, "archive.tar")
+			Expect(err).ToNot(HaveOccurred())
+			r := aferoxtar.NewReader(bytes.NewReader(data))
+
+			header, err := r.Next()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(header.Name).To(Equal("existing.txt"))
+			header, err = r.Next()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(header.Name).To(Equal("new.txt"))
+			Expect(io.ReadAll(r)).To(Equal([]byte("testing")))
+			_, err = r.Next()
+			Expect(err).To(MatchError(io.EOF))
+		})
+
+		It("should read back existing and written files", func() {
+			fs, err := aferoxtar.Append(base, "archive.tar")
+			Expect(err).ToNot(HaveOccurred())
+			DeferCleanup(fs.Close)
+			Expect(afero.WriteFile(fs, "new.txt", []byte("testing"), 0644)).To(Succeed())
+
+			Expect(afero.ReadFile(fs, "existing.txt")).To(HaveLen(600))
+			Expect(afero.ReadFile(fs, "new.txt")).To(Equal([]byte("testing")))
+		})
+
+		It("should reject entries that already exist", func() {
+			fs, err := aferoxtar.Append(base, "archive.tar")
+			Expect(err).ToNot(HaveOccurred())
+			DeferCleanup(fs.Close)
+
+			_, err = fs.Create("existing.txt")
+			Expect(err).To(MatchError(os.ErrExist))
+		})
+
+		It("should open archives with duplicate entries, showing the last", func() {
+			buf := &bytes.Buffer{}
+			tw := aferoxtar.NewWriter(buf)
+			for _, data := range []string{"first", "second"} {
+				Expect(tw.WriteHeader(&tar.Header{Name: "dup.txt", Mode: 0644, Size: int64(len(data))})).To(Succeed())
+				_, err := tw.Write([]byte(data))
+				Expect(err).ToNot(HaveOccurred())
+			}
+			Expect(tw.Close()).To(Succeed())
+			Expect(afero.WriteFile(base, "dup.tar", buf.Bytes(), 0644)).To(Succeed())
+
+			fs, err := aferoxtar.Append(base, "dup.tar")
+			Expect(err).ToNot(HaveOccurred())
+			DeferCleanup(fs.Close)
+
+			Expect(afero.ReadFile(fs, "dup.txt")).To(Equal([]byte("second")))
+			_, err = fs.Create("dup.txt")
+			Expect(err).To(MatchError(os.ErrExist))
+		})
+
+		It("should not close while a file is streaming", func() {
+			fs, err := aferoxtar.Append(base, "archive.tar", aferoxtar.WithSizeFunc(func(string) (int64, bool) {
+				return 4, true
+			}))
+			Expect(err).ToNot(HaveOccurred())
+			file, err := fs.Create("new.txt")
+			Expect(err).ToNot(HaveOccurred())
+			_, err = file.WriteString("test")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fs.Close()).To(MatchError(syscall.EBUSY))
+			Expect(file.Close()).To(Succeed())
+			Expect(fs.Close()).To(Succeed())
+		})
+
+		It("should create missing archives", func() {
+			fs, err := aferoxtar.Append(base, "new.tar")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fs.Mkdir("dir", 0755)).To(Succeed())
+			Expect(fs.Close()).To(Succeed())
+
+			file, err := base.Open("new.tar")
+			Expect(err).ToNot(HaveOccurred())
+			header, err := aferoxtar.NewReader(file).Next()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(header.Name).To(Equal("dir"))
+		})
+
+		It("should fail for archives that are not tar files", func() {
+			Expect(afero.WriteFile(base, "bad.tar", bytes.Repeat([]byte("x"), 1024), 0644)).To(Succeed())
+
+			_, err := aferoxtar.Append(base, "bad.tar")
+			Expect(err).To(MatchError(ContainSubstring("reading archive")))
+		})
+	})
+
+	Describe("Streaming", func() {
+		var (
+			buf *bytes.Buffer
+			fs  afero.Fs
+		)
+
+		read := func() map[string]*tar.Header {
+			GinkgoHelper()
+			headers := map[string]*tar.Header{}
+			r := tar.NewReader(bytes.NewReader(buf.Bytes()))
+			for {
+				h, err := r.Next()
+				if err == io.EOF {
+					return headers
+				}
+				Expect(err).NotTo(HaveOccurred())
+				headers[h.Name] = h
+			}
+		}
+
+		BeforeEach(func() {
+			buf = &bytes.Buffer{}
+		})
+
+		It("should stream files with a declared size", func() {
+			fs = aferoxtar.NewFs(aferoxtar.NewWriter(buf),
+				aferoxtar.WithSizeFunc(func(name string) (int64, bool) {
+					return 4, name == "test.txt"
+				}),
+			)
+
+			file, err := fs.Create("test.txt")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = file.WriteString("test")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(buf.String()).To(ContainSubstring("test"))
+			Expect(file.Close()).To(Succeed())
+			Expect(read()).To(HaveKeyWithValue("test.txt",
+				HaveField("Size", BeEquivalentTo(4)),
+			))
+		})
+
+		It("should declare the size with Truncate", func() {
+			fs = aferoxtar.NewFs(aferoxtar.NewWriter(buf))
+
+			file, err := fs.Create("test.txt")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = file.WriteString("testing")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(file.Truncate(4)).To(Succeed())
+			Expect(file.Truncate(8)).To(Succeed())
+			_, err = file.WriteString("1234")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(buf.String()).To(ContainSubstring("test1234"))
+			Expect(file.Close()).To(Succeed())
+		})
+
+		It("should pad short writes", func() {
+			fs = aferoxtar.NewFs(aferoxtar.NewWriter(buf),
+				aferoxtar.WithSizeFunc(func(string) (int64, bool) {
+					return 8, true
+				}),
+			)
+
+			file, err := fs.Create("test.txt")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = file.WriteString("test")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(file.Close()).To(MatchError(io.ErrShortWrite))
+			Expect(fs.Mkdir("dir", os.ModePerm)).To(Succeed())
+			Expect(read()).To(SatisfyAll(
+				HaveKeyWithValue("test.txt", HaveField("Size", BeEquivalentTo(8))),
+				HaveKey("dir"),
+			))
+		})
+
+		It("should queue other entries while a file is streaming", func() {
+			fs = aferoxtar.NewFs(aferoxtar.NewWriter(buf),
+				aferoxtar.WithSizeFunc(func(name string) (int64, bool) {
+					return 4, name == "stream.txt"
+				}),
+			)
+			stream, err := fs.Create("stream.txt")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = stream.WriteString("test")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(afero.WriteFile(fs, "buffered.txt", []byte("testing"), 0644)).To(Succeed())
+			Expect(fs.Mkdir("dir", 0755)).To(Succeed())
+			Expect(fs.MkdirAll("a/b", 0755)).To(Succeed())
+			Expect(read()).To(SatisfyAll(HaveLen(1), HaveKey("stream.txt")))
+
+			Expect(stream.Close()).To(Succeed())
+			Expect(read()).To(SatisfyAll(
+				HaveKey("stream.txt"),
+				HaveKeyWithValue("buffered.txt", HaveField("Size", BeEquivalentTo(7))),
+				HaveKey("dir"),
+				HaveKey("a/b"),
+			))
+		})
+
+		It("should buffer files with a declared size while another file is streaming", func() {
+			fs = aferoxtar.NewFs(aferoxtar.NewWriter(buf),
+				aferoxtar.WithSizeFunc(func(name string) (int64, bool) {
+					return 4, true
+				}),
+			)
+			a, err := fs.Create("a")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = a.WriteString("aa")
+			Expect(err).NotTo(HaveOccurred())
+
+			done := make(chan error)
+			go func() {
+				defer GinkgoRecover()
+				b, err := fs.Create("b")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = b.WriteString("bbbb")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = b.WriteString("b")
+				Expect(err).To(MatchError(aferoxtar.ErrWriteTooLong))
+				done <- b.Close()
+			}()
+
+			Eventually(done).Should(Receive(BeNil()))
+			_, err = a.WriteString("aa")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(a.Close()).To(Succeed())
+
+			r := tar.NewReader(bytes.NewReader(buf.Bytes()))
+			var contents []string
+			for h, err := r.Next(); err == nil; h, err = r.Next() {
+				data, err := io.ReadAll(r)
+				Expect(err).NotTo(HaveOccurred())
+				contents = append(contents, h.Name+"="+string(data))
+			}
+			Expect(contents).To(Equal([]string{"a=aaaa", "b=bbbb"}))
+		})
+
+		It("should return errors writing queued entries from the streaming file", func() {
+			fs = aferoxtar.NewFs(aferoxtar.NewWriter(buf),
+				aferoxtar.WithSizeFunc(func(name string) (int64, bool) {
+					return 4, true
+				}),
+			)
+			a, err := fs.Create("a")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = a.WriteString("aaaa")
+			Expect(err).NotTo(HaveOccurred())
+
+			b, err := fs.Create("b")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = b.WriteString("bb")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(b.Close()).To(Succeed())
+
+			Expect(a.Close()).To(MatchError(io.ErrShortWrite))
+		})
+
+		It("should not block concurrent buffered writers", func() {
+			fs = aferoxtar.NewFs(aferoxtar.NewWriter(buf))
+			a, err := fs.Create("a.txt")
+			Expect(err).NotTo(HaveOccurred())
+			b, err := fs.Create("b.txt")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = a.WriteString("a")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = b.WriteString("b")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(b.Close()).To(Succeed())
+			Expect(a.Close()).To(Succeed())
+			Expect(read()).To(SatisfyAll(HaveKey("a.txt"), HaveKey("b.txt")))
+		})
+
+		It("should spill large files to the temp Fs", func() {
+			spill := afero.NewMemMapFs()
+			fs = aferoxtar.NewFs(aferoxtar.NewWriter(buf),
+				aferoxtar.WithSpill(spill, 4),
+			)
+
+			file, err := fs.Create("test.txt")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = file.WriteString("testing")
+			Expect(err).NotTo(HaveOccurred())
+
+			temp, err := afero.ReadDir(spill, os.TempDir())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(temp).To(HaveLen(1))
+
+			Expect(file.Close()).To(Succeed())
+			temp, err = afero.ReadDir(spill, os.TempDir())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(temp).To(BeEmpty())
+			Expect(buf.String()).To(ContainSubstring("testing"))
+		})
+
+		It("should set the owner of entries", func() {
+			fs = aferoxtar.NewFs(aferoxtar.NewWriter(buf), aferoxtar.WithOwner(1000, 1001))
+
+			Expect(afero.WriteFile(fs, "test.txt", []byte("test"), os.ModePerm)).To(Succeed())
+			Expect(fs.Mkdir("dir", os.ModePerm)).To(Succeed())
+
+			Expect(read()).To(HaveEach(SatisfyAll(
+				HaveField("Uid", 1000),
+				HaveField("Gid", 1001),
+			)))
+		})
+
+		It("should write long names as PAX records", func() {
+			fs = aferoxtar.NewFs(aferoxtar.NewWriter(buf))
+			name := "dir/" + strings.Repeat("a", 120)
+
+			Expect(afero.WriteFile(fs, name, []byte("test"), os.ModePerm)).To(Succeed())
+
+			Expect(read()).To(HaveKeyWithValue(name, SatisfyAll(
+				HaveField("Format", tar.FormatPAX),
+				HaveField("PAXRecords", HaveKeyWithValue("path", name)),
+			)))
+		})
 	})
 
 	Describe("Error handling", func() {
@@ -286,3 +777,138 @@ var _ = Describe("Fs", func() {
 		})
 	})
 })
+
+var _ = Describe("Export", func() {
+	var src afero.Fs
+
+	BeforeEach(func() {
+		src = afero.NewMemMapFs()
+		Expect(src.MkdirAll("dist/bin", 0755)).To(Succeed())
+		Expect(afero.WriteFile(src, "dist/bin/tool", []byte("#!/bin/sh"), 0755)).To(Succeed())
+		Expect(afero.WriteFile(src, "dist/README.md", []byte("readme"), 0644)).To(Succeed())
+		Expect(afero.WriteFile(src, "dist/debug.log", []byte("log"), 0644)).To(Succeed())
+		Expect(afero.WriteFile(src, "other.txt", []byte("other"), 0644)).To(Succeed())
+	})
+
+	read := func(r io.Reader) map[string]*tar.Header {
+		GinkgoHelper()
+		headers := map[string]*tar.Header{}
+		tr := tar.NewReader(r)
+		for {
+			h, err := tr.Next()
+			if err == io.EOF {
+				return headers
+			}
+			Expect(err).NotTo(HaveOccurred())
+			headers[h.Name] = h
+		}
+	}
+
+	export := func(options ...aferoxtar.ExportOption) []byte {
+		GinkgoHelper()
+		buf := &bytes.Buffer{}
+		Expect(aferoxtar.Export(src, "dist", buf, options...)).To(Succeed())
+		return buf.Bytes()
+	}
+
+	It("should write the tree beneath root", func() {
+		headers := read(bytes.NewReader(export()))
+
+		Expect(headers).To(HaveLen(4))
+		Expect(headers).To(HaveKeyWithValue("bin/", HaveField("Typeflag", BeEquivalentTo(tar.TypeDir))))
+		Expect(headers).To(HaveKeyWithValue("bin/tool", SatisfyAll(
+			HaveField("Size", BeEquivalentTo(9)),
+			HaveField("Mode", BeEquivalentTo(0755)),
+		)))
+	})
+
+	It("should write sorted entries", func() {
+		var names []string
+		tr := tar.NewReader(bytes.NewReader(export(aferoxtar.Sorted)))
+		for h, err := tr.Next(); err == nil; h, err = tr.Next() {
+			names = append(names, h.Name)
+		}
+
+		Expect(names).To(Equal([]string{"README.md", "bin/", "bin/tool", "debug.log"}))
+	})
+
+	It("should produce the same bytes for the same tree", func() {
+		epoch := time.Unix(1700000000, 0)
+		options := []aferoxtar.ExportOption{
+			aferoxtar.Sorted,
+			aferoxtar.NormalizeOwner,
+			aferoxtar.ClampModTime(epoch),
+		}
+		first := export(options...)
+		Expect(src.Chtimes("dist/README.md", time.Now(), time.Now().Add(time.Hour))).To(Succeed())
+
+		Expect(export(options...)).To(Equal(first))
+		Expect(read(bytes.NewReader(first))).To(HaveEach(SatisfyAll(
+			HaveField("ModTime", BeTemporally("==", epoch)),
+			HaveField("Uid", 0),
+			HaveField("Uname", ""),
+		)))
+	})
+
+	It("should clamp modification times to SOURCE_DATE_EPOCH", func() {
+		GinkgoT().Setenv("SOURCE_DATE_EPOCH", "1700000000")
+
+		headers := read(bytes.NewReader(export(aferoxtar.SourceDateEpoch)))
+
+		Expect(headers["README.md"].ModTime).To(BeTemporally("==", time.Unix(1700000000, 0)))
+	})
+
+	It("should fail for an invalid SOURCE_DATE_EPOCH", func() {
+		GinkgoT().Setenv("SOURCE_DATE_EPOCH", "yesterday")
+
+		err := aferoxtar.Export(src, "dist", io.Discard, aferoxtar.SourceDateEpoch)
+
+		Expect(err).To(MatchError(ContainSubstring("SOURCE_DATE_EPOCH")))
+	})
+
+	DescribeTable("compression",
+		func(c aferoxtar.Compression, open func(io.Reader) (io.Reader, error)) {
+			data := export(aferoxtar.Sorted, aferoxtar.ClampModTime(time.Unix(0, 0)), aferoxtar.WithCompression(c))
+
+			r, err := open(bytes.NewReader(data))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(read(r)).To(HaveKey("bin/tool"))
+			Expect(export(aferoxtar.Sorted, aferoxtar.ClampModTime(time.Unix(0, 0)), aferoxtar.WithCompression(c))).To(Equal(data))
+		},
+		Entry("gzip", aferoxtar.Gzip, func(r io.Reader) (io.Reader, error) {
+			return gzip.NewReader(r)
+		}),
+		Entry("zstd", aferoxtar.Zstd, func(r io.Reader) (io.Reader, error) {
+			return zstd.NewReader(r)
+		}),
+	)
+
+	It("should skip filtered paths", func() {
+		headers := read(bytes.NewReader(export(
+			aferoxtar.WithFilter(filter.Not(filter.PathGlob("**/*.log"))),
+		)))
+
+		Expect(headers).NotTo(HaveKey("debug.log"))
+		Expect(headers).To(HaveKey("README.md"))
+	})
+
+	It("should filter paths relative to the root", func() {
+		headers := read(bytes.NewReader(export(
+			aferoxtar.WithFilter(filter.Not(filter.UnderDir("bin"))),
+		)))
+
+		Expect(headers).To(HaveLen(2))
+		Expect(headers).To(HaveKey("README.md"))
+		Expect(headers).To(HaveKey("debug.log"))
+	})
+
+	It("should skip ignored paths", func() {
+		Expect(afero.WriteFile(src, "dist/.exportignore", []byte("*.log\n/bin/\n"), 0644)).To(Succeed())
+
+		headers := read(bytes.NewReader(export(aferoxtar.WithIgnoreFile(".exportignore"))))
+
+		Expect(headers).To(HaveLen(2))
+		Expect(headers).To(HaveKey("README.md"))
+		Expect(headers).To(HaveKey(".exportignore"))
+	})
+})
@@ -0,0 +1,53 @@
+package tar
+
+import (
+	"os"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/writer/internal/archive"
+)
+
+type options struct {
+	archive  archive.Options
+	uid, gid int
+}
+
+type Option func(*options)
+
+// WithSizeFunc declares the size of files ahead of time, so their contents
+// are streamed to the archive as they are written instead of being buffered
+// until Close. fn reports the size of the file name, or false when it is unknown.
+//
+// Only one file can stream at a time, so a streaming file holds the archive
+// from its first Write until Close. Meanwhile other files are buffered, and
+// they and any directories are written once the streaming file is closed,
+// whose Close returns any error writing them.
+func WithSizeFunc(fn func(name string) (int64, bool)) Option {
+	return func(options *options) {
+		options.archive.Size = fn
+	}
+}
+
+// WithSpill buffers files in memory up to threshold bytes, and moves
+// larger files to temporary files in fsys until they are closed.
+func WithSpill(fsys afero.Fs, threshold int64) Option {
+	return func(options *options) {
+		options.archive.Spill = fsys
+		options.archive.Threshold = threshold
+	}
+}
+
+// WithOwner sets the Uid and Gid of written entries, which default to those of the process.
+func WithOwner(uid, gid int) Option {
+	return func(options *options) {
+		options.uid = uid
+		options.gid = gid
+	}
+}
+
+func defaultOptions() options {
+	return options{
+		uid: max(os.Getuid(), 0),
+		gid: max(os.Getgid(), 0),
+	}
+}
@@ -0,0 +1,114 @@
+package zip
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/writer/internal/archive"
+	"github.com/unmango/go/fopt"
+)
+
+const (
+	Store   = zip.Store
+	Deflate = zip.Deflate
+)
+
+// NewWriter returns a new zip.Writer that writes a zip archive to w.
+//
+// This function is a thin wrapper around archive/zip.NewWriter, mirroring
+// the writer/tar package.
+func NewWriter(w io.Writer) *zip.Writer {
+	return zip.NewWriter(w)
+}
+
+// NewReader returns a new zip.Reader reading from r, which is assumed to have the given size in bytes.
+func NewReader(r io.ReaderAt, size int64) (*zip.Reader, error) {
+	return zip.NewReader(r, size)
+}
+
+// ExportOption configures [Export].
+type ExportOption = archive.ExportOption
+
+var (
+	// Sorted writes the entries of each directory sorted by name, rather
+	// than in the order the Fs lists them.
+	Sorted ExportOption = archive.Sorted
+
+	// SourceDateEpoch clamps modification times to the SOURCE_DATE_EPOCH
+	// environment variable when it is set.
+	SourceDateEpoch ExportOption = archive.SourceDateEpoch
+
+	// ClampModTime writes modification times later than t as t.
+	ClampModTime = archive.ClampModTime
+
+	// WithFilter skips the files and directories denied by a filter.Filter,
+	// which sees their paths relative to the root.
+	WithFilter = archive.WithFilter
+
+	// WithIgnoreFile skips the files and directories matching the gitignore
+	// style patterns of an ignore file in the root, when it exists.
+	WithIgnoreFile = archive.WithIgnoreFile
+)
+
+// Export writes the files, directories and symlinks beneath root in fsys to
+// w as a zip archive, compressing files with [Deflate], and writes the
+// central directory. It does not close w.
+//
+// Modification times are written in UTC, so with [Sorted] and either
+// [ClampModTime] or [SourceDateEpoch] the same tree always produces the same bytes.
+func Export(fsys afero.Fs, root string, w io.Writer, options ...ExportOption) error {
+	opts := &archive.ExportOptions{}
+	fopt.ApplyAll(opts, options)
+
+	zw := zip.NewWriter(w)
+	err := archive.Walk(fsys, root, opts, func(e archive.Entry) error {
+		header, err := zip.FileInfoHeader(e.Info)
+		if err != nil {
+			return fmt.Errorf("%s: %w", e.Path, err)
+		}
+
+		header.Name = e.Name
+		header.Modified = opts.ModTime(e.Info.ModTime())
+
+		var r io.Reader
+		switch mode := e.Info.Mode(); {
+		case mode.IsDir():
+			header.Name += "/"
+			header.Method = zip.Store
+		case mode&fs.ModeSymlink != 0:
+			header.Method = zip.Store
+			r = strings.NewReader(e.Linkname)
+		case mode.IsRegular():
+			header.Method = zip.Deflate
+		default:
+			return &fs.PathError{Op: "export", Path: e.Path, Err: errors.ErrUnsupported}
+		}
+
+		out, err := zw.CreateHeader(header)
+		if err != nil {
+			return fmt.Errorf("%s: %w", e.Path, err)
+		}
+		if r != nil {
+			_, err = io.Copy(out, r)
+			return err
+		}
+		if !e.Info.Mode().IsRegular() {
+			return nil
+		}
+
+		file, err := fsys.Open(e.Path)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.Copy(out, file)
+		return errors.Join(err, file.Close())
+	})
+
+	return errors.Join(err, zw.Close())
+}
@@ -0,0 +1,66 @@
+package zip
+
+import (
+	"archive/zip"
+	"errors"
+	"io"
+	"io/fs"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/unmango/aferox/writer/internal/archive"
+	"github.com/unmango/go/fopt"
+)
+
+// NewFs returns an [afero.Fs] implementation that writes its contents to the
+// provided [zip.Writer]. The returned filesystem is append-only: it adds
+// files, directories and symlinks to the archive, and describes the entries
+// added so far. Files are buffered until they are closed and compressed with
+// the method chosen by [WithMethod] or [WithMethodFunc].
+//
+// The caller retains ownership of the zip.Writer and is responsible for
+// calling Close on it when all filesystem operations are complete.
+func NewFs(w *zip.Writer, options ...Option) afero.Fs {
+	opts := defaultOptions()
+	fopt.ApplyAll(&opts, options)
+	return archive.NewFs("zip.Writer", &writer{w, opts})
+}
+
+type writer struct {
+	w    *zip.Writer
+	opts options
+}
+
+// WriteEntry implements archive.Writer.
+func (w *writer) WriteEntry(h *archive.Header, r io.Reader) error {
+	header := &zip.FileHeader{
+		Name:     h.Name,
+		Method:   zip.Store,
+		Modified: h.ModTime,
+	}
+	header.SetMode(h.Mode)
+
+	switch {
+	case h.Mode.IsDir():
+		header.Name += "/"
+	case h.Mode&fs.ModeSymlink != 0:
+		r = strings.NewReader(h.Linkname)
+	case h.Mode.IsRegular():
+		header.Method = w.opts.method(h.Name)
+	default:
+		return errors.ErrUnsupported
+	}
+
+	out, err := w.w.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	if r != nil {
+		if _, err := io.Copy(out, r); err != nil {
+			return err
+		}
+	}
+
+	h.Sys = header
+	return nil
+}
@@ -0,0 +1,175 @@
+package zip_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	aferoxzip "github.com/unmango/aferox/writer/zip"
+)
+
+var _ = Describe("Fs", func() {
+	var (
+		buf  *bytes.Buffer
+		zw   *zip.Writer
+		fsys afero.Fs
+	)
+
+	read := func() map[string]*zip.File {
+		GinkgoHelper()
+		Expect(zw.Close()).To(Succeed())
+		r, err := aferoxzip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		Expect(err).NotTo(HaveOccurred())
+
+		files := map[string]*zip.File{}
+		for _, f := range r.File {
+			files[f.Name] = f
+		}
+
+		return files
+	}
+
+	contents := func(f *zip.File) string {
+		GinkgoHelper()
+		r, err := f.Open()
+		Expect(err).NotTo(HaveOccurred())
+		data, err := io.ReadAll(r)
+		Expect(err).NotTo(HaveOccurred())
+		return string(data)
+	}
+
+	BeforeEach(func() {
+		buf = &bytes.Buffer{}
+		zw = aferoxzip.NewWriter(buf)
+		fsys = aferoxzip.NewFs(zw)
+	})
+
+	It("should return its name", func() {
+		Expect(fsys.Name()).To(Equal("zip.Writer"))
+	})
+
+	It("should write files", func() {
+		Expect(afero.WriteFile(fsys, "dir/test.txt", []byte("testing"), 0600)).To(Succeed())
+
+		files := read()
+		Expect(files).To(HaveKey("dir/test.txt"))
+		Expect(contents(files["dir/test.txt"])).To(Equal("testing"))
+		Expect(files["dir/test.txt"].Method).To(Equal(aferoxzip.Deflate))
+		Expect(files["dir/test.txt"].Mode()).To(Equal(fs.FileMode(0600)))
+	})
+
+	It("should write directories", func() {
+		Expect(fsys.MkdirAll("a/b", 0755)).To(Succeed())
+
+		files := read()
+		Expect(files).To(HaveKey("a/"))
+		Expect(files).To(HaveKey("a/b/"))
+		Expect(files["a/b/"].Mode().IsDir()).To(BeTrue())
+	})
+
+	It("should write symlinks", func() {
+		linker, ok := fsys.(afero.Linker)
+		Expect(ok).To(BeTrue())
+
+		Expect(linker.SymlinkIfPossible("target.txt", "link")).To(Succeed())
+
+		files := read()
+		Expect(files).To(HaveKey("link"))
+		Expect(files["link"].Mode() & fs.ModeSymlink).NotTo(BeZero())
+		Expect(contents(files["link"])).To(Equal("target.txt"))
+	})
+
+	It("should describe written entries with their zip headers", func() {
+		Expect(afero.WriteFile(fsys, "test.txt", []byte("testing"), 0600)).To(Succeed())
+
+		info, err := fsys.Stat("test.txt")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Size()).To(BeEquivalentTo(7))
+		Expect(info.Sys()).To(BeAssignableToTypeOf(&zip.FileHeader{}))
+	})
+
+	It("should reject duplicate entries", func() {
+		Expect(afero.WriteFile(fsys, "test.txt", nil, 0600)).To(Succeed())
+
+		_, err := fsys.Create("test.txt")
+
+		Expect(err).To(MatchError(os.ErrExist))
+	})
+
+	It("should compress files with the configured method", func() {
+		fsys = aferoxzip.NewFs(zw, aferoxzip.WithMethod(aferoxzip.Store))
+
+		Expect(afero.WriteFile(fsys, "test.txt", []byte("testing"), 0600)).To(Succeed())
+
+		Expect(read()["test.txt"].Method).To(Equal(aferoxzip.Store))
+	})
+
+	It("should choose the method of each file", func() {
+		fsys = aferoxzip.NewFs(zw, aferoxzip.WithMethodFunc(func(name string) uint16 {
+			if strings.HasSuffix(name, ".gz") {
+				return aferoxzip.Store
+			}
+
+			return aferoxzip.Deflate
+		}))
+
+		Expect(afero.WriteFile(fsys, "test.txt", []byte("testing"), 0600)).To(Succeed())
+		Expect(afero.WriteFile(fsys, "test.gz", []byte("testing"), 0600)).To(Succeed())
+
+		files := read()
+		Expect(files["test.txt"].Method).To(Equal(aferoxzip.Deflate))
+		Expect(files["test.gz"].Method).To(Equal(aferoxzip.Store))
+	})
+})
+
+var _ = Describe("Export", func() {
+	var src afero.Fs
+
+	BeforeEach(func() {
+		src = afero.NewMemMapFs()
+		Expect(src.MkdirAll("dist/bin", 0755)).To(Succeed())
+		Expect(afero.WriteFile(src, "dist/bin/tool", []byte("#!/bin/sh"), 0755)).To(Succeed())
+		Expect(afero.WriteFile(src, "dist/debug.log", []byte("log"), 0644)).To(Succeed())
+		Expect(afero.WriteFile(src, "dist/.exportignore", []byte("*.log"), 0644)).To(Succeed())
+	})
+
+	export := func(options ...aferoxzip.ExportOption) []byte {
+		GinkgoHelper()
+		buf := &bytes.Buffer{}
+		Expect(aferoxzip.Export(src, "dist", buf, options...)).To(Succeed())
+		return buf.Bytes()
+	}
+
+	It("should write the tree beneath root", func() {
+		data := export(aferoxzip.Sorted, aferoxzip.WithIgnoreFile(".exportignore"))
+
+		r, err := aferoxzip.NewReader(bytes.NewReader(data), int64(len(data)))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.File).To(HaveExactElements(
+			HaveField("Name", ".exportignore"),
+			HaveField("Name", "bin/"),
+			HaveField("Name", "bin/tool"),
+		))
+
+		f, err := r.Open("bin/tool")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(io.ReadAll(f)).To(BeEquivalentTo("#!/bin/sh"))
+	})
+
+	It("should produce the same bytes for the same tree", func() {
+		epoch := time.Unix(1700000000, 0)
+		first := export(aferoxzip.Sorted, aferoxzip.ClampModTime(epoch))
+		Expect(src.Chtimes("dist/debug.log", time.Now(), time.Now().Add(time.Hour))).To(Succeed())
+
+		Expect(export(aferoxzip.Sorted, aferoxzip.ClampModTime(epoch))).To(Equal(first))
+	})
+})
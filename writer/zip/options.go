@@ -0,0 +1,34 @@
+package zip
+
+import "archive/zip"
+
+type options struct {
+	method func(name string) uint16
+}
+
+type Option func(*options)
+
+// WithMethod sets the compression method of every file, [Deflate] by default.
+func WithMethod(method uint16) Option {
+	return func(options *options) {
+		options.method = func(string) uint16 {
+			return method
+		}
+	}
+}
+
+// WithMethodFunc chooses the compression method of each file from its
+// name, for example to store files that are already compressed.
+func WithMethodFunc(fn func(name string) uint16) Option {
+	return func(options *options) {
+		options.method = fn
+	}
+}
+
+func defaultOptions() options {
+	return options{
+		method: func(string) uint16 {
+			return zip.Deflate
+		},
+	}
+}
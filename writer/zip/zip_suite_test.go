@@ -0,0 +1,13 @@
+package zip_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestZip(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Zip Suite")
+}